@@ -1,6 +1,7 @@
 package larago
 
 import (
+	"os"
 	"reflect"
 	"strings"
 
@@ -22,12 +23,20 @@ type Application struct {
 	booted bool
 	toBoot []reflect.Value
 
-	providers []ServiceProvider
+	bootingCallbacks []func(application *Application)
+
+	providers            []ServiceProvider
+	environmentProviders []environmentProviders
 
 	config       *ConfigRepository
 	configLoader ConfigLoader
 
 	commands []ConsoleCommand
+
+	modules []Module
+
+	facades         []*Facade
+	facadesDetached bool
 }
 
 // New constructor of the application.
@@ -70,9 +79,49 @@ func (app *Application) Config() *ConfigRepository {
 	return app.config
 }
 
-// Env checks if application works in this environment.
-func (app *Application) Env(name string) bool {
-	return app.config.Env() == name
+// Environment returns current environment name.
+// Taken from config when it is loaded, or from APP_ENV variable otherwise.
+func (app *Application) Environment() string {
+	if app.config != nil {
+		return app.config.Env()
+	}
+
+	return EnvironmentFromVariables()
+}
+
+// Env checks if application works in one of these environments.
+func (app *Application) Env(names ...string) bool {
+	return InEnvironment(app.Environment(), names...)
+}
+
+// InEnvironment checks if the environment is one of the names.
+func InEnvironment(environment string, names ...string) bool {
+	for _, name := range names {
+		if environment == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsProduction checks if application works in production environment.
+func (app *Application) IsProduction() bool {
+	return app.Env("production")
+}
+
+// IsLocal checks if application works in local environment.
+func (app *Application) IsLocal() bool {
+	return app.Env("local")
+}
+
+// EnvironmentFromVariables returns environment name from APP_ENV variable.
+func EnvironmentFromVariables() string {
+	if environment := os.Getenv(EnvVariable); environment != "" {
+		return environment
+	}
+
+	return DefaultEnvironment
 }
 
 // Register service.
@@ -90,10 +139,48 @@ func (app *Application) Register(providers ...ServiceProvider) {
 	}
 }
 
+// Services registered only in some environments.
+type environmentProviders struct {
+	environments []string
+	providers    []ServiceProvider
+}
+
+// RegisterIn registers services only in the listed environments.
+// Environment is checked right before booting, when .env and config are loaded.
+func (app *Application) RegisterIn(environments []string, providers ...ServiceProvider) {
+	if !app.booted {
+		app.environmentProviders = append(app.environmentProviders, environmentProviders{environments, providers})
+
+		return
+	}
+
+	if app.Env(environments...) {
+		app.Register(providers...)
+	}
+}
+
 // Facade registers application facades.
+// Facades are shortcuts to services of a single application. Applications sharing the process
+// detach them with DetachFacades and resolve services from their own containers.
 func (app *Application) Facade(wrappers ...*Facade) {
 	for _, wrapper := range wrappers {
+		app.facades = append(app.facades, wrapper)
+
 		wrapper.Application = app
+		wrapper.detached = app.facadesDetached
+		wrapper.Clear()
+	}
+}
+
+// DetachFacades registered by the application, so they fail instead of resolving services
+// of another application booted in the same process. Facades registered later are detached too.
+func (app *Application) DetachFacades() {
+	app.facadesDetached = true
+
+	for _, wrapper := range app.facades {
+		wrapper.Application = nil
+		wrapper.detached = true
+		wrapper.Clear()
 	}
 }
 
@@ -109,12 +196,28 @@ func (app *Application) GetCommands() []ConsoleCommand {
 	return app.commands
 }
 
+// OnBooting registers callback to be called right before providers are booted.
+func (app *Application) OnBooting(callback func(application *Application)) {
+	app.bootingCallbacks = append(app.bootingCallbacks, callback)
+}
+
 // Boot Application.
 func (app *Application) Boot() error {
 	if app.booted {
 		return nil
 	}
 
+	for _, scoped := range app.environmentProviders {
+		if app.Env(scoped.environments...) {
+			app.Register(scoped.providers...)
+		}
+	}
+	app.environmentProviders = nil
+
+	for _, callback := range app.bootingCallbacks {
+		callback(app)
+	}
+
 	for _, bootable := range app.toBoot {
 		if err := app.bootProvider(bootable); err != nil {
 			return err
@@ -2,9 +2,11 @@ package larago_test
 
 import (
 	"errors"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
 
 	"github.com/lara-go/larago"
 )
@@ -193,3 +195,98 @@ func TestFunctionCall(t *testing.T) {
 //
 // 	assert.True(t, application.Env("production"))
 // }
+
+/**
+ * Test environment detection.
+ */
+
+type EnvServiceProvider struct{}
+
+func (p *EnvServiceProvider) Register(application *larago.Application) {
+	application.Bind(func() (*Bind, error) {
+		return &Bind{}, nil
+	}, "local-bind")
+}
+
+func TestEnvironmentFromVariables(t *testing.T) {
+	os.Setenv(larago.EnvVariable, "local")
+	defer os.Unsetenv(larago.EnvVariable)
+
+	application := larago.New()
+
+	assert.Equal(t, "local", application.Environment())
+	assert.True(t, application.IsLocal())
+	assert.False(t, application.IsProduction())
+	assert.True(t, application.Env("testing", "local"))
+	assert.True(t, larago.InEnvironment("local", "testing", "local"))
+	assert.False(t, larago.InEnvironment("local"))
+}
+
+func TestRegisterInEnvironment(t *testing.T) {
+	os.Unsetenv(larago.EnvVariable)
+
+	production := larago.New()
+	production.RegisterIn([]string{"production"}, &EnvServiceProvider{})
+
+	local := larago.New()
+	local.RegisterIn([]string{"local"}, &EnvServiceProvider{})
+
+	// Environment is checked at boot, after .env was loaded.
+	os.Setenv(larago.EnvVariable, "local")
+	defer os.Unsetenv(larago.EnvVariable)
+
+	assert.False(t, local.Bound("local-bind"))
+
+	assert.NoError(t, production.Boot())
+	assert.NoError(t, local.Boot())
+	assert.False(t, production.Bound("local-bind"))
+	assert.True(t, local.Bound("local-bind"))
+}
+
+func TestDefaultEnvironment(t *testing.T) {
+	os.Unsetenv(larago.EnvVariable)
+
+	assert.True(t, larago.New().IsProduction())
+}
+
+/**
+ * Modules test.
+ */
+
+type CommandGreet struct{}
+
+func (c *CommandGreet) GetCommand() cli.Command {
+	return cli.Command{Name: "greet"}
+}
+
+func (c *CommandGreet) Handle(args cli.Args) error {
+	return nil
+}
+
+type BlogModule struct{}
+
+func (m *BlogModule) Name() string {
+	return "blog"
+}
+
+func (m *BlogModule) Providers() []larago.ServiceProvider {
+	return []larago.ServiceProvider{&BindServiceProvider{}}
+}
+
+func (m *BlogModule) Commands() []larago.ConsoleCommand {
+	return []larago.ConsoleCommand{&CommandGreet{}}
+}
+
+func TestModules(t *testing.T) {
+	application := larago.New()
+	application.RegisterModules(&BlogModule{})
+
+	assert.True(t, application.HasModule("blog"))
+	assert.True(t, application.Bound("bind"))
+	assert.Len(t, application.GetCommands(), 1)
+	assert.Len(t, application.Modules(), 1)
+
+	assert.Panics(t, func() {
+		application.RegisterModules(&BlogModule{})
+	})
+}
@@ -0,0 +1,77 @@
+package benchmarks_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago/cache"
+
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+)
+
+func databaseStore(b *testing.B) cache.Store {
+	db, err := gorm.Open("sqlite3", "file::memory:?mode=memory&cache=shared")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	db.AutoMigrate(cache.DatabaseItem{})
+
+	store := cache.NewDatabaseStore("cache")
+	store.DB = db
+
+	return store
+}
+
+func benchmarkStorePut(b *testing.B, store cache.Store) {
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		store.Put(fmt.Sprintf("key%d", i%100), i, time.Minute)
+	}
+}
+
+func benchmarkStoreGet(b *testing.B, store cache.Store) {
+	store.Put("key", 1, time.Minute)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	var value int
+	for i := 0; i < b.N; i++ {
+		if err := store.Get("key", &value); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMemoryStorePut(b *testing.B) {
+	benchmarkStorePut(b, cache.NewInMemoryStore())
+}
+
+func BenchmarkMemoryStoreGet(b *testing.B) {
+	benchmarkStoreGet(b, cache.NewInMemoryStore())
+}
+
+func BenchmarkDatabaseStorePut(b *testing.B) {
+	benchmarkStorePut(b, databaseStore(b))
+}
+
+func BenchmarkDatabaseStoreGet(b *testing.B) {
+	benchmarkStoreGet(b, databaseStore(b))
+}
+
+func BenchmarkRepositoryRemember(b *testing.B) {
+	repository := cache.NewRepository(cache.NewInMemoryStore())
+
+	b.ReportAllocs()
+
+	var value int
+	for i := 0; i < b.N; i++ {
+		repository.Remember("remember", time.Minute, func() (interface{}, error) {
+			return 1, nil
+		}, &value)
+	}
+}
@@ -0,0 +1,53 @@
+package benchmarks_test
+
+import (
+	"io/ioutil"
+	"log"
+	net_http "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lara-go/larago"
+	"github.com/lara-go/larago/container"
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/logger"
+	"github.com/lara-go/larago/validation"
+)
+
+func routerFactory() *http.Router {
+	logger := &logger.Logger{
+		DateTimeFormat: larago.DateTimeFormat,
+		Logger:         log.New(ioutil.Discard, "", 0),
+	}
+
+	router := http.NewRouter()
+	router.Logger = logger
+	router.Container = container.New()
+	router.ErrorsHandler = &http.ErrorsHandler{
+		Logger:                    logger,
+		ValidationErrorsConverter: &validation.OzzoErrorsConverter{},
+	}
+
+	return router
+}
+
+// Run request through the handler b.N times.
+func serve(b *testing.B, handler net_http.Handler, method, path, body string) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		request := httptest.NewRequest(method, path, strings.NewReader(body))
+		if body != "" {
+			request.Header.Set("Content-Type", "application/json")
+		}
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+
+		if recorder.Code != 200 {
+			b.Fatalf("Unexpected status %d: %s", recorder.Code, recorder.Body.String())
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package benchmarks_test
+
+import (
+	"testing"
+
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/http/responses"
+)
+
+type Payload struct {
+	ID    int      `json:"id"`
+	Name  string   `json:"name"`
+	Email string   `json:"email"`
+	Tags  []string `json:"tags"`
+}
+
+const payloadJSON = `{"id":1,"name":"John Doe","email":"john@example.com","tags":["a","b","c"]}`
+
+func BenchmarkJSONEncode(b *testing.B) {
+	router := routerFactory()
+	router.GET("/").Action(func() *Payload {
+		return &Payload{ID: 1, Name: "John Doe", Email: "john@example.com", Tags: []string{"a", "b", "c"}}
+	})
+
+	serve(b, router.Bootstrap().GetHTTPRouter(), "GET", "/", "")
+}
+
+func BenchmarkJSONDecode(b *testing.B) {
+	router := routerFactory()
+	router.POST("/").Action(func(request *http.Request) responses.Response {
+		var payload Payload
+		if err := request.ReadJSON(&payload); err != nil {
+			return responses.NewText(400, "%s", err.Error())
+		}
+
+		return responses.NewText(200, "%s", payload.Name)
+	})
+
+	serve(b, router.Bootstrap().GetHTTPRouter(), "POST", "/", payloadJSON)
+}
@@ -0,0 +1,50 @@
+package benchmarks_test
+
+import (
+	"testing"
+
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/http/responses"
+)
+
+type PassMiddleware struct{}
+
+func (m *PassMiddleware) Handle(request *http.Request, next http.Handler) responses.Response {
+	return next(request)
+}
+
+type HeaderMiddleware struct{}
+
+func (m *HeaderMiddleware) Handle(request *http.Request, next http.Handler) responses.Response {
+	return next(request).WithHeader("X-Bench", "true")
+}
+
+func benchmarkMiddlewareStack(b *testing.B, depth int) {
+	router := routerFactory()
+
+	for i := 0; i < depth; i++ {
+		if i%2 == 0 {
+			router.Middleware(&PassMiddleware{})
+		} else {
+			router.Middleware(&HeaderMiddleware{})
+		}
+	}
+
+	router.GET("/").Action(func() string {
+		return "Index"
+	})
+
+	serve(b, router.Bootstrap().GetHTTPRouter(), "GET", "/", "")
+}
+
+func BenchmarkMiddleware0(b *testing.B) {
+	benchmarkMiddlewareStack(b, 0)
+}
+
+func BenchmarkMiddleware5(b *testing.B) {
+	benchmarkMiddlewareStack(b, 5)
+}
+
+func BenchmarkMiddleware20(b *testing.B) {
+	benchmarkMiddlewareStack(b, 20)
+}
@@ -0,0 +1,50 @@
+package benchmarks_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/lara-go/larago/http"
+)
+
+func BenchmarkStaticRoute(b *testing.B) {
+	router := routerFactory()
+	router.GET("/").Action(func() string {
+		return "Index"
+	})
+
+	serve(b, router.Bootstrap().GetHTTPRouter(), "GET", "/", "")
+}
+
+func BenchmarkParamRoute(b *testing.B) {
+	router := routerFactory()
+	router.GET("/users/:id/posts/:post").Action(func(id, post string) string {
+		return id + post
+	})
+
+	serve(b, router.Bootstrap().GetHTTPRouter(), "GET", "/users/1/posts/2", "")
+}
+
+func BenchmarkManyRoutes(b *testing.B) {
+	router := routerFactory()
+	for i := 0; i < 100; i++ {
+		router.GET(fmt.Sprintf("/path%d/:id", i)).Action(func(request *http.Request) string {
+			return "Path"
+		})
+	}
+
+	serve(b, router.Bootstrap().GetHTTPRouter(), "GET", "/path99/1", "")
+}
+
+func BenchmarkGroupedRoute(b *testing.B) {
+	router := routerFactory()
+	router.Group("/api", func() {
+		router.Group("/v1", func() {
+			router.GET("/users/:id").Action(func(id string) string {
+				return id
+			})
+		})
+	})
+
+	serve(b, router.Bootstrap().GetHTTPRouter(), "GET", "/api/v1/users/1", "")
+}
@@ -0,0 +1,254 @@
+// Package cachetest provides conformance tests for cache store drivers.
+//
+//	func TestRedisStore(t *testing.T) {
+//		cachetest.TestStore(t, func() cache.Store {
+//			return NewRedisStore(client)
+//		})
+//	}
+package cachetest
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lara-go/larago/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+// Factory makes empty store for every test.
+type Factory func() cache.Store
+
+// Item is a struct value stored in tests. Stores serializing values must support it.
+type Item struct {
+	Name string
+	Tags []string
+}
+
+// TestStore runs all the conformance tests against the store.
+// Stores implementing cache.PrefixClearer and cache.AddingStore are checked for it too.
+func TestStore(t *testing.T, factory Factory) {
+	t.Run("Missed", func(t *testing.T) { TestMissed(t, factory()) })
+	t.Run("Types", func(t *testing.T) { TestTypes(t, factory()) })
+	t.Run("Overwrite", func(t *testing.T) { TestOverwrite(t, factory()) })
+	t.Run("Expiration", func(t *testing.T) { TestExpiration(t, factory()) })
+	t.Run("Forever", func(t *testing.T) { TestForever(t, factory()) })
+	t.Run("Forget", func(t *testing.T) { TestForget(t, factory()) })
+	t.Run("Clear", func(t *testing.T) { TestClear(t, factory()) })
+	t.Run("Concurrency", func(t *testing.T) { TestConcurrency(t, factory()) })
+
+	if _, ok := factory().(cache.PrefixClearer); ok {
+		t.Run("ClearPrefix", func(t *testing.T) { TestClearPrefix(t, factory()) })
+	}
+
+	if _, ok := factory().(cache.AddingStore); ok {
+		t.Run("Add", func(t *testing.T) { TestAdd(t, factory()) })
+	}
+}
+
+// TestMissed checks that missing keys return cache.ErrorMissed.
+func TestMissed(t *testing.T, store cache.Store) {
+	var value int
+
+	assert.False(t, store.Has("missing"))
+	assert.Equal(t, cache.ErrorMissed, store.Get("missing", &value))
+	assert.Equal(t, 0, value)
+
+	// Forgetting missing key is not an error.
+	store.Forget("missing")
+}
+
+// TestTypes checks that values are returned as they were saved.
+func TestTypes(t *testing.T, store cache.Store) {
+	item := Item{Name: "item", Tags: []string{"a", "b"}}
+
+	assert.Nil(t, store.Forever("int", 42))
+	assert.Nil(t, store.Forever("string", "value"))
+	assert.Nil(t, store.Forever("slice", []string{"a", "b"}))
+	assert.Nil(t, store.Forever("struct", item))
+
+	var i int
+	assert.Nil(t, store.Get("int", &i))
+	assert.Equal(t, 42, i)
+
+	var s string
+	assert.Nil(t, store.Get("string", &s))
+	assert.Equal(t, "value", s)
+
+	var slice []string
+	assert.Nil(t, store.Get("slice", &slice))
+	assert.Equal(t, []string{"a", "b"}, slice)
+
+	var loaded Item
+	assert.Nil(t, store.Get("struct", &loaded))
+	assert.Equal(t, item, loaded)
+}
+
+// TestOverwrite checks that the last saved value wins, including its lifetime.
+func TestOverwrite(t *testing.T, store cache.Store) {
+	var value int
+
+	assert.Nil(t, store.Put("overwrite", 1, time.Hour))
+	assert.Nil(t, store.Put("overwrite", 2, time.Hour))
+	assert.Nil(t, store.Get("overwrite", &value))
+	assert.Equal(t, 2, value)
+
+	// Short lifetime replaces the long one.
+	assert.Nil(t, store.Put("overwrite", 3, time.Second))
+	time.Sleep(2 * time.Second)
+	assert.False(t, store.Has("overwrite"))
+}
+
+// TestExpiration checks that values are gone after their lifetime.
+func TestExpiration(t *testing.T, store cache.Store) {
+	var value int
+
+	assert.Nil(t, store.Put("expire", 1, time.Second))
+	assert.Nil(t, store.Put("live", 2, time.Hour))
+
+	assert.True(t, store.Has("expire"))
+	assert.Nil(t, store.Get("expire", &value))
+	assert.Equal(t, 1, value)
+
+	time.Sleep(2 * time.Second)
+
+	assert.False(t, store.Has("expire"))
+	assert.Equal(t, cache.ErrorMissed, store.Get("expire", &value))
+	assert.True(t, store.Has("live"))
+}
+
+// TestForever checks that values saved forever are not expired.
+func TestForever(t *testing.T, store cache.Store) {
+	var value int
+
+	assert.Nil(t, store.Forever("forever", 1))
+	time.Sleep(time.Second)
+
+	assert.Nil(t, store.Get("forever", &value))
+	assert.Equal(t, 1, value)
+}
+
+// TestForget checks that forgotten values are removed.
+func TestForget(t *testing.T, store cache.Store) {
+	store.Forever("forget", 1)
+	store.Forever("keep", 2)
+
+	store.Forget("forget")
+
+	assert.False(t, store.Has("forget"))
+	assert.True(t, store.Has("keep"))
+}
+
+// TestClear checks that all the values are removed.
+func TestClear(t *testing.T, store cache.Store) {
+	store.Put("first", 1, time.Hour)
+	store.Forever("second", 2)
+
+	store.Clear()
+
+	assert.False(t, store.Has("first"))
+	assert.False(t, store.Has("second"))
+
+	// Store is usable after clearing.
+	assert.Nil(t, store.Forever("third", 3))
+	assert.True(t, store.Has("third"))
+}
+
+// TestClearPrefix checks that only keys with the prefix are removed, wildcards are literal.
+func TestClearPrefix(t *testing.T, store cache.Store) {
+	clearer := store.(cache.PrefixClearer)
+
+	store.Forever("app:first", 1)
+	store.Forever("app:second", 2)
+	store.Forever("other:first", 3)
+	store.Forever("app_%:first", 4)
+
+	clearer.ClearPrefix("app:")
+
+	assert.False(t, store.Has("app:first"))
+	assert.False(t, store.Has("app:second"))
+	assert.True(t, store.Has("other:first"))
+
+	clearer.ClearPrefix("app_%")
+
+	assert.False(t, store.Has("app_%:first"))
+	assert.True(t, store.Has("other:first"))
+}
+
+// TestAdd checks that only one of concurrent adds of the key succeeds, expired keys are replaced.
+func TestAdd(t *testing.T, store cache.Store) {
+	adder := store.(cache.AddingStore)
+
+	const workers = 8
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	var added []int
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+
+		go func(worker int) {
+			defer wg.Done()
+
+			ok, err := adder.Add("claimed", worker, time.Hour)
+			assert.Nil(t, err)
+
+			if ok {
+				mutex.Lock()
+				added = append(added, worker)
+				mutex.Unlock()
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	if assert.Len(t, added, 1) {
+		var value int
+
+		assert.Nil(t, store.Get("claimed", &value))
+		assert.Equal(t, added[0], value)
+	}
+
+	store.Put("expired", 1, time.Second)
+	time.Sleep(2 * time.Second)
+
+	ok, err := adder.Add("expired", 2, time.Hour)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	var value int
+	assert.Nil(t, store.Get("expired", &value))
+	assert.Equal(t, 2, value)
+}
+
+// TestConcurrency checks that concurrent writes of different keys do not interfere.
+func TestConcurrency(t *testing.T, store cache.Store) {
+	const workers = 8
+	const writes = 20
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+
+		go func(worker int) {
+			defer wg.Done()
+
+			key := fmt.Sprintf("worker:%d", worker)
+			for i := 0; i < writes; i++ {
+				store.Put(key, i, time.Hour)
+
+				var value int
+				store.Get(key, &value)
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	for worker := 0; worker < workers; worker++ {
+		var value int
+
+		assert.Nil(t, store.Get(fmt.Sprintf("worker:%d", worker), &value))
+		assert.Equal(t, writes-1, value)
+	}
+}
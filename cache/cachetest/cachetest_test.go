@@ -0,0 +1,32 @@
+package cachetest_test
+
+import (
+	"testing"
+
+	"github.com/lara-go/larago/cache"
+	"github.com/lara-go/larago/cache/cachetest"
+
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	"github.com/lara-go/larago/support/testsuite"
+)
+
+func TestInMemoryStore(t *testing.T) {
+	cachetest.TestStore(t, func() cache.Store {
+		return cache.NewInMemoryStore()
+	})
+}
+
+func TestPrefixedStore(t *testing.T) {
+	cachetest.TestStore(t, func() cache.Store {
+		return cache.NewPrefixedStore(cache.NewInMemoryStore(), "app:")
+	})
+}
+
+func TestDatabaseStore(t *testing.T) {
+	cachetest.TestStore(t, func() cache.Store {
+		store := cache.NewDatabaseStore("cache")
+		store.DB = testsuite.MemoryDB(t, cache.DatabaseItem{})
+
+		return store
+	})
+}
@@ -1,7 +1,11 @@
 package cache
 
-import "github.com/urfave/cli"
-import "github.com/lara-go/larago/logger"
+import (
+	"fmt"
+
+	"github.com/lara-go/larago/logger"
+	"github.com/urfave/cli"
+)
 
 // CommandCacheClear for the app.
 type CommandCacheClear struct {
@@ -20,6 +24,10 @@ func (c *CommandCacheClear) GetCommand() cli.Command {
 
 // Handle command.
 func (c *CommandCacheClear) Handle(args cli.Args) error {
+	if prefixed, ok := c.Repository.store.(*PrefixedStore); ok && !prefixed.Clearable() {
+		return fmt.Errorf("Cache store can not clear only keys with prefix %s, clear it by its own means", prefixed.Prefix())
+	}
+
 	c.Repository.Clear()
 
 	c.Logger.Success("Cache was cleared.")
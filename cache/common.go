@@ -15,6 +15,9 @@ var (
 	// ErrorUnserialize code.
 	ErrorUnserialize = errors.New("cache: failed to unserialize data")
 
+	// ErrorNotAdding code.
+	ErrorNotAdding = errors.New("cache: store can not add values atomically")
+
 	// ErrorTypeMissmatch code.
 	ErrorTypeMissmatch = errors.New("cache: return type missmatch")
 )
@@ -2,6 +2,7 @@ package cache
 
 import (
 	"encoding/base64"
+	"strings"
 	"time"
 
 	"github.com/jinzhu/gorm"
@@ -40,6 +41,11 @@ func NewDatabaseStore(table string) *DatabaseStore {
 	}
 }
 
+// StoreName returns name of the store.
+func (s *DatabaseStore) StoreName() string {
+	return "database"
+}
+
 // Has checks if there is such item.
 func (s *DatabaseStore) Has(key string) bool {
 	return s.findItem(key) != nil
@@ -60,12 +66,41 @@ func (s *DatabaseStore) Put(key string, value interface{}, duration time.Duratio
 	item.Expiration = carbon.NewCarbon(time.Now().Add(duration)).Time
 
 	if err := s.DB.Create(item).Error; err != nil {
-		s.DB.Where("key = ?", key).Update(item)
+		return s.DB.Model(item).Where("key = ?", key).Updates(map[string]interface{}{
+			"value":      item.Value,
+			"expiration": item.Expiration,
+		}).Error
 	}
 
 	return nil
 }
 
+// Add value if there is no such item yet. Relies on the unique key index,
+// so only one of the servers sharing the table adds it. Expired item is replaced.
+func (s *DatabaseStore) Add(key string, value interface{}, duration time.Duration) (bool, error) {
+	serialized, err := serializeValue(value)
+	if err != nil {
+		return false, ErrorSerialize
+	}
+
+	item := s.makeItem()
+	item.Key = key
+	item.Value = base64.StdEncoding.EncodeToString(serialized)
+	item.Expiration = carbon.NewCarbon(time.Now().Add(duration)).Time
+
+	if err := s.DB.Create(item).Error; err == nil {
+		return true, nil
+	}
+
+	// Key is taken, but it may be expired and not pruned yet.
+	result := s.DB.Model(item).Where("key = ? AND expiration < ?", key, time.Now()).Updates(map[string]interface{}{
+		"value":      item.Value,
+		"expiration": item.Expiration,
+	})
+
+	return result.RowsAffected > 0, result.Error
+}
+
 // Get saved value by the key.
 func (s *DatabaseStore) Get(key string, target interface{}) error {
 	item := s.findItem(key)
@@ -124,6 +159,21 @@ func (s *DatabaseStore) Clear() {
 	s.DB.Delete(s.makeItem())
 }
 
+// ClearPrefix removes all keys starting with the prefix.
+func (s *DatabaseStore) ClearPrefix(prefix string) {
+	// "!" is used as escape character since backslash is treated differently by databases.
+	escaped := strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(prefix)
+
+	s.DB.Where("key LIKE ? ESCAPE '!'", escaped+"%").Delete(s.makeItem())
+}
+
+// PruneExpired removes items expired before the time.
+func (s *DatabaseStore) PruneExpired(before time.Time) (int64, error) {
+	result := s.DB.Where("expiration < ?", before).Delete(s.makeItem())
+
+	return result.RowsAffected, result.Error
+}
+
 func (s *DatabaseStore) makeItem() *DatabaseItem {
 	return &DatabaseItem{
 		tableName: s.table,
@@ -25,6 +25,9 @@ type Cache interface {
 	// Pull saved value by the key.
 	Pull(key string, target interface{}) error
 
+	// Add saves the value only if there is no such item yet and reports if it was saved.
+	Add(key string, value interface{}, duration time.Duration) (bool, error)
+
 	// Forget the value.
 	Forget(key string)
 
@@ -52,3 +55,21 @@ type Store interface {
 	// Clear storage.
 	Clear()
 }
+
+// NamedStore tells its name, so the store can be configured separately under Cache.Stores.<name>.
+type NamedStore interface {
+	// StoreName returns name of the store (ex. "memory").
+	StoreName() string
+}
+
+// AddingStore saves values only if keys are missing, atomically for all the servers sharing the store.
+type AddingStore interface {
+	// Add value if there is no such item yet and report if it was added.
+	Add(key string, value interface{}, duration time.Duration) (bool, error)
+}
+
+// ExpiringStore removes expired items at once, so they do not pile up.
+type ExpiringStore interface {
+	// PruneExpired removes items expired before the time and returns their amount.
+	PruneExpired(before time.Time) (int64, error)
+}
@@ -1,6 +1,8 @@
 package cache
 
 import (
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/lara-go/larago/support/collection"
@@ -16,6 +18,7 @@ type memoryItem struct {
 // InMemoryStore .
 type InMemoryStore struct {
 	store *collection.Collection
+	adds  sync.Mutex
 }
 
 // NewInMemoryStore .
@@ -25,6 +28,11 @@ func NewInMemoryStore() *InMemoryStore {
 	}
 }
 
+// StoreName returns name of the store.
+func (s *InMemoryStore) StoreName() string {
+	return "memory"
+}
+
 // Has checks if there is such item.
 func (s *InMemoryStore) Has(key string) bool {
 	return s.findItem(key) != nil
@@ -40,6 +48,18 @@ func (s *InMemoryStore) Put(key string, value interface{}, duration time.Duratio
 	return nil
 }
 
+// Add value if there is no such item yet.
+func (s *InMemoryStore) Add(key string, value interface{}, duration time.Duration) (bool, error) {
+	s.adds.Lock()
+	defer s.adds.Unlock()
+
+	if s.Has(key) {
+		return false, nil
+	}
+
+	return true, s.Put(key, value, duration)
+}
+
 // Get saved value by the key.
 func (s *InMemoryStore) Get(key string, target interface{}) error {
 	item := s.findItem(key)
@@ -82,3 +102,25 @@ func (s *InMemoryStore) Forget(key string) {
 func (s *InMemoryStore) Clear() {
 	s.store = collection.New()
 }
+
+// ClearPrefix removes all keys starting with the prefix.
+func (s *InMemoryStore) ClearPrefix(prefix string) {
+	for _, key := range s.store.Keys() {
+		if name, ok := key.(string); ok && strings.HasPrefix(name, prefix) {
+			s.store.Delete(key)
+		}
+	}
+}
+
+// PruneExpired removes items expired before the time.
+func (s *InMemoryStore) PruneExpired(before time.Time) (int64, error) {
+	var pruned int64
+	for _, key := range s.store.Keys() {
+		if item, ok := s.store.Get(key).(*memoryItem); ok && item.expiration.Before(before) {
+			s.store.Delete(key)
+			pruned++
+		}
+	}
+
+	return pruned, nil
+}
@@ -2,6 +2,7 @@ package cache_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/lara-go/larago/cache"
 )
@@ -17,3 +18,18 @@ func TestMemoryStore_Forget(t *testing.T) {
 func TestMemoryStore_Clear(t *testing.T) {
 	testClear(t, cache.NewInMemoryStore())
 }
+
+func TestMemoryStore_PruneExpired(t *testing.T) {
+	store := cache.NewInMemoryStore()
+	store.Put("expired", "value", -time.Minute)
+	store.Put("alive", "value", time.Minute)
+
+	pruned, err := store.PruneExpired(time.Now())
+	if err != nil || pruned != 1 {
+		t.Fatalf("Expected 1 pruned item, got %d (%v)", pruned, err)
+	}
+
+	if !store.Has("alive") {
+		t.Error("Alive item was pruned")
+	}
+}
@@ -0,0 +1,117 @@
+package cache
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// PrefixClearer is implemented by stores able to clear only keys with the prefix.
+type PrefixClearer interface {
+	// ClearPrefix removes all keys starting with the prefix.
+	ClearPrefix(prefix string)
+}
+
+// PrefixedStore namespaces keys of the underlying store,
+// so several applications can safely share one storage.
+type PrefixedStore struct {
+	store  Store
+	prefix string
+}
+
+// NewPrefixedStore constructor.
+func NewPrefixedStore(store Store, prefix string) *PrefixedStore {
+	return &PrefixedStore{
+		store:  store,
+		prefix: prefix,
+	}
+}
+
+// Prefix getter.
+func (s *PrefixedStore) Prefix() string {
+	return s.prefix
+}
+
+// Has checks if there is such item.
+func (s *PrefixedStore) Has(key string) bool {
+	return s.store.Has(s.prefix + key)
+}
+
+// Put value in store by key.
+func (s *PrefixedStore) Put(key string, value interface{}, duration time.Duration) error {
+	return s.store.Put(s.prefix+key, value, duration)
+}
+
+// Forever put value in store by key forever.
+func (s *PrefixedStore) Forever(key string, value interface{}) error {
+	return s.store.Forever(s.prefix+key, value)
+}
+
+// Get saved value by the key.
+func (s *PrefixedStore) Get(key string, target interface{}) error {
+	return s.store.Get(s.prefix+key, target)
+}
+
+// Add value if there is no such item yet. Underlying store must implement AddingStore.
+func (s *PrefixedStore) Add(key string, value interface{}, duration time.Duration) (bool, error) {
+	store, ok := s.store.(AddingStore)
+	if !ok {
+		return false, ErrorNotAdding
+	}
+
+	return store.Add(s.prefix+key, value, duration)
+}
+
+// Forget the value.
+func (s *PrefixedStore) Forget(key string) {
+	s.store.Forget(s.prefix + key)
+}
+
+// Clearable checks if keys of the namespace can be cleared.
+func (s *PrefixedStore) Clearable() bool {
+	if s.prefix == "" {
+		return true
+	}
+
+	_, ok := s.store.(PrefixClearer)
+
+	return ok
+}
+
+// Clear keys of this namespace only. Panics if underlying store can not clear keys by prefix,
+// as clearing it entirely would remove keys of the other namespaces. Check Clearable first.
+func (s *PrefixedStore) Clear() {
+	if s.prefix == "" {
+		s.store.Clear()
+
+		return
+	}
+
+	clearer, ok := s.store.(PrefixClearer)
+	if !ok {
+		panic(fmt.Errorf("Cache store %T can not clear keys with prefix %s", s.store, s.prefix))
+	}
+
+	clearer.ClearPrefix(s.prefix)
+}
+
+var nonSlugChars = regexp.MustCompile("[^a-z0-9]+")
+
+// DefaultPrefix makes keys prefix from application name and environment (ex. "my_app_production:").
+// Cache keys are prefixed with it unless Cache.Prefix is configured.
+func DefaultPrefix(name, env string) string {
+	parts := []string{}
+	for _, part := range []string{name, env} {
+		part = strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(part), "_"), "_")
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+
+	return strings.Join(parts, "_") + ":"
+}
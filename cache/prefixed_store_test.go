@@ -0,0 +1,66 @@
+package cache_test
+
+import (
+	"testing"
+
+	"github.com/lara-go/larago/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func testNamespaces(t *testing.T, store cache.Store) {
+	first := cache.NewPrefixedStore(store, "first_app:")
+	second := cache.NewPrefixedStore(store, "second_app:")
+
+	first.Forever("key", 1)
+	second.Forever("key", 2)
+
+	var value int
+	assert.Nil(t, first.Get("key", &value))
+	assert.Equal(t, 1, value)
+	assert.True(t, store.Has("second_app:key"))
+
+	first.Clear()
+
+	assert.False(t, first.Has("key"))
+	assert.True(t, second.Has("key"))
+}
+
+func TestPrefixedStore_Forget(t *testing.T) {
+	testForget(t, cache.NewPrefixedStore(cache.NewInMemoryStore(), "app:"))
+}
+
+func TestPrefixedStore_Clear(t *testing.T) {
+	testClear(t, cache.NewPrefixedStore(cache.NewInMemoryStore(), "app:"))
+}
+
+func TestPrefixedStore_MemoryNamespaces(t *testing.T) {
+	testNamespaces(t, cache.NewInMemoryStore())
+}
+
+func TestPrefixedStore_DatabaseNamespaces(t *testing.T) {
+	testNamespaces(t, databaseStoreFactory())
+}
+
+func TestDefaultPrefix(t *testing.T) {
+	assert.Equal(t, "my_app_production:", cache.DefaultPrefix("My App!", "production"))
+	assert.Equal(t, "testing:", cache.DefaultPrefix("", "testing"))
+	assert.Equal(t, "", cache.DefaultPrefix("", ""))
+}
+
+// Store which can not clear keys by prefix.
+type plainStore struct {
+	cache.Store
+}
+
+func TestPrefixedStore_ClearWithoutPrefixClearer(t *testing.T) {
+	store := cache.NewInMemoryStore()
+	store.Forever("other_app:key", 1)
+
+	prefixed := cache.NewPrefixedStore(plainStore{store}, "app:")
+	assert.False(t, prefixed.Clearable())
+	assert.Panics(t, prefixed.Clear)
+
+	// Keys of the other namespaces are kept.
+	assert.True(t, store.Has("other_app:key"))
+	assert.True(t, cache.NewPrefixedStore(store, "app:").Clearable())
+}
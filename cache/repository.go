@@ -117,6 +117,22 @@ func (r *Repository) Pull(key string, target interface{}) error {
 	return nil
 }
 
+// Add saves the value only if there is no such item yet and reports if it was saved.
+// Store must implement AddingStore.
+func (r *Repository) Add(key string, value interface{}, duration time.Duration) (bool, error) {
+	store, ok := r.store.(AddingStore)
+	if !ok {
+		return false, ErrorNotAdding
+	}
+
+	added, err := store.Add(key, value, duration)
+	if added {
+		r.event("cache.write", key, duration)
+	}
+
+	return added, err
+}
+
 // Forget the value.
 func (r *Repository) Forget(key string) {
 	r.store.Forget(key)
@@ -20,7 +20,7 @@ func repositoryFactory() *cache.Repository {
 }
 
 func events() *EventBus.EventBus {
-	events := EventBus.New()
+	events := EventBus.New().(*EventBus.EventBus)
 	events.Subscribe("cache.write", func(key string, duration time.Duration) {
 		fmt.Printf("Put '%s' for %s\n", key, duration)
 	})
@@ -2,6 +2,7 @@ package cache
 
 import (
 	"errors"
+	"fmt"
 
 	larago "github.com/lara-go/larago"
 )
@@ -20,9 +21,43 @@ func (p *ServiceProvider) Register(application *larago.Application) {
 			return nil, errors.New("Cannot resolve cache store. 'cache.store' is empty")
 		}
 
-		repository := NewRepository(store.(Store))
+		prefixed, err := p.prefixed(application, store.(Store))
+		if err != nil {
+			return nil, err
+		}
+
+		repository := NewRepository(prefixed)
 		application.Make(repository)
 
 		return repository, nil
 	}, "cache")
 }
+
+// Namespace keys of the store with application name and environment, so several applications can share one storage.
+// Prefix is configured for the store as Cache.Stores.<name>.Prefix or for all the stores as Cache.Prefix,
+// empty prefix leaves keys as they are.
+func (p *ServiceProvider) prefixed(application *larago.Application, store Store) (Store, error) {
+	prefix, err := p.prefix(application, store)
+	if err != nil || prefix == "" {
+		return store, err
+	}
+
+	return NewPrefixedStore(store, prefix), nil
+}
+
+func (p *ServiceProvider) prefix(application *larago.Application, store Store) (string, error) {
+	config := application.Config()
+
+	if named, ok := store.(NamedStore); ok {
+		key := fmt.Sprintf("Cache.Stores.%s.Prefix", named.StoreName())
+		if config.Has(key) {
+			return config.String(key)
+		}
+	}
+
+	if config.Has("Cache.Prefix") {
+		return config.String("Cache.Prefix")
+	}
+
+	return DefaultPrefix(application.Name, application.Environment()), nil
+}
@@ -0,0 +1,55 @@
+package cache_test
+
+import (
+	"testing"
+
+	larago "github.com/lara-go/larago"
+	"github.com/lara-go/larago/cache"
+	laragoevents "github.com/lara-go/larago/events"
+	"github.com/stretchr/testify/assert"
+)
+
+type cacheConfig struct {
+	Cache map[string]interface{}
+}
+
+func (c *cacheConfig) Env() string {
+	return "testing"
+}
+
+func (c *cacheConfig) Debug() bool {
+	return false
+}
+
+// Resolve cache with the config and return the store it writes to.
+func cacheStore(t *testing.T, config *cacheConfig) *cache.InMemoryStore {
+	store := cache.NewInMemoryStore()
+
+	application := larago.New()
+	application.Name = "Shop"
+	application.SetConfig(func() larago.Config { return config }).ImportConfig()
+	application.Instance(store, "cache.store")
+	application.Register(&laragoevents.ServiceProvider{}, &cache.ServiceProvider{})
+
+	application.Get("cache").(cache.Cache).Forever("key", 1)
+
+	return store
+}
+
+func TestServiceProviderPrefixesKeys(t *testing.T) {
+	// Application name and environment by default.
+	assert.True(t, cacheStore(t, &cacheConfig{}).Has("shop_testing:key"))
+
+	// Prefix for all the stores.
+	store := cacheStore(t, &cacheConfig{Cache: map[string]interface{}{"Prefix": "app:"}})
+	assert.True(t, store.Has("app:key"))
+
+	// Prefix of the store wins, empty one leaves keys as they are.
+	store = cacheStore(t, &cacheConfig{Cache: map[string]interface{}{
+		"Prefix": "app:",
+		"Stores": map[string]interface{}{
+			"memory": map[string]interface{}{"Prefix": ""},
+		},
+	}})
+	assert.True(t, store.Has("key"))
+}
@@ -40,6 +40,11 @@ func (k *Kernel) Handle() {
 		panic(err)
 	}
 
+	k.App().Run(os.Args)
+}
+
+// App makes cli application with all the registered commands.
+func (k *Kernel) App() *cli.App {
 	app := cli.NewApp()
 
 	app.Version = k.Application.Version
@@ -49,7 +54,7 @@ func (k *Kernel) Handle() {
 	app.Flags = k.getGlobalFlags()
 	app.Commands = k.makeCommands(k.Application.GetCommands())
 
-	app.Run(os.Args)
+	return app
 }
 
 // GetGlobalFlags registers global flags.
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Ask question and read the answer line from standard input.
+func Ask(question string) string {
+	fmt.Fprintf(os.Stdout, "%s ", question)
+
+	return readLine(os.Stdin)
+}
+
+// AskWithDefault returns default value if the answer is empty.
+func AskWithDefault(question, defaultValue string) string {
+	answer := Ask(fmt.Sprintf("%s [%s]", question, defaultValue))
+	if answer == "" {
+		return defaultValue
+	}
+
+	return answer
+}
+
+// Confirm asks yes/no question. Empty answer means no.
+func Confirm(question string) bool {
+	switch strings.ToLower(Ask(fmt.Sprintf("%s [y/N]", question))) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
+
+// Read line byte by byte, so the rest of the input stays for the next question.
+func readLine(reader io.Reader) string {
+	var line []byte
+	buffer := make([]byte, 1)
+
+	for {
+		n, err := reader.Read(buffer)
+		if n > 0 {
+			if buffer[0] == '\n' {
+				break
+			}
+
+			line = append(line, buffer[0])
+		}
+
+		if err != nil {
+			break
+		}
+	}
+
+	return strings.TrimSpace(string(line))
+}
@@ -2,6 +2,7 @@ package larago
 
 import (
 	"fmt"
+	"time"
 
 	dotaccess "github.com/maxwellhealth/go-dotaccess"
 )
@@ -32,6 +33,13 @@ func (c *ConfigRepository) Get(key string) interface{} {
 	return value
 }
 
+// Has checks if config has value by dot-notation key.
+func (c *ConfigRepository) Has(key string) bool {
+	_, err := dotaccess.Get(c.config, key)
+
+	return err == nil
+}
+
 // Set value to config using dot-notation.
 func (c *ConfigRepository) Set(key string, value interface{}) {
 	err := dotaccess.Set(c.config, key, value)
@@ -40,3 +48,80 @@ func (c *ConfigRepository) Set(key string, value interface{}) {
 		panic(fmt.Sprintf("Can not resolve config value: %s", key))
 	}
 }
+
+// String returns string value.
+func (c *ConfigRepository) String(key string) (string, error) {
+	value, ok := c.Get(key).(string)
+	if !ok {
+		return "", fmt.Errorf("Config value %s must be a string, got %T", key, c.Get(key))
+	}
+
+	return value, nil
+}
+
+// Int returns integer value. Whole numbers decoded from JSON are accepted as well.
+func (c *ConfigRepository) Int(key string) (int, error) {
+	switch value := c.Get(key).(type) {
+	case int:
+		return value, nil
+	case int64:
+		return int(value), nil
+	case float64:
+		if value == float64(int(value)) {
+			return int(value), nil
+		}
+	}
+
+	return 0, fmt.Errorf("Config value %s must be an integer, got %T", key, c.Get(key))
+}
+
+// Duration returns duration value. Strings like "30s" are parsed.
+func (c *ConfigRepository) Duration(key string) (time.Duration, error) {
+	switch value := c.Get(key).(type) {
+	case time.Duration:
+		return value, nil
+	case string:
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("Config value %s must be a duration: %s", key, err)
+		}
+
+		return duration, nil
+	}
+
+	return 0, fmt.Errorf("Config value %s must be a duration, got %T", key, c.Get(key))
+}
+
+// Strings returns list of strings. Lists decoded from JSON are accepted as well, null is an empty list.
+func (c *ConfigRepository) Strings(key string) ([]string, error) {
+	switch value := c.Get(key).(type) {
+	case nil:
+		return nil, nil
+	case []string:
+		return value, nil
+	case []interface{}:
+		items := make([]string, 0, len(value))
+		for _, item := range value {
+			text, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("Config value %s must be a list of strings, got %T item", key, item)
+			}
+
+			items = append(items, text)
+		}
+
+		return items, nil
+	}
+
+	return nil, fmt.Errorf("Config value %s must be a list of strings, got %T", key, c.Get(key))
+}
+
+// Bool returns boolean value.
+func (c *ConfigRepository) Bool(key string) (bool, error) {
+	value, ok := c.Get(key).(bool)
+	if !ok {
+		return false, fmt.Errorf("Config value %s must be a boolean, got %T", key, c.Get(key))
+	}
+
+	return value, nil
+}
@@ -17,6 +17,7 @@ type Container struct {
 	Instances *Bindings
 
 	tagsResolvers []TagsResolver
+	trace         *Trace
 }
 
 // New constructor.
@@ -117,15 +118,21 @@ func (c *Container) resolveService(abstract interface{}) *reflect.Value {
 func (c *Container) resolveBinding(abstract interface{}) (*reflect.Value, error) {
 	// If instance was already resolved, do not try to do it again.
 	if c.Instances.Has(abstract) {
+		c.traceResolution(abstract, true, nil)
+
 		return c.Instances.Get(abstract), nil
 	}
 
 	if !c.Bindings.Has(abstract) {
-		return nil, fmt.Errorf("Unknown service %s", abstract)
+		err := fmt.Errorf("Unknown service %s", abstract)
+		c.traceResolution(abstract, false, err)
+
+		return nil, err
 	}
 
 	concrete := c.Bindings.Get(abstract)
 	resolved, err := c.resolve(concrete)
+	c.traceResolution(abstract, false, err)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,81 @@
+package container
+
+import (
+	"sync"
+)
+
+// Resolution of the single service.
+type Resolution struct {
+	Abstract string
+	Cached   bool
+	Error    string
+}
+
+// Trace keeps the latest resolutions made by the traced container.
+type Trace struct {
+	mutex   sync.Mutex
+	entries []Resolution
+	next    int
+	full    bool
+}
+
+// NewTrace constructor.
+func NewTrace(size int) *Trace {
+	return &Trace{
+		entries: make([]Resolution, size),
+	}
+}
+
+// Record resolution.
+func (t *Trace) Record(resolution Resolution) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if len(t.entries) == 0 {
+		return
+	}
+
+	t.entries[t.next] = resolution
+	t.next = (t.next + 1) % len(t.entries)
+	if t.next == 0 {
+		t.full = true
+	}
+}
+
+// Recent returns resolutions from the oldest to the newest.
+func (t *Trace) Recent() []Resolution {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if !t.full {
+		return append([]Resolution(nil), t.entries[:t.next]...)
+	}
+
+	return append(append([]Resolution(nil), t.entries[t.next:]...), t.entries[:t.next]...)
+}
+
+// Traced returns container sharing bindings and instances, which records its resolutions into the trace.
+// Ex. router traces resolutions of every request separately in debug mode.
+func (c *Container) Traced(trace *Trace) *Container {
+	traced := *c
+	traced.trace = trace
+
+	return &traced
+}
+
+// Record resolution of the abstract.
+func (c *Container) traceResolution(abstract interface{}, cached bool, err error) {
+	if c.trace == nil {
+		return
+	}
+
+	resolution := Resolution{
+		Abstract: normalizeAbstract(abstract),
+		Cached:   cached,
+	}
+	if err != nil {
+		resolution.Error = err.Error()
+	}
+
+	c.trace.Record(resolution)
+}
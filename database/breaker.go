@@ -0,0 +1,102 @@
+package database
+
+import (
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago/support/breaker"
+)
+
+// Scope key for the breaker completion callback.
+const breakerDoneKey = "larago:breaker_done"
+
+// UseBreaker guards every query of the connection with the circuit breaker.
+// Queries fail fast with breaker.ErrorOpen while database is considered down.
+// Not found records are not counted as failures.
+func UseBreaker(db *gorm.DB, b *breaker.Breaker) {
+	before := func(scope *gorm.Scope) {
+		done, err := b.Allow()
+		if err != nil {
+			scope.Err(err)
+
+			return
+		}
+
+		scope.Set(breakerDoneKey, done)
+	}
+
+	after := func(scope *gorm.Scope) {
+		finish(scope, scope.DB().Error)
+	}
+
+	// Row queries run even if the scope has errors and keep their own ones in the result.
+	rowBefore := func(scope *gorm.Scope) {
+		before(scope)
+
+		if scope.HasError() {
+			if result, ok := rowsQueryResult(scope); ok {
+				result.Error = scope.DB().Error
+				scope.InstanceSet(rowQueryResultKey, nil)
+			}
+		}
+	}
+
+	rowAfter := func(scope *gorm.Scope) {
+		err := scope.DB().Error
+
+		if value, ok := scope.InstanceGet(rowQueryResultKey); ok {
+			switch result := value.(type) {
+			case *gorm.RowsQueryResult:
+				if result.Error != nil {
+					err = result.Error
+				}
+			case *gorm.RowQueryResult:
+				if result.Row != nil && result.Row.Err() != nil {
+					err = result.Row.Err()
+				}
+			}
+		}
+
+		finish(scope, err)
+	}
+
+	callbacks := db.Callback()
+
+	callbacks.Create().Before("gorm:begin_transaction").Register("larago:breaker_before", before)
+	callbacks.Create().After("gorm:commit_or_rollback_transaction").Register("larago:breaker_after", after)
+	callbacks.Update().Before("gorm:begin_transaction").Register("larago:breaker_before", before)
+	callbacks.Update().After("gorm:commit_or_rollback_transaction").Register("larago:breaker_after", after)
+	callbacks.Delete().Before("gorm:begin_transaction").Register("larago:breaker_before", before)
+	callbacks.Delete().After("gorm:commit_or_rollback_transaction").Register("larago:breaker_after", after)
+	callbacks.Query().Before("gorm:query").Register("larago:breaker_before", before)
+	callbacks.Query().After("gorm:after_query").Register("larago:breaker_after", after)
+	callbacks.RowQuery().Before("gorm:row_query").Register("larago:breaker_before", rowBefore)
+	callbacks.RowQuery().After("gorm:row_query").Register("larago:breaker_after", rowAfter)
+}
+
+// Instance key gorm keeps results of row queries under.
+const rowQueryResultKey = "row_query_result"
+
+// Result of the query returning rows.
+func rowsQueryResult(scope *gorm.Scope) (*gorm.RowsQueryResult, bool) {
+	value, ok := scope.InstanceGet(rowQueryResultKey)
+	if !ok {
+		return nil, false
+	}
+
+	result, ok := value.(*gorm.RowsQueryResult)
+
+	return result, ok
+}
+
+// Report result of the query allowed by the breaker.
+func finish(scope *gorm.Scope, err error) {
+	value, ok := scope.Get(breakerDoneKey)
+	if !ok {
+		return
+	}
+
+	if err == gorm.ErrRecordNotFound {
+		err = nil
+	}
+
+	value.(func(error))(err)
+}
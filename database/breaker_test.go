@@ -0,0 +1,24 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/lara-go/larago/database"
+	"github.com/lara-go/larago/support/breaker"
+	"github.com/lara-go/larago/support/testsuite"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreakerCountsRowQueries(t *testing.T) {
+	db := testsuite.MemoryDB(t)
+
+	b := breaker.New("db", breaker.Settings{FailureThreshold: 1})
+	database.UseBreaker(db, b)
+
+	_, err := db.Raw("SELECT * FROM missing").Rows()
+	assert.Error(t, err)
+	assert.Equal(t, breaker.StateOpen, b.State())
+
+	_, err = db.Raw("SELECT 1").Rows()
+	assert.Equal(t, breaker.ErrorOpen, err)
+}
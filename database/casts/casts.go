@@ -0,0 +1,101 @@
+package casts
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Cast converts attribute between database and application representation.
+// Implement it on own types to make custom casts: Value is called on write, Scan on read.
+type Cast interface {
+	sql.Scanner
+	driver.Valuer
+}
+
+// Int attribute stored in any numeric or text column.
+type Int int64
+
+// Value for the database.
+func (i Int) Value() (driver.Value, error) {
+	return int64(i), nil
+}
+
+// Scan value from the database.
+func (i *Int) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*i = 0
+	case int64:
+		*i = Int(v)
+	case float64:
+		*i = Int(v)
+	case bool:
+		*i = 0
+		if v {
+			*i = 1
+		}
+	case []byte, string:
+		text := strings.TrimSpace(toString(v))
+		if text == "" {
+			*i = 0
+			return nil
+		}
+
+		parsed, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return fmt.Errorf("Can't cast %q to int", text)
+		}
+		*i = Int(parsed)
+	default:
+		return fmt.Errorf("Can't cast %T to int", src)
+	}
+
+	return nil
+}
+
+// Bool attribute stored as number or text ("1", "true", "yes", "on").
+type Bool bool
+
+// Value for the database.
+func (b Bool) Value() (driver.Value, error) {
+	return bool(b), nil
+}
+
+// Scan value from the database.
+func (b *Bool) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*b = false
+	case bool:
+		*b = Bool(v)
+	case int64:
+		*b = v != 0
+	case float64:
+		*b = v != 0
+	case []byte, string:
+		switch strings.ToLower(strings.TrimSpace(toString(v))) {
+		case "1", "true", "t", "yes", "y", "on":
+			*b = true
+		case "", "0", "false", "f", "no", "n", "off":
+			*b = false
+		default:
+			return fmt.Errorf("Can't cast %q to bool", toString(v))
+		}
+	default:
+		return fmt.Errorf("Can't cast %T to bool", src)
+	}
+
+	return nil
+}
+
+// Convert text value to string.
+func toString(value interface{}) string {
+	if bytes, ok := value.([]byte); ok {
+		return string(bytes)
+	}
+
+	return value.(string)
+}
@@ -0,0 +1,161 @@
+package casts_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lara-go/larago/database/casts"
+	"github.com/lara-go/larago/support/testsuite"
+	"github.com/stretchr/testify/assert"
+
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+)
+
+type address struct {
+	City string `json:"city"`
+}
+
+type event struct {
+	ID        uint            `gorm:"primary_key"`
+	Attendees casts.Int       `gorm:"type:varchar(10)"`
+	Public    casts.Bool      `gorm:"type:varchar(5)"`
+	StartsAt  casts.DateTime  `gorm:"type:varchar(20)"`
+	Day       casts.Date      `gorm:"type:varchar(10)"`
+	CreatedAt casts.Unix      `gorm:"type:integer"`
+	Meta      casts.JSONMap   `gorm:"type:text"`
+	Tags      casts.JSONArray `gorm:"type:text"`
+}
+
+func TestCastsRoundTrip(t *testing.T) {
+	db := testsuite.MemoryDB(t, &event{})
+
+	startsAt := time.Date(2018, 3, 10, 18, 30, 0, 0, time.FixedZone("EET", 2*60*60))
+
+	assert.Nil(t, db.Create(&event{
+		Attendees: 42,
+		Public:    true,
+		StartsAt:  casts.DateTime{Time: startsAt},
+		Day:       casts.Date{Time: startsAt},
+		CreatedAt: casts.Unix{Time: startsAt},
+		Meta:      casts.JSONMap{"address": address{City: "Kyiv"}},
+		Tags:      casts.JSONArray{"go", "web"},
+	}).Error)
+
+	var raw struct {
+		Attendees string
+		Public    string
+		StartsAt  string
+		Day       string
+	}
+	db.Table("events").Select("attendees, public, starts_at, day").Scan(&raw)
+	assert.Equal(t, "2018-03-10 16:30:00", raw.StartsAt)
+	assert.Equal(t, "2018-03-10", raw.Day)
+
+	var loaded event
+	assert.Nil(t, db.First(&loaded).Error)
+
+	assert.Equal(t, casts.Int(42), loaded.Attendees)
+	assert.Equal(t, casts.Bool(true), loaded.Public)
+	assert.True(t, startsAt.Equal(loaded.StartsAt.Time))
+	assert.True(t, startsAt.Equal(loaded.CreatedAt.Time))
+	assert.Equal(t, 10, loaded.Day.Day())
+	assert.Equal(t, "Kyiv", loaded.Meta["address"].(map[string]interface{})["city"])
+	assert.Equal(t, casts.JSONArray{"go", "web"}, loaded.Tags)
+}
+
+func TestScanFromText(t *testing.T) {
+	var i casts.Int
+	assert.Nil(t, i.Scan([]byte(" 15 ")))
+	assert.Equal(t, casts.Int(15), i)
+	assert.Error(t, i.Scan("abc"))
+
+	var b casts.Bool
+	assert.Nil(t, b.Scan("yes"))
+	assert.Equal(t, casts.Bool(true), b)
+	assert.Nil(t, b.Scan(int64(0)))
+	assert.Equal(t, casts.Bool(false), b)
+
+	var a address
+	assert.Nil(t, casts.ScanJSON(`{"city":"Lviv"}`, &a))
+	assert.Equal(t, "Lviv", a.City)
+}
+
+func TestScanJSONResetsTarget(t *testing.T) {
+	meta := casts.JSONMap{}
+	assert.Nil(t, meta.Scan(`{"city":"Lviv","zip":"79000"}`))
+	assert.Nil(t, meta.Scan(`{"city":"Kyiv"}`))
+	assert.Equal(t, casts.JSONMap{"city": "Kyiv"}, meta)
+
+	assert.Nil(t, meta.Scan(nil))
+	assert.Nil(t, meta)
+
+	a := address{City: "Lviv"}
+	assert.Nil(t, casts.ScanJSON(nil, &a))
+	assert.Equal(t, "", a.City)
+}
+
+func TestDateTimeIsUTC(t *testing.T) {
+	startsAt := time.Date(2018, 3, 10, 18, 30, 0, 0, time.FixedZone("EET", 2*60*60))
+
+	value, _ := casts.DateTime{Time: startsAt}.Value()
+	assert.Equal(t, "2018-03-10 16:30:00", value)
+
+	var scanned casts.DateTime
+	assert.Nil(t, scanned.Scan(value))
+	assert.True(t, startsAt.Equal(scanned.Time))
+	assert.Equal(t, time.UTC, scanned.Location())
+}
+
+type prefixEncrypter struct {
+	prefix string
+}
+
+func (e *prefixEncrypter) EncryptString(value string) (string, error) {
+	return e.prefix + value, nil
+}
+
+func (e *prefixEncrypter) DecryptString(payload string) (string, error) {
+	if !strings.HasPrefix(payload, e.prefix) {
+		return "", errors.New("Invalid payload")
+	}
+
+	return strings.TrimPrefix(payload, e.prefix), nil
+}
+
+type card struct {
+	ID     uint            `gorm:"primary_key"`
+	Number casts.Encrypted `gorm:"type:text"`
+}
+
+func TestConnectionEncrypter(t *testing.T) {
+	db := testsuite.MemoryDB(t, &card{})
+	casts.RegisterEncrypter(db, func() (casts.Encrypter, error) {
+		return &prefixEncrypter{prefix: "connection:"}, nil
+	})
+
+	stored := func() string {
+		var payload string
+		assert.Nil(t, db.Table("cards").Select("number").Row().Scan(&payload))
+		return payload
+	}
+
+	c := &card{Number: "4242"}
+	assert.Nil(t, db.Create(c).Error)
+	assert.Equal(t, "connection:4242", stored())
+
+	// Model keeps plain value.
+	assert.Equal(t, casts.Encrypted("4242"), c.Number)
+
+	assert.Nil(t, db.Model(c).Update("number", casts.Encrypted("1111")).Error)
+	assert.Equal(t, "connection:1111", stored())
+
+	c.Number = "2222"
+	assert.Nil(t, db.Save(c).Error)
+	assert.Equal(t, "connection:2222", stored())
+
+	var loaded card
+	assert.Nil(t, db.First(&loaded).Error)
+	assert.Equal(t, casts.Encrypted("2222"), loaded.Number)
+}
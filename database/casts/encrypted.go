@@ -0,0 +1,204 @@
+package casts
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Encrypter used by encrypted casts.
+type Encrypter interface {
+	EncryptString(value string) (string, error)
+	DecryptString(payload string) (string, error)
+}
+
+// EncrypterResolver returns encrypter of the connection.
+type EncrypterResolver func() (Encrypter, error)
+
+var (
+	encrypter Encrypter
+	resolvers []EncrypterResolver
+	lock      sync.RWMutex
+)
+
+// ErrorNoEncrypter is returned when encrypted attributes are used without App.Key.
+var ErrorNoEncrypter = errors.New("Encrypter is not set. Check App.Key config value")
+
+// SetEncrypter used for encrypted attributes of connections without their own encrypter and raw queries.
+func SetEncrypter(e Encrypter) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	encrypter = e
+}
+
+// RegisterEncrypter encrypts attributes saved via the connection with its own encrypter,
+// so applications sharing the process keep their keys. Called by database service provider.
+// Loaded attributes are decrypted by the encrypter which key they were encrypted with.
+func RegisterEncrypter(db *gorm.DB, resolve EncrypterResolver) {
+	lock.Lock()
+	resolvers = append(resolvers, resolve)
+	lock.Unlock()
+
+	encrypt := encryptCallback(resolve)
+
+	callbacks := db.Callback()
+	callbacks.Create().Before("gorm:create").Register("larago:encrypt", encrypt)
+	callbacks.Update().Before("gorm:update").Register("larago:encrypt", encrypt)
+}
+
+// Replace encrypted attributes of saved model with payloads encrypted by the connection encrypter.
+// Model itself keeps plain values.
+func encryptCallback(resolve EncrypterResolver) func(scope *gorm.Scope) {
+	return func(scope *gorm.Scope) {
+		if scope.HasError() {
+			return
+		}
+
+		var enc Encrypter
+		encrypt := func(value interface{}) (interface{}, bool) {
+			var plain string
+			switch v := value.(type) {
+			case Encrypted:
+				plain = string(v)
+			case *Encrypted:
+				if v == nil {
+					return nil, false
+				}
+				plain = string(*v)
+			default:
+				return nil, false
+			}
+
+			if enc == nil {
+				resolved, err := resolve()
+				if err != nil {
+					scope.Err(err)
+					return nil, false
+				}
+				enc = resolved
+			}
+
+			payload, err := enc.EncryptString(plain)
+			if err != nil {
+				scope.Err(err)
+				return nil, false
+			}
+
+			return payload, true
+		}
+
+		for _, field := range scope.Fields() {
+			if !field.Field.IsValid() || !field.Field.CanInterface() {
+				continue
+			}
+
+			if payload, ok := encrypt(field.Field.Interface()); ok {
+				field.Field = reflect.ValueOf(payload)
+			}
+		}
+
+		if attrs, ok := scope.InstanceGet("gorm:update_attrs"); ok {
+			if updates, ok := attrs.(map[string]interface{}); ok {
+				for key, value := range updates {
+					if payload, ok := encrypt(value); ok {
+						updates[key] = payload
+					}
+				}
+			}
+		}
+	}
+}
+
+// GetEncrypter used for encrypted attributes.
+func GetEncrypter() (Encrypter, error) {
+	lock.RLock()
+	defer lock.RUnlock()
+
+	if encrypter == nil {
+		return nil, ErrorNoEncrypter
+	}
+
+	return encrypter, nil
+}
+
+// Encrypted string is stored encrypted with application key and decrypted when loaded.
+// Column must be wide enough for the payload, so use text type: `gorm:"type:text"`.
+type Encrypted string
+
+// String value.
+func (e Encrypted) String() string {
+	return string(e)
+}
+
+// Value encrypts attribute before saving.
+func (e Encrypted) Value() (driver.Value, error) {
+	enc, err := GetEncrypter()
+	if err != nil {
+		return nil, err
+	}
+
+	return enc.EncryptString(string(e))
+}
+
+// Scan decrypts stored attribute.
+func (e *Encrypted) Scan(src interface{}) error {
+	var payload string
+
+	switch v := src.(type) {
+	case nil:
+		*e = ""
+		return nil
+	case string:
+		payload = v
+	case []byte:
+		payload = string(v)
+	default:
+		return fmt.Errorf("Can't scan %T into encrypted attribute", src)
+	}
+
+	value, err := decrypt(payload)
+	if err != nil {
+		return err
+	}
+
+	*e = Encrypted(value)
+
+	return nil
+}
+
+// Decrypt payload with the global encrypter or encrypters of the connections.
+// Authenticated encryption fails with other keys, so only the key which encrypted the payload decrypts it.
+func decrypt(payload string) (string, error) {
+	lock.RLock()
+	global, connections := encrypter, resolvers
+	lock.RUnlock()
+
+	err := ErrorNoEncrypter
+	if global != nil {
+		value, decryptErr := global.DecryptString(payload)
+		if decryptErr == nil {
+			return value, nil
+		}
+		err = decryptErr
+	}
+
+	for _, resolve := range connections {
+		enc, resolveErr := resolve()
+		if resolveErr != nil {
+			continue
+		}
+
+		value, decryptErr := enc.DecryptString(payload)
+		if decryptErr == nil {
+			return value, nil
+		}
+		err = decryptErr
+	}
+
+	return "", err
+}
@@ -0,0 +1,80 @@
+package casts
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// JSONMap attribute stored as JSON object.
+type JSONMap map[string]interface{}
+
+// Value for the database.
+func (m JSONMap) Value() (driver.Value, error) {
+	return JSONValue(m)
+}
+
+// Scan value from the database.
+func (m *JSONMap) Scan(src interface{}) error {
+	return ScanJSON(src, m)
+}
+
+// JSONArray attribute stored as JSON array.
+type JSONArray []interface{}
+
+// Value for the database.
+func (a JSONArray) Value() (driver.Value, error) {
+	return JSONValue(a)
+}
+
+// Scan value from the database.
+func (a *JSONArray) Scan(src interface{}) error {
+	return ScanJSON(src, a)
+}
+
+// JSONValue encodes value for the database. Nil is stored as NULL.
+// Use it to cast own structs:
+//
+//	func (a Address) Value() (driver.Value, error) { return casts.JSONValue(a) }
+//	func (a *Address) Scan(src interface{}) error { return casts.ScanJSON(src, a) }
+func JSONValue(value interface{}) (driver.Value, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if string(encoded) == "null" {
+		return nil, nil
+	}
+
+	return string(encoded), nil
+}
+
+// ScanJSON decodes database value into target. Target is reset first,
+// so scanned NULL is zero value and maps do not keep keys of the previous row.
+func ScanJSON(src interface{}, target interface{}) error {
+	pointer := reflect.ValueOf(target)
+	if pointer.Kind() != reflect.Ptr || pointer.IsNil() {
+		return fmt.Errorf("Can't scan JSON into %T", target)
+	}
+	pointer.Elem().Set(reflect.Zero(pointer.Elem().Type()))
+
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte, string:
+		text := toString(v)
+		if text == "" {
+			return nil
+		}
+
+		return json.Unmarshal([]byte(text), target)
+	default:
+		return fmt.Errorf("Can't cast %T to JSON", src)
+	}
+}
@@ -0,0 +1,138 @@
+package casts
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Layouts tried when time is stored as text.
+var Layouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04:05.999999999-07:00",
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// DateTime attribute stored as "2006-01-02 15:04:05" text in UTC.
+type DateTime struct {
+	time.Time
+}
+
+// Value for the database.
+func (t DateTime) Value() (driver.Value, error) {
+	return formatTime(t.Time.UTC(), "2006-01-02 15:04:05"), nil
+}
+
+// Scan value from the database.
+func (t *DateTime) Scan(src interface{}) error {
+	return scanTime(src, &t.Time)
+}
+
+// Date attribute stored as "2006-01-02" text.
+type Date struct {
+	time.Time
+}
+
+// Value for the database.
+func (t Date) Value() (driver.Value, error) {
+	return formatTime(t.Time, "2006-01-02"), nil
+}
+
+// Scan value from the database.
+func (t *Date) Scan(src interface{}) error {
+	return scanTime(src, &t.Time)
+}
+
+// Unix attribute stored as seconds since epoch.
+type Unix struct {
+	time.Time
+}
+
+// Value for the database.
+func (t Unix) Value() (driver.Value, error) {
+	if t.IsZero() {
+		return nil, nil
+	}
+
+	return t.Time.Unix(), nil
+}
+
+// Scan value from the database.
+func (t *Unix) Scan(src interface{}) error {
+	var seconds Int
+	if src == nil {
+		t.Time = time.Time{}
+		return nil
+	}
+
+	if err := seconds.Scan(src); err != nil {
+		return err
+	}
+
+	t.Time = time.Unix(int64(seconds), 0)
+
+	return nil
+}
+
+// ParseTime parses text using the layouts, or Layouts if none given.
+// Text without zone is taken as UTC.
+func ParseTime(value string, layouts ...string) (time.Time, error) {
+	if len(layouts) == 0 {
+		layouts = Layouts
+	}
+
+	value = strings.TrimSpace(value)
+	for _, layout := range layouts {
+		if parsed, err := time.ParseInLocation(layout, value, time.UTC); err == nil {
+			return parsed, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("Can't cast %q to time", value)
+}
+
+// Zero time is stored as NULL.
+func formatTime(t time.Time, layout string) driver.Value {
+	if t.IsZero() {
+		return nil
+	}
+
+	return t.Format(layout)
+}
+
+// Scan time stored natively, as text or as unix timestamp.
+func scanTime(src interface{}, target *time.Time) error {
+	switch v := src.(type) {
+	case nil:
+		*target = time.Time{}
+	case time.Time:
+		*target = v
+	case int64:
+		*target = time.Unix(v, 0)
+	case []byte, string:
+		text := toString(v)
+		if text == "" {
+			*target = time.Time{}
+			return nil
+		}
+
+		if seconds, err := strconv.ParseInt(text, 10, 64); err == nil {
+			*target = time.Unix(seconds, 0)
+			return nil
+		}
+
+		parsed, err := ParseTime(text)
+		if err != nil {
+			return err
+		}
+		*target = parsed
+	default:
+		return fmt.Errorf("Can't cast %T to time", src)
+	}
+
+	return nil
+}
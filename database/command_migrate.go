@@ -1,9 +1,11 @@
 package database
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago"
 	"github.com/lara-go/larago/logger"
 
 	"github.com/urfave/cli"
@@ -11,22 +13,49 @@ import (
 
 // CommandMigrate to apply DB changes.
 type CommandMigrate struct {
-	DB       *gorm.DB
-	Migrator *Migrator
-	Logger   *logger.Logger
+	DB          *gorm.DB
+	Migrator    *Migrator
+	Application *larago.Application
+	Logger      *logger.Logger
+
+	pretend bool
+	force   bool
 }
 
 // GetCommand for the cli to register.
 func (c *CommandMigrate) GetCommand() cli.Command {
 	return cli.Command{
-		Name:     "migrate",
-		Usage:    "Migrate database",
-		Category: "Migrations",
+		Name:      "migrate",
+		Usage:     "Migrate database",
+		UsageText: "Destructive migrations (dropping tables or columns, deleting rows) are not run in production without --force.\n",
+		Category:  "Migrations",
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:        "pretend",
+				Usage:       "print SQL of pending migrations without running them",
+				Destination: &c.pretend,
+			},
+			cli.BoolFlag{
+				Name:        "force",
+				Usage:       "run destructive migrations in production",
+				Destination: &c.force,
+			},
+		},
 	}
 }
 
 // Handle command.
 func (c *CommandMigrate) Handle(args cli.Args) error {
+	if c.pretend {
+		return c.printPretended()
+	}
+
+	if c.Application.IsProduction() && !c.force {
+		if err := c.checkDestructive(); err != nil {
+			return err
+		}
+	}
+
 	// Run migrations.
 	err := c.Migrator.Migrate(c.DB)
 	if err != nil {
@@ -37,3 +66,52 @@ func (c *CommandMigrate) Handle(args cli.Args) error {
 
 	return nil
 }
+
+// Print SQL of pending migrations.
+func (c *CommandMigrate) printPretended() error {
+	pretended, err := c.Migrator.Pretend(c.DB)
+	if err != nil {
+		return fmt.Errorf("Could not pretend: %v", err.Error())
+	}
+
+	if len(pretended) == 0 {
+		c.Logger.Info("Nothing to migrate.")
+		return nil
+	}
+
+	for _, migration := range pretended {
+		c.Logger.Info("%s", migration.ID)
+
+		for _, statement := range migration.Statements {
+			if IsDestructive(statement) {
+				c.Logger.Warning("%s", statement)
+			} else {
+				c.Logger.Println(statement)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Refuse to run destructive migrations.
+func (c *CommandMigrate) checkDestructive() error {
+	pretended, err := c.Migrator.Pretend(c.DB)
+	if err != nil {
+		return fmt.Errorf("Could not check migrations: %v", err.Error())
+	}
+
+	destructive := false
+	for _, migration := range pretended {
+		for _, statement := range migration.Destructive() {
+			c.Logger.Warning("%s: %s", migration.ID, statement)
+			destructive = true
+		}
+	}
+
+	if destructive {
+		return errors.New("Migrations above are destructive, run with --force to apply them in production")
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago/encryption"
+	"github.com/lara-go/larago/logger"
+
+	"github.com/urfave/cli"
+)
+
+// CommandRotateKey re-encrypts encrypted columns with the current key.
+type CommandRotateKey struct {
+	DB        *gorm.DB
+	Encrypter *encryption.Encrypter
+	Logger    *logger.Logger
+
+	primaryKey string
+	batchSize  int
+}
+
+// GetCommand for the cli to register.
+func (c *CommandRotateKey) GetCommand() cli.Command {
+	return cli.Command{
+		Name:      "db:rotate-key",
+		Usage:     "Re-encrypt encrypted columns with the current key",
+		UsageText: "Set new App.Key, move the old one to App.PreviousKeys and run the command. Remove the old key after that.\n",
+		Category:  "Database",
+		ArgsUsage: "[table:column,column ...]",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:        "primary-key",
+				Value:       "id",
+				Usage:       "primary key column used to walk over rows",
+				Destination: &c.primaryKey,
+			},
+			cli.IntFlag{
+				Name:        "batch",
+				Value:       500,
+				Usage:       "rows updated in one transaction",
+				Destination: &c.batchSize,
+			},
+		},
+	}
+}
+
+// Handle command.
+func (c *CommandRotateKey) Handle(args cli.Args) error {
+	if len(args) == 0 {
+		return fmt.Errorf("Specify columns to rotate, ex. users:ssn,notes")
+	}
+
+	rotator := NewKeyRotator(c.DB, c.Encrypter).
+		SetPrimaryKey(c.primaryKey).
+		SetBatchSize(c.batchSize)
+
+	for _, arg := range args {
+		parts := strings.SplitN(arg, ":", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return fmt.Errorf("Invalid argument %s, expected table:column,column", arg)
+		}
+
+		rotated, err := rotator.Rotate(parts[0], strings.Split(parts[1], ",")...)
+		if err != nil {
+			return err
+		}
+
+		c.Logger.Success("Rotated %d rows of %s.", rotated, parts[0])
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package database
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago/logger"
+
+	"github.com/urfave/cli"
+)
+
+// CommandSchemaDump exports current schema to the SQL file.
+type CommandSchemaDump struct {
+	DB      *gorm.DB
+	Manager *Manager
+	Logger  *logger.Logger
+
+	output string
+}
+
+// GetCommand for the cli to register.
+func (c *CommandSchemaDump) GetCommand() cli.Command {
+	return cli.Command{
+		Name:      "schema:dump",
+		Usage:     "Dump database schema",
+		UsageText: "Dumps current schema with the list of ran migrations, so fresh databases will load it before running only newer migrations.\n",
+		Category:  "Migrations",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:        "output, o",
+				Usage:       "path to the dump file",
+				Destination: &c.output,
+			},
+		},
+	}
+}
+
+// Handle command.
+func (c *CommandSchemaDump) Handle(args cli.Args) error {
+	dialect := c.DB.Dialect().GetName()
+
+	dumper, err := NewSchemaDumper(dialect, c.Manager.DSN)
+	if err != nil {
+		return err
+	}
+
+	schema, err := DumpSchema(c.DB, dumper)
+	if err != nil {
+		return fmt.Errorf("Could not dump schema: %s", err)
+	}
+
+	fileName := c.output
+	if fileName == "" {
+		fileName = SchemaPath(dialect)
+	}
+
+	if err := os.MkdirAll(path.Dir(fileName), 0755); err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(fileName, []byte(schema), 0644); err != nil {
+		return err
+	}
+
+	c.Logger.Success("Database schema dumped to: %s", fileName)
+
+	return nil
+}
@@ -0,0 +1,29 @@
+package database
+
+import (
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago"
+)
+
+// FacadeWrapper for facade.
+var FacadeWrapper = &larago.Facade{}
+
+// Facade for model cache.
+func Facade() *ModelCache {
+	return FacadeWrapper.Resolve("db.cache").(*ModelCache)
+}
+
+// FindCached finds model by primary key hitting the model cache first.
+func FindCached(db *gorm.DB, model CachedModel, id interface{}) error {
+	return Facade().Find(db, model, id)
+}
+
+// Scopes returns global scopes of the models.
+func Scopes() *GlobalScopes {
+	return FacadeWrapper.Resolve("db.scopes").(*GlobalScopes)
+}
+
+// Morphs returns map of polymorphic types.
+func Morphs() *MorphMap {
+	return FacadeWrapper.Resolve("db.morphs").(*MorphMap)
+}
@@ -0,0 +1,224 @@
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+)
+
+// JSON path like "meta->plan->tier" or "meta->tags->0".
+type jsonPath struct {
+	column string
+	keys   []string
+}
+
+// Parse path into column and keys.
+func parseJSONPath(path string) jsonPath {
+	parts := strings.Split(path, "->")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+
+	return jsonPath{
+		column: parts[0],
+		keys:   parts[1:],
+	}
+}
+
+// Path in MySQL and SQLite syntax: $."plan"[0].
+func (p jsonPath) dollar() string {
+	path := "$"
+	for _, key := range p.keys {
+		if _, err := strconv.Atoi(key); err == nil {
+			path += "[" + key + "]"
+		} else {
+			path += `."` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(key) + `"`
+		}
+	}
+
+	return path
+}
+
+// Path in Postgres array syntax: {"plan","0"}.
+func (p jsonPath) array() string {
+	keys := make([]string, len(p.keys))
+	for i, key := range p.keys {
+		keys[i] = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(key) + `"`
+	}
+
+	return "{" + strings.Join(keys, ",") + "}"
+}
+
+// WhereJSON filters by value inside JSON column: db.Scopes(WhereJSON("meta->plan", "pro")).
+func WhereJSON(path string, value interface{}) Scope {
+	return WhereJSONOp(path, "=", value)
+}
+
+// WhereJSONOp compares value inside JSON column with operator.
+func WhereJSONOp(path, operator string, value interface{}) Scope {
+	p := parseJSONPath(path)
+
+	return func(db *gorm.DB) *gorm.DB {
+		column := db.Dialect().Quote(p.column)
+		operator, err := safeOperator(operator)
+		if err != nil {
+			db.AddError(err)
+			return db
+		}
+
+		if value == nil {
+			return whereJSONNull(db, column, p, operator)
+		}
+
+		switch db.Dialect().GetName() {
+		case "postgres":
+			if isNumber(value) {
+				return db.Where(fmt.Sprintf("(%s #>> ?)::numeric %s ?", column, operator), p.array(), value)
+			}
+
+			return db.Where(fmt.Sprintf("%s #>> ? %s ?", column, operator), p.array(), jsonText(value))
+		case "mysql":
+			if isNumber(value) {
+				return db.Where(fmt.Sprintf("JSON_EXTRACT(%s, ?) %s ?", column, operator), p.dollar(), value)
+			}
+
+			return db.Where(fmt.Sprintf("JSON_UNQUOTE(JSON_EXTRACT(%s, ?)) %s ?", column, operator), p.dollar(), jsonText(value))
+		default:
+			return db.Where(fmt.Sprintf("json_extract(%s, ?) %s ?", column, operator), p.dollar(), sqliteValue(value))
+		}
+	}
+}
+
+// WhereJSONContains filters rows where JSON array (or object) contains the value.
+func WhereJSONContains(path string, value interface{}) Scope {
+	p := parseJSONPath(path)
+
+	return func(db *gorm.DB) *gorm.DB {
+		column := db.Dialect().Quote(p.column)
+
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			db.AddError(err)
+			return db
+		}
+
+		switch db.Dialect().GetName() {
+		case "postgres":
+			return db.Where(fmt.Sprintf("(%s #> ?)::jsonb @> ?::jsonb", column), p.array(), string(encoded))
+		case "mysql":
+			return db.Where(fmt.Sprintf("JSON_CONTAINS(%s, ?, ?)", column), string(encoded), p.dollar())
+		default:
+			return db.Where(
+				fmt.Sprintf("EXISTS (SELECT 1 FROM json_each(%s, ?) WHERE json_each.value = ?)", column),
+				p.dollar(), sqliteValue(value),
+			)
+		}
+	}
+}
+
+// WhereJSONLength compares length of JSON array with operator.
+func WhereJSONLength(path, operator string, length int) Scope {
+	p := parseJSONPath(path)
+
+	return func(db *gorm.DB) *gorm.DB {
+		column := db.Dialect().Quote(p.column)
+		operator, err := safeOperator(operator)
+		if err != nil {
+			db.AddError(err)
+			return db
+		}
+
+		switch db.Dialect().GetName() {
+		case "postgres":
+			return db.Where(fmt.Sprintf("jsonb_array_length((%s #> ?)::jsonb) %s ?", column, operator), p.array(), length)
+		case "mysql":
+			return db.Where(fmt.Sprintf("JSON_LENGTH(%s, ?) %s ?", column, operator), p.dollar(), length)
+		default:
+			return db.Where(fmt.Sprintf("json_array_length(%s, ?) %s ?", column, operator), p.dollar(), length)
+		}
+	}
+}
+
+// Compare value inside JSON column with null. Missing keys are null too.
+func whereJSONNull(db *gorm.DB, column string, p jsonPath, operator string) *gorm.DB {
+	var not bool
+	switch operator {
+	case "=":
+	case "!=", "<>":
+		not = true
+	default:
+		db.AddError(fmt.Errorf("JSON value can not be compared with null by %s", operator))
+		return db
+	}
+
+	switch db.Dialect().GetName() {
+	case "postgres":
+		if not {
+			return db.Where(fmt.Sprintf("%s #>> ? IS NOT NULL", column), p.array())
+		}
+
+		return db.Where(fmt.Sprintf("%s #>> ? IS NULL", column), p.array())
+	case "mysql":
+		// JSON null is extracted as JSON value, not as SQL NULL.
+		if not {
+			return db.Where(fmt.Sprintf("JSON_TYPE(JSON_EXTRACT(%s, ?)) <> 'NULL'", column), p.dollar())
+		}
+
+		return db.Where(
+			fmt.Sprintf("(JSON_EXTRACT(%s, ?) IS NULL OR JSON_TYPE(JSON_EXTRACT(%s, ?)) = 'NULL')", column, column),
+			p.dollar(), p.dollar(),
+		)
+	default:
+		if not {
+			return db.Where(fmt.Sprintf("json_extract(%s, ?) IS NOT NULL", column), p.dollar())
+		}
+
+		return db.Where(fmt.Sprintf("json_extract(%s, ?) IS NULL", column), p.dollar())
+	}
+}
+
+// Allow only comparison operators, since they are put into query as is.
+func safeOperator(operator string) (string, error) {
+	switch operator {
+	case "=", "!=", "<>", "<", "<=", ">", ">=":
+		return operator, nil
+	default:
+		return "", fmt.Errorf("Unsupported JSON comparison operator %s", operator)
+	}
+}
+
+// Check if value is a number.
+func isNumber(value interface{}) bool {
+	switch value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// Text representation of scalar value extracted from JSON by MySQL and Postgres.
+func jsonText(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// SQLite returns booleans as integers.
+func sqliteValue(value interface{}) interface{} {
+	if b, ok := value.(bool); ok {
+		if b {
+			return 1
+		}
+
+		return 0
+	}
+
+	return value
+}
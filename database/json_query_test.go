@@ -0,0 +1,50 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/lara-go/larago/database"
+	"github.com/lara-go/larago/support/testsuite"
+	"github.com/stretchr/testify/assert"
+)
+
+type account struct {
+	ID   uint `gorm:"primary_key"`
+	Meta string
+}
+
+func TestJSONQueries(t *testing.T) {
+	db := testsuite.MemoryDB(t, &account{})
+
+	if db.Exec("SELECT json('{}')").Error != nil {
+		t.Skip("SQLite is built without JSON1 extension")
+	}
+
+	db.Create(&account{Meta: `{"plan": "pro", "seats": 10, "trial": false, "tags": ["a", "b"]}`})
+	db.Create(&account{Meta: `{"plan": "free", "seats": 1, "trial": true, "tags": ["b"]}`})
+	db.Create(&account{Meta: `{"plan": null, "seats": 1, "trial": false, "tags": []}`})
+
+	count := func(scope database.Scope) int {
+		var n int
+		assert.Nil(t, db.Model(&account{}).Scopes(scope).Count(&n).Error)
+		return n
+	}
+
+	assert.Equal(t, 1, count(database.WhereJSON("meta->plan", "pro")))
+	assert.Equal(t, 1, count(database.WhereJSON("meta->trial", true)))
+	assert.Equal(t, 1, count(database.WhereJSON("meta->tags->0", "a")))
+	assert.Equal(t, 1, count(database.WhereJSONOp("meta->seats", ">", 5)))
+	assert.Equal(t, 2, count(database.WhereJSONContains("meta->tags", "b")))
+	assert.Equal(t, 1, count(database.WhereJSONLength("meta->tags", ">=", 2)))
+
+	// Null and missing values.
+	assert.Equal(t, 1, count(database.WhereJSON("meta->plan", nil)))
+	assert.Equal(t, 3, count(database.WhereJSON("meta->owner", nil)))
+	assert.Equal(t, 2, count(database.WhereJSONOp("meta->plan", "!=", nil)))
+
+	// Unsupported operators are errors, not panics.
+	var n int
+	assert.Error(t, db.Model(&account{}).Scopes(database.WhereJSONOp("meta->seats", "; DROP TABLE accounts", 1)).Count(&n).Error)
+	assert.Error(t, db.Model(&account{}).Scopes(database.WhereJSONOp("meta->plan", ">", nil)).Count(&n).Error)
+	assert.Error(t, db.Model(&account{}).Scopes(database.WhereJSONLength("meta->tags", "LIKE", 1)).Count(&n).Error)
+}
@@ -0,0 +1,159 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago/database/casts"
+)
+
+// KeyRotator re-encrypts encrypted columns with the current key.
+type KeyRotator struct {
+	db         *gorm.DB
+	encrypter  casts.Encrypter
+	primaryKey string
+	batchSize  int
+}
+
+// NewKeyRotator constructor.
+func NewKeyRotator(db *gorm.DB, encrypter casts.Encrypter) *KeyRotator {
+	return &KeyRotator{
+		db:         db,
+		encrypter:  encrypter,
+		primaryKey: "id",
+		batchSize:  500,
+	}
+}
+
+// SetPrimaryKey used to walk over rows.
+func (r *KeyRotator) SetPrimaryKey(column string) *KeyRotator {
+	r.primaryKey = column
+
+	return r
+}
+
+// SetBatchSize of rows updated in one transaction.
+func (r *KeyRotator) SetBatchSize(size int) *KeyRotator {
+	r.batchSize = size
+
+	return r
+}
+
+// Rotate columns of the table and return amount of updated rows.
+// Values must be decryptable with the current or previous keys.
+func (r *KeyRotator) Rotate(table string, columns ...string) (int, error) {
+	var last interface{}
+	total := 0
+
+	for {
+		ids, values, err := r.fetch(table, columns, last)
+		if err != nil {
+			return total, err
+		}
+
+		if len(ids) == 0 {
+			return total, nil
+		}
+
+		if err := r.update(table, columns, ids, values); err != nil {
+			return total, err
+		}
+
+		total += len(ids)
+		last = ids[len(ids)-1]
+
+		if len(ids) < r.batchSize {
+			return total, nil
+		}
+	}
+}
+
+// Fetch next batch of rows after the last primary key.
+func (r *KeyRotator) fetch(table string, columns []string, last interface{}) ([]interface{}, [][]interface{}, error) {
+	query := r.db.Table(table).
+		Select(strings.Join(append([]string{r.primaryKey}, columns...), ", ")).
+		Order(r.primaryKey).
+		Limit(r.batchSize)
+
+	if last != nil {
+		query = query.Where(fmt.Sprintf("%s > ?", r.primaryKey), last)
+	}
+
+	rows, err := query.Rows()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var ids []interface{}
+	var values [][]interface{}
+
+	for rows.Next() {
+		row := make([]interface{}, len(columns)+1)
+		pointers := make([]interface{}, len(row))
+		for i := range row {
+			pointers[i] = &row[i]
+		}
+
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, nil, err
+		}
+
+		ids = append(ids, row[0])
+		values = append(values, row[1:])
+	}
+
+	return ids, values, rows.Err()
+}
+
+// Re-encrypt batch of rows in transaction.
+func (r *KeyRotator) update(table string, columns []string, ids []interface{}, values [][]interface{}) error {
+	tx := r.db.Begin()
+
+	for i, id := range ids {
+		updates := make(map[string]interface{})
+
+		for j, column := range columns {
+			payload, ok := stringValue(values[i][j])
+			if !ok {
+				continue
+			}
+
+			plain, err := r.encrypter.DecryptString(payload)
+			if err != nil {
+				tx.Rollback()
+				return fmt.Errorf("Can't decrypt %s.%s of row %v: %s", table, column, id, err)
+			}
+
+			if updates[column], err = r.encrypter.EncryptString(plain); err != nil {
+				tx.Rollback()
+				return err
+			}
+		}
+
+		if len(updates) == 0 {
+			continue
+		}
+
+		err := tx.Table(table).Where(fmt.Sprintf("%s = ?", r.primaryKey), id).UpdateColumns(updates).Error
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
+
+// Convert scanned value to string. Nulls are skipped.
+func stringValue(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case []byte:
+		return string(v), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,64 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/lara-go/larago/database"
+	"github.com/lara-go/larago/database/casts"
+	"github.com/lara-go/larago/encryption"
+	"github.com/lara-go/larago/support/testsuite"
+	"github.com/stretchr/testify/assert"
+)
+
+type patient struct {
+	ID  uint            `gorm:"primary_key"`
+	SSN casts.Encrypted `gorm:"type:text"`
+}
+
+func makeEncrypter(t *testing.T, keys ...string) *encryption.Encrypter {
+	var parsed [][]byte
+	for _, key := range keys {
+		parsed = append(parsed, []byte(key))
+	}
+
+	encrypter, err := encryption.New(parsed[0], parsed[1:]...)
+	assert.Nil(t, err)
+
+	return encrypter
+}
+
+func TestEncryptedCastAndKeyRotation(t *testing.T) {
+	oldKey := "old-key-old-key-old-key-old-key!"
+	newKey := "new-key-new-key-new-key-new-key!"
+
+	db := testsuite.MemoryDB(t, &patient{})
+
+	casts.SetEncrypter(makeEncrypter(t, oldKey))
+	defer casts.SetEncrypter(nil)
+
+	for _, ssn := range []string{"111", "222", "333"} {
+		assert.Nil(t, db.Create(&patient{SSN: casts.Encrypted(ssn)}).Error)
+	}
+
+	// Value is stored encrypted.
+	var raw string
+	db.Table("patients").Select("ssn").Where("id = 1").Row().Scan(&raw)
+	assert.NotEqual(t, "111", raw)
+
+	var loaded patient
+	assert.Nil(t, db.First(&loaded, 1).Error)
+	assert.Equal(t, "111", loaded.SSN.String())
+
+	// Rotate to the new key, keeping old one for decryption only.
+	rotating := makeEncrypter(t, newKey, oldKey)
+	rotated, err := database.NewKeyRotator(db, rotating).SetBatchSize(2).Rotate("patients", "ssn")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, rotated)
+
+	// Old key is not needed anymore.
+	casts.SetEncrypter(makeEncrypter(t, newKey))
+
+	var patients []patient
+	assert.Nil(t, db.Order("id").Find(&patients).Error)
+	assert.Equal(t, casts.Encrypted("333"), patients[2].SSN)
+}
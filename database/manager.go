@@ -1,6 +1,8 @@
 package database
 
 import (
+	"database/sql"
+
 	"github.com/jinzhu/gorm"
 	"github.com/lara-go/larago/logger"
 )
@@ -12,31 +14,79 @@ type Manager struct {
 	Debug  bool   `di:"Config.App.Debug"`
 	Logger *logger.Logger
 
+	// Amount of prepared statements to cache, disabled if zero.
+	StatementCacheSize int `di:"-"`
+
+	// Reject writes to the primary, e.g. during maintenance.
+	ReadOnly bool `di:"-"`
+
 	connection *gorm.DB
+	statements *StatementCache
 }
 
 // Connect to the database.
 func (m *Manager) Connect() error {
-	// Open connection.
-	db, err := gorm.Open(m.Driver, m.DSN)
+	db, err := m.open(m.DSN, m.StatementCacheSize)
 	if err != nil {
 		return err
 	}
 
+	if m.ReadOnly {
+		RegisterReadOnly(db)
+	}
+
+	m.connection = db
+
+	return nil
+}
+
+// ConnectReplica opens read-only connection to the replica.
+func (m *Manager) ConnectReplica(dsn string) (*gorm.DB, error) {
+	db, err := m.open(dsn, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	RegisterReadOnly(db)
+
+	return db, nil
+}
+
+// Open connection by DSN.
+func (m *Manager) open(dsn string, statementCacheSize int) (*gorm.DB, error) {
+	pool, err := sql.Open(m.Driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check if connection is active.
-	if err = db.DB().Ping(); err != nil {
-		return err
+	if err = pool.Ping(); err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	var db *gorm.DB
+	if statementCacheSize > 0 {
+		m.statements = NewStatementCache(pool, statementCacheSize)
+		db, err = gorm.Open(m.Driver, m.statements)
+	} else {
+		db, err = gorm.Open(m.Driver, pool)
 	}
 
-	m.Logger.Debug("Connected to %s via %s", m.DSN, m.Driver)
+	if err != nil {
+		return nil, err
+	}
+
+	m.Logger.Debug("Connected to %s via %s", dsn, m.Driver)
 
 	if m.Debug {
 		db.LogMode(true)
 	}
 
-	m.connection = db
+	RegisterOptimisticLocking(db)
+	RegisterQueryLog(db)
 
-	return nil
+	return db, nil
 }
 
 // Disconnect from the database.
@@ -46,6 +96,11 @@ func (m *Manager) Disconnect() {
 	}
 }
 
+// Statements returns prepared statements cache, if it is enabled.
+func (m *Manager) Statements() *StatementCache {
+	return m.statements
+}
+
 // GetConnection to db.
 func (m *Manager) GetConnection() (*gorm.DB, error) {
 	var err error
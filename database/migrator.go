@@ -1,6 +1,10 @@
 package database
 
 import (
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"os"
 	"reflect"
 
 	"github.com/go-gormigrate/gormigrate"
@@ -13,24 +17,187 @@ type Migration interface {
 	Rollback(tx *gorm.DB) error
 }
 
+// IdentifiedMigration has its own ID instead of the struct name.
+type IdentifiedMigration interface {
+	MigrationID() string
+}
+
+// TransactionalMigration decides if migration runs in its own transaction.
+// By default every migration does on databases supporting transactional DDL.
+type TransactionalMigration interface {
+	Transactional() bool
+}
+
 // Migrator engine to work with migrations.
 type Migrator struct {
 	migrations []Migration
+
+	// Migrations of the modules, run before application ones and kept by SetMigrations.
+	moduleMigrations []Migration
+
+	// Path to the schema dump to load on fresh databases.
+	schemaPath string
 }
 
-// SetMigrations to run.
+// SetMigrations to run. Migrations of the modules are kept.
 func (m *Migrator) SetMigrations(migrations ...Migration) {
 	m.migrations = migrations
 }
 
+// AddMigrations to the end of the list.
+func (m *Migrator) AddMigrations(migrations ...Migration) {
+	m.migrations = append(m.migrations, migrations...)
+}
+
+// AddModuleMigrations adds migrations of the module.
+func (m *Migrator) AddModuleMigrations(migrations ...Migration) {
+	m.moduleMigrations = append(m.moduleMigrations, migrations...)
+}
+
+// Migrations returns all migrations in order they run.
+func (m *Migrator) Migrations() []Migration {
+	return append(append([]Migration(nil), m.moduleMigrations...), m.migrations...)
+}
+
+// AddFS adds raw SQL migrations from file system, ex. embed.FS.
+func (m *Migrator) AddFS(fsys fs.FS) error {
+	migrations, err := LoadSQLMigrations(fsys)
+	if err != nil {
+		return err
+	}
+
+	m.AddMigrations(migrations...)
+
+	return nil
+}
+
+// SetSchemaPath sets path to the schema dump.
+// By default dump is searched in ./app/database/schema/<dialect>-schema.sql.
+func (m *Migrator) SetSchemaPath(path string) {
+	m.schemaPath = path
+}
+
 // Migrate database.
+// Fresh database loads schema dump first if there is one.
 func (m *Migrator) Migrate(db *gorm.DB) error {
-	return m.makeGormigrate(db).Migrate()
+	if err := m.loadSchema(db); err != nil {
+		return err
+	}
+
+	migrations := m.Migrations()
+	if len(migrations) == 0 {
+		return m.makeGormigrate(db, false).Migrate()
+	}
+
+	// Every migration is recorded in its own transaction, so it is never applied but not recorded.
+	dialect := db.Dialect().GetName()
+	for _, migration := range migrations {
+		err := m.makeGormigrate(db, m.usesTransaction(dialect, migration)).MigrateTo(m.getMigrationName(migration))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Load schema dump into fresh database.
+func (m *Migrator) loadSchema(db *gorm.DB) error {
+	if db.HasTable(gormigrate.DefaultOptions.TableName) {
+		return nil
+	}
+
+	dialect := db.Dialect().GetName()
+
+	schemaPath := m.schemaPath
+	if schemaPath == "" {
+		schemaPath = SchemaPath(dialect)
+	}
+
+	schema, err := ioutil.ReadFile(schemaPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// MySQL driver does not run multiple statements at once by default.
+	// They run on one connection, so session settings of the dump apply to all of them.
+	if dialect == "mysql" {
+		tx := db.Begin()
+		for _, statement := range splitStatements(string(schema)) {
+			if err := tx.Exec(statement).Error; err != nil {
+				tx.Rollback()
+
+				return err
+			}
+		}
+
+		return tx.Commit().Error
+	}
+
+	return db.Exec(string(schema)).Error
+}
+
+// Pending returns migrations that have not run yet.
+func (m *Migrator) Pending(db *gorm.DB) ([]Migration, error) {
+	ran, err := m.ran(db)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, migration := range m.Migrations() {
+		if !ran[m.getMigrationName(migration)] {
+			pending = append(pending, migration)
+		}
+	}
+
+	return pending, nil
+}
+
+// Pretend returns SQL of pending migrations without executing it.
+// Reads made by migrations still hit the database.
+func (m *Migrator) Pretend(db *gorm.DB) ([]*PretendedMigration, error) {
+	pending, err := m.Pending(db)
+	if err != nil {
+		return nil, err
+	}
+
+	pretended := make([]*PretendedMigration, 0, len(pending))
+	for _, migration := range pending {
+		id := m.getMigrationName(migration)
+
+		statements, err := pretend(db, migration)
+		if err != nil {
+			return pretended, fmt.Errorf("Migration %s: %s", id, err)
+		}
+
+		pretended = append(pretended, &PretendedMigration{ID: id, Statements: statements})
+	}
+
+	return pretended, nil
 }
 
 // Rollback last migration.
 func (m *Migrator) Rollback(db *gorm.DB) error {
-	return m.makeGormigrate(db).RollbackLast()
+	ran, err := m.ran(db)
+	if err != nil {
+		return err
+	}
+
+	// Rollback and removal of the record share transaction of the migration.
+	transactional := false
+	migrations := m.Migrations()
+	for i := len(migrations) - 1; i >= 0; i-- {
+		if ran[m.getMigrationName(migrations[i])] {
+			transactional = m.usesTransaction(db.Dialect().GetName(), migrations[i])
+			break
+		}
+	}
+
+	return m.makeGormigrate(db, transactional).RollbackLast()
 }
 
 // Reset all migrations.
@@ -38,20 +205,38 @@ func (m *Migrator) Reset(db *gorm.DB) error {
 	return nil
 }
 
-// Make new gormigrate instance.
-func (m *Migrator) makeGormigrate(db *gorm.DB) *gormigrate.Gormigrate {
-	return gormigrate.New(
-		db,
-		gormigrate.DefaultOptions,
-		m.transformMigrations(),
-	)
+// IDs of migrations already run.
+func (m *Migrator) ran(db *gorm.DB) (map[string]bool, error) {
+	options := gormigrate.DefaultOptions
+	ran := make(map[string]bool)
+
+	if db.HasTable(options.TableName) {
+		var ids []string
+		if err := db.Table(options.TableName).Pluck(options.IDColumnName, &ids).Error; err != nil {
+			return nil, err
+		}
+
+		for _, id := range ids {
+			ran[id] = true
+		}
+	}
+
+	return ran, nil
+}
+
+// Make new gormigrate instance running in transaction or not.
+func (m *Migrator) makeGormigrate(db *gorm.DB, transactional bool) *gormigrate.Gormigrate {
+	options := *gormigrate.DefaultOptions
+	options.UseTransaction = transactional
+
+	return gormigrate.New(db, &options, m.transformMigrations())
 }
 
 // Transform migrations to gormigrate
 func (m *Migrator) transformMigrations() []*gormigrate.Migration {
 	var gormigrations []*gormigrate.Migration
 
-	for _, migration := range m.migrations {
+	for _, migration := range m.Migrations() {
 		gormigrations = append(gormigrations, &gormigrate.Migration{
 			ID:       m.getMigrationName(migration),
 			Migrate:  migration.Migrate,
@@ -62,8 +247,36 @@ func (m *Migrator) transformMigrations() []*gormigrate.Migration {
 	return gormigrations
 }
 
+// Check if migration should be run in transaction.
+func (m *Migrator) usesTransaction(dialect string, migration Migration) bool {
+	if !SupportsTransactionalDDL(dialect) {
+		return false
+	}
+
+	if transactional, ok := migration.(TransactionalMigration); ok {
+		return transactional.Transactional()
+	}
+
+	return true
+}
+
+// SupportsTransactionalDDL checks if schema changes can be rolled back on the database.
+// MySQL commits transaction implicitly on every DDL statement.
+func SupportsTransactionalDDL(dialect string) bool {
+	switch dialect {
+	case "postgres", "sqlite3", "mssql":
+		return true
+	default:
+		return false
+	}
+}
+
 // Get unigue migration ID from struct name.
 func (m *Migrator) getMigrationName(migration Migration) string {
+	if identified, ok := migration.(IdentifiedMigration); ok {
+		return identified.MigrationID()
+	}
+
 	t := reflect.TypeOf(migration)
 
 	if t.Kind() == reflect.Ptr {
@@ -0,0 +1,156 @@
+package database_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago"
+	"github.com/lara-go/larago/database"
+	"github.com/lara-go/larago/support/testsuite"
+	"github.com/stretchr/testify/assert"
+)
+
+type migratedPost struct {
+	ID    uint `gorm:"primary_key"`
+	Title string
+}
+
+type createPostsMigration struct{}
+
+func (m *createPostsMigration) Migrate(tx *gorm.DB) error {
+	if err := tx.CreateTable(&migratedPost{}).Error; err != nil {
+		return err
+	}
+
+	return tx.Exec("DROP TABLE IF EXISTS legacy_posts").Error
+}
+
+func (m *createPostsMigration) Rollback(tx *gorm.DB) error {
+	return tx.DropTable(&migratedPost{}).Error
+}
+
+type failingMigration struct {
+	transactional bool
+}
+
+func (m *failingMigration) MigrationID() string {
+	return "failing"
+}
+
+func (m *failingMigration) Transactional() bool {
+	return m.transactional
+}
+
+func (m *failingMigration) Migrate(tx *gorm.DB) error {
+	if err := tx.CreateTable(&migratedPost{}).Error; err != nil {
+		return err
+	}
+
+	return errors.New("Failed")
+}
+
+func (m *failingMigration) Rollback(tx *gorm.DB) error {
+	return nil
+}
+
+func TestMigratorPretend(t *testing.T) {
+	db := testsuite.MemoryDB(t)
+
+	migrator := &database.Migrator{}
+	migrator.SetMigrations(&createPostsMigration{})
+
+	pretended, err := migrator.Pretend(db)
+	assert.Nil(t, err)
+	assert.Len(t, pretended, 1)
+	assert.Equal(t, "createPostsMigration", pretended[0].ID)
+	assert.Len(t, pretended[0].Statements, 2)
+	assert.Contains(t, pretended[0].Statements[0], "CREATE TABLE")
+	assert.Equal(t, []string{"DROP TABLE IF EXISTS legacy_posts"}, pretended[0].Destructive())
+	assert.False(t, db.HasTable(&migratedPost{}))
+
+	assert.Nil(t, migrator.Migrate(db))
+	assert.True(t, db.HasTable(&migratedPost{}))
+
+	pending, err := migrator.Pending(db)
+	assert.Nil(t, err)
+	assert.Empty(t, pending)
+}
+
+func TestMigratorTransactions(t *testing.T) {
+	db := testsuite.MemoryDB(t)
+
+	migrator := &database.Migrator{}
+	migrator.SetMigrations(&failingMigration{transactional: true})
+
+	assert.NotNil(t, migrator.Migrate(db))
+	assert.False(t, db.HasTable(&migratedPost{}))
+
+	migrator.SetMigrations(&failingMigration{transactional: false})
+
+	assert.NotNil(t, migrator.Migrate(db))
+	assert.True(t, db.HasTable(&migratedPost{}))
+}
+
+func TestIsDestructive(t *testing.T) {
+	assert.True(t, database.IsDestructive("ALTER TABLE users DROP COLUMN name"))
+	assert.True(t, database.IsDestructive("drop table users"))
+	assert.True(t, database.IsDestructive("CREATE TABLE a (id int);\nTRUNCATE users;"))
+	assert.True(t, database.IsDestructive("DELETE FROM users"))
+	assert.False(t, database.IsDestructive("CREATE TABLE users (id int)"))
+	assert.False(t, database.IsDestructive("ALTER TABLE users ADD COLUMN deleted_at datetime"))
+	assert.True(t, database.IsDestructive("ALTER TABLE users DROP name"))
+	assert.True(t, database.IsDestructive("ALTER TABLE `users` DROP INDEX idx_name, DROP `name`"))
+	assert.False(t, database.IsDestructive("ALTER TABLE users DROP INDEX idx_name, DROP FOREIGN KEY fk_team"))
+	assert.False(t, database.IsDestructive(`ALTER TABLE users ALTER COLUMN name DROP NOT NULL`))
+}
+
+type unrecordedMigration struct{}
+
+func (m *unrecordedMigration) Migrate(tx *gorm.DB) error {
+	if err := tx.CreateTable(&migratedPost{}).Error; err != nil {
+		return err
+	}
+
+	// Record of the migration can not be inserted.
+	return tx.DropTable("migrations").Error
+}
+
+func (m *unrecordedMigration) Rollback(tx *gorm.DB) error {
+	return nil
+}
+
+func TestMigrationIsRecordedInItsTransaction(t *testing.T) {
+	db := testsuite.MemoryDB(t)
+
+	migrator := &database.Migrator{}
+	migrator.SetMigrations(&unrecordedMigration{})
+
+	assert.Error(t, migrator.Migrate(db))
+	assert.False(t, db.HasTable(&migratedPost{}))
+}
+
+type postsModule struct{}
+
+func (m *postsModule) Name() string {
+	return "posts"
+}
+
+func (m *postsModule) Migrations() []database.Migration {
+	return []database.Migration{&createPostsMigration{}}
+}
+
+func TestModuleMigrations(t *testing.T) {
+	application := larago.New()
+	application.Register(&database.ServiceProvider{})
+	application.RegisterModules(&postsModule{})
+	assert.NoError(t, application.Boot())
+
+	// Migrations of the modules run first and are kept when application sets its own.
+	migrator := application.Get((*database.Migrator)(nil)).(*database.Migrator)
+	migrator.SetMigrations(&failingMigration{})
+
+	migrations := migrator.Migrations()
+	assert.Len(t, migrations, 2)
+	assert.IsType(t, &createPostsMigration{}, migrations[0])
+}
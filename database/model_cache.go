@@ -0,0 +1,154 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago/cache"
+)
+
+// CachedModel opts model into the model cache.
+type CachedModel interface {
+	// CacheDuration returns how long model stays in cache. Zero means forever.
+	CacheDuration() time.Duration
+}
+
+// ModelCache caches models found by the primary key
+// and forgets them as soon as they are updated or deleted.
+// Updates and deletes of many rows at once forget every cached model of the table.
+type ModelCache struct {
+	cache   cache.Cache
+	resolve func() cache.Cache
+	once    sync.Once
+
+	// Tables with cached models.
+	tables sync.Map
+}
+
+// NewModelCache constructor.
+func NewModelCache(cache cache.Cache) *ModelCache {
+	return &ModelCache{
+		cache: cache,
+	}
+}
+
+// NewLazyModelCache resolves cache on the first use,
+// so callbacks can be registered on the connection before the cache exists.
+func NewLazyModelCache(resolve func() cache.Cache) *ModelCache {
+	return &ModelCache{
+		resolve: resolve,
+	}
+}
+
+// Cache used by the model cache, nil if it is not configured.
+func (c *ModelCache) store() cache.Cache {
+	c.once.Do(func() {
+		if c.resolve != nil {
+			c.cache = c.resolve()
+		}
+	})
+
+	return c.cache
+}
+
+// Register invalidation callbacks on the connection.
+func (c *ModelCache) Register(db *gorm.DB) {
+	db.Callback().Update().After("gorm:update").Register("larago:model_cache_forget", c.forgetCallback)
+	db.Callback().Delete().After("gorm:delete").Register("larago:model_cache_forget", c.forgetCallback)
+}
+
+// Find model by primary key hitting the cache first.
+func (c *ModelCache) Find(db *gorm.DB, model CachedModel, id interface{}) error {
+	v := reflect.ValueOf(model)
+	if v.Kind() != reflect.Ptr {
+		return fmt.Errorf("Model %T must be a pointer", model)
+	}
+
+	store := c.store()
+	if store == nil {
+		return errors.New("Cache is not configured for the model cache")
+	}
+
+	table := db.NewScope(model).TableName()
+	c.tables.Store(table, true)
+
+	key := c.key(store, table, id)
+	callback := func() (interface{}, error) {
+		err := db.First(model, id).Error
+		if err == gorm.ErrRecordNotFound {
+			return nil, ModelNotFound(model, []interface{}{id})
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return v.Elem().Interface(), nil
+	}
+
+	if duration := model.CacheDuration(); duration > 0 {
+		return store.Remember(key, duration, callback, model)
+	}
+
+	return store.RememberForever(key, callback, model)
+}
+
+// Forget cached model.
+func (c *ModelCache) Forget(db *gorm.DB, model CachedModel) {
+	scope := db.NewScope(model)
+	store := c.store()
+	if store == nil || scope.PrimaryKeyZero() {
+		return
+	}
+
+	store.Forget(c.key(store, scope.TableName(), scope.PrimaryKeyValue()))
+}
+
+// ForgetTable forgets every cached model of the table.
+func (c *ModelCache) ForgetTable(table string) {
+	if store := c.store(); store != nil {
+		store.Forever(c.versionKey(table), time.Now().UnixNano())
+	}
+}
+
+// Forget updated or deleted model if it is cached.
+func (c *ModelCache) forgetCallback(scope *gorm.Scope) {
+	if scope.HasError() {
+		return
+	}
+
+	table := scope.TableName()
+	_, cached := c.tables.Load(table)
+	if _, ok := scope.Value.(CachedModel); !ok && !cached {
+		return
+	}
+
+	store := c.store()
+	if store == nil {
+		return
+	}
+
+	// Rows changed by conditions can't be forgotten one by one.
+	if scope.PrimaryKeyZero() {
+		c.ForgetTable(table)
+		return
+	}
+
+	store.Forget(c.key(store, table, scope.PrimaryKeyValue()))
+}
+
+// Make cache key for the model. Key includes version of the table bumped by batch changes.
+func (c *ModelCache) key(store cache.Cache, table string, id interface{}) string {
+	var version int64
+	store.Get(c.versionKey(table), &version)
+
+	return fmt.Sprintf("models:%s:%d:%v", table, version, id)
+}
+
+// Make cache key for the version of the table.
+func (c *ModelCache) versionKey(table string) string {
+	return fmt.Sprintf("models:%s:version", table)
+}
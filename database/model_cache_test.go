@@ -0,0 +1,101 @@
+package database_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago/cache"
+	"github.com/lara-go/larago/database"
+	"github.com/lara-go/larago/support/testsuite"
+	"github.com/stretchr/testify/assert"
+)
+
+type cachedSetting struct {
+	ID    uint `gorm:"primary_key"`
+	Value string
+}
+
+func (s *cachedSetting) CacheDuration() time.Duration {
+	return time.Minute
+}
+
+func modelCacheFactory(t *testing.T) (*database.ModelCache, *gorm.DB) {
+	db := testsuite.MemoryDB(t, &cachedSetting{})
+
+	modelCache := database.NewModelCache(cache.NewRepository(cache.NewInMemoryStore()))
+	modelCache.Register(db)
+
+	return modelCache, db
+}
+
+func TestModelCache_Find(t *testing.T) {
+	modelCache, db := modelCacheFactory(t)
+
+	db.Create(&cachedSetting{ID: 1, Value: "first"})
+
+	var setting cachedSetting
+	assert.NoError(t, modelCache.Find(db, &setting, 1))
+	assert.Equal(t, "first", setting.Value)
+
+	// Change row behind the cache back.
+	db.Exec("UPDATE cached_settings SET value = 'changed' WHERE id = 1")
+
+	var cached cachedSetting
+	assert.NoError(t, modelCache.Find(db, &cached, 1))
+	assert.Equal(t, "first", cached.Value)
+}
+
+func TestModelCache_ForgetOnUpdate(t *testing.T) {
+	modelCache, db := modelCacheFactory(t)
+
+	db.Create(&cachedSetting{ID: 1, Value: "first"})
+
+	var setting cachedSetting
+	modelCache.Find(db, &setting, 1)
+
+	setting.Value = "second"
+	db.Save(&setting)
+
+	var fresh cachedSetting
+	assert.NoError(t, modelCache.Find(db, &fresh, 1))
+	assert.Equal(t, "second", fresh.Value)
+}
+
+func TestModelCache_ForgetOnDelete(t *testing.T) {
+	modelCache, db := modelCacheFactory(t)
+
+	db.Create(&cachedSetting{ID: 1, Value: "first"})
+
+	var setting cachedSetting
+	modelCache.Find(db, &setting, 1)
+
+	db.Delete(&setting)
+
+	var deleted cachedSetting
+	err := modelCache.Find(db, &deleted, 1)
+	assert.IsType(t, &database.ModelNotFoundError{}, err)
+}
+
+func TestModelCache_ForgetTableOnBatchChanges(t *testing.T) {
+	modelCache, db := modelCacheFactory(t)
+
+	db.Create(&cachedSetting{ID: 1, Value: "first"})
+	db.Create(&cachedSetting{ID: 2, Value: "second"})
+
+	var setting cachedSetting
+	modelCache.Find(db, &setting, 1)
+
+	db.Model(&cachedSetting{}).Where("id IN (?)", []uint{1, 2}).Update("value", "changed")
+
+	var fresh cachedSetting
+	assert.NoError(t, modelCache.Find(db, &fresh, 1))
+	assert.Equal(t, "changed", fresh.Value)
+
+	// Table is known once it is cached, so untyped queries forget it too.
+	db.Table("cached_settings").Where("id = ?", 1).Delete(nil)
+
+	var deleted cachedSetting
+	err := modelCache.Find(db, &deleted, 1)
+	assert.IsType(t, &database.ModelNotFoundError{}, err)
+}
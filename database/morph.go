@@ -0,0 +1,177 @@
+package database
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/jinzhu/gorm"
+)
+
+// MorphMap resolves models stored in polymorphic <name>_type + <name>_id columns.
+// Type is the table name of the model, the same value gorm uses for `gorm:"polymorphic:Name"`
+// MorphOne/MorphMany associations, unless other name is registered.
+type MorphMap struct {
+	mutex sync.RWMutex
+	types map[string]reflect.Type
+	names map[reflect.Type]string
+}
+
+// NewMorphMap constructor.
+func NewMorphMap() *MorphMap {
+	return &MorphMap{
+		types: make(map[string]reflect.Type),
+		names: make(map[reflect.Type]string),
+	}
+}
+
+// Register models under their table names.
+func (m *MorphMap) Register(db *gorm.DB, models ...interface{}) *MorphMap {
+	for _, model := range models {
+		m.RegisterAs(db.NewScope(model).TableName(), model)
+	}
+
+	return m
+}
+
+// RegisterAs registers model under custom type name (see `polymorphic_value` gorm tag).
+func (m *MorphMap) RegisterAs(name string, model interface{}) *MorphMap {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	t := modelType(model)
+	m.types[name] = t
+	m.names[t] = name
+
+	return m
+}
+
+// TypeName of the model stored in the type column.
+func (m *MorphMap) TypeName(db *gorm.DB, model interface{}) string {
+	m.mutex.RLock()
+	name, ok := m.names[modelType(model)]
+	m.mutex.RUnlock()
+
+	if ok {
+		return name
+	}
+
+	return db.NewScope(model).TableName()
+}
+
+// Associate sets <relation>Type and <relation>ID fields of the child to point to the owner.
+func (m *MorphMap) Associate(db *gorm.DB, child interface{}, relation string, owner interface{}) error {
+	v := reflect.Indirect(reflect.ValueOf(child))
+
+	typeField := v.FieldByName(relation + "Type")
+	idField := v.FieldByName(relation + "ID")
+	if !typeField.IsValid() || !idField.IsValid() {
+		return fmt.Errorf("Model %T has no %sType and %sID fields", child, relation, relation)
+	}
+
+	id := reflect.ValueOf(db.NewScope(owner).PrimaryKeyValue())
+	if !id.Type().ConvertibleTo(idField.Type()) {
+		return fmt.Errorf("Can't assign %s primary key to %T.%sID", id.Type(), child, relation)
+	}
+
+	typeField.SetString(m.TypeName(db, owner))
+	idField.Set(id.Convert(idField.Type()))
+
+	if field := v.FieldByName(relation); field.IsValid() && field.Kind() == reflect.Interface {
+		field.Set(reflect.ValueOf(owner))
+	}
+
+	return nil
+}
+
+// Morphs returns query of models pointing to the owner, ex. Morphs(db, &post, "commentable").Find(&comments).
+// Use First instead of Find for MorphOne relations.
+func (m *MorphMap) Morphs(db *gorm.DB, owner interface{}, name string) *gorm.DB {
+	return db.Where(
+		fmt.Sprintf("%s = ? AND %s = ?", gorm.ToDBName(name+"Type"), gorm.ToDBName(name+"ID")),
+		m.TypeName(db, owner),
+		db.NewScope(owner).PrimaryKeyValue(),
+	)
+}
+
+// LoadMorphTo eager loads owners of the model or slice of models into <relation> field,
+// which must be an interface{} ignored by gorm: `gorm:"-"`.
+// Owners of every type are loaded with one query.
+func (m *MorphMap) LoadMorphTo(db *gorm.DB, models interface{}, relation string) error {
+	items := reflect.Indirect(reflect.ValueOf(models))
+	if items.Kind() != reflect.Slice {
+		items = reflect.Append(reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(models)), 0, 1), reflect.ValueOf(models))
+	}
+
+	// Group owner ids by type.
+	ids := make(map[string][]interface{})
+	for i := 0; i < items.Len(); i++ {
+		item := reflect.Indirect(items.Index(i))
+
+		typeField := item.FieldByName(relation + "Type")
+		idField := item.FieldByName(relation + "ID")
+		if !typeField.IsValid() || !idField.IsValid() {
+			return fmt.Errorf("Model %s has no %sType and %sID fields", item.Type(), relation, relation)
+		}
+
+		if typeField.String() != "" {
+			ids[typeField.String()] = append(ids[typeField.String()], idField.Interface())
+		}
+	}
+
+	owners := make(map[string]interface{})
+	for name, keys := range ids {
+		loaded, err := m.load(db, name, keys)
+		if err != nil {
+			return err
+		}
+
+		for key, owner := range loaded {
+			owners[name+":"+key] = owner
+		}
+	}
+
+	for i := 0; i < items.Len(); i++ {
+		item := reflect.Indirect(items.Index(i))
+		field := item.FieldByName(relation)
+		if !field.IsValid() || field.Kind() != reflect.Interface {
+			return fmt.Errorf("Model %s has no interface{} field %s", item.Type(), relation)
+		}
+
+		key := item.FieldByName(relation+"Type").String() + ":" + fmt.Sprint(item.FieldByName(relation+"ID").Interface())
+		if owner, ok := owners[key]; ok {
+			field.Set(reflect.ValueOf(owner))
+		} else {
+			field.Set(reflect.Zero(field.Type()))
+		}
+	}
+
+	return nil
+}
+
+// Load owners of the type by primary keys.
+func (m *MorphMap) load(db *gorm.DB, name string, keys []interface{}) (map[string]interface{}, error) {
+	m.mutex.RLock()
+	t, ok := m.types[name]
+	m.mutex.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("Morph type %s is not registered", name)
+	}
+
+	results := reflect.New(reflect.SliceOf(reflect.PtrTo(t)))
+	scope := db.NewScope(reflect.New(t).Interface())
+
+	err := db.Where(fmt.Sprintf("%s IN (?)", scope.Quote(scope.PrimaryKey())), keys).Find(results.Interface()).Error
+	if err != nil {
+		return nil, err
+	}
+
+	loaded := make(map[string]interface{})
+	for i := 0; i < results.Elem().Len(); i++ {
+		owner := results.Elem().Index(i).Interface()
+		loaded[fmt.Sprint(db.NewScope(owner).PrimaryKeyValue())] = owner
+	}
+
+	return loaded, nil
+}
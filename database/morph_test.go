@@ -0,0 +1,69 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/lara-go/larago/database"
+	"github.com/lara-go/larago/support/testsuite"
+	"github.com/stretchr/testify/assert"
+)
+
+type post struct {
+	ID       uint `gorm:"primary_key"`
+	Title    string
+	Comments []comment `gorm:"polymorphic:Commentable"`
+}
+
+type video struct {
+	ID  uint `gorm:"primary_key"`
+	URL string
+}
+
+type comment struct {
+	ID              uint `gorm:"primary_key"`
+	Body            string
+	CommentableType string
+	CommentableID   uint
+	Commentable     interface{} `gorm:"-"`
+}
+
+func TestMorphRelations(t *testing.T) {
+	db := testsuite.MemoryDB(t, &post{}, &video{}, &comment{})
+
+	morphs := database.NewMorphMap().Register(db, &post{}, &video{})
+
+	p := &post{Title: "Hello"}
+	v := &video{URL: "https://example.com"}
+	db.Create(p)
+	db.Create(v)
+
+	first, second := &comment{Body: "Nice post"}, &comment{Body: "Nice video"}
+	assert.Nil(t, morphs.Associate(db, first, "Commentable", p))
+	assert.Nil(t, morphs.Associate(db, second, "Commentable", v))
+	db.Create(first)
+	db.Create(second)
+
+	assert.Equal(t, "posts", first.CommentableType)
+
+	// MorphMany through gorm association and query helper.
+	var loaded post
+	db.Preload("Comments").First(&loaded, p.ID)
+	assert.Len(t, loaded.Comments, 1)
+
+	var videoComments []comment
+	morphs.Morphs(db, v, "Commentable").Find(&videoComments)
+	assert.Len(t, videoComments, 1)
+	assert.Equal(t, "Nice video", videoComments[0].Body)
+
+	// MorphTo eager loading across types.
+	var comments []comment
+	db.Order("id").Find(&comments)
+	assert.Nil(t, morphs.LoadMorphTo(db, &comments, "Commentable"))
+	assert.Equal(t, "Hello", comments[0].Commentable.(*post).Title)
+	assert.Equal(t, "https://example.com", comments[1].Commentable.(*video).URL)
+
+	var single comment
+	db.First(&single, second.ID)
+	assert.Nil(t, morphs.LoadMorphTo(db, &single, "Commentable"))
+	assert.Equal(t, v.ID, single.Commentable.(*video).ID)
+}
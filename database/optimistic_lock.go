@@ -0,0 +1,90 @@
+package database
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Scope setting with version the model had before update.
+const previousVersionSetting = "larago:previous_version"
+
+// Versioned adds version column to the model. Embed it to enable optimistic locking:
+// update or delete fails with StaleModelError if the row was changed since the model was loaded.
+type Versioned struct {
+	Version uint `gorm:"not null;default:1"`
+}
+
+// CurrentVersion of the model.
+func (v *Versioned) CurrentVersion() uint {
+	return v.Version
+}
+
+// VersionedModel is a model with optimistic locking.
+type VersionedModel interface {
+	CurrentVersion() uint
+}
+
+// StaleModelError is returned when model was updated or deleted concurrently.
+type StaleModelError struct {
+	Model   interface{}
+	Version uint
+}
+
+// Error returns error message.
+func (e *StaleModelError) Error() string {
+	t := reflect.TypeOf(e.Model)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return fmt.Sprintf("Model [%s] of version %d was modified concurrently", t, e.Version)
+}
+
+// RegisterOptimisticLocking callbacks on the connection.
+func RegisterOptimisticLocking(db *gorm.DB) {
+	db.Callback().Update().Before("gorm:update").Register("larago:optimistic_lock", checkVersion)
+	db.Callback().Update().After("gorm:update").Register("larago:optimistic_lock_verify", verifyVersion)
+	db.Callback().Delete().Before("gorm:delete").Register("larago:optimistic_lock", checkDeleteVersion)
+	db.Callback().Delete().After("gorm:delete").Register("larago:optimistic_lock_verify", verifyVersion)
+}
+
+// Update row only if version has not changed and increment it.
+func checkVersion(scope *gorm.Scope) {
+	if version, ok := whereVersion(scope); ok {
+		scope.SetColumn("Version", version+1)
+	}
+}
+
+// Delete row only if version has not changed.
+func checkDeleteVersion(scope *gorm.Scope) {
+	whereVersion(scope)
+}
+
+// Limit query to the row of the loaded version.
+func whereVersion(scope *gorm.Scope) (uint, bool) {
+	model, ok := scope.Value.(VersionedModel)
+	if !ok || scope.HasError() || scope.PrimaryKeyZero() {
+		return 0, false
+	}
+
+	version := model.CurrentVersion()
+
+	scope.InstanceSet(previousVersionSetting, version)
+	scope.Search.Where(fmt.Sprintf("%s.%s = ?", scope.QuotedTableName(), scope.Quote("version")), version)
+
+	return version, true
+}
+
+// Fail if nothing was updated or deleted.
+func verifyVersion(scope *gorm.Scope) {
+	version, ok := scope.InstanceGet(previousVersionSetting)
+	if !ok || scope.HasError() || scope.DB().RowsAffected > 0 {
+		return
+	}
+
+	// Restore version, so model can be reloaded and saved again.
+	scope.SetColumn("Version", version)
+	scope.Err(&StaleModelError{Model: scope.Value, Version: version.(uint)})
+}
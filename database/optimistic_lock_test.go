@@ -0,0 +1,71 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/lara-go/larago/database"
+	"github.com/lara-go/larago/support/testsuite"
+	"github.com/stretchr/testify/assert"
+)
+
+type page struct {
+	ID    uint `gorm:"primary_key"`
+	Title string
+	database.Versioned
+}
+
+func TestOptimisticLocking(t *testing.T) {
+	db := testsuite.MemoryDB(t, &page{})
+	database.RegisterOptimisticLocking(db)
+
+	db.Create(&page{Title: "Draft"})
+
+	var first, second page
+	db.First(&first, 1)
+	db.First(&second, 1)
+	assert.Equal(t, uint(1), first.Version)
+
+	first.Title = "First edit"
+	assert.Nil(t, db.Save(&first).Error)
+	assert.Equal(t, uint(2), first.Version)
+
+	second.Title = "Second edit"
+	err := db.Save(&second).Error
+	assert.IsType(t, &database.StaleModelError{}, err)
+	assert.Equal(t, uint(1), second.Version)
+
+	err = db.Model(&second).Updates(map[string]interface{}{"title": "Again"}).Error
+	assert.IsType(t, &database.StaleModelError{}, err)
+
+	assert.Nil(t, db.Model(&first).Updates(map[string]interface{}{"title": "Third edit"}).Error)
+
+	var stored page
+	db.First(&stored, 1)
+	assert.Equal(t, "Third edit", stored.Title)
+	assert.Equal(t, uint(3), stored.Version)
+}
+
+func TestOptimisticLockingOnDelete(t *testing.T) {
+	db := testsuite.MemoryDB(t, &page{})
+	database.RegisterOptimisticLocking(db)
+
+	db.Create(&page{Title: "Draft"})
+
+	var stale, fresh page
+	db.First(&stale, 1)
+	db.First(&fresh, 1)
+
+	fresh.Title = "Edit"
+	assert.Nil(t, db.Save(&fresh).Error)
+
+	err := db.Delete(&stale).Error
+	assert.IsType(t, &database.StaleModelError{}, err)
+
+	var count int
+	db.Model(&page{}).Count(&count)
+	assert.Equal(t, 1, count)
+
+	assert.Nil(t, db.Delete(&fresh).Error)
+	db.Model(&page{}).Count(&count)
+	assert.Equal(t, 0, count)
+}
@@ -0,0 +1,169 @@
+package database
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Harmless query returning no rows, used instead of writes returning data.
+const emptyQuery = "SELECT NULL LIMIT 0"
+
+// Statements that lose data or break code still running against the old schema.
+var destructiveStatement = regexp.MustCompile(`(?im)\bDROP\s+(TABLE|COLUMN|DATABASE|SCHEMA)\b|^\s*TRUNCATE\b|^\s*DELETE\s+FROM\b`)
+
+// ALTER TABLE statements and their DROP clauses, COLUMN keyword is optional there.
+var (
+	alterTableStatement = regexp.MustCompile(`(?is)\bALTER\s+TABLE\b[^;]*`)
+	dropClause          = regexp.MustCompile(`(?i)\bDROP\s+([^\s,]+)`)
+)
+
+// Dropped by ALTER TABLE without losing data.
+var keptOnDrop = map[string]bool{
+	"INDEX":      true,
+	"KEY":        true,
+	"CONSTRAINT": true,
+	"FOREIGN":    true,
+	"PRIMARY":    true,
+	"CHECK":      true,
+	"DEFAULT":    true,
+	"NOT":        true,
+}
+
+// PretendedMigration holds SQL migration would run.
+type PretendedMigration struct {
+	ID         string
+	Statements []string
+}
+
+// Destructive returns statements that drop or delete data.
+func (m *PretendedMigration) Destructive() []string {
+	var statements []string
+
+	for _, statement := range m.Statements {
+		if IsDestructive(statement) {
+			statements = append(statements, statement)
+		}
+	}
+
+	return statements
+}
+
+// IsDestructive checks if SQL drops or deletes data.
+func IsDestructive(statement string) bool {
+	if destructiveStatement.MatchString(statement) {
+		return true
+	}
+
+	for _, alter := range alterTableStatement.FindAllString(statement, -1) {
+		for _, drop := range dropClause.FindAllStringSubmatch(alter, -1) {
+			if !keptOnDrop[strings.ToUpper(drop[1])] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Raw connection of gorm.
+type commonDB interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Prepare(query string) (*sql.Stmt, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// Connection recording writes instead of running them. Reads still hit the database,
+// so migrations checking the schema work as usual.
+type pretendConnection struct {
+	db         commonDB
+	statements []string
+}
+
+// Exec records the statement.
+func (c *pretendConnection) Exec(query string, args ...interface{}) (sql.Result, error) {
+	c.record(query, args)
+
+	return driver.RowsAffected(0), nil
+}
+
+// Prepare is not supported, as prepared statement would run for real.
+func (c *pretendConnection) Prepare(query string) (*sql.Stmt, error) {
+	return nil, errors.New("Prepared statements can not be pretended")
+}
+
+// Query runs reads and records writes.
+func (c *pretendConnection) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	if isRead(query) {
+		return c.db.Query(query, args...)
+	}
+
+	c.record(query, args)
+
+	return c.db.Query(emptyQuery)
+}
+
+// QueryRow runs reads and records writes.
+func (c *pretendConnection) QueryRow(query string, args ...interface{}) *sql.Row {
+	if isRead(query) {
+		return c.db.QueryRow(query, args...)
+	}
+
+	c.record(query, args)
+
+	return c.db.QueryRow(emptyQuery)
+}
+
+// Record statement with its arguments.
+func (c *pretendConnection) record(query string, args []interface{}) {
+	statement := strings.TrimSpace(query)
+	if len(args) > 0 {
+		statement = fmt.Sprintf("%s -- %v", statement, args)
+	}
+
+	c.statements = append(c.statements, statement)
+}
+
+// Check if query only reads data.
+func isRead(query string) bool {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return false
+	}
+
+	switch strings.ToUpper(fields[0]) {
+	case "SELECT", "SHOW", "DESCRIBE", "EXPLAIN", "PRAGMA":
+		return true
+	default:
+		return false
+	}
+}
+
+// Run migration against connection recording SQL instead of executing it.
+// Reads run in transaction which is always rolled back.
+func pretend(db *gorm.DB, migration Migration) ([]string, error) {
+	tx := db.Begin()
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	defer tx.Rollback()
+
+	connection := &pretendConnection{db: tx.CommonDB()}
+
+	recorder, err := gorm.Open(db.Dialect().GetName(), connection)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migration.Migrate(recorder); err != nil {
+		return connection.statements, err
+	}
+
+	return connection.statements, nil
+}
@@ -0,0 +1,114 @@
+package database
+
+import (
+	"sync"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Amount of queries kept by default.
+const defaultQueryLogSize = 50
+
+// Scope key with the query start time.
+const queryStartedKey = "larago:query_started"
+
+// Setting of the connection with the log to record queries into.
+const queryLogSetting = "larago:query_log"
+
+// Query executed through the connection.
+// Bound vars are not kept, so secrets do not leak into reports.
+type Query struct {
+	SQL      string
+	Duration time.Duration
+	Error    string
+	Time     time.Time
+}
+
+// QueryLog keeps the latest queries made through the connection it is attached to.
+type QueryLog struct {
+	mutex   sync.Mutex
+	entries []Query
+	next    int
+	full    bool
+}
+
+// NewQueryLog constructor.
+func NewQueryLog(size int) *QueryLog {
+	if size <= 0 {
+		size = defaultQueryLogSize
+	}
+
+	return &QueryLog{
+		entries: make([]Query, size),
+	}
+}
+
+// Record query.
+func (l *QueryLog) Record(query Query) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.entries[l.next] = query
+	l.next = (l.next + 1) % len(l.entries)
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// Recent returns queries from the oldest to the newest.
+func (l *QueryLog) Recent() []Query {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if !l.full {
+		return append([]Query(nil), l.entries[:l.next]...)
+	}
+
+	return append(append([]Query(nil), l.entries[l.next:]...), l.entries[:l.next]...)
+}
+
+// WithQueryLog returns connection recording its queries into the log, ex. the log of the request.
+func WithQueryLog(db *gorm.DB, log *QueryLog) *gorm.DB {
+	return db.Set(queryLogSetting, log)
+}
+
+// RegisterQueryLog records queries of models made via connections with the log attached by WithQueryLog.
+func RegisterQueryLog(db *gorm.DB) {
+	start := func(scope *gorm.Scope) {
+		if _, ok := scope.Get(queryLogSetting); ok {
+			scope.InstanceSet(queryStartedKey, time.Now())
+		}
+	}
+
+	record := func(scope *gorm.Scope) {
+		value, ok := scope.Get(queryLogSetting)
+		if !ok || scope.SQL == "" {
+			return
+		}
+		log := value.(*QueryLog)
+
+		query := Query{SQL: scope.SQL, Time: time.Now()}
+		if started, ok := scope.InstanceGet(queryStartedKey); ok {
+			query.Duration = time.Since(started.(time.Time))
+		}
+		if scope.HasError() {
+			query.Error = scope.DB().Error.Error()
+		}
+
+		log.Record(query)
+	}
+
+	callbacks := db.Callback()
+
+	callbacks.Create().Before("gorm:create").Register("larago:query_log_start", start)
+	callbacks.Create().After("gorm:create").Register("larago:query_log", record)
+	callbacks.Query().Before("gorm:query").Register("larago:query_log_start", start)
+	callbacks.Query().After("gorm:query").Register("larago:query_log", record)
+	callbacks.RowQuery().Before("gorm:row_query").Register("larago:query_log_start", start)
+	callbacks.RowQuery().After("gorm:row_query").Register("larago:query_log", record)
+	callbacks.Update().Before("gorm:update").Register("larago:query_log_start", start)
+	callbacks.Update().After("gorm:update").Register("larago:query_log", record)
+	callbacks.Delete().Before("gorm:delete").Register("larago:query_log_start", start)
+	callbacks.Delete().After("gorm:delete").Register("larago:query_log", record)
+}
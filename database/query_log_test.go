@@ -0,0 +1,38 @@
+package database_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/lara-go/larago/database"
+	"github.com/lara-go/larago/support/testsuite"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryLog(t *testing.T) {
+	db := testsuite.MemoryDB(t, &page{})
+
+	database.RegisterQueryLog(db)
+
+	log := database.NewQueryLog(2)
+	logged := database.WithQueryLog(db, log)
+
+	logged.Create(&page{Title: "Draft"})
+	logged.Model(&page{}).Where("id = ?", 1).Update("title", "Published")
+
+	var found page
+	logged.First(&found, 1)
+
+	// Queries of connections without log are not recorded.
+	db.Create(&page{Title: "Unlogged"})
+
+	// Only the latest queries are kept.
+	queries := log.Recent()
+	assert.Len(t, queries, 2)
+	assert.True(t, strings.HasPrefix(queries[0].SQL, "UPDATE"), queries[0].SQL)
+	assert.True(t, strings.HasPrefix(queries[1].SQL, "SELECT"), queries[1].SQL)
+	assert.Empty(t, queries[1].Error)
+
+	logged.Table("missing").Find(&found)
+	assert.NotEmpty(t, log.Recent()[1].Error)
+}
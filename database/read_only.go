@@ -0,0 +1,24 @@
+package database
+
+import (
+	"errors"
+
+	"github.com/jinzhu/gorm"
+)
+
+// ErrorReadOnly is returned on attempt to write through read-only connection.
+var ErrorReadOnly = errors.New("Database connection is read-only")
+
+// RegisterReadOnly rejects creates, updates and deletes of models made via the connection.
+// Raw Exec calls are not guarded, use read-only database user for the full protection.
+func RegisterReadOnly(db *gorm.DB) {
+	reject := func(scope *gorm.Scope) {
+		scope.Err(ErrorReadOnly)
+	}
+
+	callbacks := db.Callback()
+
+	callbacks.Create().Before("gorm:begin_transaction").Register("larago:read_only", reject)
+	callbacks.Update().Before("gorm:begin_transaction").Register("larago:read_only", reject)
+	callbacks.Delete().Before("gorm:begin_transaction").Register("larago:read_only", reject)
+}
@@ -0,0 +1,276 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Default interval between replica lag checks.
+const defaultLagCheckInterval = time.Second
+
+// LagChecker returns how far replica is behind the primary.
+type LagChecker func(replica *gorm.DB) (time.Duration, error)
+
+// Replica connection with the result of the last lag check.
+type replica struct {
+	db      *gorm.DB
+	healthy int32
+}
+
+// Replicas splits reads between replicas and sends writes to the primary.
+// Replicas lagging behind more than allowed are skipped until they catch up.
+type Replicas struct {
+	primary  *gorm.DB
+	replicas []*replica
+	next     uint32
+
+	maxLag        time.Duration
+	checkInterval time.Duration
+	lagChecker    LagChecker
+
+	watching sync.Once
+	stop     chan struct{}
+	stopping sync.Once
+}
+
+// NewReplicas constructor.
+func NewReplicas(primary *gorm.DB, replicas ...*gorm.DB) *Replicas {
+	r := &Replicas{
+		primary:       primary,
+		checkInterval: defaultLagCheckInterval,
+		stop:          make(chan struct{}),
+	}
+
+	for _, db := range replicas {
+		r.Add(db)
+	}
+
+	return r
+}
+
+// Add replica connection.
+func (r *Replicas) Add(db *gorm.DB) *Replicas {
+	r.replicas = append(r.replicas, &replica{db: db, healthy: 1})
+
+	return r
+}
+
+// SetMaxLag enables lag guard with the checker. Lag is checked by CheckLag or Watch.
+func (r *Replicas) SetMaxLag(maxLag time.Duration, checker LagChecker) *Replicas {
+	r.maxLag = maxLag
+	r.lagChecker = checker
+
+	return r
+}
+
+// SetCheckInterval sets interval between lag checks of Watch.
+func (r *Replicas) SetCheckInterval(interval time.Duration) *Replicas {
+	r.checkInterval = interval
+
+	return r
+}
+
+// Primary returns connection to write to.
+func (r *Replicas) Primary() *gorm.DB {
+	return r.primary
+}
+
+// Replica returns the next healthy replica or the primary if there are none.
+func (r *Replicas) Replica() *gorm.DB {
+	count := len(r.replicas)
+	start := atomic.AddUint32(&r.next, 1)
+
+	for i := 0; i < count; i++ {
+		replica := r.replicas[(int(start)+i)%count]
+		if atomic.LoadInt32(&replica.healthy) == 1 {
+			return replica.db
+		}
+	}
+
+	return r.primary
+}
+
+// Session makes read/write splitting session, usually one per request.
+func (r *Replicas) Session() *Session {
+	return &Session{replicas: r}
+}
+
+// Close replica connections and stops watching their lag. Primary connection is left open.
+func (r *Replicas) Close() {
+	r.stopping.Do(func() {
+		close(r.stop)
+	})
+
+	for _, replica := range r.replicas {
+		replica.db.Close()
+	}
+}
+
+// CheckLag of every replica. Replicas lagging behind more than allowed or failing to report lag are skipped.
+func (r *Replicas) CheckLag() {
+	if r.lagChecker == nil {
+		return
+	}
+
+	for _, replica := range r.replicas {
+		var healthy int32
+		if lag, err := r.lagChecker(replica.db); err == nil && lag <= r.maxLag {
+			healthy = 1
+		}
+
+		atomic.StoreInt32(&replica.healthy, healthy)
+	}
+}
+
+// Watch checks lag right away and then in background every check interval until replicas are closed,
+// so requests never wait for the checks.
+func (r *Replicas) Watch() *Replicas {
+	if r.lagChecker == nil {
+		return r
+	}
+
+	r.watching.Do(func() {
+		r.CheckLag()
+
+		interval := r.checkInterval
+		if interval <= 0 {
+			interval = defaultLagCheckInterval
+		}
+
+		go func() {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ticker.C:
+					r.CheckLag()
+				case <-r.stop:
+					return
+				}
+			}
+		}()
+	})
+
+	return r
+}
+
+// Session reads from replicas until the first write.
+// Everything after the write is read from the primary, so the client never sees stale data.
+type Session struct {
+	replicas     *Replicas
+	forcePrimary int32
+}
+
+// Read returns connection to read from.
+func (s *Session) Read() *gorm.DB {
+	if s.ForcesPrimary() {
+		return s.replicas.Primary()
+	}
+
+	return s.replicas.Replica()
+}
+
+// Write returns the primary connection and sticks the session to it.
+func (s *Session) Write() *gorm.DB {
+	s.ForcePrimary()
+
+	return s.replicas.Primary()
+}
+
+// ForcePrimary makes all the following reads go to the primary.
+func (s *Session) ForcePrimary() {
+	atomic.StoreInt32(&s.forcePrimary, 1)
+}
+
+// ForcesPrimary checks if session reads from the primary.
+func (s *Session) ForcesPrimary() bool {
+	return atomic.LoadInt32(&s.forcePrimary) == 1
+}
+
+// LagCheckerFor returns lag checker for the driver, nil if driver has no replication.
+func LagCheckerFor(driver string) LagChecker {
+	switch driver {
+	case "mysql":
+		return MySQLLag
+	case "postgres":
+		return PostgresLag
+	default:
+		return nil
+	}
+}
+
+// PostgresLag returns time since the last replayed transaction.
+// Replica which replayed everything it received has no lag, even if the primary had no writes for long.
+func PostgresLag(replica *gorm.DB) (time.Duration, error) {
+	var seconds sql.NullFloat64
+
+	row := replica.Raw(
+		"SELECT CASE WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0 " +
+			"ELSE EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()) END",
+	).Row()
+	if err := row.Scan(&seconds); err != nil {
+		return 0, err
+	}
+
+	// Not a replica or nothing replayed yet.
+	if !seconds.Valid {
+		return 0, nil
+	}
+
+	return time.Duration(seconds.Float64 * float64(time.Second)), nil
+}
+
+// MySQLLag returns Seconds_Behind_Master of the replica.
+func MySQLLag(replica *gorm.DB) (time.Duration, error) {
+	rows, err := replica.Raw("SHOW SLAVE STATUS").Rows()
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+
+	if !rows.Next() {
+		return 0, errors.New("Replication is not configured")
+	}
+
+	values := make([]sql.RawBytes, len(columns))
+	targets := make([]interface{}, len(columns))
+	for i := range values {
+		targets[i] = &values[i]
+	}
+
+	if err := rows.Scan(targets...); err != nil {
+		return 0, err
+	}
+
+	for i, column := range columns {
+		if column != "Seconds_Behind_Master" {
+			continue
+		}
+
+		// NULL means replication is stopped.
+		if values[i] == nil {
+			return 0, errors.New("Replication is stopped")
+		}
+
+		var seconds int64
+		if _, err := fmt.Sscan(string(values[i]), &seconds); err != nil {
+			return 0, err
+		}
+
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	return 0, errors.New("Seconds_Behind_Master is unknown")
+}
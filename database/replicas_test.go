@@ -0,0 +1,105 @@
+package database_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago/database"
+	"github.com/lara-go/larago/support/testsuite"
+	"github.com/stretchr/testify/assert"
+)
+
+type replicatedPost struct {
+	ID    uint `gorm:"primary_key"`
+	Title string
+}
+
+func TestSessionSticksToPrimaryAfterWrite(t *testing.T) {
+	primary := testsuite.MemoryDB(t, &replicatedPost{})
+	replica := testsuite.MemoryDB(t, &replicatedPost{})
+
+	session := database.NewReplicas(primary, replica).Session()
+	assert.Equal(t, replica, session.Read())
+
+	assert.Nil(t, session.Write().Create(&replicatedPost{Title: "Fresh"}).Error)
+	assert.True(t, session.ForcesPrimary())
+
+	// Replica has not caught up yet, but the session does not see it.
+	var post replicatedPost
+	assert.Nil(t, session.Read().First(&post).Error)
+	assert.Equal(t, "Fresh", post.Title)
+}
+
+func TestReplicasSkipLaggingReplica(t *testing.T) {
+	primary := testsuite.MemoryDB(t, &replicatedPost{})
+	replica := testsuite.MemoryDB(t, &replicatedPost{})
+
+	lag := time.Minute
+	checks := 0
+	replicas := database.NewReplicas(primary, replica).
+		SetMaxLag(time.Second, func(*gorm.DB) (time.Duration, error) {
+			checks++
+			return lag, nil
+		})
+
+	// Reads do not check lag, only CheckLag does.
+	assert.Equal(t, replica, replicas.Replica())
+	assert.Equal(t, 0, checks)
+
+	replicas.CheckLag()
+	assert.Equal(t, primary, replicas.Replica())
+
+	lag = 0
+	assert.Equal(t, primary, replicas.Replica())
+	assert.Equal(t, 1, checks)
+
+	replicas.CheckLag()
+	assert.Equal(t, replica, replicas.Replica())
+
+	replicas.SetMaxLag(time.Second, func(*gorm.DB) (time.Duration, error) {
+		return 0, errors.New("Replication is stopped")
+	})
+	replicas.CheckLag()
+	assert.Equal(t, primary, replicas.Replica())
+}
+
+func TestReplicasWatchLagInBackground(t *testing.T) {
+	primary := testsuite.MemoryDB(t, &replicatedPost{})
+	replica := testsuite.MemoryDB(t, &replicatedPost{})
+
+	lag := int64(time.Minute)
+	replicas := database.NewReplicas(primary, replica).
+		SetMaxLag(time.Second, func(*gorm.DB) (time.Duration, error) {
+			return time.Duration(atomic.LoadInt64(&lag)), nil
+		}).
+		SetCheckInterval(time.Millisecond).
+		Watch()
+	defer replicas.Close()
+
+	// The first check is made right away.
+	assert.Equal(t, primary, replicas.Replica())
+
+	atomic.StoreInt64(&lag, 0)
+	assert.Eventually(t, func() bool {
+		return replicas.Replica() == replica
+	}, time.Second, time.Millisecond)
+}
+
+func TestReadOnlyConnectionRejectsWrites(t *testing.T) {
+	db := testsuite.MemoryDB(t, &replicatedPost{})
+
+	db.Create(&replicatedPost{Title: "Before"})
+	database.RegisterReadOnly(db)
+
+	assert.Equal(t, database.ErrorReadOnly, db.Create(&replicatedPost{Title: "After"}).Error)
+	assert.Equal(t, database.ErrorReadOnly, db.Model(&replicatedPost{ID: 1}).Update("title", "Changed").Error)
+	assert.Equal(t, database.ErrorReadOnly, db.Delete(&replicatedPost{ID: 1}).Error)
+
+	var posts []replicatedPost
+	assert.Nil(t, db.Find(&posts).Error)
+	assert.Len(t, posts, 1)
+	assert.Equal(t, "Before", posts[0].Title)
+}
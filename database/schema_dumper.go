@@ -0,0 +1,196 @@
+package database
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path"
+	"strings"
+
+	"github.com/go-gormigrate/gormigrate"
+	"github.com/jinzhu/gorm"
+)
+
+var schemaPath = path.Join(".", "app", "database", "schema", "%s-schema.sql")
+
+// SchemaDumper exports current database schema to SQL.
+type SchemaDumper interface {
+	// Dump schema of the database.
+	Dump(db *gorm.DB) (string, error)
+}
+
+// SchemaPath returns path to the schema dump of the dialect.
+func SchemaPath(dialect string) string {
+	return fmt.Sprintf(schemaPath, dialect)
+}
+
+// NewSchemaDumper makes dumper for the dialect.
+func NewSchemaDumper(dialect, dsn string) (SchemaDumper, error) {
+	switch dialect {
+	case "sqlite3":
+		return &sqliteSchemaDumper{}, nil
+	case "mysql":
+		return &mysqlSchemaDumper{}, nil
+	case "postgres":
+		return &postgresSchemaDumper{dsn: dsn}, nil
+	}
+
+	return nil, fmt.Errorf("Schema dumps are not supported for %s", dialect)
+}
+
+// DumpSchema dumps schema together with already ran migrations,
+// so they will not be run again after the dump is loaded.
+func DumpSchema(db *gorm.DB, dumper SchemaDumper) (string, error) {
+	schema, err := dumper.Dump(db)
+	if err != nil {
+		return "", err
+	}
+
+	var ids []string
+	if err := db.Table(gormigrate.DefaultOptions.TableName).Pluck(gormigrate.DefaultOptions.IDColumnName, &ids).Error; err != nil {
+		return "", err
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString(strings.TrimSpace(schema))
+	buffer.WriteString("\n")
+
+	for _, id := range ids {
+		buffer.WriteString(fmt.Sprintf(
+			"INSERT INTO %s (%s) VALUES ('%s');\n",
+			gormigrate.DefaultOptions.TableName,
+			gormigrate.DefaultOptions.IDColumnName,
+			strings.Replace(id, "'", "''", -1),
+		))
+	}
+
+	return buffer.String(), nil
+}
+
+type sqliteSchemaDumper struct{}
+
+// Dump schema of the database.
+func (d *sqliteSchemaDumper) Dump(db *gorm.DB) (string, error) {
+	var statements []string
+
+	err := db.Raw(
+		"SELECT sql FROM sqlite_master WHERE sql IS NOT NULL AND name NOT LIKE 'sqlite_%' ORDER BY type = 'table' DESC, name",
+	).Pluck("sql", &statements).Error
+
+	if err != nil {
+		return "", err
+	}
+
+	return joinStatements(statements), nil
+}
+
+type mysqlSchemaDumper struct{}
+
+// Dump schema of the database.
+func (d *mysqlSchemaDumper) Dump(db *gorm.DB) (string, error) {
+	rows, err := db.Raw("SHOW FULL TABLES WHERE Table_type = 'BASE TABLE'").Rows()
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var table, tableType string
+		if err := rows.Scan(&table, &tableType); err != nil {
+			return "", err
+		}
+		tables = append(tables, table)
+	}
+
+	statements := make([]string, 0, len(tables))
+	for _, table := range tables {
+		var name, statement string
+		if err := db.Raw(fmt.Sprintf("SHOW CREATE TABLE `%s`", table)).Row().Scan(&name, &statement); err != nil {
+			return "", err
+		}
+		statements = append(statements, statement)
+	}
+
+	// Tables are listed by name, not in the order of their foreign keys.
+	statements = append([]string{"SET FOREIGN_KEY_CHECKS = 0"}, statements...)
+	statements = append(statements, "SET FOREIGN_KEY_CHECKS = 1")
+
+	return joinStatements(statements), nil
+}
+
+type postgresSchemaDumper struct {
+	dsn string
+}
+
+// Dump schema of the database via pg_dump.
+func (d *postgresSchemaDumper) Dump(db *gorm.DB) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command("pg_dump", "--schema-only", "--no-owner", "--no-privileges", d.dsn)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("pg_dump failed: %s %s", err, stderr.String())
+	}
+
+	return stdout.String(), nil
+}
+
+// Join statements into SQL file.
+func joinStatements(statements []string) string {
+	return strings.Join(statements, ";\n\n") + ";\n"
+}
+
+// Split SQL file into statements. Semicolons inside quotes and comments do not end statements.
+func splitStatements(schema string) []string {
+	var statements []string
+	var quote byte
+
+	start := 0
+	for i := 0; i < len(schema); i++ {
+		c := schema[i]
+
+		switch {
+		case quote != 0:
+			if c == '\\' && quote != '`' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '-' && strings.HasPrefix(schema[i:], "--"), c == '#':
+			if end := strings.IndexByte(schema[i:], '\n'); end >= 0 {
+				i += end
+			} else {
+				i = len(schema)
+			}
+		case c == '/' && strings.HasPrefix(schema[i:], "/*"):
+			if end := strings.Index(schema[i+2:], "*/"); end >= 0 {
+				i += end + 3
+			} else {
+				i = len(schema)
+			}
+		case c == ';':
+			statements = appendStatement(statements, schema[start:i])
+			start = i + 1
+		}
+	}
+
+	if start < len(schema) {
+		statements = appendStatement(statements, schema[start:])
+	}
+
+	return statements
+}
+
+// Append statement if it is not empty.
+func appendStatement(statements []string, statement string) []string {
+	if statement = strings.TrimSpace(statement); statement != "" {
+		statements = append(statements, statement)
+	}
+
+	return statements
+}
@@ -0,0 +1,66 @@
+package database_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago/database"
+	"github.com/stretchr/testify/assert"
+
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+)
+
+func openDatabase(t *testing.T, dir, name string) *gorm.DB {
+	db, err := gorm.Open("sqlite3", path.Join(dir, name))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+func TestSchemaDump_LoadedOnFreshDatabase(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "schema")
+	defer os.RemoveAll(dir)
+
+	createUsers := &database.SQLMigration{ID: "001_create_users", Up: "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)"}
+	createPosts := &database.SQLMigration{ID: "002_create_posts", Up: "CREATE TABLE posts (id INTEGER PRIMARY KEY, title TEXT)"}
+
+	old := openDatabase(t, dir, "old.db")
+	defer old.Close()
+
+	migrator := &database.Migrator{}
+	migrator.AddMigrations(createUsers)
+	assert.NoError(t, migrator.Migrate(old))
+
+	dumper, err := database.NewSchemaDumper("sqlite3", "")
+	assert.NoError(t, err)
+
+	schema, err := database.DumpSchema(old, dumper)
+	assert.NoError(t, err)
+	assert.Contains(t, schema, "CREATE TABLE users")
+	assert.Contains(t, schema, "'001_create_users'")
+
+	schemaPath := path.Join(dir, "sqlite3-schema.sql")
+	ioutil.WriteFile(schemaPath, []byte(schema), 0644)
+
+	fresh := openDatabase(t, dir, "fresh.db")
+	defer fresh.Close()
+
+	// Users migration would fail if it ran again, since table already exists in the dump.
+	migrator = &database.Migrator{}
+	migrator.SetSchemaPath(schemaPath)
+	migrator.AddMigrations(createUsers, createPosts)
+	assert.NoError(t, migrator.Migrate(fresh))
+
+	assert.True(t, fresh.HasTable("users"))
+	assert.True(t, fresh.HasTable("posts"))
+}
+
+func TestNewSchemaDumper_Unsupported(t *testing.T) {
+	_, err := database.NewSchemaDumper("mssql", "")
+	assert.Error(t, err)
+}
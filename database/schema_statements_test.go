@@ -0,0 +1,22 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitStatementsSkipsQuotedSemicolons(t *testing.T) {
+	schema := "SET FOREIGN_KEY_CHECKS = 0;\n\n" +
+		"CREATE TABLE `a;b` (id INT, note VARCHAR(10) DEFAULT 'x;y' COMMENT 'it''s; \\'quoted\\'');\n" +
+		"-- comment; with semicolon\n" +
+		"/* block; comment */ CREATE TABLE c (id INT);" +
+		"INSERT INTO migrations (id) VALUES (\"1;2\")"
+
+	assert.Equal(t, []string{
+		"SET FOREIGN_KEY_CHECKS = 0",
+		"CREATE TABLE `a;b` (id INT, note VARCHAR(10) DEFAULT 'x;y' COMMENT 'it''s; \\'quoted\\'')",
+		"-- comment; with semicolon\n/* block; comment */ CREATE TABLE c (id INT)",
+		"INSERT INTO migrations (id) VALUES (\"1;2\")",
+	}, splitStatements(schema))
+}
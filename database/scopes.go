@@ -0,0 +1,139 @@
+package database
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Scope is a reusable named query filter applied with db.Scopes(Active(), OfUser(id)).
+type Scope = func(db *gorm.DB) *gorm.DB
+
+// Setting to skip global scopes.
+const withoutScopesSetting = "larago:without_global_scopes"
+
+// GlobalScope filters every query of the model.
+type GlobalScope interface {
+	Apply(scope *gorm.Scope)
+}
+
+// GlobalScopeFunc adapts function to GlobalScope.
+// Use it to build conditions at query time, ex. for the current tenant.
+type GlobalScopeFunc func(scope *gorm.Scope)
+
+// Apply scope.
+func (f GlobalScopeFunc) Apply(scope *gorm.Scope) {
+	f(scope)
+}
+
+// Where makes global scope adding static condition.
+func Where(query interface{}, args ...interface{}) GlobalScope {
+	return GlobalScopeFunc(func(scope *gorm.Scope) {
+		scope.Search.Where(query, args...)
+	})
+}
+
+// GlobalScopes applies registered scopes to queries, updates and deletes of the models.
+type GlobalScopes struct {
+	mutex  sync.RWMutex
+	scopes map[reflect.Type]map[string]GlobalScope
+}
+
+// NewGlobalScopes constructor.
+func NewGlobalScopes() *GlobalScopes {
+	return &GlobalScopes{
+		scopes: make(map[reflect.Type]map[string]GlobalScope),
+	}
+}
+
+// Add named scope to the model.
+func (g *GlobalScopes) Add(model interface{}, name string, scope GlobalScope) *GlobalScopes {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	t := modelType(model)
+	if g.scopes[t] == nil {
+		g.scopes[t] = make(map[string]GlobalScope)
+	}
+
+	g.scopes[t][name] = scope
+
+	return g
+}
+
+// Remove named scope from the model.
+func (g *GlobalScopes) Remove(model interface{}, name string) *GlobalScopes {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	delete(g.scopes[modelType(model)], name)
+
+	return g
+}
+
+// Register callbacks on the connection.
+func (g *GlobalScopes) Register(db *gorm.DB) {
+	db.Callback().Query().Before("gorm:query").Register("larago:global_scopes", g.apply)
+	db.Callback().RowQuery().Before("gorm:row_query").Register("larago:global_scopes", g.apply)
+	db.Callback().Update().Before("gorm:update").Register("larago:global_scopes", g.apply)
+	db.Callback().Delete().Before("gorm:delete").Register("larago:global_scopes", g.apply)
+}
+
+// WithoutGlobalScopes disables named global scopes for the query, or all of them if no names given.
+func WithoutGlobalScopes(db *gorm.DB, names ...string) *gorm.DB {
+	if names == nil {
+		names = []string{}
+	}
+
+	return db.Set(withoutScopesSetting, names)
+}
+
+// Apply scopes of the model.
+func (g *GlobalScopes) apply(scope *gorm.Scope) {
+	if scope.Value == nil {
+		return
+	}
+
+	g.mutex.RLock()
+	scopes := g.scopes[scope.GetModelStruct().ModelType]
+	g.mutex.RUnlock()
+
+	if len(scopes) == 0 {
+		return
+	}
+
+	var skipped []string
+	if setting, ok := scope.Get(withoutScopesSetting); ok {
+		skipped = setting.([]string)
+		if len(skipped) == 0 {
+			return
+		}
+	}
+
+	for name, global := range scopes {
+		if !contains(skipped, name) {
+			global.Apply(scope)
+		}
+	}
+}
+
+// Get struct type of the model.
+func modelType(model interface{}) reflect.Type {
+	t := reflect.TypeOf(model)
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+
+	return t
+}
+
+func contains(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,58 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago/database"
+	"github.com/lara-go/larago/support/testsuite"
+	"github.com/stretchr/testify/assert"
+)
+
+type article struct {
+	ID       uint `gorm:"primary_key"`
+	TenantID uint
+	Active   bool
+}
+
+func active() database.Scope {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("active = ?", true)
+	}
+}
+
+func TestGlobalAndLocalScopes(t *testing.T) {
+	db := testsuite.MemoryDB(t, &article{})
+
+	db.Create(&article{TenantID: 1, Active: true})
+	db.Create(&article{TenantID: 1, Active: false})
+	db.Create(&article{TenantID: 2, Active: true})
+
+	tenant := uint(1)
+	scopes := database.NewGlobalScopes()
+	scopes.Register(db)
+	scopes.Add(&article{}, "tenant", database.GlobalScopeFunc(func(scope *gorm.Scope) {
+		scope.Search.Where("tenant_id = ?", tenant)
+	}))
+
+	var articles []article
+	db.Find(&articles)
+	assert.Len(t, articles, 2)
+
+	var count int
+	db.Model(&article{}).Scopes(active()).Count(&count)
+	assert.Equal(t, 1, count)
+
+	// Other tenant's rows can't be changed.
+	db.Model(&article{}).Where("id = ?", 3).Update("active", false)
+	tenant = 2
+	db.Scopes(active()).Find(&articles)
+	assert.Len(t, articles, 1)
+
+	database.WithoutGlobalScopes(db).Find(&articles)
+	assert.Len(t, articles, 3)
+
+	scopes.Remove(&article{}, "tenant")
+	db.Find(&articles)
+	assert.Len(t, articles, 3)
+}
@@ -1,8 +1,12 @@
 package database
 
 import (
+	"sync"
+
 	"github.com/jinzhu/gorm"
 	"github.com/lara-go/larago"
+	"github.com/lara-go/larago/cache"
+	"github.com/lara-go/larago/database/casts"
 )
 
 // ServiceProvider struct.
@@ -12,6 +16,11 @@ type ServiceProvider struct{}
 func (p *ServiceProvider) Register(application *larago.Application) {
 	p.registerDatabaseConnection(application)
 	p.registerMigrator(application)
+	p.registerModelCache(application)
+	p.registerGlobalScopes(application)
+	p.registerReplicas(application)
+
+	application.Bind(NewMorphMap(), "db.morphs")
 
 	application.Commands(
 		&CommandDBSeed{},
@@ -19,20 +28,171 @@ func (p *ServiceProvider) Register(application *larago.Application) {
 		&CommandMigrate{},
 		&CommandMigrateRollback{},
 		&CommandMigrateReset{},
+		&CommandSchemaDump{},
+		&CommandRotateKey{},
 	)
 }
 
+// MigrationsModule registers migrations of the module.
+type MigrationsModule interface {
+	Migrations() []Migration
+}
+
+// Boot service. Adds migrations of the modules.
+func (p *ServiceProvider) Boot(application *larago.Application) {
+	migrator := application.Get((*Migrator)(nil)).(*Migrator)
+
+	for _, module := range application.Modules() {
+		if m, ok := module.(MigrationsModule); ok {
+			migrator.AddModuleMigrations(m.Migrations()...)
+		}
+	}
+}
+
 func (p *ServiceProvider) registerDatabaseConnection(application *larago.Application) {
 	application.Bind(&Manager{}, "db")
 
+	var lock sync.Mutex
+	var db *gorm.DB
+
+	// Same connection is returned whether resolved by type or alias,
+	// so every part of the application shares the pool and its callbacks.
+	// Failed connection is tried again on the next resolve.
 	application.Bind(func() (*gorm.DB, error) {
-		var manager Manager
-		application.Make(&manager)
+		lock.Lock()
+		defer lock.Unlock()
+
+		if db != nil {
+			return db, nil
+		}
+
+		connection, err := p.connect(application)
+		if err != nil {
+			return nil, err
+		}
+		db = connection
 
-		return manager.GetConnection()
+		return db, nil
 	}, "db.connection")
 }
 
+func (p *ServiceProvider) connect(application *larago.Application) (*gorm.DB, error) {
+	var manager Manager
+	application.Make(&manager)
+
+	config := application.Config()
+	if config.Has("Database.StatementCache") {
+		size, err := config.Int("Database.StatementCache")
+		if err != nil {
+			return nil, err
+		}
+		manager.StatementCacheSize = size
+	}
+
+	if config.Has("Database.ReadOnly") {
+		readOnly, err := config.Bool("Database.ReadOnly")
+		if err != nil {
+			return nil, err
+		}
+		manager.ReadOnly = readOnly
+	}
+
+	db, err := manager.GetConnection()
+	if err != nil {
+		return nil, err
+	}
+
+	application.Get("db.scopes").(*GlobalScopes).Register(db)
+	application.Get("db.cache").(*ModelCache).Register(db)
+
+	// Encrypter is given by encryption service provider when it boots.
+	casts.RegisterEncrypter(db, func() (casts.Encrypter, error) {
+		if !application.Bound((*casts.Encrypter)(nil)) {
+			return nil, casts.ErrorNoEncrypter
+		}
+
+		return application.Get((*casts.Encrypter)(nil)).(casts.Encrypter), nil
+	})
+
+	return db, nil
+}
+
 func (p *ServiceProvider) registerMigrator(application *larago.Application) {
 	application.Bind(&Migrator{})
 }
+
+func (p *ServiceProvider) registerModelCache(application *larago.Application) {
+	// Cache is resolved on the first use, it may be stored in the database itself.
+	application.Bind(NewLazyModelCache(func() cache.Cache {
+		if !application.Bound("cache") {
+			return nil
+		}
+
+		return application.Get("cache").(cache.Cache)
+	}), "db.cache")
+}
+
+func (p *ServiceProvider) registerGlobalScopes(application *larago.Application) {
+	application.Bind(NewGlobalScopes(), "db.scopes")
+}
+
+func (p *ServiceProvider) registerReplicas(application *larago.Application) {
+	var lock sync.Mutex
+	var replicas *Replicas
+
+	// Same connections are returned whether resolved by type or alias.
+	// Failed connections are tried again on the next resolve.
+	application.Bind(func() (*Replicas, error) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if replicas != nil {
+			return replicas, nil
+		}
+
+		connected, err := p.connectReplicas(application)
+		if err != nil {
+			return nil, err
+		}
+		replicas = connected
+
+		return replicas, nil
+	}, "db.replicas")
+}
+
+func (p *ServiceProvider) connectReplicas(application *larago.Application) (*Replicas, error) {
+	config := application.Config()
+	replicas := NewReplicas(application.Get("db.connection").(*gorm.DB))
+
+	var manager Manager
+	application.Make(&manager)
+
+	if config.Has("Database.Replicas") {
+		dsns, err := config.Strings("Database.Replicas")
+		if err != nil {
+			return nil, err
+		}
+
+		for _, dsn := range dsns {
+			db, err := manager.ConnectReplica(dsn)
+			if err != nil {
+				replicas.Close()
+				return nil, err
+			}
+
+			replicas.Add(db)
+		}
+	}
+
+	if config.Has("Database.MaxReplicaLag") {
+		maxLag, err := config.Duration("Database.MaxReplicaLag")
+		if err != nil {
+			replicas.Close()
+			return nil, err
+		}
+
+		replicas.SetMaxLag(maxLag, LagCheckerFor(manager.Driver)).Watch()
+	}
+
+	return replicas, nil
+}
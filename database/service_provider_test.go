@@ -0,0 +1,147 @@
+package database_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago"
+	"github.com/lara-go/larago/database"
+	"github.com/lara-go/larago/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+type databaseConfig struct {
+	App struct {
+		Debug bool
+	}
+	Database struct {
+		Driver string
+		DSN    string
+	}
+}
+
+func (c *databaseConfig) Env() string {
+	return "testing"
+}
+
+func (c *databaseConfig) Debug() bool {
+	return false
+}
+
+func TestConnectionIsShared(t *testing.T) {
+	config := &databaseConfig{}
+	config.Database.Driver = "sqlite3"
+	config.Database.DSN = filepath.Join(t.TempDir(), "app.sqlite")
+
+	application := larago.New()
+	application.SetConfig(func() larago.Config { return config }).ImportConfig()
+	application.Register(&logger.ServiceProvider{})
+	application.Register(&database.ServiceProvider{})
+	assert.NoError(t, application.Boot())
+
+	db := application.Get("db.connection").(*gorm.DB)
+	defer db.Close()
+
+	db.AutoMigrate(&article{})
+	db.Create(&article{TenantID: 1})
+	db.Create(&article{TenantID: 2})
+
+	typed := application.Get((*gorm.DB)(nil)).(*gorm.DB)
+	assert.True(t, db == typed)
+
+	// Scopes are applied without resolving them first.
+	application.Get("db.scopes").(*database.GlobalScopes).Add(&article{}, "tenant", database.Where("tenant_id = ?", 1))
+
+	var articles []article
+	typed.Find(&articles)
+	assert.Len(t, articles, 1)
+}
+
+func TestFailedConnectionIsRetried(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "missing")
+
+	config := &databaseConfig{}
+	config.Database.Driver = "sqlite3"
+	config.Database.DSN = filepath.Join(dir, "app.sqlite")
+
+	application := larago.New()
+	application.SetConfig(func() larago.Config { return config }).ImportConfig()
+	application.Register(&logger.ServiceProvider{})
+	application.Register(&database.ServiceProvider{})
+	assert.NoError(t, application.Boot())
+
+	assert.Panics(t, func() {
+		application.Get("db.connection")
+	})
+
+	// Database became available.
+	assert.NoError(t, os.Mkdir(dir, 0755))
+
+	db := application.Get("db.connection").(*gorm.DB)
+	defer db.Close()
+	assert.True(t, db == application.Get((*gorm.DB)(nil)).(*gorm.DB))
+}
+
+type replicasConfig struct {
+	App struct {
+		Debug bool
+	}
+	Database struct {
+		Driver   string
+		DSN      string
+		Replicas interface{}
+	}
+}
+
+func (c *replicasConfig) Env() string {
+	return "testing"
+}
+
+func (c *replicasConfig) Debug() bool {
+	return false
+}
+
+func TestReplicasFromConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	replicas := func(list interface{}) (*database.Replicas, error) {
+		config := &replicasConfig{}
+		config.Database.Driver = "sqlite3"
+		config.Database.DSN = filepath.Join(dir, "primary.sqlite")
+		config.Database.Replicas = list
+
+		application := larago.New()
+		application.SetConfig(func() larago.Config { return config }).ImportConfig()
+		application.Register(&logger.ServiceProvider{})
+		application.Register(&database.ServiceProvider{})
+		assert.NoError(t, application.Boot())
+
+		var err error
+		var resolved *database.Replicas
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					err = fmt.Errorf("%v", r)
+				}
+			}()
+			resolved = application.Get("db.replicas").(*database.Replicas)
+		}()
+
+		return resolved, err
+	}
+
+	// List decoded from JSON.
+	resolved, err := replicas([]interface{}{filepath.Join(dir, "replica.sqlite")})
+	assert.NoError(t, err)
+	assert.False(t, resolved.Replica() == resolved.Primary())
+	resolved.Close()
+
+	_, err = replicas([]interface{}{1})
+	assert.Contains(t, err.Error(), "Database.Replicas")
+
+	_, err = replicas("replica.sqlite")
+	assert.Contains(t, err.Error(), "Database.Replicas")
+}
@@ -0,0 +1,92 @@
+package database
+
+import (
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+)
+
+const (
+	upSuffix   = ".up.sql"
+	downSuffix = ".down.sql"
+)
+
+// SQLMigration is a migration made of raw SQL files.
+type SQLMigration struct {
+	ID   string
+	Up   string
+	Down string
+}
+
+// MigrationID returns unique migration ID.
+func (m *SQLMigration) MigrationID() string {
+	return m.ID
+}
+
+// Migrate runs migrations.
+func (m *SQLMigration) Migrate(tx *gorm.DB) error {
+	return tx.Exec(m.Up).Error
+}
+
+// Rollback changes.
+func (m *SQLMigration) Rollback(tx *gorm.DB) error {
+	if m.Down == "" {
+		return nil
+	}
+
+	return tx.Exec(m.Down).Error
+}
+
+// LoadSQLMigrations reads "<id>.up.sql" and "<id>.down.sql" files from file system (ex. embed.FS).
+// Migrations are sorted by their IDs.
+func LoadSQLMigrations(fsys fs.FS) ([]Migration, error) {
+	indexed := make(map[string]*SQLMigration)
+
+	err := fs.WalkDir(fsys, ".", func(file string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		name := path.Base(file)
+		if !strings.HasSuffix(name, upSuffix) && !strings.HasSuffix(name, downSuffix) {
+			return nil
+		}
+
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return err
+		}
+
+		id := strings.TrimSuffix(strings.TrimSuffix(name, upSuffix), downSuffix)
+		if indexed[id] == nil {
+			indexed[id] = &SQLMigration{ID: id}
+		}
+
+		if strings.HasSuffix(name, upSuffix) {
+			indexed[id].Up = string(content)
+		} else {
+			indexed[id].Down = string(content)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(indexed))
+	for id := range indexed {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	migrations := make([]Migration, len(ids))
+	for i, id := range ids {
+		migrations[i] = indexed[id]
+	}
+
+	return migrations, nil
+}
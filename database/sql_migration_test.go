@@ -0,0 +1,31 @@
+package database_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/lara-go/larago/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadSQLMigrationsSortsByName(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/20200102_posts.up.sql":      {Data: []byte("CREATE TABLE posts (id INTEGER)")},
+		"migrations/20200102_posts.down.sql":    {Data: []byte("DROP TABLE posts")},
+		"migrations/20200101_users.up.sql":      {Data: []byte("CREATE TABLE users (id INTEGER)")},
+		"migrations/20200103_comments.up.sql":   {Data: []byte("CREATE TABLE comments (id INTEGER)")},
+		"migrations/20200101_users.down.sql":    {Data: []byte("DROP TABLE users")},
+		"migrations/20200103_comments.down.sql": {Data: []byte("DROP TABLE comments")},
+	}
+
+	for i := 0; i < 10; i++ {
+		migrations, err := database.LoadSQLMigrations(fsys)
+		assert.NoError(t, err)
+
+		var ids []string
+		for _, migration := range migrations {
+			ids = append(ids, migration.(*database.SQLMigration).ID)
+		}
+		assert.Equal(t, []string{"20200101_users", "20200102_posts", "20200103_comments"}, ids)
+	}
+}
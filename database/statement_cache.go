@@ -0,0 +1,236 @@
+package database
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/jinzhu/gorm"
+)
+
+// StatementMetrics of the statement cache.
+type StatementMetrics struct {
+	Size      int
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// HitRate returns share of queries served by cached statements.
+func (m StatementMetrics) HitRate() float64 {
+	total := m.Hits + m.Misses
+	if total == 0 {
+		return 0
+	}
+
+	return float64(m.Hits) / float64(total)
+}
+
+// Cached statement.
+type cachedStatement struct {
+	query     string
+	statement *sql.Stmt
+
+	// Calls using the statement, it is closed when evicted and not used anymore.
+	users   int
+	evicted bool
+}
+
+// StatementCache keeps LRU of prepared statements of the connection pool.
+// Pass it to gorm.Open instead of DSN. Note that gorm DB() panics then, use Pool().
+type StatementCache struct {
+	db   *sql.DB
+	size int
+
+	mutex sync.Mutex
+	items map[string]*list.Element
+	order *list.List
+
+	metrics StatementMetrics
+}
+
+// NewStatementCache constructor.
+func NewStatementCache(db *sql.DB, size int) *StatementCache {
+	return &StatementCache{
+		db:    db,
+		size:  size,
+		items: make(map[string]*list.Element),
+		order: list.New(),
+	}
+}
+
+// DB returns underlying connection pool.
+func (c *StatementCache) DB() *sql.DB {
+	return c.db
+}
+
+// Pool returns connection pool of the connection whether it caches statements or not.
+func Pool(db *gorm.DB) *sql.DB {
+	switch common := db.CommonDB().(type) {
+	case *sql.DB:
+		return common
+	case *StatementCache:
+		return common.DB()
+	}
+
+	return nil
+}
+
+// Exec query with cached statement.
+func (c *StatementCache) Exec(query string, args ...interface{}) (sql.Result, error) {
+	cached, err := c.acquire(query)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release(cached)
+
+	return cached.statement.Exec(args...)
+}
+
+// Query with cached statement.
+func (c *StatementCache) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	cached, err := c.acquire(query)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release(cached)
+
+	// Rows keep the statement open until they are closed.
+	return cached.statement.Query(args...)
+}
+
+// QueryRow with cached statement.
+func (c *StatementCache) QueryRow(query string, args ...interface{}) *sql.Row {
+	cached, err := c.acquire(query)
+	if err != nil {
+		// Let sql.Row carry the error.
+		return c.db.QueryRow(query, args...)
+	}
+	defer c.release(cached)
+
+	return cached.statement.QueryRow(args...)
+}
+
+// Prepare new statement, not cached. Caller must close it.
+func (c *StatementCache) Prepare(query string) (*sql.Stmt, error) {
+	return c.db.Prepare(query)
+}
+
+// Begin transaction. Statements are not cached inside transactions.
+func (c *StatementCache) Begin() (*sql.Tx, error) {
+	return c.db.Begin()
+}
+
+// BeginTx starts transaction with options.
+func (c *StatementCache) BeginTx(ctx context.Context, options *sql.TxOptions) (*sql.Tx, error) {
+	return c.db.BeginTx(ctx, options)
+}
+
+// Close statements and connection pool.
+func (c *StatementCache) Close() error {
+	c.Clear()
+
+	return c.db.Close()
+}
+
+// Clear closes all cached statements.
+func (c *StatementCache) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, element := range c.items {
+		c.evict(element.Value.(*cachedStatement))
+	}
+
+	c.items = make(map[string]*list.Element)
+	c.order.Init()
+}
+
+// Metrics of the cache.
+func (c *StatementCache) Metrics() StatementMetrics {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	metrics := c.metrics
+	metrics.Size = c.order.Len()
+
+	return metrics
+}
+
+// Get cached statement or prepare new one, evicting the least recently used.
+// Statement is not closed until it is released.
+func (c *StatementCache) acquire(query string) (*cachedStatement, error) {
+	c.mutex.Lock()
+	if cached := c.use(query); cached != nil {
+		c.metrics.Hits++
+		c.mutex.Unlock()
+
+		return cached, nil
+	}
+	c.metrics.Misses++
+	c.mutex.Unlock()
+
+	// Preparing is a round trip to the database, so other queries do not wait for it.
+	statement, err := c.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Another call could prepare the same query meanwhile.
+	if cached := c.use(query); cached != nil {
+		statement.Close()
+
+		return cached, nil
+	}
+
+	cached := &cachedStatement{query: query, statement: statement, users: 1}
+	c.items[query] = c.order.PushFront(cached)
+
+	for c.order.Len() > c.size {
+		oldest := c.order.Remove(c.order.Back()).(*cachedStatement)
+		delete(c.items, oldest.query)
+
+		c.evict(oldest)
+		c.metrics.Evictions++
+	}
+
+	return cached, nil
+}
+
+// Use cached statement of the query if there is one. Must be called under the lock.
+func (c *StatementCache) use(query string) *cachedStatement {
+	element, ok := c.items[query]
+	if !ok {
+		return nil
+	}
+
+	c.order.MoveToFront(element)
+
+	cached := element.Value.(*cachedStatement)
+	cached.users++
+
+	return cached
+}
+
+// Release statement after the call.
+func (c *StatementCache) release(cached *cachedStatement) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	cached.users--
+	if cached.evicted && cached.users == 0 {
+		cached.statement.Close()
+	}
+}
+
+// Close evicted statement unless some call still uses it.
+func (c *StatementCache) evict(cached *cachedStatement) {
+	cached.evicted = true
+	if cached.users == 0 {
+		cached.statement.Close()
+	}
+}
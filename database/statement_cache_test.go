@@ -0,0 +1,194 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago/database"
+	"github.com/lara-go/larago/support/testsuite"
+	"github.com/stretchr/testify/assert"
+)
+
+type visit struct {
+	ID   uint `gorm:"primary_key"`
+	Path string
+}
+
+func TestStatementCache(t *testing.T) {
+	statements := database.NewStatementCache(testsuite.MemoryDB(t).DB(), 2)
+	db, err := gorm.Open("sqlite3", statements)
+	assert.Nil(t, err)
+	defer db.Close()
+
+	db.AutoMigrate(&visit{})
+
+	// Writes run inside transactions, which bypass the cache.
+	for i := 0; i < 3; i++ {
+		db.Create(&visit{Path: "/"})
+	}
+
+	before := statements.Metrics()
+
+	for i := 0; i < 3; i++ {
+		var visits []visit
+		db.Where("path = ?", "/").Find(&visits)
+		assert.Len(t, visits, 3)
+
+		var found visit
+		db.First(&found, 1)
+		assert.Equal(t, "/", found.Path)
+	}
+
+	metrics := statements.Metrics()
+	assert.Equal(t, uint64(2), metrics.Misses-before.Misses)
+	assert.Equal(t, uint64(4), metrics.Hits-before.Hits)
+	assert.Equal(t, 2, metrics.Size)
+
+	// Third distinct query evicts the least recently used statement.
+	var count int
+	db.Model(&visit{}).Count(&count)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, 2, statements.Metrics().Size)
+	assert.Equal(t, metrics.Evictions+1, statements.Metrics().Evictions)
+
+	statements.Clear()
+	assert.Equal(t, 0, statements.Metrics().Size)
+}
+
+func TestStatementMetricsHitRate(t *testing.T) {
+	assert.Equal(t, float64(0), database.StatementMetrics{}.HitRate())
+	assert.Equal(t, 0.75, database.StatementMetrics{Hits: 3, Misses: 1}.HitRate())
+}
+
+func TestStatementCacheKeepsEvictedStatementsInUse(t *testing.T) {
+	pool, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "visits.sqlite"))
+	assert.Nil(t, err)
+
+	statements := database.NewStatementCache(pool, 1)
+	db, err := gorm.Open("sqlite3", statements)
+	assert.Nil(t, err)
+	defer db.Close()
+
+	assert.True(t, pool == database.Pool(db))
+	db.AutoMigrate(&visit{})
+
+	// Every call evicts statement of the other one.
+	var wg sync.WaitGroup
+	errs := make(chan error, 200)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				var count int
+				errs <- statements.QueryRow("SELECT COUNT(*) FROM visits").Scan(&count)
+				_, err := statements.Exec("SELECT ?", j)
+				errs <- err
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(errs)
+	}()
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+}
+
+// Driver which prepares "SLOW" statement until it is unblocked.
+type slowDriver struct {
+	preparing chan struct{}
+	unblock   chan struct{}
+}
+
+func (d *slowDriver) Open(name string) (driver.Conn, error) {
+	return &slowConn{driver: d}, nil
+}
+
+func (d *slowDriver) Connect(ctx context.Context) (driver.Conn, error) {
+	return d.Open("")
+}
+
+func (d *slowDriver) Driver() driver.Driver {
+	return d
+}
+
+type slowConn struct {
+	driver *slowDriver
+}
+
+func (c *slowConn) Prepare(query string) (driver.Stmt, error) {
+	if query == "SLOW" {
+		close(c.driver.preparing)
+		<-c.driver.unblock
+	}
+
+	return &slowStmt{}, nil
+}
+
+func (c *slowConn) Close() error {
+	return nil
+}
+
+func (c *slowConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("Transactions are not supported")
+}
+
+type slowStmt struct{}
+
+func (s *slowStmt) Close() error {
+	return nil
+}
+
+func (s *slowStmt) NumInput() int {
+	return -1
+}
+
+func (s *slowStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return driver.ResultNoRows, nil
+}
+
+func (s *slowStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, errors.New("Queries are not supported")
+}
+
+func TestStatementCachePreparesWithoutLock(t *testing.T) {
+	slow := &slowDriver{preparing: make(chan struct{}), unblock: make(chan struct{})}
+	statements := database.NewStatementCache(sql.OpenDB(slow), 10)
+	defer statements.Close()
+
+	prepared := make(chan error)
+	go func() {
+		_, err := statements.Exec("SLOW")
+		prepared <- err
+	}()
+	<-slow.preparing
+
+	// Cached queries are not blocked by preparing the slow one.
+	done := make(chan error)
+	go func() {
+		_, err := statements.Exec("FAST")
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Error("Query waited for another statement to be prepared")
+	}
+
+	close(slow.unblock)
+	assert.NoError(t, <-prepared)
+	assert.Equal(t, 2, statements.Metrics().Size)
+}
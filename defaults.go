@@ -6,4 +6,10 @@ const (
 
 	// DateTimeFormat default date-time format.
 	DateTimeFormat = "2006-01-02 15:04:05"
+
+	// EnvVariable is the name of the environment variable with application environment.
+	EnvVariable = "APP_ENV"
+
+	// DefaultEnvironment is used if environment was not set anywhere.
+	DefaultEnvironment = "production"
 )
@@ -0,0 +1,33 @@
+package encryption
+
+import (
+	"github.com/lara-go/larago/logger"
+	"github.com/urfave/cli"
+)
+
+// CommandKeyGenerate prints new encryption key.
+type CommandKeyGenerate struct {
+	Logger *logger.Logger
+}
+
+// GetCommand for the cli to register.
+func (c *CommandKeyGenerate) GetCommand() cli.Command {
+	return cli.Command{
+		Name:      "key:generate",
+		Usage:     "Generate new encryption key",
+		UsageText: "Put the key into App.Key config value. Move old key to App.PreviousKeys and run db:rotate-key to rotate it.\n",
+		Category:  "Encryption",
+	}
+}
+
+// Handle command.
+func (c *CommandKeyGenerate) Handle(args cli.Args) error {
+	key, err := GenerateKey()
+	if err != nil {
+		return err
+	}
+
+	c.Logger.Success("%s", key)
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// KeySize of AES-256 key.
+const KeySize = 32
+
+// ErrorDecrypt is returned when payload can't be decrypted with any of the keys.
+var ErrorDecrypt = errors.New("The payload can't be decrypted")
+
+// Encrypter encrypts values with AES-256-GCM.
+// Previous keys are used only for decryption, so keys can be rotated.
+type Encrypter struct {
+	key      cipher.AEAD
+	previous []cipher.AEAD
+}
+
+// New constructor.
+func New(key []byte, previous ...[]byte) (*Encrypter, error) {
+	current, err := newCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Encrypter{key: current}
+	for _, old := range previous {
+		c, err := newCipher(old)
+		if err != nil {
+			return nil, err
+		}
+
+		e.previous = append(e.previous, c)
+	}
+
+	return e, nil
+}
+
+// ParseKey parses key from config. Keys prefixed with "base64:" are decoded.
+func ParseKey(key string) ([]byte, error) {
+	if strings.HasPrefix(key, "base64:") {
+		return base64.StdEncoding.DecodeString(strings.TrimPrefix(key, "base64:"))
+	}
+
+	return []byte(key), nil
+}
+
+// GenerateKey makes new random key in "base64:" format.
+func GenerateKey() (string, error) {
+	key := make([]byte, KeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return "", err
+	}
+
+	return "base64:" + base64.StdEncoding.EncodeToString(key), nil
+}
+
+// Encrypt value. Every call returns different payload.
+func (e *Encrypter) Encrypt(value []byte) (string, error) {
+	nonce := make([]byte, e.key.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	sealed := e.key.Seal(nonce, nonce, value, nil)
+
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// EncryptString encrypts string value.
+func (e *Encrypter) EncryptString(value string) (string, error) {
+	return e.Encrypt([]byte(value))
+}
+
+// Decrypt payload with the current or one of the previous keys.
+func (e *Encrypter) Decrypt(payload string) ([]byte, error) {
+	sealed, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, ErrorDecrypt
+	}
+
+	for _, key := range append([]cipher.AEAD{e.key}, e.previous...) {
+		if value, err := open(key, sealed); err == nil {
+			return value, nil
+		}
+	}
+
+	return nil, ErrorDecrypt
+}
+
+// DecryptString decrypts payload into string.
+func (e *Encrypter) DecryptString(payload string) (string, error) {
+	value, err := e.Decrypt(payload)
+
+	return string(value), err
+}
+
+// Make AES-GCM cipher.
+func newCipher(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("Encryption key must be %d bytes long, %d given", KeySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// Open sealed value with the key.
+func open(key cipher.AEAD, sealed []byte) ([]byte, error) {
+	if len(sealed) < key.NonceSize() {
+		return nil, ErrorDecrypt
+	}
+
+	nonce, ciphertext := sealed[:key.NonceSize()], sealed[key.NonceSize():]
+
+	return key.Open(nil, nonce, ciphertext, nil)
+}
@@ -0,0 +1,55 @@
+package encryption_test
+
+import (
+	"testing"
+
+	"github.com/lara-go/larago/encryption"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeKey(t *testing.T) []byte {
+	generated, err := encryption.GenerateKey()
+	assert.Nil(t, err)
+
+	key, err := encryption.ParseKey(generated)
+	assert.Nil(t, err)
+
+	return key
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	encrypter, err := encryption.New(makeKey(t))
+	assert.Nil(t, err)
+
+	first, _ := encrypter.EncryptString("secret")
+	second, _ := encrypter.EncryptString("secret")
+	assert.NotEqual(t, first, second)
+
+	value, err := encrypter.DecryptString(first)
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", value)
+
+	_, err = encrypter.DecryptString("garbage")
+	assert.Equal(t, encryption.ErrorDecrypt, err)
+}
+
+func TestPreviousKeysDecrypt(t *testing.T) {
+	oldKey, newKey := makeKey(t), makeKey(t)
+
+	old, _ := encryption.New(oldKey)
+	payload, _ := old.EncryptString("secret")
+
+	rotated, _ := encryption.New(newKey, oldKey)
+	value, err := rotated.DecryptString(payload)
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", value)
+
+	fresh, _ := encryption.New(newKey)
+	_, err = fresh.DecryptString(payload)
+	assert.Equal(t, encryption.ErrorDecrypt, err)
+}
+
+func TestInvalidKey(t *testing.T) {
+	_, err := encryption.New([]byte("short"))
+	assert.EqualError(t, err, "Encryption key must be 32 bytes long, 5 given")
+}
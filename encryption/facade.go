@@ -0,0 +1,11 @@
+package encryption
+
+import "github.com/lara-go/larago"
+
+// FacadeWrapper for facade.
+var FacadeWrapper = &larago.Facade{}
+
+// Facade for encrypter.
+func Facade() *Encrypter {
+	return FacadeWrapper.Resolve("encrypter").(*Encrypter)
+}
@@ -0,0 +1,91 @@
+package encryption
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lara-go/larago"
+	"github.com/lara-go/larago/database/casts"
+)
+
+// ServiceProvider struct.
+type ServiceProvider struct{}
+
+// Register service.
+func (p *ServiceProvider) Register(application *larago.Application) {
+	application.Commands(&CommandKeyGenerate{})
+
+	application.Bind(func() (*Encrypter, error) {
+		return FromConfig(application.Config())
+	}, "encrypter")
+}
+
+// ErrorKeyMissing is returned when App.Key is not set.
+var ErrorKeyMissing = errors.New("App.Key is not set. Generate it with key:generate command")
+
+// Boot service. Encrypted model attributes of the application connections use its encrypter if key is set.
+// Invalid key fails the boot, so it is not found out only when attributes are encrypted.
+func (p *ServiceProvider) Boot(application *larago.Application) error {
+	encrypter, err := FromConfig(application.Config())
+	if err == ErrorKeyMissing {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	application.Instance(encrypter, "encrypter", (*casts.Encrypter)(nil))
+
+	return nil
+}
+
+// FromConfig makes encrypter with App.Key and App.PreviousKeys config values.
+func FromConfig(config *larago.ConfigRepository) (*Encrypter, error) {
+	if !config.Has("App.Key") {
+		return nil, ErrorKeyMissing
+	}
+
+	value, err := config.String("App.Key")
+	if err != nil {
+		return nil, err
+	}
+	if value == "" {
+		return nil, ErrorKeyMissing
+	}
+
+	key, err := ParseKey(value)
+	if err != nil {
+		return nil, fmt.Errorf("App.Key is invalid: %s", err)
+	}
+
+	previousKeys, err := previousKeys(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var previous [][]byte
+	for _, old := range previousKeys {
+		parsed, err := ParseKey(old)
+		if err != nil {
+			return nil, fmt.Errorf("App.PreviousKeys has invalid key: %s", err)
+		}
+
+		previous = append(previous, parsed)
+	}
+
+	encrypter, err := New(key, previous...)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid encryption key in config: %s", err)
+	}
+
+	return encrypter, nil
+}
+
+// Read App.PreviousKeys, set in code or loaded from JSON.
+func previousKeys(config *larago.ConfigRepository) ([]string, error) {
+	if !config.Has("App.PreviousKeys") {
+		return nil, nil
+	}
+
+	return config.Strings("App.PreviousKeys")
+}
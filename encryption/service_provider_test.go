@@ -0,0 +1,52 @@
+package encryption_test
+
+import (
+	"testing"
+
+	"github.com/lara-go/larago"
+	"github.com/lara-go/larago/encryption"
+	"github.com/stretchr/testify/assert"
+)
+
+type encryptionConfig struct {
+	App struct {
+		Key          string
+		PreviousKeys []interface{}
+	}
+}
+
+func (c *encryptionConfig) Env() string {
+	return "testing"
+}
+
+func (c *encryptionConfig) Debug() bool {
+	return false
+}
+
+func bootWith(config *encryptionConfig) error {
+	application := larago.New()
+	application.SetConfig(func() larago.Config { return config }).ImportConfig()
+	application.Register(&encryption.ServiceProvider{})
+
+	return application.Boot()
+}
+
+func TestBootChecksKey(t *testing.T) {
+	key, err := encryption.GenerateKey()
+	assert.NoError(t, err)
+
+	// Key may be generated later.
+	assert.NoError(t, bootWith(&encryptionConfig{}))
+
+	config := &encryptionConfig{}
+	config.App.Key = "base64:c2hvcnQ="
+	assert.Error(t, bootWith(config))
+
+	// Previous keys loaded from JSON.
+	config.App.Key = key
+	config.App.PreviousKeys = []interface{}{key}
+	assert.NoError(t, bootWith(config))
+
+	config.App.PreviousKeys = []interface{}{"short"}
+	assert.Error(t, bootWith(config))
+}
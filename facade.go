@@ -2,14 +2,22 @@ package larago
 
 import "errors"
 
+// ErrorFacadeDetached is returned when facade is used while several applications share the process.
+var ErrorFacadeDetached = errors.New("Facades are detached since several applications share the process, resolve services from the application container")
+
 // Facade struct.
 type Facade struct {
 	Application *Application
 	resolved    interface{}
+	detached    bool
 }
 
 // Resolve instance.
 func (f *Facade) Resolve(accessor interface{}) interface{} {
+	if f.detached {
+		panic(ErrorFacadeDetached)
+	}
+
 	if f.Application == nil {
 		panic(errors.New("Events facade was't registered properly"))
 	}
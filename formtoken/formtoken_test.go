@@ -0,0 +1,186 @@
+package formtoken_test
+
+import (
+	"html/template"
+	net_http "net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lara-go/larago/cache"
+	"github.com/lara-go/larago/formtoken"
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/http/errors"
+	"github.com/lara-go/larago/http/responses"
+	"github.com/lara-go/larago/session"
+	"github.com/stretchr/testify/assert"
+)
+
+func submit(guard *formtoken.Guard, token string, handler http.Handler) (response responses.Response, status int) {
+	return submitAs(guard, "", token, handler)
+}
+
+func submitAs(guard *formtoken.Guard, sessionID, token string, handler http.Handler) (response responses.Response, status int) {
+	defer func() {
+		if err, ok := recover().(*errors.HTTPError); ok {
+			status = err.HTTPStatus
+		}
+	}()
+
+	form := url.Values{formtoken.FieldName: {token}}
+	netRequest, _ := net_http.NewRequest("POST", "/orders", strings.NewReader(form.Encode()))
+	netRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	request := http.NewRequest(netRequest)
+	if sessionID != "" {
+		request.SetAttribute(session.SessionIDAttribute, sessionID)
+	}
+
+	response = guard.Handle(request, handler)
+
+	return response, response.Status()
+}
+
+func TestRejectResubmission(t *testing.T) {
+	guard := formtoken.NewGuard(cache.NewRepository(cache.NewInMemoryStore()))
+	token := formtoken.Generate()
+
+	var orders int
+	order := func(request *http.Request) responses.Response {
+		orders++
+
+		return responses.NewRedirect(303).To("/orders/1")
+	}
+	invalid := func(request *http.Request) responses.Response {
+		return responses.NewText(422, "Invalid")
+	}
+
+	// Failed submission releases the token.
+	_, status := submit(guard, token, invalid)
+	assert.Equal(t, 422, status)
+
+	_, status = submit(guard, token, order)
+	assert.Equal(t, 303, status)
+
+	_, status = submit(guard, token, order)
+	assert.Equal(t, 409, status)
+	assert.Equal(t, 1, orders)
+
+	_, status = submit(guard, formtoken.Generate(), order)
+	assert.Equal(t, 303, status)
+	assert.Equal(t, 2, orders)
+}
+
+func TestDeduplicateResubmission(t *testing.T) {
+	guard := formtoken.NewGuard(cache.NewRepository(cache.NewInMemoryStore())).Deduplicate(time.Second)
+	token := formtoken.Generate()
+
+	var orders int
+	var mutex sync.Mutex
+	order := func(request *http.Request) responses.Response {
+		mutex.Lock()
+		orders++
+		mutex.Unlock()
+
+		time.Sleep(100 * time.Millisecond)
+
+		return responses.NewRedirect(303).To("/orders/1")
+	}
+
+	// Double click: second submission waits for the first one and gets the same response.
+	var wg sync.WaitGroup
+	locations := make(chan string, 2)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			response, _ := submitAs(guard, "session", token, order)
+			locations <- response.(*responses.Redirect).GetLocation()
+		}()
+	}
+	wg.Wait()
+	close(locations)
+
+	assert.Equal(t, 1, orders)
+	for location := range locations {
+		assert.Equal(t, "/orders/1", location)
+	}
+}
+
+func TestDeduplicateOnlyForSameSession(t *testing.T) {
+	guard := formtoken.NewGuard(cache.NewRepository(cache.NewInMemoryStore())).Deduplicate(time.Second)
+	token := formtoken.Generate()
+
+	var orders int
+	order := func(request *http.Request) responses.Response {
+		orders++
+
+		return responses.NewText(201, "Order %d", orders).
+			WithHeader("Set-Cookie", "cart=; Max-Age=0").
+			WithHeader("X-Order", "1")
+	}
+
+	response, status := submitAs(guard, "first", token, order)
+	assert.Equal(t, 201, status)
+	assert.Equal(t, "Order 1", string(response.Body()))
+
+	// The same session gets the recorded response without its cookies.
+	response, status = submitAs(guard, "first", token, order)
+	assert.Equal(t, 201, status)
+	assert.Equal(t, "Order 1", string(response.Body()))
+	assert.Equal(t, "1", response.Headers()["X-Order"])
+	assert.NotContains(t, response.Headers(), "Set-Cookie")
+
+	// Another session sending the same token does not get the response of the first one.
+	response, status = submitAs(guard, "second", token, order)
+	assert.Equal(t, 201, status)
+	assert.Equal(t, "Order 2", string(response.Body()))
+
+	// Anonymous re-submissions are rejected, not replayed.
+	_, status = submit(guard, token, order)
+	assert.Equal(t, 201, status)
+	_, status = submit(guard, token, order)
+	assert.Equal(t, 409, status)
+	assert.Equal(t, 3, orders)
+}
+
+func TestRejectResubmissionToAnotherServer(t *testing.T) {
+	// Servers behind a load balancer share the cache store, but not the guard.
+	store := cache.NewInMemoryStore()
+	guards := []*formtoken.Guard{
+		formtoken.NewGuard(cache.NewRepository(store)),
+		formtoken.NewGuard(cache.NewRepository(store)),
+	}
+	token := formtoken.Generate()
+
+	var orders int
+	var mutex sync.Mutex
+	order := func(request *http.Request) responses.Response {
+		mutex.Lock()
+		orders++
+		mutex.Unlock()
+
+		return responses.NewRedirect(303).To("/orders/1")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(guard *formtoken.Guard) {
+			defer wg.Done()
+
+			submit(guard, token, order)
+		}(guards[i%2])
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, orders)
+}
+
+func TestFuncMap(t *testing.T) {
+	input := formtoken.FuncMap()["formToken"].(func() template.HTML)()
+	assert.Contains(t, string(input), `name="_form_token"`)
+}
@@ -0,0 +1,253 @@
+package formtoken
+
+import (
+	net_http "net/http"
+	"time"
+
+	"github.com/lara-go/larago/cache"
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/http/errors"
+	"github.com/lara-go/larago/http/responses"
+	"github.com/lara-go/larago/session"
+)
+
+// Defaults of the guard.
+const (
+	defaultTTL  = 24 * time.Hour
+	defaultWait = 5 * time.Second
+	pollEvery   = 50 * time.Millisecond
+)
+
+// Headers of the response meant only for the client which made the first submission.
+var clientHeaders = []string{"Set-Cookie", "Clear-Site-Data", "Www-Authenticate", "X-Request-Id", "Date"}
+
+// Submission of the form token.
+type Submission struct {
+	Done        bool
+	Status      int
+	ContentType string
+	Headers     map[string]string
+	Body        []byte
+	Location    string
+	Route       string
+	Params      map[string]string
+}
+
+// Guard middleware accepts every form token only once, so double clicks and browser retries
+// do not make double orders. Failed submissions (4xx, 5xx, panics) release the token to fix and resend the form.
+// Tokens are claimed with an atomic add, so the cache store must implement cache.AddingStore.
+// Use a store shared by all the servers, so retries landing on another server are caught too.
+type Guard struct {
+	cache       cache.Cache
+	ttl         time.Duration
+	wait        time.Duration
+	deduplicate bool
+	required    bool
+}
+
+// NewGuard constructor.
+func NewGuard(cache cache.Cache) *Guard {
+	return &Guard{
+		cache: cache,
+		ttl:   defaultTTL,
+		wait:  defaultWait,
+	}
+}
+
+// TTL sets how long used tokens are remembered.
+func (g *Guard) TTL(ttl time.Duration) *Guard {
+	g.ttl = ttl
+
+	return g
+}
+
+// Deduplicate answers re-submissions with the response of the first one instead of rejecting them.
+// If the first one is still handled, waits for it at most for wait duration.
+// Responses are replayed only to the same user or session, anonymous re-submissions are rejected.
+func (g *Guard) Deduplicate(wait time.Duration) *Guard {
+	g.deduplicate = true
+	g.wait = wait
+
+	return g
+}
+
+// Required rejects unsafe requests without token.
+func (g *Guard) Required() *Guard {
+	g.required = true
+
+	return g
+}
+
+// Handle request.
+func (g *Guard) Handle(request *http.Request, next http.Handler) responses.Response {
+	switch request.Method() {
+	case "GET", "HEAD", "OPTIONS":
+		return next(request)
+	}
+
+	token := Token(request)
+	if token == "" {
+		if g.required {
+			panic(errors.BadRequestHTTPError())
+		}
+
+		return next(request)
+	}
+
+	owner := owner(request)
+	replayable := g.deduplicate && owner != ""
+
+	key := "formtoken:" + token
+	if owner != "" {
+		key = "formtoken:" + owner + ":" + token
+	}
+
+	claimed, err := g.cache.Add(key, Submission{}, g.ttl)
+	if err != nil {
+		panic(err)
+	}
+
+	if !claimed {
+		return g.resubmitted(key, replayable)
+	}
+
+	// Release token if handler panics.
+	handled := false
+	defer func() {
+		if !handled {
+			g.cache.Forget(key)
+		}
+	}()
+
+	response := next(request)
+	handled = true
+
+	if response.Status() >= 400 {
+		g.cache.Forget(key)
+
+		return response
+	}
+
+	submission := Submission{Done: true}
+	if replayable {
+		submission = record(response)
+	}
+	g.cache.Put(key, submission, g.ttl)
+
+	return response
+}
+
+// Token of the request from the header or form field.
+func Token(request *http.Request) string {
+	if token := request.Header(HeaderName); token != "" {
+		return token
+	}
+
+	return request.FormValues().Get(FieldName)
+}
+
+// Owner of the submission: authenticated user or session, empty for anonymous requests.
+func owner(request *http.Request) string {
+	if userID, _ := request.Attribute(session.UserIDAttribute).(string); userID != "" {
+		return "user:" + userID
+	}
+
+	if sessionID, _ := request.Attribute(session.SessionIDAttribute).(string); sessionID != "" {
+		return "session:" + sessionID
+	}
+
+	return ""
+}
+
+// Respond to the re-submission.
+func (g *Guard) resubmitted(key string, replayable bool) responses.Response {
+	if replayable {
+		deadline := time.Now().Add(g.wait)
+		for {
+			var submission Submission
+			if err := g.cache.Get(key, &submission); err == nil && submission.Done {
+				if submission.Status == 0 {
+					break
+				}
+
+				return replay(&submission)
+			}
+
+			// First submission failed and released the token, but this one is a retry anyway.
+			if !g.cache.Has(key) || time.Now().After(deadline) {
+				break
+			}
+
+			time.Sleep(pollEvery)
+		}
+	}
+
+	err := errors.ConflictHTTPError()
+	err.Body.Message = "The form was already submitted."
+
+	panic(err)
+}
+
+// Record response to replay it. Streams are not recorded.
+func record(response responses.Response) Submission {
+	if _, ok := response.(*responses.Stream); ok {
+		return Submission{Done: true}
+	}
+
+	submission := Submission{
+		Done:    true,
+		Status:  response.Status(),
+		Headers: make(map[string]string),
+	}
+
+	for name, value := range response.Headers() {
+		if !isClientHeader(name) {
+			submission.Headers[name] = value
+		}
+	}
+
+	if redirect, ok := response.(*responses.Redirect); ok {
+		submission.Location = redirect.GetLocation()
+		submission.Route = redirect.GetRoute()
+		submission.Params = redirect.GetParams()
+
+		return submission
+	}
+
+	submission.ContentType = response.ContentType()
+	submission.Body = response.Body()
+
+	return submission
+}
+
+// Check if header is meant only for the client.
+func isClientHeader(name string) bool {
+	name = net_http.CanonicalHeaderKey(name)
+	for _, header := range clientHeaders {
+		if name == header {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Make response of the recorded submission.
+func replay(submission *Submission) responses.Response {
+	var response responses.Response
+
+	switch {
+	case submission.Route != "":
+		response = responses.NewRedirect(submission.Status).Route(submission.Route, submission.Params)
+	case submission.Location != "":
+		response = responses.NewRedirect(submission.Status).To(submission.Location)
+	default:
+		response = responses.NewFile(submission.Status, submission.Body, submission.ContentType)
+	}
+
+	for name, value := range submission.Headers {
+		response.WithHeader(name, value)
+	}
+
+	return response
+}
@@ -0,0 +1,24 @@
+package formtoken
+
+import (
+	"github.com/lara-go/larago"
+	"github.com/lara-go/larago/cache"
+	"github.com/lara-go/larago/view"
+)
+
+// ServiceProvider struct.
+type ServiceProvider struct{}
+
+// Register service.
+func (p *ServiceProvider) Register(application *larago.Application) {
+	application.Bind(func() (*Guard, error) {
+		return NewGuard(application.Get("cache").(cache.Cache)), nil
+	}, "formtoken")
+}
+
+// Boot service. Adds form token helpers to templates.
+func (p *ServiceProvider) Boot(application *larago.Application) {
+	if application.Bound("view") {
+		application.Get("view").(*view.Engine).Funcs(FuncMap())
+	}
+}
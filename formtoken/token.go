@@ -0,0 +1,38 @@
+package formtoken
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+)
+
+// Names of the form field and header with the token.
+const (
+	FieldName  = "_form_token"
+	HeaderName = "X-Form-Token"
+)
+
+// Generate new one-time form token.
+// Tokens only tell submissions apart, they do not replace CSRF protection.
+func Generate() string {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		panic(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(nonce)
+}
+
+// FuncMap with template helpers:
+//
+//	<form method="POST">{{ formToken }}</form>
+//	<meta name="form-token" content="{{ formTokenValue }}">
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"formToken": func() template.HTML {
+			return template.HTML(fmt.Sprintf(`<input type="hidden" name="%s" value="%s">`, FieldName, Generate()))
+		},
+		"formTokenValue": Generate,
+	}
+}
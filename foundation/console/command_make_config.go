@@ -0,0 +1,89 @@
+package console
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/lara-go/larago/logger"
+	"github.com/lara-go/larago/support/configgen"
+
+	"github.com/urfave/cli"
+)
+
+// CommandMakeConfig generates typed config structs from config files.
+type CommandMakeConfig struct {
+	Logger *logger.Logger
+
+	source string
+	output string
+}
+
+// GetCommand for the cli to register.
+func (c *CommandMakeConfig) GetCommand() cli.Command {
+	return cli.Command{
+		Name:  "make:config",
+		Usage: "Make typed config structs from config files",
+		UsageText: "Makes Config struct with a section per JSON file of the config directory, Load function reading them " +
+			"and Bind function binding sections in the container. Regenerate it when config files change.\n",
+		Category: "Code generators",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:        "source, s",
+				Usage:       "directory with JSON config files",
+				Value:       "config",
+				Destination: &c.source,
+			},
+			cli.StringFlag{
+				Name:        "output, o",
+				Usage:       "generated file",
+				Value:       path.Join(".", "app", "config", "config_gen.go"),
+				Destination: &c.output,
+			},
+		},
+	}
+}
+
+// Handle command.
+func (c *CommandMakeConfig) Handle(args cli.Args) error {
+	files, err := filepath.Glob(filepath.Join(c.source, "*.json"))
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return errors.New("There are no config files in " + c.source)
+	}
+
+	generator := configgen.New(filepath.Base(filepath.Dir(c.output)))
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		if err := generator.AddFile(strings.TrimSuffix(filepath.Base(file), ".json"), content); err != nil {
+			return err
+		}
+	}
+
+	source, err := generator.Generate()
+	if err != nil {
+		return fmt.Errorf("Can't generate config: %s", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.output), 0755); err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(c.output, source, 0644); err != nil {
+		return err
+	}
+
+	c.Logger.Success("Typed config created at: %s", c.output)
+
+	return nil
+}
@@ -0,0 +1,261 @@
+package console
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/jinzhu/inflection"
+	"github.com/lara-go/larago/logger"
+	"github.com/lara-go/larago/support/stubs"
+	"github.com/lara-go/larago/support/utils"
+
+	"github.com/urfave/cli"
+)
+
+var (
+	adminControllersPath = path.Join(".", "app", "http", "controllers", "admin")
+	adminRequestsPath    = path.Join(".", "app", "http", "requests", "admin")
+	routesPath           = path.Join(".", "app", "http", "routes")
+	adminViewsPath       = path.Join(".", "app", "views", "admin")
+)
+
+// crudField is a model field managed by the CRUD.
+type crudField struct {
+	Name     string
+	Column   string
+	Label    string
+	GoType   string
+	Input    string
+	Required bool
+}
+
+// Supported field types: go type and form input.
+var crudFieldTypes = map[string][2]string{
+	"string": {"string", "text"},
+	"text":   {"string", "textarea"},
+	"email":  {"string", "email"},
+	"int":    {"int", "number"},
+	"float":  {"float64", "number"},
+	"bool":   {"bool", "checkbox"},
+}
+
+// CommandMakeCrud generates admin CRUD for the model.
+type CommandMakeCrud struct {
+	Logger *logger.Logger
+
+	fields string
+	module string
+}
+
+// GetCommand for the cli to register.
+func (c *CommandMakeCrud) GetCommand() cli.Command {
+	return cli.Command{
+		Name:      "make:crud",
+		Usage:     "Make admin CRUD for the model",
+		UsageText: "Makes routes, controller, form request and views of the admin CRUD for the model from ./app/models.\n",
+		Category:  "Code generators",
+		ArgsUsage: "[ModelName]",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:        "fields, f",
+				Usage:       "model fields with types: string, text, email, int, float, bool (ex. 'title:string,body:text')",
+				Destination: &c.fields,
+			},
+			cli.StringFlag{
+				Name:        "module, m",
+				Usage:       "application module path, read from go.mod by default",
+				Destination: &c.module,
+			},
+		},
+	}
+}
+
+// Handle command.
+func (c *CommandMakeCrud) Handle(args cli.Args) error {
+	name := utils.UcFirst(args.Get(0))
+	if name == "" {
+		return errors.New("Model name can not be blank")
+	}
+
+	fields, err := c.parseFields()
+	if err != nil {
+		return err
+	}
+
+	module := c.module
+	if module == "" {
+		if module, err = c.readModule(); err != nil {
+			return err
+		}
+	}
+
+	vars := c.makeVars(name, module, fields)
+	snake := vars["Snake"].(string)
+	pluralSnake := vars["PluralSnake"].(string)
+
+	// Go files are formatted, views use [[ ]] delimiters to keep {{ }} for themselves.
+	files := []struct {
+		file string
+		stub string
+		isGo bool
+	}{
+		{path.Join(adminControllersPath, pluralSnake+".go"), stubs.CrudControllerStub, true},
+		{path.Join(adminRequestsPath, snake+".go"), stubs.CrudRequestStub, true},
+		{path.Join(routesPath, "admin_"+pluralSnake+".go"), stubs.CrudRoutesStub, true},
+		{path.Join(adminViewsPath, pluralSnake, "index.html"), stubs.CrudIndexViewStub, false},
+		{path.Join(adminViewsPath, pluralSnake, "form.html"), stubs.CrudFormViewStub, false},
+	}
+
+	for _, f := range files {
+		if err := c.makeFile(f.file, f.stub, f.isGo, vars); err != nil {
+			return fmt.Errorf("Can't make %s: %s", f.file, err)
+		}
+
+		c.Logger.Success("Created: %s", f.file)
+	}
+
+	c.Logger.Info("Register routes with routes.Admin%sRoutes(router, authMiddleware...)", vars["Plural"])
+
+	return nil
+}
+
+// Parse fields flag.
+func (c *CommandMakeCrud) parseFields() ([]crudField, error) {
+	if c.fields == "" {
+		return nil, errors.New("Fields can not be blank. Use --fields 'title:string,body:text'")
+	}
+
+	var fields []crudField
+	for _, definition := range strings.Split(c.fields, ",") {
+		parts := strings.SplitN(strings.TrimSpace(definition), ":", 2)
+		if len(parts) == 1 {
+			parts = append(parts, "string")
+		}
+
+		types, ok := crudFieldTypes[parts[1]]
+		if !ok {
+			return nil, fmt.Errorf("Unknown type %s of field %s", parts[1], parts[0])
+		}
+
+		column := utils.ToSnake(parts[0])
+		fields = append(fields, crudField{
+			Name:     c.camel(column),
+			Column:   column,
+			Label:    utils.UcFirst(strings.Replace(column, "_", " ", -1)),
+			GoType:   types[0],
+			Input:    types[1],
+			Required: types[0] == "string",
+		})
+	}
+
+	return fields, nil
+}
+
+// Make template variables.
+func (c *CommandMakeCrud) makeVars(name, module string, fields []crudField) map[string]interface{} {
+	var searchColumns, conditions []string
+	for _, field := range fields {
+		if field.GoType == "string" {
+			searchColumns = append(searchColumns, field.Column)
+			conditions = append(conditions, field.Column+" LIKE ?")
+		}
+	}
+
+	// Search by ID if there are no text fields.
+	if len(conditions) == 0 {
+		searchColumns = []string{"id"}
+		conditions = []string{"CAST(id AS CHAR) LIKE ?"}
+	}
+
+	return map[string]interface{}{
+		"Name":            name,
+		"Snake":           utils.ToSnake(name),
+		"Plural":          inflection.Plural(name),
+		"PluralSnake":     utils.ToSnake(inflection.Plural(name)),
+		"Module":          module,
+		"Fields":          fields,
+		"Columns":         len(fields) + 2,
+		"SearchColumns":   searchColumns,
+		"SearchCondition": strings.Join(conditions, " OR "),
+	}
+}
+
+// Render stub into the file.
+func (c *CommandMakeCrud) makeFile(fileName, stub string, isGo bool, vars map[string]interface{}) error {
+	if _, err := os.Stat(fileName); err == nil {
+		return errors.New("file already exists")
+	}
+
+	if err := os.MkdirAll(path.Dir(fileName), 0755); err != nil {
+		return err
+	}
+
+	t := template.New(path.Base(fileName))
+	if !isGo {
+		t = t.Delims("[[", "]]")
+	}
+	t = template.Must(t.Parse(stub))
+
+	var content strings.Builder
+	if err := t.Execute(&content, vars); err != nil {
+		return err
+	}
+
+	result := []byte(strings.TrimLeft(content.String(), "\n"))
+	if isGo {
+		formatted, err := format.Source(result)
+		if err != nil {
+			return err
+		}
+		result = formatted
+	}
+
+	f, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(result)
+
+	return err
+}
+
+// Read application module path from go.mod.
+func (c *CommandMakeCrud) readModule() (string, error) {
+	f, err := os.Open("go.mod")
+	if err != nil {
+		return "", errors.New("Can not read go.mod, use --module to set application module path")
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`), nil
+		}
+	}
+
+	return "", errors.New("Module path was not found in go.mod")
+}
+
+// Convert snake_case column into CamelCase field name.
+func (c *CommandMakeCrud) camel(column string) string {
+	parts := strings.Split(column, "_")
+	for i, part := range parts {
+		if part == "id" {
+			parts[i] = "ID"
+		} else {
+			parts[i] = utils.UcFirst(part)
+		}
+	}
+
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,65 @@
+package console_test
+
+import (
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/lara-go/larago/foundation/console"
+	"github.com/lara-go/larago/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+const crudModel = `package models
+
+// Post model.
+type Post struct {
+	ID    uint
+	Title string
+	Body  string
+	Views int
+}
+`
+
+func TestMakeCrudCompiles(t *testing.T) {
+	goBinary, err := exec.LookPath("go")
+	if err != nil || testing.Short() {
+		t.Skip("Go toolchain is required to compile generated code")
+	}
+
+	// Generated code is put inside the module, so it builds against the framework sources.
+	os.MkdirAll("testdata", 0755)
+	directory, err := ioutil.TempDir("testdata", "crud")
+	assert.NoError(t, err)
+	defer os.RemoveAll("testdata")
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(directory, "app", "models"), 0755))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(directory, "app", "models", "post.go"), []byte(crudModel), 0644))
+
+	command := &console.CommandMakeCrud{
+		Logger: &logger.Logger{Logger: log.New(ioutil.Discard, "", 0)},
+	}
+
+	flags := flag.NewFlagSet("make:crud", flag.ContinueOnError)
+	for _, f := range command.GetCommand().Flags {
+		f.Apply(flags)
+	}
+	module := "github.com/lara-go/larago/foundation/console/" + filepath.ToSlash(directory)
+	assert.NoError(t, flags.Parse([]string{"--fields", "title:string,body:text,views:int", "--module", module, "Post"}))
+
+	wd, _ := os.Getwd()
+	assert.NoError(t, os.Chdir(directory))
+	err = command.Handle(cli.Args(flags.Args()))
+	os.Chdir(wd)
+	assert.NoError(t, err)
+
+	build := exec.Command(goBinary, "build", "./...")
+	build.Dir = directory
+	output, err := build.CombinedOutput()
+	assert.NoError(t, err, string(output))
+}
@@ -0,0 +1,92 @@
+package middleware
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/http/errors"
+	"github.com/lara-go/larago/http/responses"
+)
+
+// Bulkhead caps concurrent requests going through it.
+// Requests over the limit wait in a bounded queue and are rejected with 503 when it is full
+// or when they waited longer than timeout. Use one instance per group of routes to protect.
+type Bulkhead struct {
+	slots   chan struct{}
+	queue   chan struct{}
+	timeout time.Duration
+}
+
+// NewBulkhead constructor.
+func NewBulkhead(concurrency, queueSize int, timeout time.Duration) *Bulkhead {
+	return &Bulkhead{
+		slots:   make(chan struct{}, concurrency),
+		queue:   make(chan struct{}, queueSize),
+		timeout: timeout,
+	}
+}
+
+// InFlight returns number of requests being handled.
+func (m *Bulkhead) InFlight() int {
+	return len(m.slots)
+}
+
+// Queued returns number of requests waiting for a slot.
+func (m *Bulkhead) Queued() int {
+	return len(m.queue)
+}
+
+// Handle request.
+func (m *Bulkhead) Handle(request *http.Request, next http.Handler) responses.Response {
+	if !m.acquire() {
+		panic(errors.ServiceUnavailableHTTPError().WithHeader("Retry-After", m.retryAfter()))
+	}
+	defer m.release()
+
+	return next(request)
+}
+
+// Take a slot, waiting in the queue if needed.
+func (m *Bulkhead) acquire() bool {
+	select {
+	case m.slots <- struct{}{}:
+		return true
+	default:
+	}
+
+	// Take place in the queue.
+	select {
+	case m.queue <- struct{}{}:
+	default:
+		return false
+	}
+	defer func() {
+		<-m.queue
+	}()
+
+	timer := time.NewTimer(m.timeout)
+	defer timer.Stop()
+
+	select {
+	case m.slots <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+func (m *Bulkhead) release() {
+	<-m.slots
+}
+
+// Seconds client should wait before retrying.
+func (m *Bulkhead) retryAfter() string {
+	seconds := int(math.Ceil(m.timeout.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	return strconv.Itoa(seconds)
+}
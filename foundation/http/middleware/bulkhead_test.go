@@ -0,0 +1,82 @@
+package middleware_test
+
+import (
+	net_http "net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lara-go/larago/foundation/http/middleware"
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/http/errors"
+	"github.com/lara-go/larago/http/responses"
+	"github.com/stretchr/testify/assert"
+)
+
+func handleThrough(bulkhead *middleware.Bulkhead, release chan struct{}) (err *errors.HTTPError) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = r.(*errors.HTTPError)
+		}
+	}()
+
+	netRequest, _ := net_http.NewRequest("GET", "/", nil)
+	bulkhead.Handle(http.NewRequest(netRequest), func(request *http.Request) responses.Response {
+		<-release
+
+		return responses.NewText(200, "ok")
+	})
+
+	return nil
+}
+
+func TestBulkhead(t *testing.T) {
+	bulkhead := middleware.NewBulkhead(1, 1, 500*time.Millisecond)
+	release := make(chan struct{})
+
+	var wg sync.WaitGroup
+	results := make(chan *errors.HTTPError, 2)
+
+	// First request takes the slot, second one waits in the queue.
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results <- handleThrough(bulkhead, release)
+		}()
+	}
+
+	for bulkhead.InFlight() != 1 || bulkhead.Queued() != 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	// Queue is full, so third request is rejected right away.
+	err := handleThrough(bulkhead, release)
+	assert.NotNil(t, err)
+	assert.Equal(t, 503, err.HTTPStatus)
+	assert.Equal(t, "1", err.Headers["Retry-After"])
+
+	close(release)
+	wg.Wait()
+	close(results)
+
+	for result := range results {
+		assert.Nil(t, result)
+	}
+}
+
+func TestBulkhead_Timeout(t *testing.T) {
+	bulkhead := middleware.NewBulkhead(1, 1, 10*time.Millisecond)
+	release := make(chan struct{})
+
+	go handleThrough(bulkhead, release)
+	for bulkhead.InFlight() != 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	err := handleThrough(bulkhead, release)
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, bulkhead.Queued())
+
+	close(release)
+}
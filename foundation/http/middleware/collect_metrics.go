@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/http/responses"
+)
+
+// CollectMetrics observes latency and load balancer queue time of every request.
+type CollectMetrics struct {
+	Metrics *http.Metrics
+}
+
+// Handle request.
+func (m *CollectMetrics) Handle(request *http.Request, next http.Handler) responses.Response {
+	startTime := time.Now()
+
+	response := next(request)
+
+	m.Metrics.Observe(response.Status(), time.Now().Sub(startTime), request.QueueTime())
+
+	return response
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/lara-go/larago/http"
@@ -24,12 +25,19 @@ func (m *LogRequests) Handle(request *http.Request, next http.Handler) responses
 	response := next(request)
 
 	if m.NeedToLog {
+		// Time spent in the load balancer queue, if it reports one.
+		queued := ""
+		if queueTime := request.QueueTime(); queueTime > 0 {
+			queued = fmt.Sprintf(" (queued %v)", queueTime)
+		}
+
 		// Write request info to the log.
 		m.Logger.Info(
-			"%d %4v %s %s %s",
+			"%d %4v%s %s %s %s",
 
 			response.Status(),         // HTTP status code.
 			time.Now().Sub(startTime), // Latency
+			queued,                    // Queue time
 			request.IP(),              // Remote IP
 			method,                    // HTTP method
 			path,                      // Request URI
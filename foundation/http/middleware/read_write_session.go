@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"github.com/lara-go/larago/database"
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/http/responses"
+)
+
+// DBSessionAttribute is the request attribute with read/write splitting session.
+const DBSessionAttribute = "db.session"
+
+// ReadWriteSession starts read/write splitting session for every request.
+// Requests that are not safe read from the primary from the very beginning.
+type ReadWriteSession struct {
+	Replicas *database.Replicas
+}
+
+// Handle request.
+func (m *ReadWriteSession) Handle(request *http.Request, next http.Handler) responses.Response {
+	session := m.Replicas.Session()
+
+	switch request.Method() {
+	case "GET", "HEAD", "OPTIONS":
+	default:
+		session.ForcePrimary()
+	}
+
+	request.SetAttribute(DBSessionAttribute, session)
+
+	return next(request)
+}
+
+// DBSession returns read/write splitting session of the request.
+func DBSession(request *http.Request) *database.Session {
+	session, _ := request.Attribute(DBSessionAttribute).(*database.Session)
+
+	return session
+}
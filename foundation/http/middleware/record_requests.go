@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"path"
+	"time"
+
+	"github.com/lara-go/larago"
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/http/responses"
+	"github.com/lara-go/larago/logger"
+)
+
+// RecordRequests saves requests to replay them later with http:replay.
+// Enable only while debugging. Secrets are redacted, but recordings still contain personal data.
+type RecordRequests struct {
+	NeedToRecord bool `di:"Config.HTTP.RecordRequests"`
+	Application  *larago.Application
+	Logger       *logger.Logger
+}
+
+// Handle request.
+func (m *RecordRequests) Handle(request *http.Request, next http.Handler) responses.Response {
+	if !m.NeedToRecord {
+		return next(request)
+	}
+
+	recording := http.NewRecording(request)
+	startTime := time.Now()
+
+	response := next(request)
+
+	recording.Duration = time.Now().Sub(startTime)
+	recording.Status = response.Status()
+
+	store := http.NewRecordingsStore(path.Join(m.Application.HomeDirectory, "recordings"))
+	if err := store.Save(recording); err != nil {
+		m.Logger.Error(err)
+	}
+
+	return response
+}
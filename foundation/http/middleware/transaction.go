@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/http/responses"
+)
+
+// DBTransactionAttribute is the request attribute with the request transaction.
+const DBTransactionAttribute = "db.transaction"
+
+// Transaction runs request in database transaction. It is committed when handler responds with 2xx
+// and rolled back on any other status, error or panic. Opt-in for handlers making several writes.
+// Streamed responses are written after the transaction is finished.
+type Transaction struct {
+	DB *gorm.DB `di:"db.connection"`
+}
+
+// Handle request.
+func (m *Transaction) Handle(request *http.Request, next http.Handler) responses.Response {
+	tx := request.WithQueryLog(m.DB).Begin()
+	if tx.Error != nil {
+		panic(tx.Error)
+	}
+
+	// Roll back if handler panics or responds with failure.
+	committed := false
+	defer func() {
+		if !committed {
+			tx.Rollback()
+		}
+	}()
+
+	request.SetAttribute(DBTransactionAttribute, tx)
+
+	response := next(request)
+
+	if status := response.Status(); status >= 200 && status < 300 {
+		if err := tx.Commit().Error; err != nil {
+			panic(err)
+		}
+		committed = true
+	}
+
+	return response
+}
+
+// DBTransaction returns transaction of the request, nil if it runs without one.
+func DBTransaction(request *http.Request) *gorm.DB {
+	tx, _ := request.Attribute(DBTransactionAttribute).(*gorm.DB)
+
+	return tx
+}
@@ -0,0 +1,69 @@
+package middleware_test
+
+import (
+	net_http "net/http"
+	"testing"
+
+	"github.com/lara-go/larago"
+	"github.com/lara-go/larago/foundation/http/middleware"
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/http/responses"
+	"github.com/lara-go/larago/support/testsuite"
+	"github.com/stretchr/testify/assert"
+
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+)
+
+type order struct {
+	ID     uint `gorm:"primary_key"`
+	Number string
+}
+
+func TestTransaction(t *testing.T) {
+	db := testsuite.MemoryDB(t, &order{})
+
+	transaction := &middleware.Transaction{DB: db}
+	handle := func(status int, fail bool) {
+		defer func() { recover() }()
+
+		netRequest, _ := net_http.NewRequest("POST", "/orders", nil)
+		transaction.Handle(http.NewRequest(netRequest), func(request *http.Request) responses.Response {
+			tx := middleware.DBTransaction(request)
+			tx.Create(&order{Number: "first"})
+			tx.Create(&order{Number: "second"})
+
+			if fail {
+				panic("failed")
+			}
+
+			return responses.NewText(status, "")
+		})
+	}
+
+	count := func() (count int) {
+		db.Model(&order{}).Count(&count)
+
+		return
+	}
+
+	handle(201, false)
+	assert.Equal(t, 2, count())
+
+	handle(422, false)
+	assert.Equal(t, 2, count())
+
+	handle(201, true)
+	assert.Equal(t, 2, count())
+}
+
+func TestTransactionUsesSharedConnection(t *testing.T) {
+	db := testsuite.MemoryDB(t)
+
+	application := larago.New()
+	application.Instance(db, "db.connection")
+	application.Instance(testsuite.MemoryDB(t))
+
+	var transaction middleware.Transaction
+	application.Make(&transaction)
+	assert.True(t, db == transaction.DB)
+}
@@ -13,6 +13,8 @@ func (p *ServiceProvider) Register(application *larago.Application) {
 	application.Commands(
 		&console.CommandEnv{},
 		&console.CommandMakeCommand{},
+		&console.CommandMakeConfig{},
+		&console.CommandMakeCrud{},
 		&console.CommandMakeMiddleware{},
 		&console.CommandMakeModel{},
 		&console.CommandMakeProvider{},
@@ -0,0 +1,22 @@
+package geoip
+
+import "strings"
+
+// Default locales of the countries, used when nothing else is known about the client.
+var countryLocales = map[string]string{
+	"AT": "de", "AR": "es", "BE": "nl", "BR": "pt", "BY": "be", "CH": "de",
+	"CL": "es", "CN": "zh", "CO": "es", "CZ": "cs", "DE": "de", "DK": "da",
+	"ES": "es", "FI": "fi", "FR": "fr", "GR": "el", "HU": "hu", "IL": "he",
+	"IT": "it", "JP": "ja", "KR": "ko", "KZ": "kk", "MX": "es", "NL": "nl",
+	"NO": "nb", "PL": "pl", "PT": "pt", "RO": "ro", "RU": "ru", "SE": "sv",
+	"SK": "sk", "TR": "tr", "TW": "zh", "UA": "uk",
+}
+
+// CountryLocale returns default locale of the country or fallback one.
+func CountryLocale(country, fallback string) string {
+	if locale, ok := countryLocales[strings.ToUpper(country)]; ok {
+		return locale
+	}
+
+	return fallback
+}
@@ -0,0 +1,74 @@
+package geoip
+
+import (
+	"strings"
+
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/http/errors"
+	"github.com/lara-go/larago/http/responses"
+)
+
+// LocationAttribute is the request attribute with full client location.
+const LocationAttribute = "geoip.location"
+
+// Middleware resolves client location and exposes it via Request.Country() and Request.City().
+type Middleware struct {
+	Reader *Reader
+}
+
+// Handle request.
+func (m *Middleware) Handle(request *http.Request, next http.Handler) responses.Response {
+	if location, err := m.Reader.Lookup(request.IP()); err == nil {
+		request.SetAttribute(LocationAttribute, location)
+		request.SetAttribute(http.CountryAttribute, location.CountryCode)
+		request.SetAttribute(http.CityAttribute, location.City)
+	}
+
+	return next(request)
+}
+
+// RequestLocation returns client location resolved by the middleware.
+func RequestLocation(request *http.Request) *Location {
+	location, _ := request.Attribute(LocationAttribute).(*Location)
+
+	return location
+}
+
+// CountriesGate lets through only requests from allowed countries.
+// Must be used after geoip middleware.
+type CountriesGate struct {
+	countries map[string]bool
+	allow     bool
+}
+
+// OnlyCountries allows requests only from listed countries.
+func OnlyCountries(countries ...string) *CountriesGate {
+	return newCountriesGate(true, countries)
+}
+
+// ExceptCountries denies requests from listed countries.
+func ExceptCountries(countries ...string) *CountriesGate {
+	return newCountriesGate(false, countries)
+}
+
+func newCountriesGate(allow bool, countries []string) *CountriesGate {
+	gate := &CountriesGate{
+		countries: make(map[string]bool, len(countries)),
+		allow:     allow,
+	}
+
+	for _, country := range countries {
+		gate.countries[strings.ToUpper(country)] = true
+	}
+
+	return gate
+}
+
+// Handle request.
+func (m *CountriesGate) Handle(request *http.Request, next http.Handler) responses.Response {
+	if m.countries[request.Country()] != m.allow {
+		panic(errors.ForbiddenHTTPError())
+	}
+
+	return next(request)
+}
@@ -0,0 +1,54 @@
+package geoip_test
+
+import (
+	net_http "net/http"
+	"testing"
+
+	"github.com/lara-go/larago/geoip"
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/http/responses"
+	"github.com/stretchr/testify/assert"
+)
+
+func requestFrom(country string) *http.Request {
+	netRequest, _ := net_http.NewRequest("GET", "/", nil)
+	request := http.NewRequest(netRequest)
+	request.SetAttribute(http.CountryAttribute, country)
+
+	return request
+}
+
+func handle(gate *geoip.CountriesGate, request *http.Request) (passed bool) {
+	defer func() {
+		recover()
+	}()
+
+	gate.Handle(request, func(request *http.Request) responses.Response {
+		passed = true
+
+		return responses.NewText(200, "ok")
+	})
+
+	return passed
+}
+
+func TestOnlyCountries(t *testing.T) {
+	gate := geoip.OnlyCountries("de", "FR")
+
+	assert.True(t, handle(gate, requestFrom("DE")))
+	assert.True(t, handle(gate, requestFrom("FR")))
+	assert.False(t, handle(gate, requestFrom("US")))
+	assert.False(t, handle(gate, requestFrom("")))
+}
+
+func TestExceptCountries(t *testing.T) {
+	gate := geoip.ExceptCountries("US")
+
+	assert.False(t, handle(gate, requestFrom("US")))
+	assert.True(t, handle(gate, requestFrom("DE")))
+}
+
+func TestCountryLocale(t *testing.T) {
+	assert.Equal(t, "de", geoip.CountryLocale("at", "en"))
+	assert.Equal(t, "en", geoip.CountryLocale("US", "en"))
+}
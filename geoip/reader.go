@@ -0,0 +1,106 @@
+package geoip
+
+import (
+	"fmt"
+	"net"
+
+	maxminddb "github.com/oschwald/maxminddb-golang"
+)
+
+// Location of the IP address.
+type Location struct {
+	CountryCode   string
+	CountryName   string
+	ContinentCode string
+	City          string
+	PostalCode    string
+	TimeZone      string
+	Latitude      float64
+	Longitude     float64
+}
+
+// Record layout of GeoIP2/GeoLite2 City and Country databases.
+type record struct {
+	City struct {
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"city"`
+	Continent struct {
+		Code string `maxminddb:"code"`
+	} `maxminddb:"continent"`
+	Country struct {
+		ISOCode string            `maxminddb:"iso_code"`
+		Names   map[string]string `maxminddb:"names"`
+	} `maxminddb:"country"`
+	Location struct {
+		Latitude  float64 `maxminddb:"latitude"`
+		Longitude float64 `maxminddb:"longitude"`
+		TimeZone  string  `maxminddb:"time_zone"`
+	} `maxminddb:"location"`
+	Postal struct {
+		Code string `maxminddb:"code"`
+	} `maxminddb:"postal"`
+}
+
+// Reader resolves locations from MaxMind DB.
+type Reader struct {
+	db *maxminddb.Reader
+}
+
+// Open MaxMind DB file.
+func Open(file string) (*Reader, error) {
+	db, err := maxminddb.Open(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Reader{db: db}, nil
+}
+
+// FromBytes reads MaxMind DB from memory.
+func FromBytes(buffer []byte) (*Reader, error) {
+	db, err := maxminddb.FromBytes(buffer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Reader{db: db}, nil
+}
+
+// Lookup location of the IP address.
+func (r *Reader) Lookup(address string) (*Location, error) {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return nil, fmt.Errorf("Invalid IP address %s", address)
+	}
+
+	var rec record
+	if err := r.db.Lookup(ip, &rec); err != nil {
+		return nil, err
+	}
+
+	return &Location{
+		CountryCode:   rec.Country.ISOCode,
+		CountryName:   rec.Country.Names["en"],
+		ContinentCode: rec.Continent.Code,
+		City:          rec.City.Names["en"],
+		PostalCode:    rec.Postal.Code,
+		TimeZone:      rec.Location.TimeZone,
+		Latitude:      rec.Location.Latitude,
+		Longitude:     rec.Location.Longitude,
+	}, nil
+}
+
+// Country returns ISO country code of the IP address.
+func (r *Reader) Country(address string) string {
+	location, err := r.Lookup(address)
+	if err != nil {
+		return ""
+	}
+
+	return location.CountryCode
+}
+
+// Close database.
+func (r *Reader) Close() error {
+	return r.db.Close()
+}
@@ -0,0 +1,22 @@
+package geoip
+
+import (
+	"path"
+
+	"github.com/lara-go/larago"
+)
+
+// ServiceProvider struct.
+type ServiceProvider struct{}
+
+// Register service.
+func (p *ServiceProvider) Register(application *larago.Application) {
+	application.Bind(func() (*Reader, error) {
+		file := path.Join(application.HomeDirectory, "GeoLite2-City.mmdb")
+		if application.Config().Has("GeoIP.Database") {
+			file = application.Config().Get("GeoIP.Database").(string)
+		}
+
+		return Open(file)
+	}, "geoip")
+}
@@ -0,0 +1,45 @@
+package housekeeping
+
+import (
+	"time"
+
+	"github.com/lara-go/larago/logger"
+
+	"github.com/urfave/cli"
+)
+
+// CommandPrune removes expired data of the stores.
+type CommandPrune struct {
+	Housekeeper *Housekeeper
+	Logger      *logger.Logger
+}
+
+// GetCommand for the cli to register.
+func (c *CommandPrune) GetCommand() cli.Command {
+	return cli.Command{
+		Name:      "housekeeping:prune",
+		Usage:     "Prune expired data of the stores",
+		UsageText: "Prunes every registered store, or only the given ones.\n",
+		Category:  "Housekeeping",
+		ArgsUsage: "[store...]",
+	}
+}
+
+// Handle command.
+func (c *CommandPrune) Handle(args cli.Args) error {
+	results, err := c.Housekeeper.Prune(time.Now(), args...)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if result.Err != nil {
+			c.Logger.Warning("Can't prune %s: %s", result.Store, result.Err)
+			continue
+		}
+
+		c.Logger.Success("Pruned %d items of %s older than %s.", result.Pruned, result.Store, result.Retention)
+	}
+
+	return nil
+}
@@ -0,0 +1,11 @@
+package housekeeping
+
+import "github.com/lara-go/larago"
+
+// FacadeWrapper for facade.
+var FacadeWrapper = &larago.Facade{}
+
+// Facade returns housekeeper to register stores.
+func Facade() *Housekeeper {
+	return FacadeWrapper.Resolve("housekeeping").(*Housekeeper)
+}
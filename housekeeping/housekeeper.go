@@ -0,0 +1,215 @@
+package housekeeping
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Pruner removes data of the store which is older than the cutoff.
+type Pruner interface {
+	// Prune data older than the time and return amount of removed items.
+	Prune(before time.Time) (int64, error)
+}
+
+// PrunerFunc adapts function to the Pruner.
+type PrunerFunc func(before time.Time) (int64, error)
+
+// Prune data older than the time.
+func (f PrunerFunc) Prune(before time.Time) (int64, error) {
+	return f(before)
+}
+
+// Result of the store pruning.
+type Result struct {
+	Store     string
+	Retention time.Duration
+	Pruned    int64
+	Err       error
+}
+
+// Store registered for housekeeping.
+type store struct {
+	name      string
+	retention time.Duration
+	pruner    Pruner
+}
+
+// Housekeeper prunes expired data of the registered stores.
+type Housekeeper struct {
+	mutex      sync.Mutex
+	stores     []*store
+	retentions map[string]time.Duration
+}
+
+// NewHousekeeper constructor.
+func NewHousekeeper() *Housekeeper {
+	return &Housekeeper{
+		retentions: make(map[string]time.Duration),
+	}
+}
+
+// Add store with default retention. Store added under the same name replaces previous one.
+func (h *Housekeeper) Add(name string, retention time.Duration, pruner Pruner) *Housekeeper {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for _, existing := range h.stores {
+		if existing.name == name {
+			existing.retention = retention
+			existing.pruner = pruner
+
+			return h
+		}
+	}
+
+	h.stores = append(h.stores, &store{name: name, retention: retention, pruner: pruner})
+
+	return h
+}
+
+// Retain data of the store for the duration, overriding its default retention.
+func (h *Housekeeper) Retain(name string, retention time.Duration) *Housekeeper {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.retentions[name] = retention
+
+	return h
+}
+
+// Retention of the store.
+func (h *Housekeeper) Retention(name string) (time.Duration, bool) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for _, store := range h.stores {
+		if store.name == name {
+			return h.retention(store), true
+		}
+	}
+
+	return 0, false
+}
+
+// Stores returns names of the registered stores.
+func (h *Housekeeper) Stores() []string {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	names := make([]string, len(h.stores))
+	for i, store := range h.stores {
+		names[i] = store.name
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Prune stores, every one if no names are given.
+// Failure of one store does not stop pruning of the others.
+func (h *Housekeeper) Prune(now time.Time, names ...string) ([]Result, error) {
+	stores, err := h.selected(names)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]Result, 0, len(stores))
+	for _, store := range stores {
+		pruned, err := store.pruner.Prune(now.Add(-store.retention))
+
+		results = append(results, Result{
+			Store:     store.name,
+			Retention: store.retention,
+			Pruned:    pruned,
+			Err:       err,
+		})
+	}
+
+	return results, nil
+}
+
+// Run prunes every store, to be scheduled as a task:
+//
+//	schedule.Facade().Call("housekeeping", housekeeping.Facade().Run).Daily()
+func (h *Housekeeper) Run(output io.Writer) error {
+	results, err := h.Prune(time.Now())
+	if err != nil {
+		return err
+	}
+
+	var failed []string
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Fprintf(output, "%s: %s\n", result.Store, result.Err)
+			failed = append(failed, result.Store)
+
+			continue
+		}
+
+		fmt.Fprintf(output, "%s: %d pruned\n", result.Store, result.Pruned)
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("Can't prune %s", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+// Copy stores to prune with overrides applied, so pruning runs unlocked.
+func (h *Housekeeper) selected(names []string) ([]store, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	for _, name := range names {
+		if !h.has(name) {
+			return nil, fmt.Errorf("Store %s is not registered for housekeeping", name)
+		}
+	}
+
+	stores := make([]store, 0, len(h.stores))
+	for _, registered := range h.stores {
+		if len(names) == 0 || contains(names, registered.name) {
+			selected := *registered
+			selected.retention = h.retention(registered)
+			stores = append(stores, selected)
+		}
+	}
+
+	return stores, nil
+}
+
+// Retention of the store with overrides applied.
+func (h *Housekeeper) retention(store *store) time.Duration {
+	if retention, ok := h.retentions[store.name]; ok {
+		return retention
+	}
+
+	return store.retention
+}
+
+// Check if store is registered.
+func (h *Housekeeper) has(name string) bool {
+	for _, store := range h.stores {
+		if store.name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func contains(names []string, name string) bool {
+	for _, candidate := range names {
+		if candidate == name {
+			return true
+		}
+	}
+
+	return false
+}
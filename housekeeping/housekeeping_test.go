@@ -0,0 +1,134 @@
+package housekeeping_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago/housekeeping"
+	"github.com/stretchr/testify/assert"
+
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+)
+
+func TestHousekeeperRetention(t *testing.T) {
+	now := time.Now()
+	cutoffs := make(map[string]time.Time)
+
+	pruner := func(name string) housekeeping.Pruner {
+		return housekeeping.PrunerFunc(func(before time.Time) (int64, error) {
+			cutoffs[name] = before
+			return 1, nil
+		})
+	}
+
+	housekeeper := housekeeping.NewHousekeeper().
+		Add("uploads", time.Hour, pruner("uploads")).
+		Add("resets", 24*time.Hour, pruner("resets")).
+		Retain("resets", 2*time.Hour)
+
+	retention, ok := housekeeper.Retention("resets")
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Hour, retention)
+	assert.Equal(t, []string{"resets", "uploads"}, housekeeper.Stores())
+
+	results, err := housekeeper.Prune(now)
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, now.Add(-time.Hour), cutoffs["uploads"])
+	assert.Equal(t, now.Add(-2*time.Hour), cutoffs["resets"])
+
+	results, err = housekeeper.Prune(now, "uploads")
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+
+	_, err = housekeeper.Prune(now, "unknown")
+	assert.Error(t, err)
+}
+
+func TestHousekeeperRun(t *testing.T) {
+	var pruned bool
+	housekeeper := housekeeping.NewHousekeeper().
+		Add("broken", 0, housekeeping.PrunerFunc(func(time.Time) (int64, error) {
+			return 0, errors.New("database is gone")
+		})).
+		Add("files", 0, housekeeping.PrunerFunc(func(time.Time) (int64, error) {
+			pruned = true
+			return 3, nil
+		}))
+
+	var output bytes.Buffer
+	err := housekeeper.Run(&output)
+
+	assert.EqualError(t, err, "Can't prune broken")
+	assert.True(t, pruned, "failure of one store must not stop the others")
+	assert.Contains(t, output.String(), "files: 3 pruned")
+	assert.Contains(t, output.String(), "broken: database is gone")
+}
+
+func TestDirectoryPruner(t *testing.T) {
+	root, _ := ioutil.TempDir("", "housekeeping")
+	defer os.RemoveAll(root)
+
+	old := time.Now().Add(-48 * time.Hour)
+	write := func(name string, modified time.Time) {
+		file := filepath.Join(root, name)
+		os.MkdirAll(filepath.Dir(file), 0755)
+		ioutil.WriteFile(file, []byte("upload"), 0644)
+		os.Chtimes(file, modified, modified)
+	}
+
+	write("stale/a.tmp", old)
+	write("fresh.tmp", time.Now())
+	write("mixed/old.tmp", old)
+	write("mixed/new.tmp", time.Now())
+	os.Chtimes(filepath.Join(root, "stale"), old, old)
+
+	pruned, err := housekeeping.NewDirectoryPruner(root).Prune(time.Now().Add(-24 * time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), pruned)
+
+	assert.NoFileExists(t, filepath.Join(root, "stale", "a.tmp"))
+	assert.NoDirExists(t, filepath.Join(root, "stale"))
+	assert.NoFileExists(t, filepath.Join(root, "mixed", "old.tmp"))
+	assert.FileExists(t, filepath.Join(root, "mixed", "new.tmp"))
+	assert.FileExists(t, filepath.Join(root, "fresh.tmp"))
+
+	pruned, err = housekeeping.NewDirectoryPruner(filepath.Join(root, "missing")).Prune(time.Now())
+	assert.NoError(t, err)
+	assert.Zero(t, pruned)
+}
+
+type passwordReset struct {
+	ID        uint `gorm:"primary_key"`
+	Email     string
+	CreatedAt time.Time
+}
+
+func TestTablePruner(t *testing.T) {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	assert.NoError(t, err)
+	defer db.Close()
+	db.DB().SetMaxOpenConns(1)
+
+	db.CreateTable(&passwordReset{})
+	db.Create(&passwordReset{Email: "old@example.com", CreatedAt: time.Now().Add(-2 * time.Hour)})
+	db.Create(&passwordReset{Email: "kept@example.com", CreatedAt: time.Now().Add(-2 * time.Hour)})
+	db.Create(&passwordReset{Email: "new@example.com", CreatedAt: time.Now()})
+
+	pruned, err := housekeeping.NewTablePruner(db, "password_resets", "created_at").
+		Where("email <> ?", "kept@example.com").
+		Prune(time.Now().Add(-time.Hour))
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), pruned)
+
+	var left int
+	db.Model(&passwordReset{}).Count(&left)
+	assert.Equal(t, 2, left)
+}
@@ -0,0 +1,110 @@
+package housekeeping
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// TablePruner deletes rows which time column is older than the cutoff.
+type TablePruner struct {
+	db         *gorm.DB
+	table      string
+	column     string
+	conditions []condition
+}
+
+// Additional condition of the pruned rows.
+type condition struct {
+	query string
+	args  []interface{}
+}
+
+// NewTablePruner constructor, ex. for password reset tokens:
+//
+//	housekeeping.NewTablePruner(db, "password_resets", "created_at")
+func NewTablePruner(db *gorm.DB, table, column string) *TablePruner {
+	return &TablePruner{
+		db:     db,
+		table:  table,
+		column: column,
+	}
+}
+
+// Where limits pruned rows with additional condition.
+func (p *TablePruner) Where(query string, args ...interface{}) *TablePruner {
+	p.conditions = append(p.conditions, condition{query: query, args: args})
+
+	return p
+}
+
+// Prune rows older than the time.
+func (p *TablePruner) Prune(before time.Time) (int64, error) {
+	query := p.db.Table(p.table).Where(p.db.Dialect().Quote(p.column)+" < ?", before)
+	for _, condition := range p.conditions {
+		query = query.Where(condition.query, condition.args...)
+	}
+
+	result := query.Delete(nil)
+
+	return result.RowsAffected, result.Error
+}
+
+// DirectoryPruner removes files not modified since the cutoff, and old directories left empty.
+type DirectoryPruner struct {
+	root string
+}
+
+// NewDirectoryPruner constructor.
+func NewDirectoryPruner(root string) *DirectoryPruner {
+	return &DirectoryPruner{
+		root: root,
+	}
+}
+
+// Prune files older than the time.
+func (p *DirectoryPruner) Prune(before time.Time) (int64, error) {
+	if _, err := os.Stat(p.root); os.IsNotExist(err) {
+		return 0, nil
+	}
+
+	var pruned int64
+	var directories []string
+
+	err := filepath.Walk(p.root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			// File could be removed by someone else meanwhile.
+			if os.IsNotExist(err) {
+				return nil
+			}
+
+			return err
+		}
+
+		if info.IsDir() {
+			if path != p.root && info.ModTime().Before(before) {
+				directories = append(directories, path)
+			}
+
+			return nil
+		}
+
+		if info.ModTime().Before(before) {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			pruned++
+		}
+
+		return nil
+	})
+
+	// Remove the deepest directories first, non-empty ones are kept.
+	for i := len(directories) - 1; i >= 0; i-- {
+		os.Remove(directories[i])
+	}
+
+	return pruned, err
+}
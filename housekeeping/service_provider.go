@@ -0,0 +1,154 @@
+package housekeeping
+
+import (
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago"
+	"github.com/lara-go/larago/cache"
+	"github.com/lara-go/larago/outbox"
+	"github.com/lara-go/larago/storage"
+)
+
+// Default retentions of the framework stores.
+const (
+	defaultOutboxRetention  = 7 * 24 * time.Hour
+	defaultUploadsRetention = 24 * time.Hour
+)
+
+// ServiceProvider struct.
+type ServiceProvider struct{}
+
+// Register service.
+func (p *ServiceProvider) Register(application *larago.Application) {
+	application.Commands(&CommandPrune{})
+
+	var housekeeper *Housekeeper
+	var once sync.Once
+
+	application.Bind(func() (*Housekeeper, error) {
+		once.Do(func() {
+			housekeeper = NewHousekeeper()
+		})
+
+		return housekeeper, nil
+	}, "housekeeping")
+}
+
+// Parse retentions given as durations or strings like "72h".
+func parseRetentions(value interface{}) (map[string]time.Duration, error) {
+	retentions := make(map[string]time.Duration)
+
+	switch values := value.(type) {
+	case map[string]time.Duration:
+		return values, nil
+	case map[string]string:
+		for name, retention := range values {
+			duration, err := time.ParseDuration(retention)
+			if err != nil {
+				return nil, fmt.Errorf("Config value Housekeeping.Retention.%s must be a duration: %s", name, err)
+			}
+			retentions[name] = duration
+		}
+	case map[string]interface{}:
+		for name, retention := range values {
+			switch v := retention.(type) {
+			case time.Duration:
+				retentions[name] = v
+			case string:
+				duration, err := time.ParseDuration(v)
+				if err != nil {
+					return nil, fmt.Errorf("Config value Housekeeping.Retention.%s must be a duration: %s", name, err)
+				}
+				retentions[name] = duration
+			default:
+				return nil, fmt.Errorf("Config value Housekeeping.Retention.%s must be a duration, got %T", name, retention)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("Config value Housekeeping.Retention must be a map of durations, got %T", value)
+	}
+
+	return retentions, nil
+}
+
+// Boot registers stores of the framework. Services are resolved only when pruning.
+func (p *ServiceProvider) Boot(application *larago.Application) error {
+	housekeeper := application.Get("housekeeping").(*Housekeeper)
+	config := application.Config()
+
+	// Housekeeping.Retention overrides retention of the stores by name.
+	if config.Has("Housekeeping.Retention") {
+		retentions, err := parseRetentions(config.Get("Housekeeping.Retention"))
+		if err != nil {
+			return err
+		}
+
+		for name, retention := range retentions {
+			housekeeper.Retain(name, retention)
+		}
+	}
+
+	// Expired cache items, including session registry.
+	if application.Bound("cache.store") {
+		housekeeper.Add("cache", 0, PrunerFunc(func(before time.Time) (int64, error) {
+			store, ok := application.Get("cache.store").(cache.ExpiringStore)
+			if !ok {
+				return 0, nil
+			}
+
+			return store.PruneExpired(before)
+		}))
+	}
+
+	// Outbox messages given up after all attempts.
+	if application.Bound("outbox") {
+		housekeeper.Add("outbox", defaultOutboxRetention, PrunerFunc(func(before time.Time) (int64, error) {
+			relay := application.Get("outbox").(*outbox.Relay)
+
+			return NewTablePruner(application.Get("db.connection").(*gorm.DB), outbox.Message{}.TableName(), "created_at").
+				Where("sent_at IS NULL AND attempts >= ?", relay.MaxAttempts()).
+				Prune(before)
+		}))
+	}
+
+	root := path.Join(application.HomeDirectory, "storage")
+	if config.Has("Storage.Root") {
+		var err error
+		if root, err = config.String("Storage.Root"); err != nil {
+			return err
+		}
+	}
+
+	// Temporary uploads never moved to their place.
+	uploads := path.Join(root, "tmp")
+	if config.Has("Housekeeping.Uploads") {
+		var err error
+		if uploads, err = config.String("Housekeeping.Uploads"); err != nil {
+			return err
+		}
+	}
+	housekeeper.Add("uploads", defaultUploadsRetention, NewDirectoryPruner(uploads))
+
+	// Expired responses cached on the storage disk, Housekeeping.Responses is their prefix.
+	if application.Bound("storage") {
+		prefix := "responses"
+		if config.Has("Housekeeping.Responses") {
+			var err error
+			if prefix, err = config.String("Housekeeping.Responses"); err != nil {
+				return err
+			}
+		}
+
+		housekeeper.Add("responses", 0, PrunerFunc(func(before time.Time) (int64, error) {
+			disk := application.Get("storage").(storage.Disk)
+
+			return storage.NewResponseCache(disk, 0).Prefix(prefix).Prune(before)
+		}))
+	}
+
+	return nil
+}
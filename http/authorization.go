@@ -0,0 +1,84 @@
+package http
+
+import (
+	"strings"
+
+	"github.com/lara-go/larago/http/errors"
+)
+
+// Authorizer checks permissions of the request user for routes declaring abilities or roles.
+type Authorizer interface {
+	// Can checks if user is allowed to perform the ability.
+	Can(request *Request, ability string) bool
+
+	// HasRole checks if user has the role.
+	HasRole(request *Request, role string) bool
+}
+
+// Can requires user to have every of the abilities, ex. "posts.update".
+func (r *Route) Can(abilities ...string) *Route {
+	r.Abilities = append(r.Abilities, abilities...)
+
+	return r
+}
+
+// Role requires user to have any of the roles.
+func (r *Route) Role(roles ...string) *Route {
+	r.Roles = append(r.Roles, roles...)
+
+	return r
+}
+
+// Requirements returns human readable authorization requirements of the route.
+func (r *Route) Requirements() string {
+	var requirements []string
+
+	if len(r.Abilities) > 0 {
+		requirements = append(requirements, "can: "+strings.Join(r.Abilities, ", "))
+	}
+	if len(r.Roles) > 0 {
+		requirements = append(requirements, "role: "+strings.Join(r.Roles, " | "))
+	}
+
+	return strings.Join(requirements, "; ")
+}
+
+// SetAuthorizer sets checker of route abilities and roles.
+func (r *Router) SetAuthorizer(authorizer Authorizer) *Router {
+	r.Container.Make(authorizer)
+
+	r.authorizer = authorizer
+
+	return r
+}
+
+// Authorize request against requirements of its route.
+// Routes with requirements are forbidden if there is no authorizer, so they never become public by mistake.
+func (r *Router) authorize(request *Request) error {
+	route := request.Route
+	if len(route.Abilities) == 0 && len(route.Roles) == 0 {
+		return nil
+	}
+
+	if r.authorizer == nil {
+		return errors.ForbiddenHTTPError()
+	}
+
+	for _, ability := range route.Abilities {
+		if !r.authorizer.Can(request, ability) {
+			return errors.ForbiddenHTTPError()
+		}
+	}
+
+	if len(route.Roles) == 0 {
+		return nil
+	}
+
+	for _, role := range route.Roles {
+		if r.authorizer.HasRole(request, role) {
+			return nil
+		}
+	}
+
+	return errors.ForbiddenHTTPError()
+}
@@ -0,0 +1,57 @@
+package http_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lara-go/larago/http"
+	"github.com/stretchr/testify/assert"
+)
+
+// Takes abilities and roles of the user from headers.
+type headerAuthorizer struct{}
+
+func (a *headerAuthorizer) Can(request *http.Request, ability string) bool {
+	return strings.Contains(request.Header("X-Abilities"), ability)
+}
+
+func (a *headerAuthorizer) HasRole(request *http.Request, role string) bool {
+	return request.Header("X-Role") == role
+}
+
+func TestRouteAuthorization(t *testing.T) {
+	router := factory()
+
+	router.GET("/posts").Action(func() string { return "public" })
+	router.PUT("/posts/:id").Can("posts.update").Action(func() string { return "updated" })
+	router.DELETE("/posts/:id").Can("posts.delete").Role("admin", "editor").Action(func() string { return "deleted" })
+	router.Bootstrap()
+
+	serve := func(method, path, abilities, role string) int {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(method, path, nil)
+		r.Header.Set("X-Abilities", abilities)
+		r.Header.Set("X-Role", role)
+
+		router.ServeHTTP(w, r)
+
+		return w.Code
+	}
+
+	// Routes with requirements are closed until there is an authorizer.
+	assert.Equal(t, 403, serve("PUT", "/posts/1", "posts.update", ""))
+
+	router.SetAuthorizer(&headerAuthorizer{})
+
+	assert.Equal(t, 200, serve("GET", "/posts", "", ""))
+	assert.Equal(t, 200, serve("PUT", "/posts/1", "posts.update", ""))
+	assert.Equal(t, 403, serve("PUT", "/posts/1", "posts.create", ""))
+	assert.Equal(t, 200, serve("DELETE", "/posts/1", "posts.delete", "editor"))
+	assert.Equal(t, 403, serve("DELETE", "/posts/1", "posts.delete", "author"))
+	assert.Equal(t, 403, serve("DELETE", "/posts/1", "", "admin"))
+
+	routes := router.GetRoutes()
+	assert.Equal(t, "", routes[0].Requirements())
+	assert.Equal(t, "can: posts.delete; role: admin | editor", routes[2].Requirements())
+}
@@ -0,0 +1,70 @@
+package http
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// NormalizeBrackets converts keys in bracket notation to the dot notation of the decoder:
+// "user[name]" becomes "user.name", "items[0][id]" becomes "items.0.id" and "tags[]" becomes "tags".
+// Malformed keys are kept as is.
+func NormalizeBrackets(values url.Values) url.Values {
+	normalized := make(url.Values, len(values))
+
+	// Sorted, so values of "tags" and "tags[]" are merged in the same order every time.
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		name := bracketsToDots(key)
+		normalized[name] = append(normalized[name], values[key]...)
+	}
+
+	return normalized
+}
+
+// Convert single key, returning it unchanged if it is malformed.
+func bracketsToDots(key string) string {
+	open := strings.IndexByte(key, '[')
+	if open <= 0 {
+		return key
+	}
+
+	parts := []string{key[:open]}
+	rest := key[open:]
+
+	for rest != "" {
+		if rest[0] != '[' {
+			return key
+		}
+
+		end := strings.IndexByte(rest, ']')
+		if end < 0 {
+			return key
+		}
+
+		segment := rest[1:end]
+		rest = rest[end+1:]
+
+		// Empty brackets append to the list, only allowed at the end.
+		if segment == "" {
+			if rest != "" {
+				return key
+			}
+
+			break
+		}
+
+		if strings.ContainsAny(segment, "[.") {
+			return key
+		}
+
+		parts = append(parts, segment)
+	}
+
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,236 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	net_http "net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lara-go/larago/logger"
+
+	"github.com/urfave/cli"
+)
+
+// CommandBench runs load against the booted application.
+type CommandBench struct {
+	Router *Router
+	Logger *logger.Logger
+
+	url         string
+	method      string
+	connections int
+	duration    time.Duration
+	headers     cli.StringSlice
+}
+
+// Result of one request made by the benchmark.
+type benchResult struct {
+	latency time.Duration
+	status  int
+	err     error
+}
+
+// GetCommand for the cli to register.
+func (c *CommandBench) GetCommand() cli.Command {
+	return cli.Command{
+		Name:      "bench:http",
+		Usage:     "Run load against the application",
+		UsageText: "Boots application on a random local port (or uses --url) and hammers it with requests.\n",
+		Category:  "HTTP server",
+		ArgsUsage: "[path]",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:        "url, u",
+				Usage:       "external url to run load against instead of booted app",
+				Destination: &c.url,
+			},
+			cli.StringFlag{
+				Name:        "method, m",
+				Value:       net_http.MethodGet,
+				Usage:       "HTTP method to use",
+				Destination: &c.method,
+			},
+			cli.IntFlag{
+				Name:        "connections, c",
+				Value:       10,
+				Usage:       "number of concurrent connections",
+				Destination: &c.connections,
+			},
+			cli.DurationFlag{
+				Name:        "duration, d",
+				Value:       10 * time.Second,
+				Usage:       "duration of the test",
+				Destination: &c.duration,
+			},
+			cli.StringSliceFlag{
+				Name:  "header, H",
+				Usage: "additional header to send (ex. 'Accept: application/json')",
+				Value: &c.headers,
+			},
+		},
+	}
+}
+
+// Handle command.
+func (c *CommandBench) Handle(args cli.Args) error {
+	if c.connections <= 0 {
+		return errors.New("Number of connections must be positive")
+	}
+
+	target := c.url
+	if target == "" {
+		base, closer, err := c.serve()
+		if err != nil {
+			return fmt.Errorf("Can't boot application: %s", err)
+		}
+		defer closer()
+
+		target = base + args.Get(0)
+	}
+
+	c.Logger.Info("Running %s load against %s with %d connections...", c.duration, target, c.connections)
+
+	results := c.run(target)
+	c.report(results)
+
+	return nil
+}
+
+// Serve booted application on a random local port.
+func (c *CommandBench) serve() (string, func(), error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", nil, err
+	}
+
+	server := &net_http.Server{Handler: c.Router.Bootstrap().GetHTTPRouter()}
+	go server.Serve(listener)
+
+	return "http://" + listener.Addr().String(), func() { server.Close() }, nil
+}
+
+// Run workers until duration is over.
+func (c *CommandBench) run(target string) []benchResult {
+	client := &net_http.Client{
+		Transport: &net_http.Transport{
+			MaxIdleConnsPerHost: c.connections,
+		},
+	}
+
+	deadline := time.Now().Add(c.duration)
+	collected := make(chan []benchResult, c.connections)
+
+	var wg sync.WaitGroup
+	for i := 0; i < c.connections; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			var results []benchResult
+			for time.Now().Before(deadline) {
+				results = append(results, c.hit(client, target))
+			}
+
+			collected <- results
+		}()
+	}
+
+	wg.Wait()
+	close(collected)
+
+	var results []benchResult
+	for part := range collected {
+		results = append(results, part...)
+	}
+
+	return results
+}
+
+// Make one request.
+func (c *CommandBench) hit(client *net_http.Client, target string) benchResult {
+	request, err := net_http.NewRequest(c.method, target, nil)
+	if err != nil {
+		return benchResult{err: err}
+	}
+
+	for _, header := range c.headers {
+		if parts := strings.SplitN(header, ":", 2); len(parts) == 2 {
+			request.Header.Set(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
+		}
+	}
+
+	startTime := time.Now()
+	response, err := client.Do(request)
+	if err != nil {
+		return benchResult{latency: time.Since(startTime), err: err}
+	}
+
+	// Drain body to reuse keep-alive connection.
+	io.Copy(ioutil.Discard, response.Body)
+	response.Body.Close()
+
+	return benchResult{latency: time.Since(startTime), status: response.StatusCode}
+}
+
+// Print summary.
+func (c *CommandBench) report(results []benchResult) {
+	var errorsCount, non2xx int
+	var total time.Duration
+
+	latencies := make([]time.Duration, 0, len(results))
+	for _, result := range results {
+		if result.err != nil {
+			errorsCount++
+			continue
+		}
+
+		if result.status < 200 || result.status > 299 {
+			non2xx++
+		}
+
+		total += result.latency
+		latencies = append(latencies, result.latency)
+	}
+
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+
+	count := len(latencies)
+	if count == 0 {
+		c.Logger.Warning("No successful requests were made, %d errors.", errorsCount)
+
+		return
+	}
+
+	c.Logger.Success("%d requests in %s, %.2f req/sec", len(results), c.duration, float64(len(results))/c.duration.Seconds())
+	c.Logger.Info(
+		"Latency avg %s, p50 %s, p90 %s, p99 %s, max %s",
+		total/time.Duration(count),
+		percentile(latencies, 50),
+		percentile(latencies, 90),
+		percentile(latencies, 99),
+		latencies[count-1],
+	)
+
+	if non2xx > 0 || errorsCount > 0 {
+		c.Logger.Warning("Non-2xx responses: %d, connection errors: %d", non2xx, errorsCount)
+	}
+}
+
+// Get percentile value from sorted latencies.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	index := len(sorted) * p / 100
+	if index >= len(sorted) {
+		index = len(sorted) - 1
+	}
+
+	return sorted[index]
+}
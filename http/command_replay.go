@@ -0,0 +1,155 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	net_http "net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/lara-go/larago"
+	"github.com/lara-go/larago/httpclient"
+	"github.com/lara-go/larago/logger"
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/urfave/cli"
+)
+
+// CommandReplay replays recorded requests against the application.
+type CommandReplay struct {
+	Application *larago.Application
+	Router      *Router
+	Logger      *logger.Logger
+
+	url  string
+	list bool
+}
+
+// GetCommand for the cli to register.
+func (c *CommandReplay) GetCommand() cli.Command {
+	return cli.Command{
+		Name:      "http:replay",
+		Usage:     "Replay recorded request",
+		UsageText: "Replays request recorded by RecordRequests middleware against booted application (or --url).\n",
+		Category:  "HTTP server",
+		ArgsUsage: "[id]",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:        "url, u",
+				Usage:       "base url of running application to replay request against",
+				Destination: &c.url,
+			},
+			cli.BoolFlag{
+				Name:        "list",
+				Usage:       "list recorded requests",
+				Destination: &c.list,
+			},
+		},
+	}
+}
+
+// Handle command.
+func (c *CommandReplay) Handle(args cli.Args) error {
+	store := NewRecordingsStore(path.Join(c.Application.HomeDirectory, "recordings"))
+
+	if c.list {
+		return c.listRecordings(store)
+	}
+
+	if args.First() == "" {
+		return errors.New("Recording ID is required. Use --list to see recorded requests")
+	}
+
+	recording, err := store.Find(args.First())
+	if err != nil {
+		return fmt.Errorf("Could not load recording %s: %s", args.First(), err)
+	}
+
+	c.Logger.Info(
+		"Replaying %s %s recorded at %s (%d in %v)",
+		recording.Method, recording.URL, recording.Time.Format(c.Application.DateTimeFormat), recording.Status, recording.Duration,
+	)
+
+	startTime := time.Now()
+
+	response, err := c.replay(recording)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	body, _ := ioutil.ReadAll(response.Body)
+
+	c.Logger.Success("%d in %v", response.StatusCode, time.Now().Sub(startTime))
+
+	names := make([]string, 0, len(response.Header))
+	for name := range response.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, value := range response.Header[name] {
+			fmt.Fprintf(os.Stdout, "%s: %s\n", name, value)
+		}
+	}
+
+	fmt.Fprintf(os.Stdout, "\n%s\n", body)
+
+	return nil
+}
+
+// Send recorded request to the running application or to the booted one.
+func (c *CommandReplay) replay(recording *Recording) (*net_http.Response, error) {
+	if c.url != "" {
+		request, err := recording.ToRequest(c.url)
+		if err != nil {
+			return nil, err
+		}
+
+		return httpclient.Default.Do(request)
+	}
+
+	request, err := recording.ToRequest("")
+	if err != nil {
+		return nil, err
+	}
+	request.RequestURI = recording.URL
+
+	recorder := httptest.NewRecorder()
+	c.Router.Bootstrap().Handler().ServeHTTP(recorder, request)
+
+	return recorder.Result(), nil
+}
+
+func (c *CommandReplay) listRecordings(store *RecordingsStore) error {
+	recordings, err := store.List()
+	if err != nil {
+		return err
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"ID", "Time", "Method", "URI", "Route", "Status", "Duration"})
+	table.SetColWidth(200)
+	table.SetAutoFormatHeaders(false)
+
+	for _, recording := range recordings {
+		table.Append([]string{
+			recording.ID,
+			recording.Time.Format(c.Application.DateTimeFormat),
+			recording.Method,
+			recording.URL,
+			recording.Route,
+			fmt.Sprint(recording.Status),
+			fmt.Sprint(recording.Duration),
+		})
+	}
+
+	table.Render()
+
+	return nil
+}
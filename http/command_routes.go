@@ -30,7 +30,7 @@ func (c *CommandRoutes) GetCommand() cli.Command {
 // Handle command.
 func (c *CommandRoutes) Handle(args cli.Args) error {
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Method", "URI", "Name", "Middleware"})
+	table.SetHeader([]string{"Method", "URI", "Name", "Middleware", "Authorization"})
 	table.SetColWidth(200) // Set max col width to 200. There may be lots of middleware or long path.
 	table.SetAutoFormatHeaders(false)
 
@@ -58,5 +58,5 @@ func (c *CommandRoutes) makeRow(route *Route, globalMiddleware []Middleware) []s
 		middleware[i] = fmt.Sprintf("%s", reflect.TypeOf(allMiddleware[i]).Elem())
 	}
 
-	return []string{route.Method, route.Path, route.Name, strings.Join(middleware, ", ")}
+	return []string{route.Method, route.Path, route.Name, strings.Join(middleware, ", "), route.Requirements()}
 }
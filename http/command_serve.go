@@ -10,7 +10,9 @@ type CommandServe struct {
 	Router *Router
 	Config *larago.ConfigRepository
 
-	listen string
+	listen  string
+	fastCGI bool
+	cgi     bool
 }
 
 // GetCommand for the cli to register.
@@ -25,6 +27,16 @@ func (c *CommandServe) GetCommand() cli.Command {
 				Usage:       "address to listen to (ex. 0.0.0.0:8080)",
 				Destination: &c.listen,
 			},
+			cli.BoolFlag{
+				Name:        "fastcgi",
+				Usage:       "serve FastCGI instead of HTTP, listen to \"unix:/path\" for unix socket or \"stdin\"",
+				Destination: &c.fastCGI,
+			},
+			cli.BoolFlag{
+				Name:        "cgi",
+				Usage:       "handle single CGI request",
+				Destination: &c.cgi,
+			},
 		},
 	}
 }
@@ -35,7 +47,38 @@ func (c *CommandServe) Handle(args cli.Args) error {
 		c.Config.Set("HTTP.Listen", c.listen)
 	}
 
-	return c.Router.
-		Bootstrap().
-		Listen(c.Config.Get("HTTP.Listen").(string))
+	options, err := ServerOptionsFromConfig(c.Config)
+	if err != nil {
+		return err
+	}
+
+	router := c.Router.
+		SetServerOptions(options).
+		Bootstrap()
+	listen := c.Config.Get("HTTP.Listen").(string)
+
+	switch {
+	case c.cgi:
+		return router.ServeCGI()
+	case c.fastCGI:
+		return c.serveFastCGI(router, listen)
+	default:
+		return router.Listen(listen)
+	}
+}
+
+// Serve FastCGI on the address or stdin.
+func (c *CommandServe) serveFastCGI(router *Router, listen string) error {
+	if listen == "stdin" {
+		return router.ServeFastCGI(nil)
+	}
+
+	listener, err := FastCGIListener(listen)
+	if err != nil {
+		return err
+	}
+
+	router.Logger.Info("Serving FastCGI at %s.", listen)
+
+	return router.ServeFastCGI(listener)
 }
@@ -0,0 +1,208 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	http_errors "github.com/lara-go/larago/http/errors"
+)
+
+// ErrorDraining is returned on attempt to open persistent connection while server is shutting down.
+var ErrorDraining = errors.New("Server is draining connections")
+
+// CloseServiceRestart is WebSocket close code asking clients to reconnect after a while.
+const CloseServiceRestart = 1012
+
+// Default time clients get to reconnect, spread over it not to come back all at once.
+const defaultDrainGrace = 5 * time.Second
+
+// Connections tracks persistent connections (SSE streams, WebSockets) to drain them before shutdown.
+type Connections struct {
+	mutex    sync.Mutex
+	draining bool
+	grace    time.Duration
+	open     map[*Connection]struct{}
+	closed   chan struct{}
+}
+
+// NewConnections constructor.
+func NewConnections() *Connections {
+	return &Connections{
+		grace: defaultDrainGrace,
+		open:  make(map[*Connection]struct{}),
+	}
+}
+
+// SetGrace sets time clients get to reconnect.
+func (c *Connections) SetGrace(grace time.Duration) *Connections {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.grace = grace
+
+	return c
+}
+
+// Open registers persistent connection. Close it when it is done.
+func (c *Connections) Open() (*Connection, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.draining {
+		return nil, ErrorDraining
+	}
+
+	connection := &Connection{
+		connections: c,
+		drain:       make(chan struct{}),
+	}
+	c.open[connection] = struct{}{}
+
+	return connection, nil
+}
+
+// Grace returns time clients get to reconnect.
+func (c *Connections) Grace() time.Duration {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.grace
+}
+
+// Count of open connections.
+func (c *Connections) Count() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return len(c.open)
+}
+
+// Draining checks if new connections are rejected.
+func (c *Connections) Draining() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.draining
+}
+
+// Drain stops accepting new connections, asks open ones to reconnect
+// and waits until they are closed or context is done.
+func (c *Connections) Drain(ctx context.Context) error {
+	c.mutex.Lock()
+	if !c.draining {
+		c.draining = true
+		c.closed = make(chan struct{})
+
+		for connection := range c.open {
+			connection.signal()
+		}
+	}
+	c.checkClosed()
+	closed := c.closed
+	c.mutex.Unlock()
+
+	select {
+	case <-closed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Resume accepting connections, ex. when shutdown was cancelled.
+func (c *Connections) Resume() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.draining = false
+}
+
+// Unregister closed connection.
+func (c *Connections) close(connection *Connection) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	delete(c.open, connection)
+	c.checkClosed()
+}
+
+// Signal drain end when the last connection is closed. Must be called under the lock.
+func (c *Connections) checkClosed() {
+	if c.draining && len(c.open) == 0 {
+		select {
+		case <-c.closed:
+		default:
+			close(c.closed)
+		}
+	}
+}
+
+// Connection is a single persistent connection.
+type Connection struct {
+	connections *Connections
+	drain       chan struct{}
+	drainOnce   sync.Once
+	closeOnce   sync.Once
+}
+
+// Drained returns channel closed when connection has to be finished.
+// WebSocket handlers should close the socket with CloseServiceRestart code.
+func (c *Connection) Drained() <-chan struct{} {
+	return c.drain
+}
+
+// ReconnectIn returns random delay within grace period for the client to reconnect after,
+// so clients of the drained server do not come back all at once.
+func (c *Connection) ReconnectIn() time.Duration {
+	grace := c.connections.Grace()
+	if grace <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(grace)))
+}
+
+// Close connection.
+func (c *Connection) Close() {
+	c.closeOnce.Do(func() {
+		c.connections.close(c)
+	})
+}
+
+// Ask connection to finish.
+func (c *Connection) signal() {
+	c.drainOnce.Do(func() {
+		close(c.drain)
+	})
+}
+
+// OpenConnection registers persistent connection of the request, ex. WebSocket after upgrade,
+// to be drained when server shuts down.
+func (r *Request) OpenConnection() (*Connection, error) {
+	if r.connections == nil {
+		r.connections = NewConnections()
+	}
+
+	return r.connections.Open()
+}
+
+// Check if request asks for persistent connection.
+func isPersistent(request *Request) bool {
+	return strings.EqualFold(request.Header("Upgrade"), "websocket") ||
+		strings.Contains(request.Header("Accept"), "text/event-stream")
+}
+
+// Error returned to clients asking for persistent connections while draining.
+func drainingError(grace time.Duration) error {
+	seconds := int(grace / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	return http_errors.ServiceUnavailableHTTPError().WithHeader("Retry-After", strconv.Itoa(seconds))
+}
@@ -0,0 +1,102 @@
+package http_test
+
+import (
+	"bufio"
+	"context"
+	net_http "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/http/responses"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDrainEventStreams(t *testing.T) {
+	router := factory()
+	router.SetServerOptions(&http.ServerOptions{DrainGrace: time.Second})
+
+	router.GET("/events").Action(func(request *http.Request) responses.Response {
+		return http.NewEventStream(request, func(events *http.EventStream) error {
+			events.Send("hello", map[string]string{"name": "john"})
+
+			<-events.Done()
+
+			return nil
+		})
+	})
+
+	server := httptest.NewServer(router.Bootstrap())
+	defer server.Close()
+
+	response, err := net_http.Get(server.URL + "/events")
+	assert.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, "text/event-stream; charset=utf-8", response.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(response.Body)
+	assert.Equal(t, "event: hello\ndata: {\"name\":\"john\"}\n\n", readEvent(reader))
+	assert.Equal(t, 1, router.Connections().Count())
+
+	drained := make(chan error)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		drained <- router.Connections().Drain(ctx)
+	}()
+
+	reconnect := readEvent(reader)
+	assert.True(t, strings.HasPrefix(reconnect, "retry: "), reconnect)
+	assert.Contains(t, reconnect, "event: reconnect\n")
+	assert.NoError(t, <-drained)
+	assert.Equal(t, 0, router.Connections().Count())
+
+	// New streams are rejected while draining.
+	request, _ := net_http.NewRequest("GET", server.URL+"/events", nil)
+	request.Header.Set("Accept", "text/event-stream")
+	rejected, err := net_http.DefaultClient.Do(request)
+	assert.NoError(t, err)
+	rejected.Body.Close()
+	assert.Equal(t, 503, rejected.StatusCode)
+	assert.Equal(t, "1", rejected.Header.Get("Retry-After"))
+}
+
+func TestDrainTimeout(t *testing.T) {
+	connections := http.NewConnections()
+
+	connection, err := connections.Open()
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.Equal(t, context.DeadlineExceeded, connections.Drain(ctx))
+	assert.True(t, connections.Draining())
+
+	select {
+	case <-connection.Drained():
+	default:
+		t.Error("Connection was not asked to finish")
+	}
+
+	_, err = connections.Open()
+	assert.Equal(t, http.ErrorDraining, err)
+
+	connection.Close()
+	assert.NoError(t, connections.Drain(context.Background()))
+}
+
+// Read lines of the single event.
+func readEvent(reader *bufio.Reader) string {
+	var event string
+	for {
+		line, err := reader.ReadString('\n')
+		event += line
+		if err != nil || line == "\n" {
+			return event
+		}
+	}
+}
@@ -81,6 +81,28 @@ func ValidationFailedHTTPError() *HTTPError {
 	}
 }
 
+// ConflictHTTPError error.
+func ConflictHTTPError() *HTTPError {
+	return &HTTPError{
+		Body: Body{
+			ID:      "conflict",
+			Message: "The resource was modified by someone else.",
+		},
+		HTTPStatus: http.StatusConflict,
+	}
+}
+
+// TooManyRequestsHTTPError error.
+func TooManyRequestsHTTPError() *HTTPError {
+	return &HTTPError{
+		Body: Body{
+			ID:      "too_many_requests",
+			Message: "Too many requests.",
+		},
+		HTTPStatus: http.StatusTooManyRequests,
+	}
+}
+
 // ServiceUnavailableHTTPError error.
 func ServiceUnavailableHTTPError() *HTTPError {
 	return &HTTPError{
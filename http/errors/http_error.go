@@ -9,12 +9,13 @@ type Body struct {
 
 // HTTPError custom API error.
 type HTTPError struct {
-	Body         Body        `json:"error"`
-	Meta         interface{} `json:"meta,omitempty"`
-	HTTPStatus   int         `json:"-"`
-	Context      interface{} `json:"-"`
-	ShouldReport bool        `json:"-"`
-	HasTrace     bool        `json:"-"`
+	Body         Body              `json:"error"`
+	Meta         interface{}       `json:"meta,omitempty"`
+	HTTPStatus   int               `json:"-"`
+	Context      interface{}       `json:"-"`
+	ShouldReport bool              `json:"-"`
+	HasTrace     bool              `json:"-"`
+	Headers      map[string]string `json:"-"`
 }
 
 // Error returns error message.
@@ -38,6 +39,17 @@ func (e *HTTPError) WithMeta(meta interface{}) *HTTPError {
 	return e
 }
 
+// WithHeader attaches header to the error response.
+func (e *HTTPError) WithHeader(name, value string) *HTTPError {
+	if e.Headers == nil {
+		e.Headers = make(map[string]string)
+	}
+
+	e.Headers[name] = value
+
+	return e
+}
+
 // WithTrace enables stacktrace reporting in logs.
 func (e *HTTPError) WithTrace() *HTTPError {
 	e.HasTrace = true
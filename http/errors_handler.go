@@ -43,9 +43,13 @@ func (h *ErrorsHandler) Render(request *Request, err error) responses.Response {
 	case request.WantsJSON():
 		response = responses.NewJSON(httpErr.HTTPStatus, httpErr)
 	case request.WantsHTML():
-		response = responses.NewHTML(httpErr.HTTPStatus, httpErr.Body.Message)
+		response = responses.NewHTML(httpErr.HTTPStatus, "%s", httpErr.Body.Message)
 	default:
-		response = responses.NewText(httpErr.HTTPStatus, httpErr.Body.Message)
+		response = responses.NewText(httpErr.HTTPStatus, "%s", httpErr.Body.Message)
+	}
+
+	for name, value := range httpErr.Headers {
+		response.WithHeader(name, value)
 	}
 
 	return response
@@ -56,15 +60,27 @@ func (h *ErrorsHandler) makeHTTPError(err error) *errors.HTTPError {
 	switch e := err.(type) {
 	case *errors.HTTPError:
 		return e
+	case *PanicError:
+		return h.makePanicError(e)
 	case *validation.Error:
 		return h.makeValidationError(e)
 	case *database.ModelNotFoundError:
 		return h.makeModelNotFoundError(e)
+	case *database.StaleModelError:
+		return h.makeStaleModelError(e)
 	default:
 		return errors.UnknownError(err, h.Debug)
 	}
 }
 
+// Make http error for recovered panic, with the panic report as context.
+func (h *ErrorsHandler) makePanicError(err *PanicError) *errors.HTTPError {
+	e := *h.makeHTTPError(err.Err)
+	e.Context = err.Report
+
+	return &e
+}
+
 // Make http error for model not found errors.
 func (h *ErrorsHandler) makeModelNotFoundError(err *database.ModelNotFoundError) *errors.HTTPError {
 	e := errors.NotFoundHTTPError()
@@ -75,6 +91,16 @@ func (h *ErrorsHandler) makeModelNotFoundError(err *database.ModelNotFoundError)
 	return e.WithContext(err)
 }
 
+// Make http error for concurrent modifications.
+func (h *ErrorsHandler) makeStaleModelError(err *database.StaleModelError) *errors.HTTPError {
+	e := errors.ConflictHTTPError()
+	if h.Debug {
+		e.Body.Message = err.Error()
+	}
+
+	return e.WithContext(err)
+}
+
 // Make http validation error.
 func (h *ErrorsHandler) makeValidationError(err *validation.Error) *errors.HTTPError {
 	httpError := errors.ValidationFailedHTTPError()
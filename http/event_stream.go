@@ -0,0 +1,141 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/lara-go/larago/http/responses"
+)
+
+// EventStream sends server-sent events over persistent connection.
+type EventStream struct {
+	stream     *responses.Stream
+	connection *Connection
+	done       chan struct{}
+}
+
+// NewEventStream makes server-sent events response. Callback should return when Done is closed:
+// client went away or server is draining connections. Drained clients are asked to reconnect
+// at random moment within the grace period, so another server takes them smoothly.
+//
+//	return http.NewEventStream(request, func(events *http.EventStream) error {
+//		for {
+//			select {
+//			case message := <-messages:
+//				events.Send("message", message)
+//			case <-events.Done():
+//				return nil
+//			}
+//		}
+//	})
+func NewEventStream(request *Request, callback func(events *EventStream) error) responses.Response {
+	ctx := request.BaseRequest().Context()
+	connection, err := request.OpenConnection()
+	if err != nil {
+		panic(drainingError(request.connections.Grace()))
+	}
+
+	return responses.NewStream(200, func(stream *responses.Stream) error {
+		defer connection.Close()
+
+		events := &EventStream{
+			stream:     stream,
+			connection: connection,
+			done:       make(chan struct{}),
+		}
+
+		finished := make(chan struct{})
+		defer close(finished)
+		go events.watch(ctx, finished)
+
+		// Send headers right away, so client knows stream is open.
+		stream.Flush()
+
+		err := callback(events)
+
+		select {
+		case <-connection.Drained():
+			events.reconnect()
+		default:
+		}
+
+		return err
+	}).
+		WithContentType("text/event-stream").
+		WithHeader("Cache-Control", "no-cache").
+		WithHeader("X-Accel-Buffering", "no")
+}
+
+// Send event. Data is sent as is if it is a string, otherwise it is encoded to JSON.
+func (s *EventStream) Send(event string, data interface{}) error {
+	payload, ok := data.(string)
+	if !ok {
+		encoded, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		payload = string(encoded)
+	}
+
+	var message strings.Builder
+	if event != "" {
+		fmt.Fprintf(&message, "event: %s\n", event)
+	}
+	for _, line := range strings.Split(payload, "\n") {
+		fmt.Fprintf(&message, "data: %s\n", line)
+	}
+	message.WriteString("\n")
+
+	return s.write(message.String())
+}
+
+// Comment sends comment line, ex. to keep connection alive behind proxies.
+func (s *EventStream) Comment(text string) error {
+	return s.write(": " + text + "\n\n")
+}
+
+// Done returns channel closed when client went away or server is draining connections.
+func (s *EventStream) Done() <-chan struct{} {
+	return s.done
+}
+
+// Draining checks if stream is finished because server shuts down.
+func (s *EventStream) Draining() bool {
+	select {
+	case <-s.connection.Drained():
+		return true
+	default:
+		return false
+	}
+}
+
+// Write chunk and flush it to the client.
+func (s *EventStream) write(chunk string) error {
+	if _, err := s.stream.WriteString(chunk); err != nil {
+		return err
+	}
+
+	s.stream.Flush()
+
+	return nil
+}
+
+// Ask client to reconnect after random delay within the grace period.
+func (s *EventStream) reconnect() {
+	delay := s.connection.ReconnectIn().Milliseconds()
+
+	s.write(fmt.Sprintf("retry: %d\nevent: reconnect\ndata: {\"in\":%d}\n\n", delay, delay))
+}
+
+// Close done channel when client goes away or drain starts.
+func (s *EventStream) watch(ctx context.Context, finished <-chan struct{}) {
+	select {
+	case <-ctx.Done():
+	case <-s.connection.Drained():
+	case <-finished:
+	}
+
+	close(s.done)
+}
@@ -0,0 +1,45 @@
+package http
+
+import (
+	"net"
+	"net/http/cgi"
+	"net/http/fcgi"
+	"os"
+	"strings"
+)
+
+// ServeFastCGI serves requests passed by the web server over FastCGI.
+// Nil listener accepts connections on stdin, as expected from apps spawned by the web server.
+// Do not forget to run Bootstrap in order to prepare and set routes.
+func (r *Router) ServeFastCGI(listener net.Listener) error {
+	if listener != nil && r.serverOptions.MaxConnections > 0 {
+		listener = LimitListener(listener, r.serverOptions.MaxConnections)
+	}
+
+	return fcgi.Serve(listener, r)
+}
+
+// ServeCGI handles the only request of CGI invocation.
+// Stdout carries the response, so logs are moved to stderr.
+// Do not forget to run Bootstrap in order to prepare and set routes.
+func (r *Router) ServeCGI() error {
+	r.Logger.SetOutput(os.Stderr)
+
+	return cgi.Serve(r)
+}
+
+// FastCGIListener listens to TCP address or unix socket prefixed with "unix:".
+func FastCGIListener(address string) (net.Listener, error) {
+	if strings.HasPrefix(address, "unix:") {
+		path := strings.TrimPrefix(address, "unix:")
+
+		// Remove socket left by the previous run.
+		if info, err := os.Stat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+			os.Remove(path)
+		}
+
+		return net.Listen("unix", path)
+	}
+
+	return net.Listen("tcp", address)
+}
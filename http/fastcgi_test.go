@@ -0,0 +1,90 @@
+package http_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lara-go/larago/http"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServeFastCGI(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "fastcgi")
+	defer os.RemoveAll(dir)
+
+	router := factory()
+	router.GET("/hello/:name").Action(func(name string) string {
+		return "Hello, " + name
+	})
+	router.Bootstrap()
+
+	address := "unix:" + filepath.Join(dir, "app.sock")
+	listener, err := http.FastCGIListener(address)
+	assert.NoError(t, err)
+	defer listener.Close()
+
+	go router.ServeFastCGI(listener)
+
+	conn, err := net.Dial("unix", strings.TrimPrefix(address, "unix:"))
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	output := fastCGIRequest(t, conn, map[string]string{
+		"REQUEST_METHOD":  "GET",
+		"REQUEST_URI":     "/hello/john",
+		"SERVER_PROTOCOL": "HTTP/1.1",
+		"HTTP_HOST":       "example.com",
+	})
+
+	assert.Contains(t, output, "Status: 200 OK")
+	assert.Contains(t, output, "Hello, john")
+}
+
+// Send single request and return content of stdout records.
+func fastCGIRequest(t *testing.T, conn net.Conn, params map[string]string) string {
+	record := func(recordType byte, content []byte) {
+		header := []byte{1, recordType, 0, 1, 0, 0, 0, 0}
+		binary.BigEndian.PutUint16(header[4:], uint16(len(content)))
+		conn.Write(append(header, content...))
+	}
+
+	// Begin request with responder role.
+	record(1, []byte{0, 1, 0, 0, 0, 0, 0, 0})
+
+	var encoded bytes.Buffer
+	for name, value := range params {
+		encoded.WriteByte(byte(len(name)))
+		encoded.WriteByte(byte(len(value)))
+		encoded.WriteString(name + value)
+	}
+	record(4, encoded.Bytes())
+	record(4, nil)
+	record(5, nil)
+
+	var stdout bytes.Buffer
+	reader := bufio.NewReader(conn)
+	for {
+		header := make([]byte, 8)
+		if _, err := io.ReadFull(reader, header); err != nil {
+			t.Fatal(err)
+		}
+
+		content := make([]byte, int(binary.BigEndian.Uint16(header[4:]))+int(header[6]))
+		io.ReadFull(reader, content)
+
+		switch header[1] {
+		case 6:
+			stdout.Write(content[:len(content)-int(header[6])])
+		case 3:
+			return stdout.String()
+		}
+	}
+}
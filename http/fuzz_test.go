@@ -0,0 +1,135 @@
+package http_test
+
+import (
+	net_http "net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/lara-go/larago/http"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeBrackets(t *testing.T) {
+	values := url.Values{
+		"name":             {"plain"},
+		"user[name]":       {"John"},
+		"items[0][id]":     {"1"},
+		"tags[]":           {"a", "b"},
+		"broken[name":      {"x"},
+		"list[][id]":       {"y"},
+		"[leading]":        {"z"},
+		"user[profile][a]": {"deep"},
+	}
+
+	normalized := http.NormalizeBrackets(values)
+
+	assert.Equal(t, []string{"plain"}, normalized["name"])
+	assert.Equal(t, []string{"John"}, normalized["user.name"])
+	assert.Equal(t, []string{"1"}, normalized["items.0.id"])
+	assert.Equal(t, []string{"a", "b"}, normalized["tags"])
+	assert.Equal(t, []string{"deep"}, normalized["user.profile.a"])
+	assert.Equal(t, []string{"x"}, normalized["broken[name"])
+	assert.Equal(t, []string{"y"}, normalized["list[][id]"])
+	assert.Equal(t, []string{"z"}, normalized["[leading]"])
+}
+
+func TestNegotiate(t *testing.T) {
+	accept := "text/html;q=0.8, application/json, text/*;q=0.5, */*;q=0.1"
+
+	assert.Equal(t, "application/json", http.Negotiate(accept, "text/html", "application/json"))
+	assert.Equal(t, "text/html", http.Negotiate(accept, "text/plain", "text/html"))
+	assert.Equal(t, "text/plain", http.Negotiate(accept, "text/plain", "image/png"))
+	assert.Equal(t, "", http.Negotiate("application/json, */*;q=0", "image/png"))
+	assert.Equal(t, "text/html", http.Negotiate("", "text/html", "application/json"))
+
+	ranges := http.ParseAccept("text/*, text/html;level=1, bogus, */*;q=0.2, image/png;q=2")
+	assert.Len(t, ranges, 3)
+	assert.Equal(t, "text/html", ranges[0].String())
+	assert.Equal(t, "1", ranges[0].Params["level"])
+	assert.Equal(t, "text/*", ranges[1].String())
+	assert.Equal(t, 0.2, ranges[2].Quality)
+}
+
+func FuzzNormalizeBrackets(f *testing.F) {
+	for _, seed := range []string{"a", "a[b]", "a[]", "a[0][b]", "a[b", "a]b[", "[a]", "a[][b]", "a[[b]]", "a[b.c]"} {
+		f.Add(seed, "value")
+	}
+
+	f.Fuzz(func(t *testing.T, key, value string) {
+		normalized := http.NormalizeBrackets(url.Values{key: {value}})
+
+		// Exactly one key with the same value.
+		assert.Len(t, normalized, 1)
+		for name, values := range normalized {
+			assert.Equal(t, []string{value}, values)
+
+			// Normalization is idempotent.
+			assert.Equal(t, normalized, http.NormalizeBrackets(url.Values{name: values}))
+		}
+	})
+}
+
+func FuzzParseAccept(f *testing.F) {
+	for _, seed := range []string{"", "*/*", "text/html;q=0.5", "application/json, text/*;q=0.1", "a/b;q=x", ";;;", "*/json", "text/html;level", "text/html;q=1.0001"} {
+		f.Add(seed, "text/html")
+	}
+
+	f.Fuzz(func(t *testing.T, accept, offer string) {
+		ranges := http.ParseAccept(accept)
+
+		for i, mediaRange := range ranges {
+			assert.True(t, mediaRange.Quality >= 0 && mediaRange.Quality <= 1)
+			assert.NotEmpty(t, mediaRange.Type)
+			assert.NotEmpty(t, mediaRange.Subtype)
+
+			if i > 0 {
+				assert.True(t, ranges[i-1].Quality >= mediaRange.Quality)
+			}
+		}
+
+		// Result is always one of the offers or nothing.
+		result := http.Negotiate(accept, offer, "application/json")
+		assert.Contains(t, []string{"", offer, "application/json"}, result)
+	})
+}
+
+func FuzzRouteMatching(f *testing.F) {
+	router := factory()
+	router.GET("/").Action(func() string { return "index" })
+	router.GET("/users/:id").Action(func(request *http.Request) string {
+		return request.Param("id").(string)
+	})
+	router.GET("/files/*path").Action(func(request *http.Request) string {
+		return request.Param("path").(string)
+	})
+	router.Bootstrap()
+
+	handler := router.Handler()
+
+	for _, seed := range []string{"/", "/users/1", "/users/", "/files/a/b", "//", "/users/1/", "/%zz", "/users/..", "/files/"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, path string) {
+		request := httptest.NewRequest("GET", "/", nil)
+		request.URL.Path = path
+		request.RequestURI = path
+
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+
+		switch recorder.Code {
+		case net_http.StatusOK, net_http.StatusNotFound, net_http.StatusMovedPermanently,
+			net_http.StatusTemporaryRedirect, net_http.StatusPermanentRedirect, net_http.StatusMethodNotAllowed:
+		default:
+			t.Fatalf("Unexpected status %d for %q", recorder.Code, path)
+		}
+
+		// Single segment after /users/ is always matched as the id.
+		if id := strings.TrimPrefix(path, "/users/"); id != path && id != "" && !strings.Contains(id, "/") && recorder.Code == net_http.StatusOK {
+			assert.Contains(t, recorder.Body.String(), id)
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package http
+
+import (
+	net_http "net/http"
+
+	"github.com/lara-go/larago/http/responses"
+)
+
+// RequestReceivedHook is called as soon as request arrives, before routing.
+type RequestReceivedHook func(request *net_http.Request)
+
+// RouteMatchedHook is called when route was matched, before middleware pipeline.
+type RouteMatchedHook func(request *Request)
+
+// ResponseSentHook is called after response was sent to the client.
+type ResponseSentHook func(request *Request, response responses.Response)
+
+// PanicHook is called when panic was recovered while handling request.
+type PanicHook func(request *Request, err error, stack []byte)
+
+// Hooks subscribed to the request lifecycle.
+type Hooks struct {
+	requestReceived []RequestReceivedHook
+	routeMatched    []RouteMatchedHook
+	responseSent    []ResponseSentHook
+	panic           []PanicHook
+}
+
+// Fire request received hooks.
+func (h *Hooks) fireRequestReceived(request *net_http.Request) {
+	for _, hook := range h.requestReceived {
+		hook(request)
+	}
+}
+
+// Fire route matched hooks.
+func (h *Hooks) fireRouteMatched(request *Request) {
+	for _, hook := range h.routeMatched {
+		hook(request)
+	}
+}
+
+// Fire response sent hooks.
+func (h *Hooks) fireResponseSent(request *Request, response responses.Response) {
+	for _, hook := range h.responseSent {
+		hook(request, response)
+	}
+}
+
+// Fire panic hooks.
+func (h *Hooks) firePanic(request *Request, err error, stack []byte) {
+	for _, hook := range h.panic {
+		hook(request, err, stack)
+	}
+}
+
+// OnRequestReceived subscribes hook to every incoming request.
+func (r *Router) OnRequestReceived(hook RequestReceivedHook) *Router {
+	r.hooks.requestReceived = append(r.hooks.requestReceived, hook)
+
+	return r
+}
+
+// OnRouteMatched subscribes hook to every matched route.
+func (r *Router) OnRouteMatched(hook RouteMatchedHook) *Router {
+	r.hooks.routeMatched = append(r.hooks.routeMatched, hook)
+
+	return r
+}
+
+// OnResponseSent subscribes hook to every sent response.
+func (r *Router) OnResponseSent(hook ResponseSentHook) *Router {
+	r.hooks.responseSent = append(r.hooks.responseSent, hook)
+
+	return r
+}
+
+// OnPanic subscribes hook to every recovered panic.
+func (r *Router) OnPanic(hook PanicHook) *Router {
+	r.hooks.panic = append(r.hooks.panic, hook)
+
+	return r
+}
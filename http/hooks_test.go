@@ -0,0 +1,51 @@
+package http_test
+
+import (
+	net_http "net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/http/responses"
+	"github.com/lara-go/larago/support/testsuite"
+)
+
+func TestLifecycleHooks(t *testing.T) {
+	router := factory()
+
+	var fired []string
+	router.
+		OnRequestReceived(func(request *net_http.Request) {
+			fired = append(fired, "received")
+		}).
+		OnRouteMatched(func(request *http.Request) {
+			fired = append(fired, "matched "+request.Route.Path)
+		}).
+		OnResponseSent(func(request *http.Request, response responses.Response) {
+			fired = append(fired, "sent")
+		}).
+		OnPanic(func(request *http.Request, err error, stack []byte) {
+			fired = append(fired, "panic "+err.Error())
+		})
+
+	router.GET("/").Action(func() string {
+		return "Index"
+	})
+	router.GET("/panic").Action(func() {
+		panic("Panic!")
+	})
+
+	e := testsuite.NewHTTPExpect(router.Bootstrap().Handler(), t)
+
+	e.GET("/").Expect().Status(200)
+	assert.Equal(t, []string{"received", "matched /", "sent"}, fired)
+
+	fired = nil
+	e.GET("/panic").Expect().Status(500)
+	assert.Equal(t, []string{"received", "matched /panic", "panic Panic!", "sent"}, fired)
+
+	fired = nil
+	e.GET("/not-found").Expect().Status(404)
+	assert.Equal(t, []string{"received", "sent"}, fired)
+}
@@ -0,0 +1,144 @@
+package http
+
+import (
+	"net"
+	net_http "net/http"
+	"strings"
+	"sync"
+
+	"github.com/lara-go/larago"
+	"github.com/lara-go/larago/foundation/bootstrappers"
+)
+
+// HostSwitch dispatches requests to handlers by host name,
+// so several handlers can share one listener (ex. admin.example.com and example.com).
+type HostSwitch struct {
+	mutex    sync.RWMutex
+	hosts    map[string]net_http.Handler
+	wildcard map[string]net_http.Handler
+	fallback net_http.Handler
+}
+
+// NewHostSwitch constructor.
+func NewHostSwitch() *HostSwitch {
+	return &HostSwitch{
+		hosts:    make(map[string]net_http.Handler),
+		wildcard: make(map[string]net_http.Handler),
+	}
+}
+
+// Handle requests to the host. Host may start with "*." to match all subdomains.
+func (s *HostSwitch) Handle(host string, handler net_http.Handler) *HostSwitch {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	host = strings.ToLower(host)
+	if strings.HasPrefix(host, "*.") {
+		s.wildcard[strings.TrimPrefix(host, "*")] = handler
+	} else {
+		s.hosts[host] = handler
+	}
+
+	return s
+}
+
+// Fallback handles requests to unknown hosts. Responds with 404 if not set.
+func (s *HostSwitch) Fallback(handler net_http.Handler) *HostSwitch {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.fallback = handler
+
+	return s
+}
+
+// ServeHTTP implements net/http handler.
+func (s *HostSwitch) ServeHTTP(w net_http.ResponseWriter, r *net_http.Request) {
+	if handler := s.match(r.Host); handler != nil {
+		handler.ServeHTTP(w, r)
+		return
+	}
+
+	net_http.NotFound(w, r)
+}
+
+// Find handler for the host, the most specific wildcard wins.
+func (s *HostSwitch) match(host string) net_http.Handler {
+	if name, _, err := net.SplitHostPort(host); err == nil {
+		host = name
+	}
+	host = strings.ToLower(host)
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if handler, ok := s.hosts[host]; ok {
+		return handler
+	}
+
+	for suffix := host; suffix != ""; {
+		dot := strings.Index(suffix[1:], ".")
+		if dot < 0 {
+			break
+		}
+		suffix = suffix[dot+1:]
+
+		if handler, ok := s.wildcard[suffix]; ok {
+			return handler
+		}
+	}
+
+	return s.fallback
+}
+
+// Applications booted by BootRouter.
+var hosted struct {
+	sync.Mutex
+	applications []*larago.Application
+}
+
+// BootRouter bootstraps application outside of the console kernel
+// and returns its router ready to serve requests, ex. mounted with HostSwitch next to other handlers.
+// Several applications can be booted in one process, each with its own container, config and connections.
+// Global facades would resolve services of only one of them, so they are detached from all of them.
+func BootRouter(application *larago.Application) (*Router, error) {
+	hosted.Lock()
+	defer hosted.Unlock()
+
+	err := application.BootstrapWith(
+		bootstrappers.LoadConfig,
+		bootstrappers.BootProviders,
+	)
+	if err != nil {
+		return nil, err
+	}
+	hostApplication(application)
+
+	options, err := ServerOptionsFromConfig(application.Config())
+	if err != nil {
+		return nil, err
+	}
+
+	router := application.Get("router").(*Router)
+	router.SetServerOptions(options)
+
+	return router.Bootstrap(), nil
+}
+
+// Remember hosted application and detach facades when there are several of them.
+func hostApplication(application *larago.Application) {
+	for _, booted := range hosted.applications {
+		if booted == application {
+			return
+		}
+	}
+
+	hosted.applications = append(hosted.applications, application)
+	if len(hosted.applications) < 2 {
+		return
+	}
+
+	for _, booted := range hosted.applications {
+		booted.DetachFacades()
+	}
+}
@@ -0,0 +1,155 @@
+package http_test
+
+import (
+	"io"
+	net_http "net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago"
+	"github.com/lara-go/larago/database"
+	"github.com/lara-go/larago/database/casts"
+	"github.com/lara-go/larago/encryption"
+	"github.com/lara-go/larago/events"
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/logger"
+	"github.com/lara-go/larago/validation"
+	"github.com/stretchr/testify/assert"
+
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+)
+
+func TestHostSwitch(t *testing.T) {
+	respond := func(body string) net_http.Handler {
+		return net_http.HandlerFunc(func(w net_http.ResponseWriter, r *net_http.Request) {
+			io.WriteString(w, body)
+		})
+	}
+
+	hosts := http.NewHostSwitch().
+		Handle("example.com", respond("public")).
+		Handle("Admin.example.com", respond("admin")).
+		Handle("*.example.com", respond("tenant")).
+		Handle("*.eu.example.com", respond("eu tenant"))
+
+	serve := func(host string) (int, string) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/", nil)
+		r.Host = host
+
+		hosts.ServeHTTP(w, r)
+
+		return w.Code, w.Body.String()
+	}
+
+	cases := map[string]string{
+		"example.com":           "public",
+		"example.com:8080":      "public",
+		"admin.example.com":     "admin",
+		"ADMIN.example.com":     "admin",
+		"acme.example.com":      "tenant",
+		"a.b.example.com":       "tenant",
+		"acme.eu.example.com":   "eu tenant",
+		"acme.eu.example.com:1": "eu tenant",
+	}
+	for host, expected := range cases {
+		code, body := serve(host)
+		assert.Equal(t, 200, code, host)
+		assert.Equal(t, expected, body, host)
+	}
+
+	code, _ := serve("example.org")
+	assert.Equal(t, 404, code)
+
+	hosts.Fallback(respond("fallback"))
+	_, body := serve("example.org")
+	assert.Equal(t, "fallback", body)
+}
+
+type hostConfig struct {
+	App struct {
+		Debug bool
+		Key   string
+	}
+	Database struct {
+		Driver string
+		DSN    string
+	}
+}
+
+func (c *hostConfig) Env() string {
+	return "testing"
+}
+
+func (c *hostConfig) Debug() bool {
+	return false
+}
+
+type hostedSecret struct {
+	ID    uint            `gorm:"primary_key"`
+	Value casts.Encrypted `gorm:"type:text"`
+}
+
+func TestBootRouterIsolatesApplications(t *testing.T) {
+	dir := t.TempDir()
+
+	boot := func(name string) *larago.Application {
+		key, err := encryption.GenerateKey()
+		assert.NoError(t, err)
+
+		config := &hostConfig{}
+		config.App.Key = key
+		config.Database.Driver = "sqlite3"
+		config.Database.DSN = filepath.Join(dir, name+".db")
+
+		application := larago.New().SetConfig(func() larago.Config { return config })
+		application.Register(
+			&logger.ServiceProvider{}, &events.ServiceProvider{}, &validation.ServiceProvider{}, &http.ServiceProvider{},
+			&encryption.ServiceProvider{}, &database.ServiceProvider{},
+		)
+		application.Facade(encryption.FacadeWrapper)
+
+		router, err := http.BootRouter(application)
+		assert.NoError(t, err)
+		assert.NotNil(t, router)
+
+		return application
+	}
+
+	applications := map[string]*larago.Application{"first": boot("first"), "second": boot("second")}
+	encrypters := map[string]*encryption.Encrypter{}
+	for name, application := range applications {
+		encrypters[name] = application.Get("encrypter").(*encryption.Encrypter)
+	}
+	assert.NotEqual(t, encrypters["first"], encrypters["second"])
+
+	for name, application := range applications {
+		db := application.Get("db.connection").(*gorm.DB)
+		assert.NoError(t, db.AutoMigrate(&hostedSecret{}).Error)
+		assert.NoError(t, db.Create(&hostedSecret{Value: casts.Encrypted(name)}).Error)
+
+		// Attribute is encrypted with the key of its own application only.
+		var payload string
+		assert.NoError(t, db.Table("hosted_secrets").Select("value").Row().Scan(&payload))
+		for other, encrypter := range encrypters {
+			value, err := encrypter.DecryptString(payload)
+			if other == name {
+				assert.NoError(t, err)
+				assert.Equal(t, name, value)
+			} else {
+				assert.Error(t, err)
+			}
+		}
+
+		var loaded hostedSecret
+		assert.NoError(t, db.First(&loaded).Error)
+		assert.Equal(t, casts.Encrypted(name), loaded.Value)
+	}
+
+	// Global facades can not tell applications apart.
+	assert.PanicsWithValue(t, larago.ErrorFacadeDetached, func() {
+		encryption.Facade()
+	})
+}
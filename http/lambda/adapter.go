@@ -0,0 +1,224 @@
+package lambda
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	net_http "net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/lara-go/larago/http/responses"
+)
+
+// Adapter translates Lambda events into requests to the handler and its responses back.
+// Pass Handle to lambda.Start:
+//
+//	router, err := http.BootRouter(application)
+//	lambda.Start(lambda.NewAdapter(router).Handle)
+type Adapter struct {
+	handler net_http.Handler
+}
+
+// NewAdapter constructor.
+func NewAdapter(handler net_http.Handler) *Adapter {
+	return &Adapter{handler: handler}
+}
+
+// Handle event.
+func (a *Adapter) Handle(ctx context.Context, event Event) (*Response, error) {
+	request, err := NewRequest(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	captured := responses.NewCaptured()
+	a.handler.ServeHTTP(captured, request)
+
+	return NewResponse(event, captured), nil
+}
+
+// NewRequest makes net/http request from the event.
+func NewRequest(ctx context.Context, event Event) (*net_http.Request, error) {
+	body := []byte(event.Body)
+	if event.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(event.Body)
+		if err != nil {
+			return nil, fmt.Errorf("Can't decode event body: %s", err)
+		}
+		body = decoded
+	}
+
+	method, path, query, remoteAddr := event.HTTPMethod, event.Path, eventQuery(event), event.RequestContext.Identity.SourceIP
+	if event.IsV2() {
+		method, path, query, remoteAddr = event.RequestContext.HTTP.Method, event.RawPath, event.RawQueryString, event.RequestContext.HTTP.SourceIP
+	}
+
+	target := path
+	if query != "" {
+		target += "?" + query
+	}
+
+	request, err := net_http.NewRequest(method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	for name, values := range event.MultiValueHeaders {
+		for _, value := range values {
+			request.Header.Add(name, value)
+		}
+	}
+	for name, value := range event.Headers {
+		if _, ok := request.Header[net_http.CanonicalHeaderKey(name)]; !ok {
+			request.Header.Set(name, value)
+		}
+	}
+	if len(event.Cookies) > 0 {
+		request.Header.Set("Cookie", strings.Join(event.Cookies, "; "))
+	}
+
+	request.Host = request.Header.Get("Host")
+	if request.Host == "" {
+		request.Host = event.RequestContext.DomainName
+	}
+	request.RequestURI = target
+	request.RemoteAddr = remoteAddr
+	request.ContentLength = int64(len(body))
+
+	return request.WithContext(ctx), nil
+}
+
+// NewResponse makes Lambda response in the format expected by the event source.
+func NewResponse(event Event, response responses.Response) *Response {
+	result := &Response{
+		StatusCode: response.Status(),
+	}
+
+	header := responseHeader(response)
+
+	switch {
+	case event.IsV2():
+		result.Cookies = header["Set-Cookie"]
+		header.Del("Set-Cookie")
+		result.Headers = joinHeader(header)
+	case event.MultiValueHeaders != nil:
+		result.MultiValueHeaders = header
+	default:
+		result.Headers = joinHeader(header)
+	}
+
+	if event.IsALB() {
+		result.StatusDescription = fmt.Sprintf("%d %s", result.StatusCode, net_http.StatusText(result.StatusCode))
+	}
+
+	body := response.Body()
+	if isBinary(header.Get("Content-Type")) {
+		result.Body = base64.StdEncoding.EncodeToString(body)
+		result.IsBase64Encoded = true
+	} else {
+		result.Body = string(body)
+	}
+
+	return result
+}
+
+// Collect response headers keeping multiple values of captured responses.
+func responseHeader(response responses.Response) net_http.Header {
+	header := make(net_http.Header)
+	if captured, ok := response.(*responses.Captured); ok {
+		for name, values := range captured.Header() {
+			header[name] = append([]string(nil), values...)
+		}
+	}
+
+	if multi, ok := response.(responses.MultiValueHeaders); ok {
+		for name, values := range multi.HeaderValues() {
+			if _, ok := header[name]; !ok {
+				header[name] = values
+			}
+		}
+	} else {
+		for name, value := range response.Headers() {
+			if _, ok := header[net_http.CanonicalHeaderKey(name)]; !ok {
+				header.Set(name, value)
+			}
+		}
+	}
+	if header.Get("Content-Type") == "" {
+		header.Set("Content-Type", responses.ContentTypeHeader(response.ContentType()))
+	}
+
+	sent := make(map[string]bool)
+	for _, cookie := range header["Set-Cookie"] {
+		sent[cookie] = true
+	}
+	for _, cookie := range response.Cookies() {
+		if value := cookie.String(); !sent[value] {
+			header.Add("Set-Cookie", value)
+		}
+	}
+
+	return header
+}
+
+// Build query string of REST API or ALB event.
+func eventQuery(event Event) string {
+	query := make(url.Values)
+
+	for name, values := range event.MultiValueQueryStringParameters {
+		query[name] = values
+	}
+	for name, value := range event.QueryStringParameters {
+		if _, ok := query[name]; !ok {
+			query.Set(name, value)
+		}
+	}
+
+	if !event.IsALB() {
+		return query.Encode()
+	}
+
+	// Load balancer passes parameters as they were sent, without decoding.
+	var pairs []string
+	for name, values := range query {
+		for _, value := range values {
+			pairs = append(pairs, name+"="+value)
+		}
+	}
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, "&")
+}
+
+// Join multiple values of the same header with comma.
+func joinHeader(header net_http.Header) map[string]string {
+	joined := make(map[string]string, len(header))
+	for name, values := range header {
+		joined[name] = strings.Join(values, ",")
+	}
+
+	return joined
+}
+
+// Check if content can't be sent as a plain string.
+func isBinary(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return true
+	}
+
+	switch {
+	case strings.HasPrefix(mediaType, "text/"),
+		strings.HasSuffix(mediaType, "json"),
+		strings.HasSuffix(mediaType, "xml"),
+		strings.HasSuffix(mediaType, "javascript"),
+		mediaType == "application/x-www-form-urlencoded":
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,128 @@
+package lambda_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	net_http "net/http"
+	"testing"
+
+	"github.com/lara-go/larago/http/lambda"
+	"github.com/stretchr/testify/assert"
+)
+
+// Echoes request back.
+var echo = net_http.HandlerFunc(func(w net_http.ResponseWriter, r *net_http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+
+	w.Header().Add("Vary", "Accept")
+	w.Header().Add("Vary", "Origin")
+	net_http.SetCookie(w, &net_http.Cookie{Name: "a", Value: "1"})
+	net_http.SetCookie(w, &net_http.Cookie{Name: "b", Value: "2"})
+
+	if r.URL.Path == "/image" {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(body)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"method": r.Method,
+		"path":   r.URL.Path,
+		"query":  r.URL.Query(),
+		"host":   r.Host,
+		"ip":     r.RemoteAddr,
+		"cookie": r.Header.Get("Cookie"),
+		"body":   string(body),
+	})
+})
+
+func decode(t *testing.T, body string) map[string]interface{} {
+	var data map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(body), &data))
+
+	return data
+}
+
+func TestRESTEvent(t *testing.T) {
+	var event lambda.Event
+	json.Unmarshal([]byte(`{
+		"httpMethod": "POST",
+		"path": "/users",
+		"multiValueQueryStringParameters": {"tag": ["a", "b"]},
+		"queryStringParameters": {"tag": "b", "page": "2"},
+		"headers": {"Host": "api.example.com"},
+		"multiValueHeaders": {"Host": ["api.example.com"]},
+		"body": "`+base64.StdEncoding.EncodeToString([]byte("payload"))+`",
+		"isBase64Encoded": true,
+		"requestContext": {"identity": {"sourceIp": "10.0.0.1"}}
+	}`), &event)
+
+	response, err := lambda.NewAdapter(echo).Handle(context.Background(), event)
+	assert.NoError(t, err)
+	assert.Equal(t, 200, response.StatusCode)
+	assert.False(t, response.IsBase64Encoded)
+	assert.Equal(t, []string{"Accept", "Origin"}, response.MultiValueHeaders["Vary"])
+	assert.Equal(t, []string{"a=1", "b=2"}, response.MultiValueHeaders["Set-Cookie"])
+
+	data := decode(t, response.Body)
+	assert.Equal(t, "POST", data["method"])
+	assert.Equal(t, "/users", data["path"])
+	assert.Equal(t, map[string]interface{}{"tag": []interface{}{"a", "b"}, "page": []interface{}{"2"}}, data["query"])
+	assert.Equal(t, "api.example.com", data["host"])
+	assert.Equal(t, "10.0.0.1", data["ip"])
+	assert.Equal(t, "payload", data["body"])
+}
+
+func TestV2Event(t *testing.T) {
+	var event lambda.Event
+	json.Unmarshal([]byte(`{
+		"version": "2.0",
+		"rawPath": "/image",
+		"rawQueryString": "size=large",
+		"cookies": ["session=abc", "theme=dark"],
+		"headers": {"content-type": "image/png"},
+		"body": "`+base64.StdEncoding.EncodeToString([]byte{0x89, 'P', 'N', 'G'})+`",
+		"isBase64Encoded": true,
+		"requestContext": {"domainName": "fn.lambda-url.aws", "http": {"method": "PUT", "sourceIp": "10.0.0.2"}}
+	}`), &event)
+
+	response, err := lambda.NewAdapter(echo).Handle(context.Background(), event)
+	assert.NoError(t, err)
+	assert.True(t, response.IsBase64Encoded)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte{0x89, 'P', 'N', 'G'}), response.Body)
+	assert.Equal(t, "image/png", response.Headers["Content-Type"])
+	assert.Equal(t, "Accept,Origin", response.Headers["Vary"])
+	assert.Equal(t, []string{"a=1", "b=2"}, response.Cookies)
+	assert.NotContains(t, response.Headers, "Set-Cookie")
+
+	request, err := lambda.NewRequest(context.Background(), event)
+	assert.NoError(t, err)
+	assert.Equal(t, "PUT", request.Method)
+	assert.Equal(t, "large", request.URL.Query().Get("size"))
+	assert.Equal(t, "session=abc; theme=dark", request.Header.Get("Cookie"))
+	assert.Equal(t, "fn.lambda-url.aws", request.Host)
+}
+
+func TestALBEvent(t *testing.T) {
+	var event lambda.Event
+	json.Unmarshal([]byte(`{
+		"httpMethod": "GET",
+		"path": "/search",
+		"queryStringParameters": {"q": "a%20b"},
+		"headers": {"host": "lb.example.com"},
+		"body": "",
+		"requestContext": {"elb": {"targetGroupArn": "arn"}}
+	}`), &event)
+
+	response, err := lambda.NewAdapter(echo).Handle(context.Background(), event)
+	assert.NoError(t, err)
+	assert.Equal(t, "200 OK", response.StatusDescription)
+	assert.Equal(t, "Accept,Origin", response.Headers["Vary"])
+	assert.Nil(t, response.MultiValueHeaders)
+
+	data := decode(t, response.Body)
+	assert.Equal(t, map[string]interface{}{"q": []interface{}{"a b"}}, data["query"])
+}
@@ -0,0 +1,71 @@
+package lambda
+
+// Event is a union of API Gateway REST (v1), HTTP API (v2), Function URL and ALB events.
+// Unmarshal the raw payload into it, shapes of different sources are told apart by version.
+type Event struct {
+	Version string `json:"version"`
+
+	// REST API and ALB.
+	HTTPMethod                      string              `json:"httpMethod"`
+	Path                            string              `json:"path"`
+	QueryStringParameters           map[string]string   `json:"queryStringParameters"`
+	MultiValueQueryStringParameters map[string][]string `json:"multiValueQueryStringParameters"`
+	MultiValueHeaders               map[string][]string `json:"multiValueHeaders"`
+
+	// HTTP API and Function URL.
+	RawPath        string   `json:"rawPath"`
+	RawQueryString string   `json:"rawQueryString"`
+	Cookies        []string `json:"cookies"`
+
+	Headers         map[string]string `json:"headers"`
+	Body            string            `json:"body"`
+	IsBase64Encoded bool              `json:"isBase64Encoded"`
+	RequestContext  RequestContext    `json:"requestContext"`
+}
+
+// RequestContext of the event.
+type RequestContext struct {
+	RequestID  string          `json:"requestId"`
+	DomainName string          `json:"domainName"`
+	Identity   Identity        `json:"identity"`
+	HTTP       HTTPDescription `json:"http"`
+	ELB        *ELBContext     `json:"elb,omitempty"`
+}
+
+// Identity of the REST API caller.
+type Identity struct {
+	SourceIP string `json:"sourceIp"`
+}
+
+// HTTPDescription of the HTTP API or Function URL request.
+type HTTPDescription struct {
+	Method   string `json:"method"`
+	Path     string `json:"path"`
+	SourceIP string `json:"sourceIp"`
+}
+
+// ELBContext is set for events sent by the load balancer.
+type ELBContext struct {
+	TargetGroupArn string `json:"targetGroupArn"`
+}
+
+// IsV2 checks if event has HTTP API (v2) payload format, used by Function URLs as well.
+func (e *Event) IsV2() bool {
+	return e.Version == "2.0"
+}
+
+// IsALB checks if event was sent by the load balancer.
+func (e *Event) IsALB() bool {
+	return e.RequestContext.ELB != nil
+}
+
+// Response to return to the Lambda runtime.
+type Response struct {
+	StatusCode        int                 `json:"statusCode"`
+	StatusDescription string              `json:"statusDescription,omitempty"`
+	Headers           map[string]string   `json:"headers,omitempty"`
+	MultiValueHeaders map[string][]string `json:"multiValueHeaders,omitempty"`
+	Cookies           []string            `json:"cookies,omitempty"`
+	Body              string              `json:"body"`
+	IsBase64Encoded   bool                `json:"isBase64Encoded"`
+}
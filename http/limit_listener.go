@@ -0,0 +1,78 @@
+package http
+
+import (
+	"net"
+	"sync"
+)
+
+// LimitListener returns a Listener that accepts at most n simultaneous connections.
+func LimitListener(listener net.Listener, n int) net.Listener {
+	return &limitListener{
+		Listener: listener,
+		sem:      make(chan struct{}, n),
+		done:     make(chan struct{}),
+	}
+}
+
+type limitListener struct {
+	net.Listener
+
+	sem       chan struct{}
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// Acquire slot for the new connection.
+// Returns false if listener was closed while waiting.
+func (l *limitListener) acquire() bool {
+	select {
+	case <-l.done:
+		return false
+	case l.sem <- struct{}{}:
+		return true
+	}
+}
+
+// Release slot.
+func (l *limitListener) release() {
+	<-l.sem
+}
+
+// Accept waits for free slot and then for the next connection.
+func (l *limitListener) Accept() (net.Conn, error) {
+	if !l.acquire() {
+		return nil, net.ErrClosed
+	}
+
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		l.release()
+
+		return nil, err
+	}
+
+	return &limitListenerConn{Conn: conn, release: l.release}, nil
+}
+
+// Close listener.
+func (l *limitListener) Close() error {
+	err := l.Listener.Close()
+	l.closeOnce.Do(func() { close(l.done) })
+
+	return err
+}
+
+type limitListenerConn struct {
+	net.Conn
+
+	releaseOnce sync.Once
+	release     func()
+}
+
+// Close connection and free the slot.
+func (c *limitListenerConn) Close() error {
+	err := c.Conn.Close()
+	c.releaseOnce.Do(c.release)
+
+	return err
+}
@@ -0,0 +1,52 @@
+package http_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lara-go/larago/http"
+)
+
+func TestLimitListener(t *testing.T) {
+	base, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+
+	listener := http.LimitListener(base, 1)
+	defer listener.Close()
+
+	accepted := make(chan net.Conn, 2)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			accepted <- conn
+		}
+	}()
+
+	first, _ := net.Dial("tcp", base.Addr().String())
+	defer first.Close()
+	second, _ := net.Dial("tcp", base.Addr().String())
+	defer second.Close()
+
+	// Only one connection can be accepted at the moment.
+	conn := <-accepted
+	select {
+	case <-accepted:
+		t.Fatal("Second connection was accepted over the limit")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// Free the slot and wait for the second one.
+	conn.Close()
+	select {
+	case conn := <-accepted:
+		conn.Close()
+	case <-time.After(time.Second):
+		t.Fatal("Second connection was not accepted after slot was released")
+	}
+}
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"sync"
+	"time"
+)
+
+// MetricsSnapshot of handled requests.
+type MetricsSnapshot struct {
+	Requests     uint64
+	ServerErrors uint64
+
+	// Time spent in the app.
+	AverageLatency time.Duration
+	MaxLatency     time.Duration
+
+	// Time spent waiting in front of the app, reported by the load balancer.
+	AverageQueueTime time.Duration
+	MaxQueueTime     time.Duration
+}
+
+// Metrics collects latency of the app and queue time of the load balancer separately,
+// to tell slow code from lack of capacity.
+type Metrics struct {
+	mutex sync.Mutex
+
+	requests     uint64
+	serverErrors uint64
+	latency      time.Duration
+	maxLatency   time.Duration
+
+	// Requests with queue time reported.
+	queued       uint64
+	queueTime    time.Duration
+	maxQueueTime time.Duration
+}
+
+// NewMetrics constructor.
+func NewMetrics() *Metrics {
+	return &Metrics{}
+}
+
+// Observe handled request.
+func (m *Metrics) Observe(status int, latency, queueTime time.Duration) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.requests++
+	if status >= 500 {
+		m.serverErrors++
+	}
+
+	m.latency += latency
+	if latency > m.maxLatency {
+		m.maxLatency = latency
+	}
+
+	if queueTime > 0 {
+		m.queued++
+		m.queueTime += queueTime
+		if queueTime > m.maxQueueTime {
+			m.maxQueueTime = queueTime
+		}
+	}
+}
+
+// Snapshot returns current values.
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	snapshot := MetricsSnapshot{
+		Requests:     m.requests,
+		ServerErrors: m.serverErrors,
+		MaxLatency:   m.maxLatency,
+		MaxQueueTime: m.maxQueueTime,
+	}
+
+	if m.requests > 0 {
+		snapshot.AverageLatency = m.latency / time.Duration(m.requests)
+	}
+	if m.queued > 0 {
+		snapshot.AverageQueueTime = m.queueTime / time.Duration(m.queued)
+	}
+
+	return snapshot
+}
+
+// Reset collected values.
+func (m *Metrics) Reset() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.requests, m.serverErrors, m.queued = 0, 0, 0
+	m.latency, m.maxLatency, m.queueTime, m.maxQueueTime = 0, 0, 0, 0
+}
@@ -0,0 +1,151 @@
+package http
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MediaRange is a single entry of the Accept header.
+type MediaRange struct {
+	Type    string
+	Subtype string
+	Quality float64
+	Params  map[string]string
+}
+
+// String returns media range as "type/subtype".
+func (m MediaRange) String() string {
+	return m.Type + "/" + m.Subtype
+}
+
+// Matches checks if content type falls into the range.
+func (m MediaRange) Matches(contentType string) bool {
+	mediaType, subtype, ok := splitMediaType(contentType)
+	if !ok {
+		return false
+	}
+
+	return (m.Type == "*" || m.Type == mediaType) && (m.Subtype == "*" || m.Subtype == subtype)
+}
+
+// How specific the range is: */* < type/* < type/subtype.
+func (m MediaRange) specificity() int {
+	switch {
+	case m.Type == "*":
+		return 0
+	case m.Subtype == "*":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// ParseAccept parses Accept header, most preferred ranges first.
+// Malformed entries are skipped.
+func ParseAccept(header string) []MediaRange {
+	var ranges []MediaRange
+
+	for _, entry := range strings.Split(header, ",") {
+		parts := strings.Split(entry, ";")
+
+		mediaType, subtype, ok := splitMediaType(parts[0])
+		if !ok {
+			continue
+		}
+
+		mediaRange := MediaRange{Type: mediaType, Subtype: subtype, Quality: 1}
+		for _, param := range parts[1:] {
+			name, value, found := strings.Cut(param, "=")
+			name = strings.ToLower(strings.TrimSpace(name))
+			value = strings.Trim(strings.TrimSpace(value), `"`)
+
+			if !found || name == "" {
+				ok = false
+				break
+			}
+
+			if name == "q" {
+				quality, err := strconv.ParseFloat(value, 64)
+				if err != nil || quality < 0 || quality > 1 {
+					ok = false
+					break
+				}
+
+				mediaRange.Quality = quality
+				continue
+			}
+
+			if mediaRange.Params == nil {
+				mediaRange.Params = make(map[string]string)
+			}
+			mediaRange.Params[name] = value
+		}
+
+		if ok {
+			ranges = append(ranges, mediaRange)
+		}
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		if ranges[i].Quality != ranges[j].Quality {
+			return ranges[i].Quality > ranges[j].Quality
+		}
+
+		return ranges[i].specificity() > ranges[j].specificity()
+	})
+
+	return ranges
+}
+
+// Split "type/subtype" into lower cased parts. Single "*" means "*/*".
+func splitMediaType(value string) (string, string, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "*" {
+		return "*", "*", true
+	}
+
+	mediaType, subtype, found := strings.Cut(value, "/")
+	if !found || mediaType == "" || subtype == "" || strings.ContainsAny(subtype, "/ ") {
+		return "", "", false
+	}
+
+	// "*/json" is not a valid range.
+	if mediaType == "*" && subtype != "*" {
+		return "", "", false
+	}
+
+	return mediaType, subtype, true
+}
+
+// Negotiate returns the offered content type client prefers, empty if none is acceptable.
+// Missing Accept header accepts the first offer.
+func Negotiate(accept string, offers ...string) string {
+	if len(offers) == 0 {
+		return ""
+	}
+
+	if strings.TrimSpace(accept) == "" {
+		return offers[0]
+	}
+
+	ranges := ParseAccept(accept)
+
+	best := ""
+	bestQuality := 0.0
+	for _, offer := range offers {
+		// The most specific matching range decides the quality.
+		quality, specificity := 0.0, -1
+		for _, mediaRange := range ranges {
+			if mediaRange.Matches(offer) && mediaRange.specificity() > specificity {
+				quality, specificity = mediaRange.Quality, mediaRange.specificity()
+			}
+		}
+
+		if quality > bestQuality {
+			best, bestQuality = offer, quality
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,160 @@
+package http
+
+import (
+	"context"
+	net_http "net/http"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/lara-go/larago/http/responses"
+)
+
+// Action makes route action from net/http handler.
+// Route params are available via httprouter.ParamsFromContext.
+func Action(handler net_http.Handler) func(request *Request) responses.Response {
+	return func(request *Request) responses.Response {
+		response := responses.NewCaptured()
+
+		handler.ServeHTTP(response, withParams(request))
+
+		return response
+	}
+}
+
+// ActionFunc makes route action from net/http handler function.
+func ActionFunc(handler func(net_http.ResponseWriter, *net_http.Request)) func(request *Request) responses.Response {
+	return Action(net_http.HandlerFunc(handler))
+}
+
+// WrapMiddleware makes larago middleware from net/http one.
+//
+//	router.Middleware(http.WrapMiddleware(handlers.CompressHandler))
+func WrapMiddleware(middleware func(net_http.Handler) net_http.Handler) Middleware {
+	return &netMiddleware{wrap: middleware}
+}
+
+// Middleware adapter for net/http middleware.
+type netMiddleware struct {
+	wrap func(net_http.Handler) net_http.Handler
+}
+
+// Handle request.
+func (m *netMiddleware) Handle(request *Request, next Handler) responses.Response {
+	captured := responses.NewCaptured()
+
+	var response responses.Response
+	inner := net_http.HandlerFunc(func(w net_http.ResponseWriter, req *net_http.Request) {
+		// Middleware may pass modified request (ex. with context values).
+		request.request = req
+
+		response = next(request)
+
+		// Middleware wrapped the writer (ex. to compress the body), so response has to go through it.
+		if w != net_http.ResponseWriter(captured) {
+			WriteResponse(w, response)
+
+			response = nil
+		}
+	})
+
+	m.wrap(inner).ServeHTTP(captured, request.BaseRequest())
+
+	// Middleware responded itself or the response was written through its writer.
+	if response == nil {
+		return captured
+	}
+
+	return mergeCaptured(response, captured)
+}
+
+// Response able to keep several values of the header.
+type headerAdder interface {
+	AddHeader(name, value string)
+}
+
+// Add headers and cookies set by middleware before calling the next handler.
+// Headers of the response win, as they were set later.
+func mergeCaptured(response responses.Response, captured *responses.Captured) responses.Response {
+	existing := make(map[string]bool)
+	for name := range response.Headers() {
+		existing[net_http.CanonicalHeaderKey(name)] = true
+	}
+
+	for name, values := range captured.Header() {
+		if name == "Content-Type" || name == "Set-Cookie" || existing[name] {
+			continue
+		}
+
+		if adder, ok := response.(headerAdder); ok {
+			for _, value := range values {
+				adder.AddHeader(name, value)
+			}
+		} else {
+			response.WithHeader(name, strings.Join(values, ", "))
+		}
+	}
+
+	cookies := captured.Cookies()
+	if len(cookies) == 0 {
+		return response
+	}
+
+	// Cookies of captured response live in its headers.
+	if inner, ok := response.(*responses.Captured); ok {
+		for _, cookie := range cookies {
+			inner.Header().Add("Set-Cookie", cookie.String())
+		}
+
+		return inner
+	}
+
+	response.WithCookies(append(response.Cookies(), cookies...)...)
+
+	return response
+}
+
+// Attach route params to net/http request context.
+func withParams(request *Request) *net_http.Request {
+	base := request.BaseRequest()
+	if len(request.Params) == 0 {
+		return base
+	}
+
+	return base.WithContext(context.WithValue(base.Context(), httprouter.ParamsKey, request.Params))
+}
+
+// WriteResponse sends response to net/http writer.
+func WriteResponse(w net_http.ResponseWriter, response responses.Response) {
+	// Send content type.
+	w.Header().Set("Content-Type", responses.ContentTypeHeader(response.ContentType()))
+
+	// Send additional headers.
+	if multi, ok := response.(responses.MultiValueHeaders); ok {
+		for name, values := range multi.HeaderValues() {
+			w.Header().Del(name)
+			for _, value := range values {
+				w.Header().Add(name, value)
+			}
+		}
+	} else {
+		for name, value := range response.Headers() {
+			w.Header().Set(name, value)
+		}
+	}
+
+	// Send cookies.
+	for _, cookie := range response.Cookies() {
+		net_http.SetCookie(w, cookie)
+	}
+
+	// Send status.
+	w.WriteHeader(response.Status())
+
+	// Send body.
+	if stream, ok := response.(*responses.Stream); ok {
+		stream.Send(w)
+		return
+	}
+
+	w.Write(response.Body())
+}
@@ -0,0 +1,111 @@
+package http_test
+
+import (
+	"io"
+	net_http "net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/http/responses"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetHTTPInterop(t *testing.T) {
+	router := factory()
+
+	// Sets header and cookie before the next handler.
+	stamp := func(next net_http.Handler) net_http.Handler {
+		return net_http.HandlerFunc(func(w net_http.ResponseWriter, r *net_http.Request) {
+			w.Header().Set("X-Stamp", "yes")
+			w.Header().Add("Link", "</app.css>; rel=preload; as=style")
+			w.Header().Add("Link", "</app.js>; rel=preload; as=script")
+			net_http.SetCookie(w, &net_http.Cookie{Name: "visited", Value: "1"})
+
+			next.ServeHTTP(w, r)
+		})
+	}
+
+	// Responds without calling the next handler.
+	deny := func(next net_http.Handler) net_http.Handler {
+		return net_http.HandlerFunc(func(w net_http.ResponseWriter, r *net_http.Request) {
+			net_http.Error(w, "denied", net_http.StatusForbidden)
+		})
+	}
+
+	// Wraps the writer.
+	prefix := func(next net_http.Handler) net_http.Handler {
+		return net_http.HandlerFunc(func(w net_http.ResponseWriter, r *net_http.Request) {
+			io.WriteString(w, "> ")
+			next.ServeHTTP(&teeWriter{w}, r)
+		})
+	}
+
+	router.Middleware(http.WrapMiddleware(stamp))
+
+	router.GET("/users/:id").Action(http.ActionFunc(func(w net_http.ResponseWriter, r *net_http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(201)
+		io.WriteString(w, `{"id":"`+httprouter.ParamsFromContext(r.Context()).ByName("id")+`"}`)
+	}))
+	router.GET("/native").Action(func() string {
+		return "native"
+	})
+	router.GET("/report.pdf").Action(func() responses.Response {
+		return responses.NewPDF(200, []byte("%PDF-1.4"), "report.pdf")
+	})
+	router.GET("/denied").Middleware(http.WrapMiddleware(deny)).Action(func() string {
+		return "secret"
+	})
+	router.GET("/prefixed").Middleware(http.WrapMiddleware(prefix)).Action(func() string {
+		return "text"
+	})
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+
+		return w
+	}
+
+	router.Bootstrap()
+
+	w := serve("/users/42")
+	assert.Equal(t, 201, w.Code)
+	assert.Equal(t, `{"id":"42"}`, w.Body.String())
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Equal(t, "yes", w.Header().Get("X-Stamp"))
+	assert.Contains(t, w.Header().Get("Set-Cookie"), "visited=1")
+
+	w = serve("/native")
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "native", w.Body.String())
+	assert.Equal(t, "yes", w.Header().Get("X-Stamp"))
+	assert.Contains(t, w.Header().Get("Set-Cookie"), "visited=1")
+	assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+
+	// Several values of the header set by middleware are kept apart.
+	for _, path := range []string{"/users/42", "/native"} {
+		assert.Equal(t, []string{"</app.css>; rel=preload; as=style", "</app.js>; rel=preload; as=script"}, serve(path).Header()["Link"])
+	}
+
+	w = serve("/report.pdf")
+	assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+
+	w = serve("/denied")
+	assert.Equal(t, 403, w.Code)
+	assert.Equal(t, "denied\n", w.Body.String())
+
+	w = serve("/prefixed")
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "> text", w.Body.String())
+
+	// Router itself is a standard handler.
+	var _ net_http.Handler = router
+}
+
+// Writer wrapping the original one.
+type teeWriter struct {
+	net_http.ResponseWriter
+}
@@ -0,0 +1,224 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/lara-go/larago/container"
+	"github.com/lara-go/larago/database"
+)
+
+// Max length of the request body kept in the report.
+const panicBodySnippetSize = 2048
+
+// Max length of the request body read to build the snippet.
+const panicBodyReadSize = 64 << 10
+
+// Amount of the latest resolutions and queries of the request kept for the report.
+const panicTraceSize = 50
+
+// PanicReport holds everything known about the request at the moment of panic.
+type PanicReport struct {
+	Error       string
+	Stack       string
+	Method      string
+	URL         string
+	Route       string
+	Params      map[string]string
+	Headers     map[string]string
+	Body        string
+	Queries     []database.Query
+	Resolutions []container.Resolution
+}
+
+// PanicError is an error recovered from panic, passed to the errors handler with the report.
+type PanicError struct {
+	Err    error
+	Report *PanicReport
+}
+
+// Error message of the recovered error.
+func (e *PanicError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns recovered error.
+func (e *PanicError) Unwrap() error {
+	return e.Err
+}
+
+// NewPanicReport collects report of the panic happened while handling request.
+func NewPanicReport(request *Request, err error, stack []byte) *PanicReport {
+	base := request.BaseRequest()
+
+	report := &PanicReport{
+		Error:   err.Error(),
+		Stack:   string(stack),
+		Method:  base.Method,
+		URL:     sanitizeURL(base.URL),
+		Params:  make(map[string]string),
+		Headers: make(map[string]string),
+		Body:    bodySnippet(request),
+	}
+
+	if request.Route != nil {
+		report.Route = request.Route.Path
+	}
+
+	for _, param := range request.Params {
+		report.Params[param.Key] = param.Value
+	}
+
+	for name, values := range base.Header {
+		report.Headers[name] = sanitizeValue(name, strings.Join(values, ", "))
+	}
+
+	return report
+}
+
+// String formats report for logs.
+func (r *PanicReport) String() string {
+	var report strings.Builder
+
+	fmt.Fprintf(&report, "Panic: %s\n", r.Error)
+	fmt.Fprintf(&report, "Request: %s %s\n", r.Method, r.URL)
+	if r.Route != "" {
+		fmt.Fprintf(&report, "Route: %s %v\n", r.Route, r.Params)
+	}
+
+	names := make([]string, 0, len(r.Headers))
+	for name := range r.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&report, "  %s: %s\n", name, r.Headers[name])
+	}
+
+	if r.Body != "" {
+		fmt.Fprintf(&report, "Body: %s\n", r.Body)
+	}
+
+	if len(r.Queries) > 0 {
+		report.WriteString("Queries:\n")
+		for _, query := range r.Queries {
+			fmt.Fprintf(&report, "  [%s] %s", query.Duration, query.SQL)
+			if query.Error != "" {
+				fmt.Fprintf(&report, " (%s)", query.Error)
+			}
+			report.WriteString("\n")
+		}
+	}
+
+	if len(r.Resolutions) > 0 {
+		report.WriteString("Resolutions:\n")
+		for _, resolution := range r.Resolutions {
+			fmt.Fprintf(&report, "  %s", resolution.Abstract)
+			if resolution.Cached {
+				report.WriteString(" (cached)")
+			}
+			if resolution.Error != "" {
+				fmt.Fprintf(&report, " (%s)", resolution.Error)
+			}
+			report.WriteString("\n")
+		}
+	}
+
+	fmt.Fprintf(&report, "Stack:\n%s", r.Stack)
+
+	return report.String()
+}
+
+// GoString is used by the logger to print report as context.
+func (r *PanicReport) GoString() string {
+	return r.String()
+}
+
+// Build report of the recovered panic, with the latest queries and resolutions of the request.
+func (r *Router) panicReport(request *Request, err error, stack []byte) *PanicReport {
+	report := NewPanicReport(request, err, stack)
+
+	if request.queries != nil {
+		report.Queries = request.queries.Recent()
+	}
+
+	if request.trace != nil {
+		report.Resolutions = request.trace.Recent()
+	}
+
+	return report
+}
+
+// Container of the request, which traces resolutions in debug mode.
+func (r *Router) requestContainer(request *Request) *container.Container {
+	if request.trace == nil {
+		return r.Container
+	}
+
+	return r.Container.Traced(request.trace)
+}
+
+// WithQueryLog returns connection which queries are reported on panic of the request in debug mode.
+func (r *Request) WithQueryLog(db *gorm.DB) *gorm.DB {
+	if r.queries == nil {
+		return db
+	}
+
+	return database.WithQueryLog(db, r.queries)
+}
+
+// Take sanitized beginning of the request body.
+func bodySnippet(request *Request) string {
+	base := request.BaseRequest()
+
+	// Body of the parsed form is already consumed.
+	if base.PostForm != nil {
+		return truncate(sanitizeValues(base.PostForm).Encode())
+	}
+
+	if base.Body == nil {
+		return ""
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(base.Body, panicBodyReadSize+1))
+	base.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(body), base.Body))
+	if err != nil || len(body) == 0 {
+		return ""
+	}
+
+	contentType := base.Header.Get("Content-Type")
+
+	// Secrets can not be found in the cut JSON or form.
+	if len(body) > panicBodyReadSize && !strings.HasPrefix(contentType, "text/") {
+		return fmt.Sprintf("[%s, more than %d bytes]", contentType, panicBodyReadSize)
+	}
+
+	switch {
+	case strings.Contains(contentType, "json"), strings.Contains(contentType, "application/x-www-form-urlencoded"):
+		sanitized, err := sanitizeBody(contentType, body)
+		if err != nil {
+			return fmt.Sprintf("[malformed %s, %d bytes]", contentType, len(body))
+		}
+
+		return truncate(string(sanitized))
+	case strings.HasPrefix(contentType, "text/"):
+		return truncate(string(body))
+	default:
+		return fmt.Sprintf("[%s, %d bytes]", contentType, len(body))
+	}
+}
+
+// Cut body to the snippet size.
+func truncate(body string) string {
+	if len(body) <= panicBodySnippetSize {
+		return body
+	}
+
+	return body[:panicBodySnippetSize] + "..."
+}
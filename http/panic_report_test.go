@@ -0,0 +1,133 @@
+package http_test
+
+import (
+	"bytes"
+	"log"
+	net_http "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lara-go/larago/database"
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/support/testsuite"
+	"github.com/stretchr/testify/assert"
+
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+)
+
+type mailer struct{}
+
+type debugConfig struct{}
+
+func (c *debugConfig) Env() string {
+	return "testing"
+}
+
+func (c *debugConfig) Debug() bool {
+	return true
+}
+
+type reportedUser struct {
+	ID uint
+}
+
+func TestPanicReport(t *testing.T) {
+	router := factory()
+
+	output := &bytes.Buffer{}
+	router.Logger.Logger = log.New(output, "", 0)
+
+	router.Config = &debugConfig{}
+	router.Container.Instance(&mailer{}, "mailer")
+
+	db := testsuite.MemoryDB(t, &reportedUser{})
+	database.RegisterQueryLog(db)
+
+	var report *http.PanicReport
+	router.OnPanic(func(request *http.Request, err error, stack []byte) {
+		report = http.NewPanicReport(request, err, stack)
+	})
+
+	router.POST("/users/:id").Action(func(request *http.Request, m *mailer) string {
+		var user reportedUser
+		request.WithQueryLog(db).First(&user)
+
+		panic("boom")
+	})
+
+	body := `{"email":"john@example.com","password":"secret","profile":{"api_key":"xyz"}}`
+	request := httptest.NewRequest("POST", "/users/7?token=abc&page=2", strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer abc")
+
+	w := httptest.NewRecorder()
+	router.Bootstrap().ServeHTTP(w, request)
+	assert.Equal(t, net_http.StatusInternalServerError, w.Code)
+
+	assert.Equal(t, "boom", report.Error)
+	assert.Equal(t, "/users/:id", report.Route)
+	assert.Equal(t, map[string]string{"id": "7"}, report.Params)
+	assert.Equal(t, "/users/7?page=2&token=%5Bredacted%5D", report.URL)
+	assert.Equal(t, "[redacted]", report.Headers["Authorization"])
+	assert.Equal(t, `{"email":"john@example.com","password":"[redacted]","profile":{"api_key":"[redacted]"}}`, report.Body)
+	assert.Contains(t, report.Stack, "panic_report_test.go")
+
+	// Reported report has the latest queries and container resolutions.
+	logged := output.String()
+	assert.Contains(t, logged, "Panic: boom")
+	assert.Contains(t, logged, `SELECT * FROM "reported_users"`)
+	assert.Contains(t, logged, "http_test.mailer (cached)")
+	assert.NotContains(t, logged, "Bearer abc")
+}
+
+func TestPanicReportIsRequestScoped(t *testing.T) {
+	router := factory()
+
+	output := &bytes.Buffer{}
+	router.Logger.Logger = log.New(output, "", 0)
+	router.Container.Instance(&mailer{}, "mailer")
+
+	router.GET("/mail").Action(func(m *mailer) string {
+		return "sent"
+	})
+	router.GET("/fail").Action(func() string {
+		panic("boom")
+	})
+
+	handler := router.Bootstrap()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/mail", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/fail", nil))
+
+	// Resolutions are not traced out of debug mode, nor shared between requests.
+	assert.Contains(t, output.String(), "Panic: boom")
+	assert.NotContains(t, output.String(), "Resolutions:")
+
+	output.Reset()
+	router.Config = &debugConfig{}
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/mail", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/fail", nil))
+
+	assert.Contains(t, output.String(), "Panic: boom")
+	assert.NotContains(t, output.String(), "mailer")
+}
+
+func TestPanicReportReadsLimitedBody(t *testing.T) {
+	router := factory()
+
+	var report *http.PanicReport
+	router.OnPanic(func(request *http.Request, err error, stack []byte) {
+		report = http.NewPanicReport(request, err, stack)
+	})
+
+	router.POST("/upload").Action(func() string {
+		panic("boom")
+	})
+
+	body := `{"password":"secret","data":"` + strings.Repeat("x", 100<<10) + `"}`
+	request := httptest.NewRequest("POST", "/upload", strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	router.Bootstrap().ServeHTTP(httptest.NewRecorder(), request)
+
+	assert.Equal(t, "[application/json, more than 65536 bytes]", report.Body)
+}
@@ -0,0 +1,129 @@
+package http
+
+import (
+	net_http "net/http"
+	"path"
+	"strings"
+
+	"github.com/lara-go/larago/http/responses"
+)
+
+// Destinations of preloaded assets by extension.
+var preloadDestinations = map[string]string{
+	".css":   "style",
+	".js":    "script",
+	".mjs":   "script",
+	".woff":  "font",
+	".woff2": "font",
+	".ttf":   "font",
+	".png":   "image",
+	".jpg":   "image",
+	".jpeg":  "image",
+	".gif":   "image",
+	".svg":   "image",
+	".webp":  "image",
+	".avif":  "image",
+}
+
+// PreloadLink makes Link header value for the asset path.
+// Values already in Link format are returned as is.
+func PreloadLink(link string) string {
+	if strings.HasPrefix(link, "<") {
+		return link
+	}
+
+	value := "<" + link + ">; rel=preload"
+
+	if as, ok := preloadDestinations[strings.ToLower(path.Ext(link))]; ok {
+		value += "; as=" + as
+
+		// Fonts are always fetched in anonymous mode.
+		if as == "font" {
+			value += "; crossorigin"
+		}
+	}
+
+	return value
+}
+
+// Send 103 Early Hints with preload links of the route.
+// Links stay in headers of the final response as well.
+func sendEarlyHints(route *Route, w net_http.ResponseWriter, req *net_http.Request) {
+	// Informational responses are not allowed for HTTP/1.0 clients.
+	if len(route.Preloads) == 0 || !req.ProtoAtLeast(1, 1) {
+		return
+	}
+
+	for _, link := range route.Preloads {
+		w.Header().Add("Link", PreloadLink(link))
+	}
+
+	// Other writers (FastCGI, CGI, captured responses) take any status as the final one,
+	// so they only get Link headers of the response.
+	if informational(w) {
+		w.WriteHeader(net_http.StatusEarlyHints)
+	}
+}
+
+// Check if writer sends 1xx statuses before the final one. Connections of net/http server
+// can be hijacked (HTTP/1.x) or pushed to (HTTP/2), it keeps them informational since Go 1.19.
+func informational(w net_http.ResponseWriter) bool {
+	switch w.(type) {
+	case net_http.Hijacker, net_http.Pusher:
+		return true
+	}
+
+	return false
+}
+
+// Push route preloads and resources from the Link header of the response over HTTP/2.
+func pushResources(request *Request, response responses.Response, w net_http.ResponseWriter) {
+	pusher, ok := w.(net_http.Pusher)
+	if !ok || request.BaseRequest().ProtoMajor < 2 {
+		return
+	}
+
+	targets := append([]string{}, request.Route.Preloads...)
+	for name, value := range response.Headers() {
+		if strings.EqualFold(name, "Link") {
+			targets = append(targets, PreloadTargets(value)...)
+		}
+	}
+
+	for _, target := range targets {
+		if strings.HasPrefix(target, "<") {
+			target = strings.Trim(strings.SplitN(target, ";", 2)[0], "<> ")
+		}
+
+		// Only resources of the same origin can be pushed.
+		if !strings.HasPrefix(target, "/") || strings.HasPrefix(target, "//") {
+			continue
+		}
+
+		if err := pusher.Push(target, nil); err != nil {
+			return
+		}
+	}
+}
+
+// PreloadTargets returns URLs of preload links from Link header value.
+func PreloadTargets(header string) []string {
+	var targets []string
+
+	for _, link := range strings.Split(header, ",") {
+		parts := strings.Split(link, ";")
+
+		preload := false
+		for _, param := range parts[1:] {
+			if strings.EqualFold(strings.TrimSpace(param), "rel=preload") {
+				preload = true
+			}
+		}
+
+		if preload {
+			targets = append(targets, strings.Trim(strings.TrimSpace(parts[0]), "<>"))
+		}
+	}
+
+	return targets
+}
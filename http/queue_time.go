@@ -0,0 +1,70 @@
+package http
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers set by load balancers with the time request was received.
+var queueStartHeaders = []string{"X-Request-Start", "X-Queue-Start"}
+
+// QueueTime returns how long request waited between the load balancer and the app.
+// Zero if upstream did not set X-Request-Start or X-Queue-Start header.
+func (r *Request) QueueTime() time.Duration {
+	for _, name := range queueStartHeaders {
+		start, ok := ParseQueueStart(r.Header(name))
+		if !ok {
+			continue
+		}
+
+		// Clocks of the balancer and the app may drift.
+		if queued := r.receivedAt.Sub(start); queued > 0 {
+			return queued
+		}
+
+		return 0
+	}
+
+	return 0
+}
+
+// ReceivedAt returns time request reached the app.
+func (r *Request) ReceivedAt() time.Time {
+	return r.receivedAt
+}
+
+// ParseQueueStart parses timestamp set by load balancer.
+// Supports "t=" prefix (nginx, New Relic), fractional seconds and integer seconds, milli-, micro- and nanoseconds,
+// told apart by magnitude.
+func ParseQueueStart(value string) (time.Time, bool) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "t=")
+	if value == "" {
+		return time.Time{}, false
+	}
+
+	if strings.Contains(value, ".") {
+		seconds, err := strconv.ParseFloat(value, 64)
+		if err != nil || seconds <= 0 {
+			return time.Time{}, false
+		}
+
+		return time.Unix(0, int64(seconds*float64(time.Second))), true
+	}
+
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || number <= 0 {
+		return time.Time{}, false
+	}
+
+	switch {
+	case number > 1e17:
+		return time.Unix(0, number), true
+	case number > 1e14:
+		return time.Unix(0, number*int64(time.Microsecond)), true
+	case number > 1e11:
+		return time.Unix(0, number*int64(time.Millisecond)), true
+	default:
+		return time.Unix(number, 0), true
+	}
+}
@@ -0,0 +1,66 @@
+package http_test
+
+import (
+	net_http "net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/lara-go/larago/http"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQueueStart(t *testing.T) {
+	expected := time.Unix(1500000000, 123000000)
+
+	cases := map[string]time.Time{
+		"t=1500000000.123":    expected,
+		"1500000000123":       expected,
+		"t=1500000000123000":  expected,
+		"1500000000123000000": expected,
+		"1500000000":          time.Unix(1500000000, 0),
+	}
+	for value, want := range cases {
+		parsed, ok := http.ParseQueueStart(value)
+		assert.True(t, ok, value)
+		assert.WithinDuration(t, want, parsed, time.Microsecond, value)
+	}
+
+	for _, value := range []string{"", "t=", "abc", "-5"} {
+		_, ok := http.ParseQueueStart(value)
+		assert.False(t, ok, value)
+	}
+}
+
+func TestQueueTime(t *testing.T) {
+	netRequest, _ := net_http.NewRequest("GET", "/", nil)
+	request := http.NewRequest(netRequest)
+	assert.Equal(t, time.Duration(0), request.QueueTime())
+
+	start := request.ReceivedAt().Add(-150 * time.Millisecond)
+	netRequest.Header.Set("X-Queue-Start", "t="+strconv.FormatInt(start.UnixNano()/int64(time.Microsecond), 10))
+	assert.InDelta(t, 150*time.Millisecond, request.QueueTime(), float64(time.Millisecond))
+
+	// Balancer clock is ahead.
+	netRequest.Header.Set("X-Request-Start", strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10))
+	assert.Equal(t, time.Duration(0), request.QueueTime())
+}
+
+func TestMetrics(t *testing.T) {
+	metrics := http.NewMetrics()
+
+	metrics.Observe(200, 10*time.Millisecond, 0)
+	metrics.Observe(500, 30*time.Millisecond, 40*time.Millisecond)
+	metrics.Observe(200, 20*time.Millisecond, 20*time.Millisecond)
+
+	snapshot := metrics.Snapshot()
+	assert.Equal(t, uint64(3), snapshot.Requests)
+	assert.Equal(t, uint64(1), snapshot.ServerErrors)
+	assert.Equal(t, 20*time.Millisecond, snapshot.AverageLatency)
+	assert.Equal(t, 30*time.Millisecond, snapshot.MaxLatency)
+	assert.Equal(t, 30*time.Millisecond, snapshot.AverageQueueTime)
+	assert.Equal(t, 40*time.Millisecond, snapshot.MaxQueueTime)
+
+	metrics.Reset()
+	assert.Equal(t, http.MetricsSnapshot{}, metrics.Snapshot())
+}
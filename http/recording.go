@@ -0,0 +1,254 @@
+package http
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	net_http "net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Max length of the request body kept in the recording. Larger bodies are not recorded.
+const recordingBodySize = 1 << 20
+
+// DefaultRecordingsLimit is the amount of the latest recordings kept in the store.
+const DefaultRecordingsLimit = 100
+
+// Recording of the handled request.
+type Recording struct {
+	ID       string              `json:"id"`
+	Time     time.Time           `json:"time"`
+	Method   string              `json:"method"`
+	URL      string              `json:"url"`
+	Host     string              `json:"host"`
+	Headers  map[string][]string `json:"headers"`
+	Body     []byte              `json:"body"`
+	BodySize int64               `json:"body_size,omitempty"`
+	Route    string              `json:"route"`
+	Status   int                 `json:"status"`
+	Duration time.Duration       `json:"duration"`
+}
+
+// NewRecording makes recording from request.
+// Secrets of headers, query and body are redacted, so such requests can not be fully replayed.
+func NewRecording(request *Request) *Recording {
+	id := make([]byte, 4)
+	rand.Read(id)
+
+	base := request.BaseRequest()
+	recording := &Recording{
+		ID:      fmt.Sprintf("%s-%s", time.Now().Format("20060102-150405"), hex.EncodeToString(id)),
+		Time:    time.Now(),
+		Method:  base.Method,
+		URL:     sanitizeRequestURI(base.RequestURI),
+		Host:    base.Host,
+		Headers: make(map[string][]string, len(base.Header)),
+	}
+
+	if request.Route != nil {
+		recording.Route = request.Route.Path
+	}
+
+	for name, values := range base.Header {
+		for _, value := range values {
+			recording.Headers[name] = append(recording.Headers[name], sanitizeValue(name, value))
+		}
+	}
+
+	if base.Body != nil {
+		recording.Body, recording.BodySize = recordingBody(request)
+	}
+
+	return recording
+}
+
+// Read sanitized body of the request. Body which is too large is not kept, only its size.
+func recordingBody(request *Request) ([]byte, int64) {
+	base := request.BaseRequest()
+
+	body, err := ioutil.ReadAll(io.LimitReader(base.Body, recordingBodySize+1))
+	base.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(body), base.Body))
+	if err != nil || len(body) == 0 {
+		return nil, 0
+	}
+
+	if len(body) > recordingBodySize {
+		if base.ContentLength > 0 {
+			return nil, base.ContentLength
+		}
+
+		return nil, int64(len(body))
+	}
+
+	sanitized, err := sanitizeBody(base.Header.Get("Content-Type"), body)
+	if err != nil {
+		return body, int64(len(body))
+	}
+
+	return sanitized, int64(len(body))
+}
+
+// Hide sensitive query params of the request URI.
+func sanitizeRequestURI(uri string) string {
+	parsed, err := url.ParseRequestURI(uri)
+	if err != nil || parsed.RawQuery == "" {
+		return uri
+	}
+
+	return sanitizeURL(parsed)
+}
+
+// ToRequest makes net/http request to replay the recording.
+func (r *Recording) ToRequest(baseURL string) (*net_http.Request, error) {
+	request, err := net_http.NewRequest(r.Method, strings.TrimRight(baseURL, "/")+r.URL, bytes.NewReader(r.Body))
+	if err != nil {
+		return nil, err
+	}
+
+	for name, values := range r.Headers {
+		for _, value := range values {
+			if value != redacted {
+				request.Header.Add(name, value)
+			}
+		}
+	}
+
+	if r.Host != "" {
+		request.Host = r.Host
+	}
+
+	return request, nil
+}
+
+// RecordingsStore keeps the latest recordings as JSON files in the directory.
+type RecordingsStore struct {
+	directory string
+	limit     int
+}
+
+// NewRecordingsStore constructor.
+func NewRecordingsStore(directory string) *RecordingsStore {
+	return &RecordingsStore{
+		directory: directory,
+		limit:     DefaultRecordingsLimit,
+	}
+}
+
+// Limit sets amount of the latest recordings to keep. Zero keeps all of them.
+func (s *RecordingsStore) Limit(limit int) *RecordingsStore {
+	s.limit = limit
+
+	return s
+}
+
+// Save recording, removing the oldest ones over the limit.
+func (s *RecordingsStore) Save(recording *Recording) error {
+	if err := os.MkdirAll(s.directory, 0755); err != nil {
+		return err
+	}
+
+	content, err := json.MarshalIndent(recording, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(s.file(recording.ID), content, 0600); err != nil {
+		return err
+	}
+
+	return s.rotate()
+}
+
+// Remove the oldest recordings over the limit. IDs start with the recording time, so names are sorted by it.
+func (s *RecordingsStore) rotate() error {
+	if s.limit <= 0 {
+		return nil
+	}
+
+	names, err := s.names()
+	if err != nil {
+		return err
+	}
+
+	sort.Strings(names)
+	for i := 0; i < len(names)-s.limit; i++ {
+		if err := os.Remove(s.file(names[i])); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Find recording by ID.
+func (s *RecordingsStore) Find(id string) (*Recording, error) {
+	content, err := ioutil.ReadFile(s.file(id))
+	if err != nil {
+		return nil, err
+	}
+
+	var recording Recording
+	if err := json.Unmarshal(content, &recording); err != nil {
+		return nil, err
+	}
+
+	return &recording, nil
+}
+
+// List recordings, most recent first.
+func (s *RecordingsStore) List() ([]*Recording, error) {
+	names, err := s.names()
+	if err != nil {
+		return nil, err
+	}
+
+	var recordings []*Recording
+	for _, name := range names {
+		recording, err := s.Find(name)
+		if err != nil {
+			return nil, err
+		}
+
+		recordings = append(recordings, recording)
+	}
+
+	sort.Slice(recordings, func(i, j int) bool {
+		return recordings[i].Time.After(recordings[j].Time)
+	})
+
+	return recordings, nil
+}
+
+// IDs of the saved recordings.
+func (s *RecordingsStore) names() ([]string, error) {
+	files, err := ioutil.ReadDir(s.directory)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, file := range files {
+		if !file.IsDir() && path.Ext(file.Name()) == ".json" {
+			names = append(names, strings.TrimSuffix(file.Name(), ".json"))
+		}
+	}
+
+	return names, nil
+}
+
+// Path to the recording file.
+func (s *RecordingsStore) file(id string) string {
+	return path.Join(s.directory, path.Base(id)+".json")
+}
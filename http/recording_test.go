@@ -0,0 +1,103 @@
+package http_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	net_http "net/http"
+	"os"
+	"testing"
+
+	"github.com/lara-go/larago/http"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordingsStore(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "recordings")
+	defer os.RemoveAll(dir)
+
+	netRequest, _ := net_http.NewRequest("POST", "http://example.com/users?active=1", bytes.NewBufferString(`{"name":"john"}`))
+	netRequest.RequestURI = "/users?active=1"
+	netRequest.Header.Set("Content-Type", "application/json")
+
+	request := http.NewRequest(netRequest)
+	recording := http.NewRecording(request)
+	recording.Status = 201
+
+	// Body must still be readable by handlers.
+	body, _ := ioutil.ReadAll(netRequest.Body)
+	assert.Equal(t, `{"name":"john"}`, string(body))
+
+	store := http.NewRecordingsStore(dir)
+	assert.NoError(t, store.Save(recording))
+
+	found, err := store.Find(recording.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "POST", found.Method)
+	assert.Equal(t, "/users?active=1", found.URL)
+	assert.Equal(t, 201, found.Status)
+
+	list, err := store.List()
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	replay, err := found.ToRequest("http://127.0.0.1:8080")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://127.0.0.1:8080/users?active=1", replay.URL.String())
+	assert.Equal(t, "application/json", replay.Header.Get("Content-Type"))
+	assert.Equal(t, "example.com", replay.Host)
+
+	replayed, _ := ioutil.ReadAll(replay.Body)
+	assert.Equal(t, `{"name":"john"}`, string(replayed))
+}
+
+func TestRecordingRedactsSecrets(t *testing.T) {
+	netRequest, _ := net_http.NewRequest("POST", "http://example.com/login?token=abc&next=/", bytes.NewBufferString(`{"email":"john@example.com","password":"secret"}`))
+	netRequest.RequestURI = "/login?token=abc&next=/"
+	netRequest.Header.Set("Content-Type", "application/json")
+	netRequest.Header.Set("Authorization", "Bearer abc")
+	netRequest.Header.Add("Cookie", "session=abc")
+
+	recording := http.NewRecording(http.NewRequest(netRequest))
+
+	assert.Equal(t, "/login?next=%2F&token=%5Bredacted%5D", recording.URL)
+	assert.Equal(t, []string{"[redacted]"}, recording.Headers["Authorization"])
+	assert.Equal(t, []string{"[redacted]"}, recording.Headers["Cookie"])
+	assert.JSONEq(t, `{"email":"john@example.com","password":"[redacted]"}`, string(recording.Body))
+
+	// Handlers still get the original body.
+	body, _ := ioutil.ReadAll(netRequest.Body)
+	assert.Equal(t, `{"email":"john@example.com","password":"secret"}`, string(body))
+
+	replay, err := recording.ToRequest("http://127.0.0.1:8080")
+	assert.NoError(t, err)
+	assert.Empty(t, replay.Header.Get("Authorization"))
+}
+
+func TestRecordingSkipsLargeBody(t *testing.T) {
+	large := bytes.Repeat([]byte("a"), 2<<20)
+	netRequest, _ := net_http.NewRequest("POST", "http://example.com/upload", bytes.NewReader(large))
+	netRequest.Header.Set("Content-Type", "application/octet-stream")
+
+	recording := http.NewRecording(http.NewRequest(netRequest))
+	assert.Nil(t, recording.Body)
+	assert.Equal(t, int64(len(large)), recording.BodySize)
+
+	body, _ := ioutil.ReadAll(netRequest.Body)
+	assert.Len(t, body, len(large))
+}
+
+func TestRecordingsStoreLimit(t *testing.T) {
+	store := http.NewRecordingsStore(t.TempDir()).Limit(2)
+
+	for i := 1; i <= 3; i++ {
+		assert.NoError(t, store.Save(&http.Recording{ID: fmt.Sprintf("20240101-00000%d-0000", i)}))
+	}
+
+	list, err := store.List()
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+
+	_, err = store.Find("20240101-000001-0000")
+	assert.Error(t, err)
+}
@@ -5,29 +5,95 @@ import (
 	"encoding/json"
 	"errors"
 	"io/ioutil"
+	"mime/multipart"
 	"net"
 	net_http "net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/gorilla/schema"
 	"github.com/julienschmidt/httprouter"
+	"github.com/lara-go/larago/container"
+	"github.com/lara-go/larago/database"
+	"github.com/lara-go/larago/support/filetype"
+	"github.com/lara-go/larago/support/useragent"
 )
 
+// MultipartMemory is amount of uploaded data kept in memory, the rest is stored in temporary files.
+var MultipartMemory int64 = 32 << 20
+
+// Request body up to this size is read into buffer preallocated by Content-Length.
+const preallocatedBodySize = 1 << 20
+
+// Request attributes filled by the geoip middleware.
+const (
+	CountryAttribute = "geoip.country"
+	CityAttribute    = "geoip.city"
+)
+
+// Request attribute with parsed User-Agent header.
+const userAgentAttribute = "useragent"
+
 // Request handles http request.
 type Request struct {
-	request  *net_http.Request
-	Route    *Route
-	Params   httprouter.Params
-	Bindings []interface{}
+	request    *net_http.Request
+	Route      *Route
+	Params     httprouter.Params
+	Bindings   []interface{}
+	attributes map[string]interface{}
+	receivedAt time.Time
+
+	// Persistent connections of the router.
+	connections *Connections
+
+	// Resolutions and queries of the request, reported on panic in debug mode.
+	trace   *container.Trace
+	queries *database.QueryLog
 }
 
 // NewRequest constructor.
 func NewRequest(netRequest *net_http.Request) *Request {
 	return &Request{
-		request:  netRequest,
-		Bindings: make([]interface{}, 0),
+		request:    netRequest,
+		Bindings:   make([]interface{}, 0),
+		receivedAt: time.Now(),
+	}
+}
+
+// SetAttribute attaches custom value to the request.
+func (r *Request) SetAttribute(key string, value interface{}) {
+	if r.attributes == nil {
+		r.attributes = make(map[string]interface{})
 	}
+
+	r.attributes[key] = value
+}
+
+// Attribute returns custom value attached to the request.
+func (r *Request) Attribute(key string) interface{} {
+	return r.attributes[key]
+}
+
+// HasAttribute checks if there is custom value attached to the request.
+func (r *Request) HasAttribute(key string) bool {
+	_, ok := r.attributes[key]
+
+	return ok
+}
+
+// Country returns ISO code of the client country resolved by the geoip middleware.
+func (r *Request) Country() string {
+	country, _ := r.Attribute(CountryAttribute).(string)
+
+	return country
+}
+
+// City returns name of the client city resolved by the geoip middleware.
+func (r *Request) City() string {
+	city, _ := r.Attribute(CityAttribute).(string)
+
+	return city
 }
 
 // BaseRequest returns base net/http request.
@@ -61,6 +127,18 @@ func (r *Request) URL() string {
 	return r.request.RequestURI
 }
 
+// UserAgent returns parsed User-Agent header.
+func (r *Request) UserAgent() *useragent.UserAgent {
+	if agent, ok := r.Attribute(userAgentAttribute).(*useragent.UserAgent); ok {
+		return agent
+	}
+
+	agent := useragent.Parse(r.Header("User-Agent"))
+	r.SetAttribute(userAgentAttribute, agent)
+
+	return agent
+}
+
 // Referer returns referer from header.
 func (r *Request) Referer() string {
 	return r.Header("Referer")
@@ -119,6 +197,11 @@ func (r *Request) WantsPlainText() bool {
 	return r.HeaderContains("accept", "text/plain")
 }
 
+// Accepts returns the offered content type client prefers, empty if none is acceptable.
+func (r *Request) Accepts(offers ...string) string {
+	return Negotiate(r.Header("Accept"), offers...)
+}
+
 // Cookie returns cookie value.
 func (r *Request) Cookie(name string) string {
 	cookie, err := r.request.Cookie(name)
@@ -163,6 +246,10 @@ func (r *Request) FormValues() url.Values {
 // Parse form values.
 func (r *Request) parseForm() error {
 	if r.request.Form == nil {
+		if r.HeaderContains("Content-Type", "multipart/form-data") {
+			return r.request.ParseMultipartForm(MultipartMemory)
+		}
+
 		if err := r.request.ParseForm(); err != nil {
 			return err
 		}
@@ -171,6 +258,29 @@ func (r *Request) parseForm() error {
 	return nil
 }
 
+// File returns uploaded file. Never trust its name and Content-Type, use FileType instead.
+func (r *Request) File(name string) (*multipart.FileHeader, error) {
+	if err := r.parseForm(); err != nil {
+		return nil, err
+	}
+
+	if r.request.MultipartForm == nil || len(r.request.MultipartForm.File[name]) == 0 {
+		return nil, net_http.ErrMissingFile
+	}
+
+	return r.request.MultipartForm.File[name][0], nil
+}
+
+// FileType detects type of the uploaded file by its content.
+func (r *Request) FileType(name string) (filetype.Type, error) {
+	file, err := r.File(name)
+	if err != nil {
+		return filetype.Unknown, err
+	}
+
+	return filetype.DetectUpload(file)
+}
+
 // ReadQuery unmarshal query to the structure.
 func (r *Request) ReadQuery(target interface{}) error {
 	return r.decodeValues(target, r.Query())
@@ -192,11 +302,11 @@ func (r *Request) ReadParams(target interface{}) error {
 	return r.decodeValues(target, r.ParamValues())
 }
 
-// Decode url.Values.
+// Decode url.Values. Keys in bracket notation are supported too.
 func (r *Request) decodeValues(target interface{}, values url.Values) error {
 	decoder := schema.NewDecoder()
 
-	if err := decoder.Decode(target, values); err != nil {
+	if err := decoder.Decode(target, NormalizeBrackets(values)); err != nil {
 		return err
 	}
 
@@ -218,20 +328,31 @@ func (r *Request) ReadJSON(target interface{}) error {
 	return nil
 }
 
+// Body returns raw request body. Body may be read again later.
+func (r *Request) Body() ([]byte, error) {
+	return r.readBody()
+}
+
 // Read raw body.
 func (r *Request) readBody() ([]byte, error) {
 	if r.request.Body == nil {
 		return nil, errors.New("Body was empty")
 	}
 
+	// Body is kept to be read again, so it gets its own buffer allocated once when the size is known.
+	var buffer bytes.Buffer
+	if size := r.request.ContentLength; size > 0 && size <= preallocatedBodySize {
+		buffer.Grow(int(size) + bytes.MinRead)
+	}
+
 	// Read raw body from request.
-	rawBody, err := ioutil.ReadAll(r.request.Body)
-	if err != nil {
+	if _, err := buffer.ReadFrom(r.request.Body); err != nil {
 		return nil, err
 	}
+	rawBody := buffer.Bytes()
 
 	// Return parsed body back to base request.
-	r.request.Body = ioutil.NopCloser(bytes.NewBuffer(rawBody))
+	r.request.Body = ioutil.NopCloser(bytes.NewReader(rawBody))
 
 	return rawBody, nil
 }
@@ -1,6 +1,9 @@
 package responses
 
-import net_http "net/http"
+import (
+	net_http "net/http"
+	"strings"
+)
 
 // AbstractResponse handles response.
 type AbstractResponse struct {
@@ -8,9 +11,16 @@ type AbstractResponse struct {
 
 	status  int
 	headers map[string]string
+	values  net_http.Header
 	cookies []*net_http.Cookie
 }
 
+// MultiValueHeaders is implemented by responses which headers may have several values.
+type MultiValueHeaders interface {
+	// HeaderValues returns all the headers to send.
+	HeaderValues() net_http.Header
+}
+
 // Status returns HTTP status.
 func (r *AbstractResponse) Status() int {
 	return r.status
@@ -30,9 +40,45 @@ func (r *AbstractResponse) SetHeader(name, value string) {
 	r.headers[name] = value
 }
 
+// AddHeader attaches one more value of the header, ex. one more Link.
+func (r *AbstractResponse) AddHeader(name, value string) {
+	if r.values == nil {
+		r.values = make(net_http.Header)
+	}
+
+	r.values.Add(name, value)
+}
+
 // Headers returns set of additional headers to send.
+// Several values of the header are joined with comma, use HeaderValues to get them apart.
 func (r *AbstractResponse) Headers() map[string]string {
-	return r.headers
+	if len(r.values) == 0 {
+		return r.headers
+	}
+
+	headers := make(map[string]string, len(r.headers)+len(r.values))
+	for name, values := range r.values {
+		headers[name] = strings.Join(values, ", ")
+	}
+	for name, value := range r.headers {
+		headers[name] = value
+	}
+
+	return headers
+}
+
+// HeaderValues returns additional headers to send with all their values.
+// Header set with SetHeader replaces added values.
+func (r *AbstractResponse) HeaderValues() net_http.Header {
+	header := make(net_http.Header, len(r.headers)+len(r.values))
+	for name, values := range r.values {
+		header[name] = append([]string(nil), values...)
+	}
+	for name, value := range r.headers {
+		header.Set(name, value)
+	}
+
+	return header
 }
 
 // SetCookies attaches cookies to response.
@@ -50,6 +96,30 @@ func (r *AbstractResponse) ContentType() string {
 	return "text/plain"
 }
 
+// ContentTypeHeader makes Content-Type header value, adding charset to text types.
+func ContentTypeHeader(contentType string) string {
+	if strings.Contains(contentType, "charset=") || !isText(contentType) {
+		return contentType
+	}
+
+	return contentType + "; charset=utf-8"
+}
+
+// Check if media type is a text.
+func isText(contentType string) bool {
+	if strings.HasPrefix(contentType, "text/") {
+		return true
+	}
+
+	for _, suffix := range []string{"json", "xml", "javascript", "x-www-form-urlencoded"} {
+		if strings.HasSuffix(contentType, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Body returns content.
 func (r *AbstractResponse) Body() []byte {
 	return []byte("")
@@ -0,0 +1,140 @@
+package responses
+
+import (
+	"bytes"
+	"mime"
+	net_http "net/http"
+	"strings"
+)
+
+// Captured response records output of net/http handler.
+// It implements http.ResponseWriter, so it can be passed to any standard handler.
+type Captured struct {
+	AbstractResponse
+
+	header      net_http.Header
+	body        bytes.Buffer
+	wroteHeader bool
+}
+
+// NewCaptured makes empty captured response with 200 status.
+func NewCaptured() *Captured {
+	response := &Captured{
+		header: make(net_http.Header),
+	}
+	response.SetStatus(net_http.StatusOK)
+
+	return response
+}
+
+// Header returns headers set by handler.
+func (r *Captured) Header() net_http.Header {
+	return r.header
+}
+
+// Write appends data to the body.
+func (r *Captured) Write(data []byte) (int, error) {
+	if !r.wroteHeader {
+		r.WriteHeader(net_http.StatusOK)
+	}
+
+	return r.body.Write(data)
+}
+
+// WriteHeader records status. Only the first call takes effect like in net/http.
+func (r *Captured) WriteHeader(status int) {
+	if r.wroteHeader {
+		return
+	}
+
+	r.wroteHeader = true
+	r.SetStatus(status)
+}
+
+// Written checks if handler sent status or body.
+func (r *Captured) Written() bool {
+	return r.wroteHeader
+}
+
+// WithStatus sets HTTP status.
+func (r *Captured) WithStatus(status int) Response {
+	r.SetStatus(status)
+
+	return r
+}
+
+// WithHeader attaches header to response.
+func (r *Captured) WithHeader(name, value string) Response {
+	r.SetHeader(name, value)
+
+	return r
+}
+
+// WithCookies attaches cookies to response.
+func (r *Captured) WithCookies(cookie ...*net_http.Cookie) Response {
+	r.SetCookies(cookie)
+
+	return r
+}
+
+// ContentType returns media type set by handler or sniffed from the body.
+func (r *Captured) ContentType() string {
+	contentType := r.header.Get("Content-Type")
+	if contentType == "" {
+		contentType = net_http.DetectContentType(r.body.Bytes())
+	}
+
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mediaType
+	}
+
+	return contentType
+}
+
+// Headers returns headers set by handler merged with the attached ones.
+// Multiple values of the same header are joined with comma.
+func (r *Captured) Headers() map[string]string {
+	headers := make(map[string]string, len(r.header))
+
+	for name, values := range r.header {
+		if name == "Set-Cookie" {
+			continue
+		}
+
+		headers[name] = strings.Join(values, ", ")
+	}
+
+	for name, value := range r.AbstractResponse.Headers() {
+		headers[name] = value
+	}
+
+	return headers
+}
+
+// HeaderValues returns headers set by handler with all their values, merged with the attached ones.
+func (r *Captured) HeaderValues() net_http.Header {
+	header := make(net_http.Header, len(r.header))
+	for name, values := range r.header {
+		if name != "Set-Cookie" {
+			header[name] = append([]string(nil), values...)
+		}
+	}
+
+	for name, values := range r.AbstractResponse.HeaderValues() {
+		header[name] = values
+	}
+
+	return header
+}
+
+// Cookies returns cookies set by handler and the attached ones.
+func (r *Captured) Cookies() []*net_http.Cookie {
+	cookies := (&net_http.Response{Header: r.header}).Cookies()
+
+	return append(cookies, r.AbstractResponse.Cookies()...)
+}
+
+// Body returns content.
+func (r *Captured) Body() []byte {
+	return r.body.Bytes()
+}
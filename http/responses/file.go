@@ -0,0 +1,71 @@
+package responses
+
+import net_http "net/http"
+
+// File response with binary content, ex. generated report or thumbnail.
+type File struct {
+	AbstractResponse
+
+	content     []byte
+	contentType string
+}
+
+// NewFile sends content of the given type.
+func NewFile(status int, content []byte, contentType string) *File {
+	response := &File{
+		content:     content,
+		contentType: contentType,
+	}
+	response.SetStatus(status)
+
+	return response
+}
+
+// Inline shows file in browser.
+func (r *File) Inline(filename string) *File {
+	r.SetHeader("Content-Disposition", disposition("inline", filename))
+
+	return r
+}
+
+// Download forces browser to download file.
+func (r *File) Download(filename string) *File {
+	r.SetHeader("Content-Disposition", disposition("attachment", filename))
+
+	return r
+}
+
+// WithStatus sets HTTP status.
+func (r *File) WithStatus(status int) Response {
+	r.SetStatus(status)
+
+	return r
+}
+
+// WithHeader attaches header to response.
+func (r *File) WithHeader(name, value string) Response {
+	r.SetHeader(name, value)
+
+	return r
+}
+
+// WithCookies attaches cookies to response.
+func (r *File) WithCookies(cookie ...*net_http.Cookie) Response {
+	r.SetCookies(cookie)
+
+	return r
+}
+
+// ContentType returns Content-Type header.
+func (r *File) ContentType() string {
+	if r.contentType == "" {
+		return "application/octet-stream"
+	}
+
+	return r.contentType
+}
+
+// Body returns content.
+func (r *File) Body() []byte {
+	return r.content
+}
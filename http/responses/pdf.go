@@ -0,0 +1,80 @@
+package responses
+
+import (
+	"fmt"
+	net_http "net/http"
+	"strings"
+)
+
+// PDF response.
+type PDF struct {
+	AbstractResponse
+
+	content []byte
+}
+
+// NewPDF sends PDF document displayed inline in browser.
+func NewPDF(status int, content []byte, filename string) *PDF {
+	response := &PDF{
+		content: content,
+	}
+	response.SetStatus(status)
+
+	return response.Inline(filename)
+}
+
+// Inline shows document in browser.
+func (r *PDF) Inline(filename string) *PDF {
+	r.SetHeader("Content-Disposition", disposition("inline", filename))
+
+	return r
+}
+
+// Download forces browser to download document.
+func (r *PDF) Download(filename string) *PDF {
+	r.SetHeader("Content-Disposition", disposition("attachment", filename))
+
+	return r
+}
+
+// WithStatus sets HTTP status.
+func (r *PDF) WithStatus(status int) Response {
+	r.SetStatus(status)
+
+	return r
+}
+
+// WithHeader attaches header to response.
+func (r *PDF) WithHeader(name, value string) Response {
+	r.SetHeader(name, value)
+
+	return r
+}
+
+// WithCookies attaches cookies to response.
+func (r *PDF) WithCookies(cookie ...*net_http.Cookie) Response {
+	r.SetCookies(cookie)
+
+	return r
+}
+
+// ContentType returns Content-Type header.
+func (r *PDF) ContentType() string {
+	return "application/pdf"
+}
+
+// Body returns content.
+func (r *PDF) Body() []byte {
+	return r.content
+}
+
+// Make Content-Disposition header value.
+func disposition(kind, filename string) string {
+	if filename == "" {
+		return kind
+	}
+
+	filename = strings.NewReplacer(`"`, "", "\r", "", "\n", "").Replace(filename)
+
+	return fmt.Sprintf(`%s; filename="%s"`, kind, filename)
+}
@@ -76,6 +76,11 @@ func (r *Redirect) GetRoute() string {
 	return r.route
 }
 
+// GetParams returns params of the route to redirect to.
+func (r *Redirect) GetParams() map[string]string {
+	return r.params
+}
+
 // GetLocation formats location to redirect to and returns it.
 func (r *Redirect) GetLocation() string {
 	if !strings.Contains(r.location, ":") {
@@ -0,0 +1,104 @@
+package responses
+
+import (
+	"bytes"
+	"io"
+	net_http "net/http"
+)
+
+// Stream response writes body progressively, flushing chunks to the client as they are ready.
+type Stream struct {
+	AbstractResponse
+
+	contentType string
+	write       func(stream *Stream) error
+
+	writer  io.Writer
+	flusher net_http.Flusher
+	err     error
+}
+
+// NewStream makes response writing body with the callback.
+func NewStream(status int, write func(stream *Stream) error) *Stream {
+	response := &Stream{
+		contentType: "text/html",
+		write:       write,
+	}
+	response.SetStatus(status)
+
+	return response
+}
+
+// WithStatus sets HTTP status.
+func (r *Stream) WithStatus(status int) Response {
+	r.SetStatus(status)
+
+	return r
+}
+
+// WithHeader attaches header to response.
+func (r *Stream) WithHeader(name, value string) Response {
+	r.SetHeader(name, value)
+
+	return r
+}
+
+// WithCookies attaches cookies to response.
+func (r *Stream) WithCookies(cookie ...*net_http.Cookie) Response {
+	r.SetCookies(cookie)
+
+	return r
+}
+
+// WithContentType sets Content-Type of the stream, text/html by default.
+func (r *Stream) WithContentType(contentType string) *Stream {
+	r.contentType = contentType
+
+	return r
+}
+
+// ContentType returns Content-Type header.
+func (r *Stream) ContentType() string {
+	return r.contentType
+}
+
+// Write chunk of the body.
+func (r *Stream) Write(data []byte) (int, error) {
+	return r.writer.Write(data)
+}
+
+// WriteString writes chunk of the body.
+func (r *Stream) WriteString(data string) (int, error) {
+	return io.WriteString(r.writer, data)
+}
+
+// Flush sends buffered chunks to the client.
+func (r *Stream) Flush() {
+	if r.flusher != nil {
+		r.flusher.Flush()
+	}
+}
+
+// Send runs the callback writing body to the client.
+// Headers and status have to be sent before.
+func (r *Stream) Send(w io.Writer) error {
+	r.writer = w
+	r.flusher, _ = w.(net_http.Flusher)
+
+	r.err = r.write(r)
+
+	return r.err
+}
+
+// Err returns error returned by the callback.
+func (r *Stream) Err() error {
+	return r.err
+}
+
+// Body runs the callback collecting the whole body, for cases when it can't be streamed.
+func (r *Stream) Body() []byte {
+	var buffer bytes.Buffer
+	r.Send(&buffer)
+
+	return buffer.Bytes()
+}
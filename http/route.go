@@ -10,6 +10,11 @@ type Route struct {
 	Middlewares []Middleware
 	Handler     interface{}
 	ToValidate  []validation.SelfValidator
+	Cost        int
+	Preloads    []string
+	Push        bool
+	Abilities   []string
+	Roles       []string
 }
 
 // NewRoute constructor.
@@ -41,6 +46,30 @@ func (r *Route) Action(handler interface{}) *Route {
 	return r
 }
 
+// WithCost sets amount of rate limiter tokens the route consumes.
+func (r *Route) WithCost(cost int) *Route {
+	r.Cost = cost
+
+	return r
+}
+
+// Preload sends links with 103 Early Hints before the action runs.
+// Accepts paths (ex. /css/app.css) or complete Link header values.
+// Early Hints require Go 1.19 or newer, older net/http takes them as the final status.
+func (r *Route) Preload(links ...string) *Route {
+	r.Preloads = append(r.Preloads, links...)
+
+	return r
+}
+
+// PushResources pushes preloaded resources over HTTP/2.
+// Clients not supporting push still get them as Link preload headers.
+func (r *Route) PushResources() *Route {
+	r.Push = true
+
+	return r
+}
+
 // Validate request.
 func (r *Route) Validate(requests ...validation.SelfValidator) *Route {
 	r.ToValidate = requests
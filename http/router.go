@@ -1,12 +1,19 @@
 package http
 
 import (
+	"context"
 	"fmt"
+	"io/fs"
+	"net"
 	net_http "net/http"
+	"runtime/debug"
+	"strings"
+	"sync"
 
 	"github.com/asaskevich/EventBus"
 	"github.com/lara-go/larago"
 	"github.com/lara-go/larago/container"
+	"github.com/lara-go/larago/database"
 	"github.com/lara-go/larago/http/errors"
 	"github.com/lara-go/larago/http/responses"
 	"github.com/lara-go/larago/logger"
@@ -43,6 +50,26 @@ type Router struct {
 
 	// Action handler's arguments injectors.
 	argsInjectors []ArgsInjector
+
+	// Options of the net/http server.
+	serverOptions *ServerOptions
+
+	// Running net/http server, guarded as Shutdown is called from another goroutine.
+	server     *net_http.Server
+	serverLock sync.Mutex
+	shutdown   bool
+
+	// Map of path:file system with static assets.
+	statics map[string]fs.FS
+
+	// Request lifecycle hooks.
+	hooks Hooks
+
+	// Checks abilities and roles required by routes.
+	authorizer Authorizer
+
+	// Persistent connections to drain on shutdown.
+	connections *Connections
 }
 
 // NewRouter constructor.
@@ -50,10 +77,13 @@ func NewRouter() *Router {
 	router := &Router{
 		aliases:     make(map[string]*Route),
 		bindings:    make(map[string]BindingCallback),
+		statics:     make(map[string]fs.FS),
 		groupsStack: make([]*GroupRoute, 0),
 		argsInjectors: []ArgsInjector{
 			&RouteParamsInjector{},
 		},
+		serverOptions: DefaultServerOptions(),
+		connections:   NewConnections(),
 	}
 
 	router.router = httprouter.New()
@@ -130,17 +160,64 @@ func (r *Router) Middleware(middleware ...Middleware) *Router {
 	return r
 }
 
+// MiddlewareIn sets global middleware to run only in the listed environments.
+// Environment is checked on the first request, when config is surely loaded.
+func (r *Router) MiddlewareIn(environments []string, middleware ...Middleware) *Router {
+	for _, m := range middleware {
+		r.middleware = append(r.middleware, &environmentMiddleware{Middleware: m, environments: environments})
+	}
+
+	return r
+}
+
+// Global middleware running only in some environments.
+type environmentMiddleware struct {
+	Middleware
+	environments []string
+}
+
+// Leave middleware of the current environment only.
+func (r *Router) environmentMiddleware(middleware []Middleware) []Middleware {
+	environment := larago.EnvironmentFromVariables()
+	if r.Config != nil {
+		environment = r.Config.Env()
+	}
+
+	active := make([]Middleware, 0, len(middleware))
+	for _, m := range middleware {
+		scoped, ok := m.(*environmentMiddleware)
+		if !ok {
+			active = append(active, m)
+		} else if larago.InEnvironment(environment, scoped.environments...) {
+			active = append(active, scoped.Middleware)
+		}
+	}
+
+	return active
+}
+
 // Bind route param to the specified callback return value.
 func (r *Router) Bind(param string, callback BindingCallback) {
 	r.bindings[param] = callback
 }
 
+// Static serves files from file system (ex. embed.FS) under the path.
+func (r *Router) Static(path string, fsys fs.FS) *Router {
+	r.statics[strings.TrimSuffix(path, "/")] = fsys
+
+	return r
+}
+
 // Bootstrap router to be ready to handle requests.
 func (r *Router) Bootstrap() *Router {
 	for _, route := range r.routes {
 		r.setHTTPRoute(route)
 	}
 
+	for path, fsys := range r.statics {
+		r.router.ServeFiles(path+"/*filepath", net_http.FS(fsys))
+	}
+
 	return r
 }
 
@@ -155,7 +232,96 @@ func (r *Router) Listen(listen string) error {
 		r.Config.Debug(),
 	)
 
-	return net_http.ListenAndServe(listen, r.router)
+	listener, err := net.Listen("tcp", listen)
+	if err != nil {
+		return err
+	}
+
+	return r.Serve(listener)
+}
+
+// Serve requests accepted by listener.
+// Do not forget to run Bootstrap in order to prepare and set routes.
+func (r *Router) Serve(listener net.Listener) error {
+	if r.serverOptions.MaxConnections > 0 {
+		listener = LimitListener(listener, r.serverOptions.MaxConnections)
+	}
+
+	r.serverLock.Lock()
+	if r.shutdown {
+		r.serverLock.Unlock()
+		listener.Close()
+
+		return net_http.ErrServerClosed
+	}
+	server := r.makeServer()
+	r.server = server
+	r.serverLock.Unlock()
+
+	return server.Serve(listener)
+}
+
+// Shutdown gracefully stops running server.
+// Persistent connections are drained first, as server waits for them as for any other active request.
+// Server started after shutdown is closed right away.
+func (r *Router) Shutdown(ctx context.Context) error {
+	r.serverLock.Lock()
+	r.shutdown = true
+	server := r.server
+	r.serverLock.Unlock()
+
+	if server == nil {
+		return nil
+	}
+
+	if err := r.connections.Drain(ctx); err != nil {
+		r.Logger.Warning("%d connections were not drained in time.", r.connections.Count())
+	}
+
+	return server.Shutdown(ctx)
+}
+
+// Connections returns persistent connections drained on shutdown.
+func (r *Router) Connections() *Connections {
+	return r.connections
+}
+
+// Make net/http server with configured limits.
+func (r *Router) makeServer() *net_http.Server {
+	return &net_http.Server{
+		Handler:           r.Handler(),
+		ReadTimeout:       r.serverOptions.ReadTimeout,
+		ReadHeaderTimeout: r.serverOptions.ReadHeaderTimeout,
+		WriteTimeout:      r.serverOptions.WriteTimeout,
+		IdleTimeout:       r.serverOptions.IdleTimeout,
+		MaxHeaderBytes:    r.serverOptions.MaxHeaderBytes,
+	}
+}
+
+// SetServerOptions sets net/http server limits.
+func (r *Router) SetServerOptions(options *ServerOptions) *Router {
+	r.serverOptions = options
+	r.connections.SetGrace(options.DrainGrace)
+
+	return r
+}
+
+// GetServerOptions returns net/http server limits.
+func (r *Router) GetServerOptions() *ServerOptions {
+	return r.serverOptions
+}
+
+// Handler returns net/http handler firing request received hooks before routing.
+func (r *Router) Handler() net_http.Handler {
+	return r
+}
+
+// ServeHTTP implements net/http handler, so router can be embedded into existing servers.
+// Do not forget to run Bootstrap in order to prepare and set routes.
+func (r *Router) ServeHTTP(w net_http.ResponseWriter, req *net_http.Request) {
+	r.hooks.fireRequestReceived(req)
+
+	r.router.ServeHTTP(w, req)
 }
 
 // Set route to httprouter.
@@ -184,23 +350,47 @@ func (r *Router) setHTTPRoute(route *Route) {
 func (r *Router) wrapHandlers(route *Route) httprouter.Handle {
 	// Merge global middleware with route ones.
 	middleware := append(r.middleware, route.Middlewares...)
+	var environmentOnce sync.Once
 
 	// Return httprouter handler.
 	return func(w net_http.ResponseWriter, req *net_http.Request, ps httprouter.Params) {
+		environmentOnce.Do(func() {
+			middleware = r.environmentMiddleware(middleware)
+		})
+
+		// Requests and responses are not pooled: the container, event subscribers
+		// and hooks may keep them after the request is handled.
 		request := NewRequest(req)
 		request.Route = route
 		request.Params = ps
+		request.connections = r.connections
+
+		if r.Config != nil && r.Config.Debug() {
+			request.trace = container.NewTrace(panicTraceSize)
+			request.queries = database.NewQueryLog(panicTraceSize)
+		}
 
 		// Handle panics during pipeline.
 		defer r.panicHandler(w, request)
 
+		r.hooks.fireRouteMatched(request)
+
+		// New persistent connections would only delay the shutdown.
+		if r.connections.Draining() && isPersistent(request) {
+			r.send(r.formatErrorResponse(request, drainingError(r.connections.Grace())), request, w)
+			return
+		}
+
+		// Let the browser start fetching assets while the action works.
+		sendEarlyHints(route, w, req)
+
 		// Save request to container.
 		r.Container.Instance(request)
 
 		// Run request through all middleware chaining one by one.
 		// then dispatch action handler itself, obtain response
 		// and lift it back.
-		response := NewPipeline(r.Container).
+		response := NewPipeline(r.requestContainer(request)).
 			Send(request).
 			Through(middleware).
 			Then(r.dispatchRequest)
@@ -219,6 +409,14 @@ func (r *Router) panicHandler(w net_http.ResponseWriter, request *Request) {
 			err = fmt.Errorf("%s", re)
 		}
 
+		stack := debug.Stack()
+		r.hooks.firePanic(request, err, stack)
+
+		// Attach structured report to the errors which are going to be reported.
+		if httpErr, ok := err.(*errors.HTTPError); !ok || httpErr.WantsToBeReported() {
+			err = &PanicError{Err: err, Report: r.panicReport(request, err, stack)}
+		}
+
 		r.send(r.formatErrorResponse(request, err), request, w)
 	}
 }
@@ -240,7 +438,12 @@ func (r *Router) substituteBindings(request *Request) {
 // Final pipeline callback.
 // Dispatches route handler and returns Response.
 func (r *Router) dispatchRequest(request *Request) responses.Response {
-	action := r.Container.Wrap(request.Route.Handler)
+	// Check abilities and roles declared on the route.
+	if err := r.authorize(request); err != nil {
+		return r.formatErrorResponse(request, err)
+	}
+
+	action := r.requestContainer(request).Wrap(request.Route.Handler)
 
 	// Substitute bindings.
 	r.substituteBindings(request)
@@ -274,13 +477,13 @@ func (r *Router) dispatchRequest(request *Request) responses.Response {
 func (r *Router) formatResponse(request *Request, result interface{}) responses.Response {
 	switch v := result.(type) {
 	case string:
-		return responses.NewText(200, fmt.Sprintf("%s", v))
+		return responses.NewText(200, "%s", v)
 	case int:
-		return responses.NewText(200, fmt.Sprintf("%d", v))
+		return responses.NewText(200, "%d", v)
 	case float64:
-		return responses.NewText(200, fmt.Sprintf("%e", v))
+		return responses.NewText(200, "%e", v)
 	case bool:
-		return responses.NewText(200, fmt.Sprintf("%t", v))
+		return responses.NewText(200, "%t", v)
 	case error:
 		return r.formatErrorResponse(request, v)
 	default:
@@ -304,6 +507,8 @@ func (r *Router) send(response responses.Response, request *Request, w net_http.
 	default:
 		r.sendResponse(resp, request, w)
 	}
+
+	r.hooks.fireResponseSent(request, response)
 }
 
 // Send redirect response via native http.Redirect.
@@ -324,24 +529,16 @@ func (r *Router) sendResponse(response responses.Response, request *Request, w n
 		r.Events.Publish("router:request-handled", request, response)
 	}
 
-	// Send content type.
-	w.Header().Set("content-type", response.ContentType()+"; charset=utf-8")
-
-	// Send additional headers.
-	for name, value := range response.Headers() {
-		w.Header().Set(name, value)
+	if request.Route != nil && request.Route.Push {
+		pushResources(request, response, w)
 	}
 
-	// Send cookies.
-	for _, cookie := range response.Cookies() {
-		net_http.SetCookie(w, cookie)
-	}
+	WriteResponse(w, response)
 
-	// Send status.
-	w.WriteHeader(response.Status())
-
-	// Send body.
-	w.Write(response.Body())
+	// Headers are already sent, so stream errors can only be reported.
+	if stream, ok := response.(*responses.Stream); ok && stream.Err() != nil {
+		r.ErrorsHandler.Report(stream.Err())
+	}
 }
 
 // GetHTTPRouter returns httprouter instance.
@@ -354,9 +551,9 @@ func (r *Router) GetRoutes() []*Route {
 	return r.routes
 }
 
-// GetMiddleware returns all glbally registered middleware.
+// GetMiddleware returns all glbally registered middleware of the current environment.
 func (r *Router) GetMiddleware() []Middleware {
-	return r.middleware
+	return r.environmentMiddleware(r.middleware)
 }
 
 // SetArgsInjectors sets additional components that can inject more custom arguments to route action handler.
@@ -386,12 +583,12 @@ func (r *Router) SetMethodNotAllowedHandler(handler net_http.HandlerFunc) *Route
 func (r *Router) handleNotFound(w net_http.ResponseWriter, req *net_http.Request) {
 	request := NewRequest(req)
 
-	r.sendResponse(r.ErrorsHandler.Render(request, errors.NotFoundHTTPError()), request, w)
+	r.send(r.ErrorsHandler.Render(request, errors.NotFoundHTTPError()), request, w)
 }
 
 // Custom handler for MethodNotAllowed errors.
 func (r *Router) handleMethodNotAllowed(w net_http.ResponseWriter, req *net_http.Request) {
 	request := NewRequest(req)
 
-	r.sendResponse(r.ErrorsHandler.Render(request, errors.MethodNotAllowedHTTPError()), request, w)
+	r.send(r.ErrorsHandler.Render(request, errors.MethodNotAllowedHTTPError()), request, w)
 }
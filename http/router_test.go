@@ -5,6 +5,7 @@ import (
 	"io/ioutil"
 	"log"
 	net_http "net/http"
+	"os"
 	"testing"
 
 	ozzo "github.com/go-ozzo/ozzo-validation"
@@ -17,6 +18,7 @@ import (
 	"github.com/lara-go/larago/logger"
 	"github.com/lara-go/larago/support/testsuite"
 	"github.com/lara-go/larago/validation"
+	"github.com/stretchr/testify/assert"
 )
 
 func factory() *http.Router {
@@ -311,6 +313,25 @@ func TestGroupsAndMiddleware(t *testing.T) {
 	e.GET("/group1/group2/path2").Expect().Status(200).Body().Equal("Group2 path First Zero")
 }
 
+func TestMiddlewareIn(t *testing.T) {
+	router := factory()
+
+	router.MiddlewareIn([]string{"local", "testing"}, &FirstMiddleware{})
+	router.MiddlewareIn([]string{"production"}, &SecondMiddleware{})
+
+	router.GET("/").Action(func() string {
+		return "Index"
+	})
+
+	// Environment is checked on the first request, after .env was loaded.
+	os.Setenv(larago.EnvVariable, "local")
+	defer os.Unsetenv(larago.EnvVariable)
+
+	e := testsuite.NewHTTPExpect(router.Bootstrap().GetHTTPRouter(), t)
+	e.GET("/").Expect().Status(200).Body().Equal("Index First")
+	assert.Len(t, router.GetMiddleware(), 1)
+}
+
 func TestBindings(t *testing.T) {
 	router := factory()
 
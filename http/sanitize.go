@@ -0,0 +1,102 @@
+package http
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+// Value placed instead of the secrets.
+const redacted = "[redacted]"
+
+// Names of the fields and headers which values are never kept.
+var sensitiveNames = []string{
+	"password", "passwd", "secret", "token", "api_key", "apikey",
+	"authorization", "cookie", "credit_card", "card_number", "cvv",
+}
+
+// Check if field or header holds a secret.
+func isSensitive(name string) bool {
+	name = strings.ToLower(strings.Replace(name, "-", "_", -1))
+	for _, sensitive := range sensitiveNames {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Hide value of the sensitive field.
+func sanitizeValue(name, value string) string {
+	if isSensitive(name) {
+		return redacted
+	}
+
+	return value
+}
+
+// Hide sensitive query params.
+func sanitizeURL(u *url.URL) string {
+	sanitized := *u
+	sanitized.RawQuery = sanitizeValues(u.Query()).Encode()
+	if sanitized.User != nil {
+		sanitized.User = url.User(sanitized.User.Username())
+	}
+
+	return sanitized.String()
+}
+
+// Hide sensitive form values.
+func sanitizeValues(values url.Values) url.Values {
+	sanitized := make(url.Values, len(values))
+	for name, list := range values {
+		for _, value := range list {
+			sanitized.Add(name, sanitizeValue(name, value))
+		}
+	}
+
+	return sanitized
+}
+
+// Hide sensitive JSON fields recursively.
+func sanitizeJSON(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if isSensitive(key) {
+				v[key] = redacted
+			} else {
+				v[key] = sanitizeJSON(item)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = sanitizeJSON(item)
+		}
+	}
+
+	return value
+}
+
+// Hide secrets of JSON and form bodies. Other bodies are returned as is.
+func sanitizeBody(contentType string, body []byte) ([]byte, error) {
+	switch {
+	case strings.Contains(contentType, "json"):
+		var payload interface{}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, err
+		}
+
+		return json.Marshal(sanitizeJSON(payload))
+	case strings.Contains(contentType, "application/x-www-form-urlencoded"):
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, err
+		}
+
+		return []byte(sanitizeValues(values).Encode()), nil
+	default:
+		return body, nil
+	}
+}
@@ -0,0 +1,85 @@
+package http
+
+import (
+	"time"
+
+	"github.com/lara-go/larago"
+)
+
+// ServerOptions configures underlying net/http server.
+// Zero values disable appropriate limit.
+type ServerOptions struct {
+	// ReadTimeout is the maximum duration for reading the entire request, including the body.
+	ReadTimeout time.Duration
+
+	// ReadHeaderTimeout is the amount of time allowed to read request headers.
+	// Protects against slowloris clients sending headers byte by byte.
+	ReadHeaderTimeout time.Duration
+
+	// WriteTimeout is the maximum duration before timing out writes of the response.
+	WriteTimeout time.Duration
+
+	// IdleTimeout is the maximum amount of time to wait for the next request when keep-alives are enabled.
+	IdleTimeout time.Duration
+
+	// MaxHeaderBytes controls the maximum number of bytes the server will read parsing the request headers.
+	MaxHeaderBytes int
+
+	// MaxConnections limits number of simultaneously accepted connections.
+	MaxConnections int
+
+	// DrainGrace is time SSE and WebSocket clients get to reconnect when server shuts down.
+	DrainGrace time.Duration
+}
+
+// DefaultServerOptions returns options suitable for the most applications.
+// WriteTimeout is disabled by default not to break long-running responses.
+func DefaultServerOptions() *ServerOptions {
+	return &ServerOptions{
+		ReadTimeout:       time.Minute,
+		ReadHeaderTimeout: 10 * time.Second,
+		IdleTimeout:       2 * time.Minute,
+		MaxHeaderBytes:    1 << 20,
+		MaxConnections:    10000,
+		DrainGrace:        5 * time.Second,
+	}
+}
+
+// ServerOptionsFromConfig makes options overriding defaults with values from HTTP config section if there are any.
+// Values of wrong types are reported with the config key, not silently ignored.
+func ServerOptionsFromConfig(config *larago.ConfigRepository) (*ServerOptions, error) {
+	options := DefaultServerOptions()
+
+	durations := map[string]*time.Duration{
+		"HTTP.ReadTimeout":       &options.ReadTimeout,
+		"HTTP.ReadHeaderTimeout": &options.ReadHeaderTimeout,
+		"HTTP.WriteTimeout":      &options.WriteTimeout,
+		"HTTP.IdleTimeout":       &options.IdleTimeout,
+		"HTTP.DrainGrace":        &options.DrainGrace,
+	}
+	for key, target := range durations {
+		if config.Has(key) {
+			value, err := config.Duration(key)
+			if err != nil {
+				return nil, err
+			}
+			*target = value
+		}
+	}
+
+	ints := map[string]*int{
+		"HTTP.MaxHeaderBytes": &options.MaxHeaderBytes,
+		"HTTP.MaxConnections": &options.MaxConnections,
+	}
+	for key, target := range ints {
+		if config.Has(key) {
+			value, err := config.Int(key)
+			if err != nil {
+				return nil, err
+			}
+			*target = value
+		}
+	}
+
+	return options, nil
+}
@@ -0,0 +1,57 @@
+package http
+
+import (
+	"context"
+	"net"
+	net_http "net/http"
+)
+
+// ServerComponent runs router as a long-running component of the supervisor.
+type ServerComponent struct {
+	router *Router
+	listen string
+	ready  chan struct{}
+}
+
+// NewServerComponent constructor.
+func NewServerComponent(router *Router, listen string) *ServerComponent {
+	return &ServerComponent{
+		router: router,
+		listen: listen,
+		ready:  make(chan struct{}),
+	}
+}
+
+// Name of the component.
+func (c *ServerComponent) Name() string {
+	return "http"
+}
+
+// Start listening to requests.
+func (c *ServerComponent) Start() error {
+	listener, err := net.Listen("tcp", c.listen)
+	if err != nil {
+		return err
+	}
+
+	c.router.Bootstrap()
+	close(c.ready)
+
+	c.router.Logger.Info("Serving app at %s.", c.listen)
+
+	if err := c.router.Serve(listener); err != net_http.ErrServerClosed {
+		return err
+	}
+
+	return nil
+}
+
+// Ready returns channel closed when server started listening.
+func (c *ServerComponent) Ready() <-chan struct{} {
+	return c.ready
+}
+
+// Stop server gracefully.
+func (c *ServerComponent) Stop(ctx context.Context) error {
+	return c.router.Shutdown(ctx)
+}
@@ -0,0 +1,54 @@
+package http_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lara-go/larago"
+	"github.com/lara-go/larago/http"
+	"github.com/stretchr/testify/assert"
+)
+
+type serverConfig struct {
+	HTTP struct {
+		ReadTimeout    string
+		MaxConnections float64
+	}
+}
+
+func (c *serverConfig) Env() string {
+	return "testing"
+}
+
+func (c *serverConfig) Debug() bool {
+	return false
+}
+
+func serverOptions(config *serverConfig) (*http.ServerOptions, error) {
+	application := larago.New()
+	application.SetConfig(func() larago.Config { return config }).ImportConfig()
+
+	return http.ServerOptionsFromConfig(application.Config())
+}
+
+func TestServerOptionsFromConfig(t *testing.T) {
+	// Values loaded from JSON.
+	config := &serverConfig{}
+	config.HTTP.ReadTimeout = "30s"
+	config.HTTP.MaxConnections = 500
+
+	options, err := serverOptions(config)
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Second, options.ReadTimeout)
+	assert.Equal(t, 500, options.MaxConnections)
+	assert.Equal(t, http.DefaultServerOptions().IdleTimeout, options.IdleTimeout)
+
+	config.HTTP.ReadTimeout = "soon"
+	_, err = serverOptions(config)
+	assert.Contains(t, err.Error(), "HTTP.ReadTimeout")
+
+	config.HTTP.ReadTimeout = "30s"
+	config.HTTP.MaxConnections = 1.5
+	_, err = serverOptions(config)
+	assert.Contains(t, err.Error(), "HTTP.MaxConnections")
+}
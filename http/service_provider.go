@@ -12,11 +12,30 @@ func (p *ServiceProvider) Register(application *larago.Application) {
 		&CommandUp{},
 		&CommandServe{},
 		&CommandRoutes{},
+		&CommandBench{},
+		&CommandReplay{},
 	)
 
 	// Register server itself.
 	p.registerRouter(application)
 	p.registerErrorsHandler(application)
+	p.registerMetrics(application)
+}
+
+// RoutesModule registers routes of the module.
+type RoutesModule interface {
+	Routes(router *Router)
+}
+
+// Boot service. Registers routes of the modules.
+func (p *ServiceProvider) Boot(application *larago.Application) {
+	router := application.Get("router").(*Router)
+
+	for _, module := range application.Modules() {
+		if m, ok := module.(RoutesModule); ok {
+			m.Routes(router)
+		}
+	}
 }
 
 func (p *ServiceProvider) registerRouter(application *larago.Application) {
@@ -26,3 +45,7 @@ func (p *ServiceProvider) registerRouter(application *larago.Application) {
 func (p *ServiceProvider) registerErrorsHandler(application *larago.Application) {
 	application.Bind(&ErrorsHandler{}, (*ErrorsHandlerInterface)(nil))
 }
+
+func (p *ServiceProvider) registerMetrics(application *larago.Application) {
+	application.Bind(NewMetrics(), "http.metrics")
+}
@@ -0,0 +1,117 @@
+package http_test
+
+import (
+	"bufio"
+	"context"
+	net_http "net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"net/textproto"
+	"testing"
+
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/http/responses"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStreamAndEarlyHints(t *testing.T) {
+	router := factory()
+
+	proceed := make(chan struct{})
+	router.GET("/stream").Action(func() responses.Response {
+		return responses.NewStream(200, func(stream *responses.Stream) error {
+			stream.WriteString("head\n")
+			stream.Flush()
+
+			// Client has to see the head before the rest is written.
+			<-proceed
+			stream.WriteString("body\n")
+
+			return nil
+		}).WithContentType("text/plain")
+	})
+	router.GET("/page").Preload("/css/app.css", "/fonts/app.woff2", "</api/me>; rel=preload; as=fetch").Action(func() string {
+		return "page"
+	})
+
+	server := httptest.NewServer(router.Bootstrap())
+	defer server.Close()
+
+	response, err := net_http.Get(server.URL + "/stream")
+	assert.NoError(t, err)
+	defer response.Body.Close()
+
+	reader := bufio.NewReader(response.Body)
+	line, _ := reader.ReadString('\n')
+	assert.Equal(t, "head\n", line)
+	assert.Equal(t, "text/plain; charset=utf-8", response.Header.Get("Content-Type"))
+
+	close(proceed)
+	line, _ = reader.ReadString('\n')
+	assert.Equal(t, "body\n", line)
+
+	var hints []textproto.MIMEHeader
+	trace := &httptrace.ClientTrace{
+		Got1xxResponse: func(code int, header textproto.MIMEHeader) error {
+			if code == net_http.StatusEarlyHints {
+				hints = append(hints, header)
+			}
+
+			return nil
+		},
+	}
+
+	request, _ := net_http.NewRequest("GET", server.URL+"/page", nil)
+	request = request.WithContext(httptrace.WithClientTrace(context.Background(), trace))
+
+	response, err = net_http.DefaultClient.Do(request)
+	assert.NoError(t, err)
+	response.Body.Close()
+
+	assert.Equal(t, 200, response.StatusCode)
+	assert.Len(t, hints, 1)
+	assert.Equal(t, []string{
+		"</css/app.css>; rel=preload; as=style",
+		"</fonts/app.woff2>; rel=preload; as=font; crossorigin",
+		"</api/me>; rel=preload; as=fetch",
+	}, hints[0]["Link"])
+}
+
+func TestEarlyHintsAreNotFinal(t *testing.T) {
+	router := factory()
+	router.GET("/page").Preload("/css/app.css").Action(func() string {
+		return "page"
+	})
+
+	// Captured writer can't tell informational status from the final one.
+	captured := responses.NewCaptured()
+	router.Bootstrap().ServeHTTP(captured, httptest.NewRequest("GET", "/page", nil))
+
+	assert.Equal(t, 200, captured.Status())
+	assert.Equal(t, "page", string(captured.Body()))
+	assert.Equal(t, "</css/app.css>; rel=preload; as=style", captured.Header().Get("Link"))
+}
+
+func TestStreamBody(t *testing.T) {
+	stream := responses.NewStream(200, func(stream *responses.Stream) error {
+		stream.WriteString("a")
+		stream.Flush()
+		stream.WriteString("b")
+
+		return nil
+	})
+
+	// Buffered when there is no writer to stream to.
+	assert.Equal(t, "ab", string(stream.Body()))
+
+	captured := responses.NewCaptured()
+	http.WriteResponse(captured, stream)
+	assert.Equal(t, "ab", string(captured.Body()))
+	assert.Equal(t, "text/html", captured.ContentType())
+}
+
+func TestPreloadTargets(t *testing.T) {
+	targets := http.PreloadTargets(`</css/app.css>; rel=preload; as=style, <https://cdn.example.com/a.js>; rel=preload, </next>; rel=prefetch`)
+
+	assert.Equal(t, []string{"/css/app.css", "https://cdn.example.com/a.js"}, targets)
+}
@@ -0,0 +1,51 @@
+package httpclient
+
+import (
+	net_http "net/http"
+	"sync"
+	"time"
+)
+
+// Transport all the clients send requests through. Replaced by httpclienttest.Fake in tests.
+var (
+	transport      net_http.RoundTripper = net_http.DefaultTransport
+	transportMutex sync.RWMutex
+)
+
+// Default client.
+var Default = New(30 * time.Second)
+
+// New makes client with the timeout. Its requests can be intercepted by Fake.
+func New(timeout time.Duration) *net_http.Client {
+	return &net_http.Client{
+		Timeout:   timeout,
+		Transport: sharedTransport{},
+	}
+}
+
+// Transport delegating to the current package transport on every request.
+type sharedTransport struct{}
+
+// RoundTrip sends request.
+func (sharedTransport) RoundTrip(request *net_http.Request) (*net_http.Response, error) {
+	return currentTransport().RoundTrip(request)
+}
+
+// Get current transport.
+func currentTransport() net_http.RoundTripper {
+	transportMutex.RLock()
+	defer transportMutex.RUnlock()
+
+	return transport
+}
+
+// SetTransport of all the clients returning the previous one.
+func SetTransport(next net_http.RoundTripper) net_http.RoundTripper {
+	transportMutex.Lock()
+	defer transportMutex.Unlock()
+
+	previous := transport
+	transport = next
+
+	return previous
+}
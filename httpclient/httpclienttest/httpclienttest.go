@@ -0,0 +1,337 @@
+// Package httpclienttest fakes outbound requests of the clients made by httpclient package.
+//
+//	fake := httpclienttest.Fake()
+//	defer fake.Restore()
+//
+//	fake.Stub(httpclienttest.URL("https://api.example.com/*")).RespondJSON(200, result)
+package httpclienttest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	net_http "net/http"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/lara-go/larago/httpclient"
+)
+
+// RecordedRequest sent through the fake transport.
+type RecordedRequest struct {
+	Method string
+	URL    string
+	Header net_http.Header
+	Body   []byte
+}
+
+// JSON decodes request body.
+func (r *RecordedRequest) JSON(target interface{}) error {
+	return json.Unmarshal(r.Body, target)
+}
+
+// Matcher checks if request is the one expected.
+type Matcher func(request *RecordedRequest) bool
+
+// Method matches request method.
+func Method(method string) Matcher {
+	return func(request *RecordedRequest) bool {
+		return strings.EqualFold(request.Method, method)
+	}
+}
+
+// URL matches full request URL, "*" matches any characters.
+func URL(pattern string) Matcher {
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	expression := regexp.MustCompile("^" + strings.Join(parts, ".*") + "$")
+
+	return func(request *RecordedRequest) bool {
+		return expression.MatchString(request.URL)
+	}
+}
+
+// Header matches request header value.
+func Header(name, value string) Matcher {
+	return func(request *RecordedRequest) bool {
+		return request.Header.Get(name) == value
+	}
+}
+
+// BodyContains matches requests with body containing substring.
+func BodyContains(substring string) Matcher {
+	return func(request *RecordedRequest) bool {
+		return bytes.Contains(request.Body, []byte(substring))
+	}
+}
+
+// JSONBody matches requests which JSON body equals to the value.
+func JSONBody(value interface{}) Matcher {
+	encoded, _ := json.Marshal(value)
+
+	var expected interface{}
+	json.Unmarshal(encoded, &expected)
+
+	return func(request *RecordedRequest) bool {
+		var actual interface{}
+		if err := request.JSON(&actual); err != nil {
+			return false
+		}
+
+		return reflect.DeepEqual(actual, expected)
+	}
+}
+
+// Check if request satisfies all the matchers.
+func matches(request *RecordedRequest, matchers []Matcher) bool {
+	for _, matcher := range matchers {
+		if !matcher(request) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FakeResponse returned by the fake transport.
+type FakeResponse struct {
+	Status int
+	Header net_http.Header
+	Body   string
+
+	// Error is returned instead of response, ex. to simulate connection failures.
+	Error error
+}
+
+// Make net/http response.
+func (r *FakeResponse) response(request *net_http.Request) (*net_http.Response, error) {
+	if r.Error != nil {
+		return nil, r.Error
+	}
+
+	header := r.Header
+	if header == nil {
+		header = make(net_http.Header)
+	}
+
+	return &net_http.Response{
+		Status:        fmt.Sprintf("%d %s", r.Status, net_http.StatusText(r.Status)),
+		StatusCode:    r.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       request,
+	}, nil
+}
+
+// Stub responds to matching requests.
+// Responses are returned in sequence, the last one is repeated.
+type Stub struct {
+	matchers  []Matcher
+	responses []*FakeResponse
+	next      int
+}
+
+// Respond adds response to the sequence.
+func (s *Stub) Respond(status int, body string) *Stub {
+	return s.RespondWith(&FakeResponse{Status: status, Body: body})
+}
+
+// RespondJSON adds JSON response to the sequence.
+func (s *Stub) RespondJSON(status int, value interface{}) *Stub {
+	body, err := json.Marshal(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return s.RespondWith(&FakeResponse{
+		Status: status,
+		Header: net_http.Header{"Content-Type": {"application/json"}},
+		Body:   string(body),
+	})
+}
+
+// Fail adds connection error to the sequence.
+func (s *Stub) Fail(err error) *Stub {
+	return s.RespondWith(&FakeResponse{Error: err})
+}
+
+// RespondWith adds custom response to the sequence.
+func (s *Stub) RespondWith(response *FakeResponse) *Stub {
+	s.responses = append(s.responses, response)
+
+	return s
+}
+
+// Take the next response of the sequence.
+func (s *Stub) take() *FakeResponse {
+	if len(s.responses) == 0 {
+		return &FakeResponse{Status: net_http.StatusOK}
+	}
+
+	response := s.responses[s.next]
+	if s.next < len(s.responses)-1 {
+		s.next++
+	}
+
+	return response
+}
+
+// FakeTransport intercepts outbound requests of the clients made by httpclient package.
+// Requests without matching stub fail, so nothing leaves the process.
+type FakeTransport struct {
+	mutex    sync.Mutex
+	stubs    []*Stub
+	recorded []*RecordedRequest
+	previous net_http.RoundTripper
+}
+
+// Fake starts intercepting outbound requests. Call Restore when done.
+//
+//	fake := httpclient.Fake()
+//	defer fake.Restore()
+//
+//	fake.Stub(httpclient.Method("POST"), httpclient.URL("https://api.example.com/*")).
+//		Respond(500, "").
+//		RespondJSON(200, map[string]string{"status": "ok"})
+func Fake() *FakeTransport {
+	fake := &FakeTransport{}
+	fake.previous = httpclient.SetTransport(fake)
+
+	return fake
+}
+
+// Restore the real transport.
+func (f *FakeTransport) Restore() {
+	httpclient.SetTransport(f.previous)
+}
+
+// Stub responses to the requests matching all the matchers. No matchers match every request.
+// Stubs are checked in the order they were added.
+func (f *FakeTransport) Stub(matchers ...Matcher) *Stub {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	stub := &Stub{matchers: matchers}
+	f.stubs = append(f.stubs, stub)
+
+	return stub
+}
+
+// RoundTrip records request and returns stubbed response.
+func (f *FakeTransport) RoundTrip(request *net_http.Request) (*net_http.Response, error) {
+	recorded := &RecordedRequest{
+		Method: request.Method,
+		URL:    request.URL.String(),
+		Header: request.Header.Clone(),
+	}
+
+	if request.Body != nil {
+		body, err := ioutil.ReadAll(request.Body)
+		request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		recorded.Body = body
+	}
+
+	f.mutex.Lock()
+	f.recorded = append(f.recorded, recorded)
+
+	var response *FakeResponse
+	for _, stub := range f.stubs {
+		if matches(recorded, stub.matchers) {
+			response = stub.take()
+			break
+		}
+	}
+	f.mutex.Unlock()
+
+	if response == nil {
+		return nil, fmt.Errorf("No fake response for %s %s", recorded.Method, recorded.URL)
+	}
+
+	return response.response(request)
+}
+
+// Recorded returns all the requests sent.
+func (f *FakeTransport) Recorded(matchers ...Matcher) []*RecordedRequest {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	var recorded []*RecordedRequest
+	for _, request := range f.recorded {
+		if matches(request, matchers) {
+			recorded = append(recorded, request)
+		}
+	}
+
+	return recorded
+}
+
+// AssertSent checks that at least one request matching all the matchers was sent.
+func (f *FakeTransport) AssertSent(t *testing.T, matchers ...Matcher) bool {
+	t.Helper()
+
+	if len(f.Recorded(matchers...)) == 0 {
+		t.Errorf("Expected request was not sent, sent requests:\n%s", f.describe())
+		return false
+	}
+
+	return true
+}
+
+// AssertSentCount checks how many requests matching all the matchers were sent.
+func (f *FakeTransport) AssertSentCount(t *testing.T, count int, matchers ...Matcher) bool {
+	t.Helper()
+
+	if sent := len(f.Recorded(matchers...)); sent != count {
+		t.Errorf("Expected %d requests to be sent, got %d, sent requests:\n%s", count, sent, f.describe())
+		return false
+	}
+
+	return true
+}
+
+// AssertNotSent checks that no request matching all the matchers was sent.
+func (f *FakeTransport) AssertNotSent(t *testing.T, matchers ...Matcher) bool {
+	t.Helper()
+
+	if len(f.Recorded(matchers...)) > 0 {
+		t.Errorf("Unexpected request was sent, sent requests:\n%s", f.describe())
+		return false
+	}
+
+	return true
+}
+
+// AssertNothingSent checks that no requests were sent at all.
+func (f *FakeTransport) AssertNothingSent(t *testing.T) bool {
+	t.Helper()
+
+	return f.AssertNotSent(t)
+}
+
+// List sent requests for failure messages.
+func (f *FakeTransport) describe() string {
+	var lines []string
+	for _, request := range f.Recorded() {
+		lines = append(lines, fmt.Sprintf("  %s %s", request.Method, request.URL))
+	}
+
+	if len(lines) == 0 {
+		return "  none"
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,73 @@
+package httpclienttest_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/lara-go/larago/httpclient"
+	"github.com/lara-go/larago/httpclient/httpclienttest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFake(t *testing.T) {
+	fake := httpclienttest.Fake()
+	defer fake.Restore()
+
+	fake.Stub(httpclienttest.Method("POST"), httpclienttest.URL("https://api.example.com/orders/*")).
+		Fail(errors.New("Connection reset")).
+		Respond(503, "").
+		RespondJSON(201, map[string]int{"id": 1})
+
+	fake.Stub(httpclienttest.URL("https://api.example.com/*")).
+		Respond(200, "ok")
+
+	client := httpclient.New(0)
+
+	// Sequential responses, the last one repeats.
+	_, err := client.Post("https://api.example.com/orders/new", "application/json", strings.NewReader(`{"sku": "A1"}`))
+	assert.NotNil(t, err)
+
+	response, err := client.Post("https://api.example.com/orders/new", "application/json", strings.NewReader(`{"sku": "A1"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, 503, response.StatusCode)
+
+	for i := 0; i < 2; i++ {
+		response, err = client.Post("https://api.example.com/orders/new", "application/json", strings.NewReader(`{"sku": "B2"}`))
+		assert.Nil(t, err)
+		assert.Equal(t, 201, response.StatusCode)
+		assert.Equal(t, "application/json", response.Header.Get("Content-Type"))
+	}
+
+	response, err = httpclient.Default.Get("https://api.example.com/status")
+	assert.Nil(t, err)
+	body, _ := ioutil.ReadAll(response.Body)
+	assert.Equal(t, "ok", string(body))
+
+	// Unexpected hosts are never reached.
+	_, err = client.Get("https://other.example.com/")
+	assert.Contains(t, err.Error(), "No fake response for GET https://other.example.com/")
+
+	fake.AssertSent(t, httpclienttest.Method("GET"), httpclienttest.URL("*/status"))
+	fake.AssertSentCount(t, 4, httpclienttest.URL("*/orders/*"))
+	fake.AssertSentCount(t, 2, httpclienttest.JSONBody(map[string]string{"sku": "B2"}))
+	fake.AssertSent(t, httpclienttest.BodyContains(`"A1"`), httpclienttest.Header("Content-Type", "application/json"))
+	fake.AssertNotSent(t, httpclienttest.Method("DELETE"))
+}
+
+func TestFakeRestore(t *testing.T) {
+	fake := httpclienttest.Fake()
+	fake.Stub().Respond(204, "")
+
+	response, err := httpclient.Default.Get("http://localhost/")
+	assert.Nil(t, err)
+	assert.Equal(t, 204, response.StatusCode)
+	assert.Len(t, fake.Recorded(), 1)
+
+	fake.Restore()
+
+	// Requests go to the real transport again.
+	httpclient.Default.Get("http://localhost:1/")
+	assert.Len(t, fake.Recorded(), 1)
+}
@@ -0,0 +1,60 @@
+package larago
+
+import "fmt"
+
+// Module bundles routes, migrations, views, translations, providers and commands
+// of the bounded context, so it is registered with one call.
+// Every part is optional, module provides it by implementing the part interface:
+// ProvidersModule, CommandsModule, http.RoutesModule, database.MigrationsModule,
+// view.ViewsModule and translation.TranslationsModule.
+type Module interface {
+	// Name of the module, unique within the application.
+	Name() string
+}
+
+// ProvidersModule registers service providers of the module.
+type ProvidersModule interface {
+	Providers() []ServiceProvider
+}
+
+// CommandsModule registers console commands of the module.
+type CommandsModule interface {
+	Commands() []ConsoleCommand
+}
+
+// RegisterModules registers modules with their providers and commands.
+// Other parts are picked up by the framework providers on boot,
+// so modules have to be registered before the application is booted.
+func (app *Application) RegisterModules(modules ...Module) {
+	for _, module := range modules {
+		if app.HasModule(module.Name()) {
+			panic(fmt.Errorf("Module %s is already registered", module.Name()))
+		}
+
+		app.modules = append(app.modules, module)
+
+		if m, ok := module.(ProvidersModule); ok {
+			app.Register(m.Providers()...)
+		}
+
+		if m, ok := module.(CommandsModule); ok {
+			app.Commands(m.Commands()...)
+		}
+	}
+}
+
+// Modules returns registered modules in order of registration.
+func (app *Application) Modules() []Module {
+	return app.modules
+}
+
+// HasModule checks if module is registered.
+func (app *Application) HasModule(name string) bool {
+	for _, module := range app.modules {
+		if module.Name() == name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,168 @@
+package monitor
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Collector returns current values of the metric group.
+type Collector func() (interface{}, error)
+
+// Sample of every metric group taken at once.
+type Sample struct {
+	Time    time.Time              `json:"time"`
+	Metrics map[string]interface{} `json:"metrics"`
+	Errors  map[string]string      `json:"errors,omitempty"`
+}
+
+// Broadcaster samples metrics periodically and sends them to every subscriber.
+// Metrics are collected only while somebody is watching.
+type Broadcaster struct {
+	mutex       sync.Mutex
+	interval    time.Duration
+	collectors  map[string]Collector
+	subscribers map[chan Sample]struct{}
+	stop        chan struct{}
+}
+
+// NewBroadcaster constructor. Interval must be positive.
+func NewBroadcaster(interval time.Duration) *Broadcaster {
+	if interval <= 0 {
+		panic(fmt.Errorf("Monitor interval must be positive, got %s", interval))
+	}
+
+	return &Broadcaster{
+		interval:    interval,
+		collectors:  make(map[string]Collector),
+		subscribers: make(map[chan Sample]struct{}),
+	}
+}
+
+// Add metric group. Collector should be cheap, it is called every interval.
+func (b *Broadcaster) Add(name string, collector Collector) *Broadcaster {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.collectors[name] = collector
+
+	return b
+}
+
+// Groups returns names of the metric groups.
+func (b *Broadcaster) Groups() []string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	names := make([]string, 0, len(b.collectors))
+	for name := range b.collectors {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Collect sample of every metric group.
+func (b *Broadcaster) Collect() Sample {
+	b.mutex.Lock()
+	collectors := make(map[string]Collector, len(b.collectors))
+	for name, collector := range b.collectors {
+		collectors[name] = collector
+	}
+	b.mutex.Unlock()
+
+	sample := Sample{
+		Time:    time.Now(),
+		Metrics: make(map[string]interface{}, len(collectors)),
+	}
+
+	for name, collector := range collectors {
+		value, err := collector()
+		if err != nil {
+			if sample.Errors == nil {
+				sample.Errors = make(map[string]string)
+			}
+			sample.Errors[name] = err.Error()
+
+			continue
+		}
+
+		sample.Metrics[name] = value
+	}
+
+	return sample
+}
+
+// Subscribe to samples. Slow subscribers miss samples instead of slowing down the others.
+// Call returned function to unsubscribe.
+func (b *Broadcaster) Subscribe() (<-chan Sample, func()) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	samples := make(chan Sample, 1)
+	b.subscribers[samples] = struct{}{}
+
+	if len(b.subscribers) == 1 {
+		b.stop = make(chan struct{})
+		go b.run(b.stop)
+	}
+
+	var once sync.Once
+
+	return samples, func() {
+		once.Do(func() {
+			b.unsubscribe(samples)
+		})
+	}
+}
+
+// Subscribers returns number of the subscribers.
+func (b *Broadcaster) Subscribers() int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return len(b.subscribers)
+}
+
+// Stop sampling when the last subscriber goes away.
+func (b *Broadcaster) unsubscribe(samples chan Sample) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	delete(b.subscribers, samples)
+
+	if len(b.subscribers) == 0 {
+		close(b.stop)
+	}
+}
+
+// Sample metrics until stopped.
+func (b *Broadcaster) run(stop chan struct{}) {
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			b.publish(b.Collect())
+		}
+	}
+}
+
+// Send sample to every subscriber.
+func (b *Broadcaster) publish(sample Sample) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for subscriber := range b.subscribers {
+		select {
+		case subscriber <- sample:
+		default:
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package monitor
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/asaskevich/EventBus"
+)
+
+// CacheSnapshot of the cache usage.
+type CacheSnapshot struct {
+	Hits    uint64  `json:"hits"`
+	Misses  uint64  `json:"misses"`
+	Writes  uint64  `json:"writes"`
+	Deletes uint64  `json:"deletes"`
+	HitRate float64 `json:"hit_rate"`
+}
+
+// CacheMetrics counts cache operations from the events fired by cache repository.
+type CacheMetrics struct {
+	hits    uint64
+	misses  uint64
+	writes  uint64
+	deletes uint64
+}
+
+// NewCacheMetrics constructor.
+func NewCacheMetrics() *CacheMetrics {
+	return &CacheMetrics{}
+}
+
+// Listen to cache events.
+func (m *CacheMetrics) Listen(events *EventBus.EventBus) {
+	events.Subscribe("cache.hit", func(key string) {
+		atomic.AddUint64(&m.hits, 1)
+	})
+	events.Subscribe("cache.miss", func(key string) {
+		atomic.AddUint64(&m.misses, 1)
+	})
+	events.Subscribe("cache.write", func(key string, duration time.Duration) {
+		atomic.AddUint64(&m.writes, 1)
+	})
+	events.Subscribe("cache.delete", func(key string) {
+		atomic.AddUint64(&m.deletes, 1)
+	})
+}
+
+// Snapshot returns current values.
+func (m *CacheMetrics) Snapshot() CacheSnapshot {
+	snapshot := CacheSnapshot{
+		Hits:    atomic.LoadUint64(&m.hits),
+		Misses:  atomic.LoadUint64(&m.misses),
+		Writes:  atomic.LoadUint64(&m.writes),
+		Deletes: atomic.LoadUint64(&m.deletes),
+	}
+
+	if lookups := snapshot.Hits + snapshot.Misses; lookups > 0 {
+		snapshot.HitRate = float64(snapshot.Hits) / float64(lookups)
+	}
+
+	return snapshot
+}
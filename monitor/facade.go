@@ -0,0 +1,11 @@
+package monitor
+
+import "github.com/lara-go/larago"
+
+// FacadeWrapper for facade.
+var FacadeWrapper = &larago.Facade{}
+
+// Facade returns metrics broadcaster.
+func Facade() *Broadcaster {
+	return FacadeWrapper.Resolve("monitor").(*Broadcaster)
+}
@@ -0,0 +1,101 @@
+package monitor
+
+import (
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/http/responses"
+)
+
+// Ability required to watch metrics.
+const Ability = "monitor.view"
+
+// Routes registers dashboard page and metrics stream under the path.
+// Both require the user to have monitor.view ability, so router must have an authorizer set.
+//
+//	monitor.Routes(router, "/_monitor", monitor.Facade())
+func Routes(router *http.Router, path string, broadcaster *Broadcaster) {
+	router.GET(path).Can(Ability).As("monitor.dashboard").Action(broadcaster.Dashboard)
+	router.GET(path + "/stream").Can(Ability).As("monitor.stream").Action(broadcaster.Stream)
+}
+
+// Stream metrics samples as server-sent "metrics" events.
+func (b *Broadcaster) Stream(request *http.Request) responses.Response {
+	return http.NewEventStream(request, func(events *http.EventStream) error {
+		samples, unsubscribe := b.Subscribe()
+		defer unsubscribe()
+
+		// Send current values right away, not to wait for the first tick.
+		if err := events.Send("metrics", b.Collect()); err != nil {
+			return nil
+		}
+
+		for {
+			select {
+			case sample := <-samples:
+				if err := events.Send("metrics", sample); err != nil {
+					return nil
+				}
+			case <-events.Done():
+				return nil
+			}
+		}
+	})
+}
+
+// Dashboard page rendering metrics stream.
+func (b *Broadcaster) Dashboard(request *http.Request) responses.Response {
+	return responses.NewHTML(200, dashboardHTML, request.BaseRequest().URL.Path+"/stream")
+}
+
+// Page of the dashboard, stream URL is substituted.
+const dashboardHTML = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Monitor</title>
+<style>
+body { font: 14px monospace; margin: 2em; }
+h2 { font-size: 1em; margin: 1.5em 0 .5em; }
+td { padding: 2px 1em 2px 0; }
+#status { color: #888; }
+</style>
+</head>
+<body>
+<div id="status">Connecting...</div>
+<div id="metrics"></div>
+<script>
+var stream = new EventSource(%q);
+stream.onerror = function () {
+	document.getElementById("status").textContent = "Reconnecting...";
+};
+stream.addEventListener("metrics", function (event) {
+	var sample = JSON.parse(event.data);
+	var metrics = document.getElementById("metrics");
+	// Values and errors are set as text, so they are never rendered as HTML.
+	var element = function (tag, text) {
+		var node = document.createElement(tag);
+		if (text !== undefined) {
+			node.textContent = typeof text === "object" ? JSON.stringify(text) : String(text);
+		}
+		return node;
+	};
+	metrics.textContent = "";
+	Object.keys(sample.metrics).sort().forEach(function (group) {
+		metrics.appendChild(element("h2", group));
+		var table = metrics.appendChild(element("table"));
+		var values = sample.metrics[group];
+		Object.keys(values).forEach(function (name) {
+			var row = table.appendChild(element("tr"));
+			row.appendChild(element("td", name));
+			row.appendChild(element("td", values[name]));
+		});
+	});
+	Object.keys(sample.errors || {}).forEach(function (group) {
+		metrics.appendChild(element("h2", group));
+		metrics.appendChild(element("div", sample.errors[group]));
+	});
+	document.getElementById("status").textContent = "Updated " + new Date(sample.time).toLocaleTimeString();
+});
+</script>
+</body>
+</html>
+`
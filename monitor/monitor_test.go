@@ -0,0 +1,194 @@
+package monitor_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"log"
+	net_http "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/asaskevich/EventBus"
+	"github.com/lara-go/larago"
+	"github.com/lara-go/larago/container"
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/logger"
+	"github.com/lara-go/larago/monitor"
+	"github.com/lara-go/larago/validation"
+	"github.com/stretchr/testify/assert"
+)
+
+// Takes abilities of the user from headers.
+type headerAuthorizer struct{}
+
+func (a *headerAuthorizer) Can(request *http.Request, ability string) bool {
+	return strings.Contains(request.Header("X-Abilities"), ability)
+}
+
+func (a *headerAuthorizer) HasRole(request *http.Request, role string) bool {
+	return false
+}
+
+func routerFactory() *http.Router {
+	logger := &logger.Logger{
+		DateTimeFormat: larago.DateTimeFormat,
+		Logger:         log.New(ioutil.Discard, "", 0),
+	}
+
+	router := http.NewRouter()
+	router.Logger = logger
+	router.Container = container.New()
+	router.ErrorsHandler = &http.ErrorsHandler{
+		Logger:                    logger,
+		ValidationErrorsConverter: &validation.OzzoErrorsConverter{},
+	}
+	router.SetAuthorizer(&headerAuthorizer{})
+
+	return router
+}
+
+func TestBroadcaster(t *testing.T) {
+	broadcaster := monitor.NewBroadcaster(10*time.Millisecond).
+		Add("requests", func() (interface{}, error) {
+			return map[string]int{"count": 5}, nil
+		}).
+		Add("queue", func() (interface{}, error) {
+			return nil, errors.New("database is gone")
+		})
+
+	assert.Equal(t, []string{"queue", "requests"}, broadcaster.Groups())
+
+	samples, unsubscribe := broadcaster.Subscribe()
+	assert.Equal(t, 1, broadcaster.Subscribers())
+
+	select {
+	case sample := <-samples:
+		assert.Equal(t, map[string]int{"count": 5}, sample.Metrics["requests"])
+		assert.Equal(t, "database is gone", sample.Errors["queue"])
+	case <-time.After(time.Second):
+		t.Fatal("No sample was broadcasted")
+	}
+
+	unsubscribe()
+	unsubscribe()
+	assert.Equal(t, 0, broadcaster.Subscribers())
+
+	// Sampling starts again for the new subscriber.
+	samples, unsubscribe = broadcaster.Subscribe()
+	defer unsubscribe()
+
+	select {
+	case <-samples:
+	case <-time.After(time.Second):
+		t.Fatal("Sampling was not restarted")
+	}
+}
+
+func TestCacheMetrics(t *testing.T) {
+	events := EventBus.New().(*EventBus.EventBus)
+	metrics := monitor.NewCacheMetrics()
+	metrics.Listen(events)
+
+	events.Publish("cache.hit", "a")
+	events.Publish("cache.hit", "a")
+	events.Publish("cache.hit", "b")
+	events.Publish("cache.miss", "c")
+	events.Publish("cache.write", "c", time.Minute)
+	events.Publish("cache.delete", "c")
+
+	snapshot := metrics.Snapshot()
+	assert.Equal(t, uint64(3), snapshot.Hits)
+	assert.Equal(t, uint64(1), snapshot.Misses)
+	assert.Equal(t, uint64(1), snapshot.Writes)
+	assert.Equal(t, uint64(1), snapshot.Deletes)
+	assert.Equal(t, 0.75, snapshot.HitRate)
+}
+
+func TestStream(t *testing.T) {
+	broadcaster := monitor.NewBroadcaster(10*time.Millisecond).
+		Add("requests", func() (interface{}, error) {
+			return map[string]int{"count": 5}, nil
+		})
+
+	router := routerFactory()
+	monitor.Routes(router, "/_monitor", broadcaster)
+
+	server := httptest.NewServer(router.Bootstrap())
+	defer server.Close()
+
+	request := func(path, abilities string) *net_http.Response {
+		r, _ := net_http.NewRequest("GET", server.URL+path, nil)
+		r.Header.Set("X-Abilities", abilities)
+		response, err := net_http.DefaultClient.Do(r)
+		assert.NoError(t, err)
+
+		return response
+	}
+
+	forbidden := request("/_monitor/stream", "")
+	forbidden.Body.Close()
+	assert.Equal(t, 403, forbidden.StatusCode)
+
+	dashboard := request("/_monitor", monitor.Ability)
+	page, _ := ioutil.ReadAll(dashboard.Body)
+	dashboard.Body.Close()
+	assert.Equal(t, 200, dashboard.StatusCode)
+	assert.Contains(t, string(page), `new EventSource("/_monitor/stream")`)
+	assert.NotContains(t, string(page), "innerHTML")
+
+	stream := request("/_monitor/stream", monitor.Ability)
+	assert.Equal(t, "text/event-stream; charset=utf-8", stream.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(stream.Body)
+	for i := 0; i < 2; i++ {
+		event, _ := reader.ReadString('\n')
+		assert.Equal(t, "event: metrics\n", event)
+
+		data, _ := reader.ReadString('\n')
+		var sample monitor.Sample
+		assert.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(data, "data: ")), &sample))
+		assert.Equal(t, map[string]interface{}{"count": float64(5)}, sample.Metrics["requests"])
+
+		reader.ReadString('\n')
+	}
+
+	stream.Body.Close()
+
+	// Sampling stops when the viewer goes away.
+	assert.Eventually(t, func() bool {
+		return broadcaster.Subscribers() == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+type monitorConfig struct {
+	Monitor struct {
+		Interval string
+	}
+}
+
+func (c *monitorConfig) Env() string {
+	return "testing"
+}
+
+func (c *monitorConfig) Debug() bool {
+	return false
+}
+
+func TestServiceProviderRejectsNonPositiveInterval(t *testing.T) {
+	for _, interval := range []string{"0s", "-1s"} {
+		config := &monitorConfig{}
+		config.Monitor.Interval = interval
+
+		application := larago.New()
+		application.SetConfig(func() larago.Config { return config }).ImportConfig()
+		application.Register(&monitor.ServiceProvider{})
+
+		assert.PanicsWithError(t, "Can't resolve monitor: Config value Monitor.Interval must be positive, got "+interval, func() {
+			application.Get("monitor")
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package monitor
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/asaskevich/EventBus"
+	"github.com/lara-go/larago"
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/outbox"
+)
+
+// Default interval between samples.
+const defaultInterval = time.Second
+
+// ServiceProvider struct.
+type ServiceProvider struct{}
+
+// Register service.
+func (p *ServiceProvider) Register(application *larago.Application) {
+	var broadcaster *Broadcaster
+	var once sync.Once
+
+	var err error
+
+	application.Bind(func() (*Broadcaster, error) {
+		once.Do(func() {
+			interval := defaultInterval
+			if application.Config().Has("Monitor.Interval") {
+				if interval, err = application.Config().Duration("Monitor.Interval"); err != nil {
+					return
+				}
+
+				if interval <= 0 {
+					err = fmt.Errorf("Config value Monitor.Interval must be positive, got %s", interval)
+					return
+				}
+			}
+
+			broadcaster = NewBroadcaster(interval)
+		})
+
+		return broadcaster, err
+	}, "monitor")
+
+	application.Bind(NewCacheMetrics(), "monitor.cache")
+}
+
+// Boot service. Adds metrics of the registered framework services.
+func (p *ServiceProvider) Boot(application *larago.Application) {
+	broadcaster := application.Get("monitor").(*Broadcaster)
+
+	broadcaster.Add("runtime", func() (interface{}, error) {
+		var memory runtime.MemStats
+		runtime.ReadMemStats(&memory)
+
+		return map[string]interface{}{
+			"goroutines": runtime.NumGoroutine(),
+			"heap_alloc": memory.HeapAlloc,
+			"heap_inuse": memory.HeapInuse,
+			"num_gc":     memory.NumGC,
+		}, nil
+	})
+
+	// Requests and load balancer queue time observed by CollectMetrics middleware.
+	if application.Bound("http.metrics") {
+		metrics := application.Get("http.metrics").(*http.Metrics)
+		broadcaster.Add("requests", func() (interface{}, error) {
+			return metrics.Snapshot(), nil
+		})
+	}
+
+	if application.Bound("router") {
+		router := application.Get("router").(*http.Router)
+		broadcaster.Add("connections", func() (interface{}, error) {
+			return map[string]interface{}{
+				"open":     router.Connections().Count(),
+				"draining": router.Connections().Draining(),
+			}, nil
+		})
+	}
+
+	if application.Bound("events") {
+		cacheMetrics := application.Get("monitor.cache").(*CacheMetrics)
+		cacheMetrics.Listen(application.Get("events").(*EventBus.EventBus))
+
+		broadcaster.Add("cache", func() (interface{}, error) {
+			return cacheMetrics.Snapshot(), nil
+		})
+	}
+
+	// Outbox is resolved when sampled, not to connect to the database on boot.
+	if application.Bound("outbox") {
+		broadcaster.Add("queue", func() (interface{}, error) {
+			relay := application.Get("outbox").(*outbox.Relay)
+
+			pending, err := relay.Pending()
+			if err != nil {
+				return nil, err
+			}
+
+			failed, err := relay.Failed()
+			if err != nil {
+				return nil, err
+			}
+
+			return map[string]interface{}{"pending": pending, "failed": failed}, nil
+		})
+	}
+}
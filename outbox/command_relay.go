@@ -0,0 +1,72 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/asaskevich/EventBus"
+	"github.com/lara-go/larago/logger"
+
+	"github.com/urfave/cli"
+)
+
+// CommandRelay delivers messages from the outbox.
+type CommandRelay struct {
+	Relay  *Relay
+	Events *EventBus.EventBus
+	Logger *logger.Logger
+
+	once bool
+}
+
+// GetCommand for the cli to register.
+func (c *CommandRelay) GetCommand() cli.Command {
+	return cli.Command{
+		Name:     "outbox:relay",
+		Usage:    "Deliver events and webhooks from the outbox",
+		Category: "Outbox",
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:        "once",
+				Usage:       "deliver available messages and exit",
+				Destination: &c.once,
+			},
+		},
+	}
+}
+
+// Handle command.
+func (c *CommandRelay) Handle(args cli.Args) error {
+	if c.once {
+		total := 0
+		for {
+			relayed, err := c.Relay.RelayBatch()
+			if err != nil {
+				return err
+			}
+
+			total += relayed
+			if relayed < c.Relay.batchSize {
+				break
+			}
+		}
+
+		c.Logger.Success("Relayed %d messages.", total)
+
+		return nil
+	}
+
+	// Stop relay before application exits on sigterm.
+	if c.Events != nil {
+		c.Events.Subscribe("sigterm", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			c.Relay.Stop(ctx)
+		})
+	}
+
+	c.Logger.Info("Relaying outbox messages...")
+
+	return c.Relay.Start()
+}
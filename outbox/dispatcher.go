@@ -0,0 +1,75 @@
+package outbox
+
+import (
+	"bytes"
+	"fmt"
+	net_http "net/http"
+	"strconv"
+	"time"
+
+	"github.com/asaskevich/EventBus"
+	"github.com/lara-go/larago/httpclient"
+)
+
+// Dispatcher delivers relayed messages.
+type Dispatcher interface {
+	// Dispatch message. Error means message will be retried later.
+	Dispatch(message *Message) error
+}
+
+// DefaultDispatcher publishes events on the events bus and posts webhooks.
+type DefaultDispatcher struct {
+	events *EventBus.EventBus
+	client *net_http.Client
+}
+
+// NewDefaultDispatcher constructor.
+func NewDefaultDispatcher(events *EventBus.EventBus) *DefaultDispatcher {
+	return &DefaultDispatcher{
+		events: events,
+		client: httpclient.New(10 * time.Second),
+	}
+}
+
+// Dispatch message.
+func (d *DefaultDispatcher) Dispatch(message *Message) error {
+	switch message.Kind {
+	case KindEvent:
+		if d.events == nil {
+			return fmt.Errorf("Events bus is not available to publish %s", message.Topic)
+		}
+
+		d.events.Publish(message.Topic, message)
+
+		return nil
+
+	case KindWebhook:
+		return d.post(message)
+	}
+
+	return fmt.Errorf("Unknown outbox message kind %s", message.Kind)
+}
+
+// Post webhook.
+func (d *DefaultDispatcher) post(message *Message) error {
+	request, err := net_http.NewRequest("POST", message.URL, bytes.NewBufferString(message.Payload))
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Outbox-Topic", message.Topic)
+	request.Header.Set("X-Outbox-Message", strconv.FormatUint(uint64(message.ID), 10))
+
+	response, err := d.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 300 {
+		return fmt.Errorf("Webhook %s responded with %d", message.URL, response.StatusCode)
+	}
+
+	return nil
+}
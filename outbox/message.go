@@ -0,0 +1,80 @@
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Message kinds.
+const (
+	KindEvent   = "event"
+	KindWebhook = "webhook"
+)
+
+// Message waiting to be relayed.
+type Message struct {
+	ID          uint   `gorm:"primary_key"`
+	Kind        string `gorm:"size:16"`
+	Topic       string
+	URL         string
+	Payload     string `gorm:"type:text"`
+	Attempts    int
+	LastError   string     `gorm:"type:text"`
+	AvailableAt time.Time  `gorm:"index"`
+	ClaimedBy   string     `gorm:"size:32;index"`
+	SentAt      *time.Time `gorm:"index"`
+	FailedAt    *time.Time `gorm:"index"`
+	CreatedAt   time.Time
+}
+
+// TableName of the outbox.
+func (Message) TableName() string {
+	return "outbox_messages"
+}
+
+// Decode payload into target.
+func (m *Message) Decode(target interface{}) error {
+	return json.Unmarshal([]byte(m.Payload), target)
+}
+
+// Record event in the outbox within the transaction of the business change.
+func Record(tx *gorm.DB, topic string, payload interface{}) error {
+	return record(tx, &Message{Kind: KindEvent, Topic: topic}, payload)
+}
+
+// RecordWebhook records webhook call in the outbox within the transaction of the business change.
+func RecordWebhook(tx *gorm.DB, url, topic string, payload interface{}) error {
+	return record(tx, &Message{Kind: KindWebhook, URL: url, Topic: topic}, payload)
+}
+
+func record(tx *gorm.DB, message *Message, payload interface{}) error {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	message.Payload = string(encoded)
+	message.AvailableAt = time.Now()
+
+	return tx.Create(message).Error
+}
+
+// Migration creates outbox table.
+type Migration struct{}
+
+// MigrationID returns unique migration ID.
+func (m *Migration) MigrationID() string {
+	return "outbox_create_messages_table"
+}
+
+// Migrate runs migrations.
+func (m *Migration) Migrate(tx *gorm.DB) error {
+	return tx.AutoMigrate(&Message{}).Error
+}
+
+// Rollback changes.
+func (m *Migration) Rollback(tx *gorm.DB) error {
+	return tx.DropTableIfExists(&Message{}).Error
+}
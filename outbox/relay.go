@@ -0,0 +1,243 @@
+package outbox
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago/logger"
+)
+
+// Relay delivers messages from the outbox.
+// Every message is delivered at least once, so consumers must be idempotent.
+// Several relays may run at once, every batch is claimed by one of them for the lease time.
+type Relay struct {
+	db         *gorm.DB
+	dispatcher Dispatcher
+	logger     *logger.Logger
+
+	interval    time.Duration
+	batchSize   int
+	maxAttempts int
+	lease       time.Duration
+
+	stop     chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+// NewRelay constructor.
+func NewRelay(db *gorm.DB, dispatcher Dispatcher, logger *logger.Logger) *Relay {
+	return &Relay{
+		db:          db,
+		dispatcher:  dispatcher,
+		logger:      logger,
+		interval:    time.Second,
+		batchSize:   100,
+		maxAttempts: 10,
+		lease:       time.Minute,
+		stop:        make(chan struct{}),
+		stopped:     make(chan struct{}),
+	}
+}
+
+// SetInterval between outbox polls.
+func (r *Relay) SetInterval(interval time.Duration) *Relay {
+	r.interval = interval
+
+	return r
+}
+
+// SetBatchSize of messages relayed at once.
+func (r *Relay) SetBatchSize(size int) *Relay {
+	r.batchSize = size
+
+	return r
+}
+
+// SetMaxAttempts before message is given up.
+func (r *Relay) SetMaxAttempts(attempts int) *Relay {
+	r.maxAttempts = attempts
+
+	return r
+}
+
+// SetLease of the claimed batch. Messages not relayed within it are left to other relays.
+func (r *Relay) SetLease(lease time.Duration) *Relay {
+	r.lease = lease
+
+	return r
+}
+
+// MaxAttempts before message is given up.
+func (r *Relay) MaxAttempts() int {
+	return r.maxAttempts
+}
+
+// Pending returns amount of messages waiting to be relayed.
+func (r *Relay) Pending() (int, error) {
+	var count int
+	err := r.db.Model(&Message{}).Where("sent_at IS NULL AND failed_at IS NULL").Count(&count).Error
+
+	return count, err
+}
+
+// Failed returns amount of messages given up after max attempts.
+func (r *Relay) Failed() (int, error) {
+	var count int
+	err := r.db.Model(&Message{}).Where("failed_at IS NOT NULL").Count(&count).Error
+
+	return count, err
+}
+
+// Name of the component.
+func (r *Relay) Name() string {
+	return "outbox"
+}
+
+// Start relaying. Blocks until stopped.
+func (r *Relay) Start() error {
+	defer close(r.stopped)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		// Drain outbox completely before sleeping.
+		for {
+			relayed, err := r.RelayBatch()
+			if err != nil && r.logger != nil {
+				r.logger.Error(err)
+			}
+			if err != nil || relayed < r.batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-r.stop:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop relaying.
+func (r *Relay) Stop(ctx context.Context) error {
+	r.stopOnce.Do(func() {
+		close(r.stop)
+	})
+
+	select {
+	case <-r.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RelayBatch delivers one batch of available messages and returns their amount.
+func (r *Relay) RelayBatch() (int, error) {
+	messages, until, err := r.claim()
+	if err != nil {
+		return 0, err
+	}
+
+	for i, message := range messages {
+		// Messages left after the lease may be claimed by another relay already.
+		if time.Now().After(until) {
+			return i, nil
+		}
+
+		if err := r.relay(message); err != nil {
+			return i, err
+		}
+	}
+
+	return len(messages), nil
+}
+
+// Claim available messages hiding them from other relays until the lease ends.
+func (r *Relay) claim() ([]*Message, time.Time, error) {
+	var ids []uint
+	var messages []*Message
+
+	now := time.Now()
+	until := now.Add(r.lease)
+	available := "sent_at IS NULL AND failed_at IS NULL AND available_at <= ?"
+
+	err := r.db.Model(&Message{}).Where(available, now).Order("id").Limit(r.batchSize).Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return nil, until, err
+	}
+
+	// Conditions are checked again, so only one relay gets every message.
+	claim := newClaim()
+	err = r.db.Model(&Message{}).
+		Where("id IN (?)", ids).
+		Where(available, now).
+		Updates(map[string]interface{}{"claimed_by": claim, "available_at": until}).Error
+
+	if err != nil {
+		return nil, until, err
+	}
+
+	err = r.db.Where("claimed_by = ? AND sent_at IS NULL", claim).Order("id").Find(&messages).Error
+
+	return messages, until, err
+}
+
+// Deliver message and save the result.
+func (r *Relay) relay(message *Message) error {
+	if err := r.dispatcher.Dispatch(message); err != nil {
+		message.Attempts++
+		message.LastError = err.Error()
+		message.AvailableAt = time.Now().Add(backoff(message.Attempts))
+
+		if message.Attempts >= r.maxAttempts {
+			now := time.Now()
+			message.FailedAt = &now
+
+			if r.logger != nil {
+				r.logger.Error(fmt.Errorf("Outbox message %d (%s) failed %d times and is given up: %s", message.ID, message.Topic, message.Attempts, err))
+			}
+		} else if r.logger != nil {
+			r.logger.Warning("Outbox message %d (%s) failed: %s", message.ID, message.Topic, err)
+		}
+
+		return r.db.Model(message).Updates(map[string]interface{}{
+			"attempts":     message.Attempts,
+			"last_error":   message.LastError,
+			"available_at": message.AvailableAt,
+			"failed_at":    message.FailedAt,
+		}).Error
+	}
+
+	now := time.Now()
+	message.SentAt = &now
+
+	return r.db.Model(message).Update("sent_at", now).Error
+}
+
+// Unique claim of the batch.
+func newClaim() string {
+	random := make([]byte, 16)
+	rand.Read(random)
+
+	return hex.EncodeToString(random)
+}
+
+// Exponential backoff capped by one hour.
+func backoff(attempts int) time.Duration {
+	delay := time.Duration(math.Pow(2, float64(attempts))) * time.Second
+	if delay > time.Hour {
+		delay = time.Hour
+	}
+
+	return delay
+}
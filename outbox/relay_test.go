@@ -0,0 +1,177 @@
+package outbox_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago/httpclient/httpclienttest"
+	"github.com/lara-go/larago/outbox"
+	"github.com/lara-go/larago/support/testsuite"
+	"github.com/stretchr/testify/assert"
+
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+)
+
+type order struct {
+	ID     uint `gorm:"primary_key"`
+	Amount int
+}
+
+type recordingDispatcher struct {
+	fail     bool
+	messages []*outbox.Message
+}
+
+func (d *recordingDispatcher) Dispatch(message *outbox.Message) error {
+	if d.fail {
+		return errors.New("unavailable")
+	}
+
+	d.messages = append(d.messages, message)
+
+	return nil
+}
+
+func databaseFactory(t *testing.T) *gorm.DB {
+	db := testsuite.MemoryDB(t, &order{})
+	(&outbox.Migration{}).Migrate(db)
+
+	return db
+}
+
+func TestRecord_RolledBackWithTransaction(t *testing.T) {
+	db := databaseFactory(t)
+
+	tx := db.Begin()
+	tx.Create(&order{Amount: 10})
+	assert.NoError(t, outbox.Record(tx, "order.created", map[string]int{"amount": 10}))
+	tx.Rollback()
+
+	var count int
+	db.Model(&outbox.Message{}).Count(&count)
+	assert.Equal(t, 0, count)
+}
+
+func TestRelay_RelayBatch(t *testing.T) {
+	db := databaseFactory(t)
+
+	tx := db.Begin()
+	tx.Create(&order{Amount: 10})
+	outbox.Record(tx, "order.created", map[string]int{"amount": 10})
+	outbox.RecordWebhook(tx, "http://example.com/hook", "order.created", map[string]int{"amount": 10})
+	tx.Commit()
+
+	dispatcher := &recordingDispatcher{}
+	relay := outbox.NewRelay(db, dispatcher, nil)
+
+	pending, err := relay.Pending()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, pending)
+
+	relayed, err := relay.RelayBatch()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, relayed)
+	assert.Equal(t, outbox.KindEvent, dispatcher.messages[0].Kind)
+	assert.Equal(t, outbox.KindWebhook, dispatcher.messages[1].Kind)
+
+	var payload map[string]int
+	dispatcher.messages[0].Decode(&payload)
+	assert.Equal(t, 10, payload["amount"])
+
+	// Sent messages are not relayed twice.
+	relayed, _ = relay.RelayBatch()
+	assert.Equal(t, 0, relayed)
+
+	pending, _ = relay.Pending()
+	assert.Equal(t, 0, pending)
+}
+
+func TestRelay_RetryLater(t *testing.T) {
+	db := databaseFactory(t)
+
+	outbox.Record(db, "order.created", nil)
+
+	relay := outbox.NewRelay(db, &recordingDispatcher{fail: true}, nil)
+	relayed, err := relay.RelayBatch()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, relayed)
+
+	var message outbox.Message
+	db.First(&message)
+	assert.Equal(t, 1, message.Attempts)
+	assert.Equal(t, "unavailable", message.LastError)
+	assert.Nil(t, message.SentAt)
+
+	// Message is postponed with backoff.
+	relayed, _ = relay.RelayBatch()
+	assert.Equal(t, 0, relayed)
+}
+
+type reentrantDispatcher struct {
+	relay    *outbox.Relay
+	relayed  int
+	messages int
+}
+
+func (d *reentrantDispatcher) Dispatch(message *outbox.Message) error {
+	// Another relay runs while the batch is dispatched.
+	relayed, _ := d.relay.RelayBatch()
+	d.relayed += relayed
+	d.messages++
+
+	return nil
+}
+
+func TestRelay_ClaimsBatch(t *testing.T) {
+	db := databaseFactory(t)
+
+	outbox.Record(db, "order.created", nil)
+	outbox.Record(db, "order.paid", nil)
+
+	other := &recordingDispatcher{}
+	dispatcher := &reentrantDispatcher{relay: outbox.NewRelay(db, other, nil)}
+
+	relayed, err := outbox.NewRelay(db, dispatcher, nil).RelayBatch()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, relayed)
+	assert.Equal(t, 2, dispatcher.messages)
+	assert.Equal(t, 0, dispatcher.relayed)
+	assert.Empty(t, other.messages)
+}
+
+func TestRelay_GiveUpAfterMaxAttempts(t *testing.T) {
+	db := databaseFactory(t)
+
+	outbox.Record(db, "order.created", nil)
+
+	relay := outbox.NewRelay(db, &recordingDispatcher{fail: true}, nil).SetMaxAttempts(1)
+	relay.RelayBatch()
+
+	var message outbox.Message
+	db.First(&message)
+	assert.NotNil(t, message.FailedAt)
+
+	failed, err := relay.Failed()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, failed)
+
+	pending, _ := relay.Pending()
+	assert.Equal(t, 0, pending)
+}
+
+func TestDefaultDispatcher_PostsWebhook(t *testing.T) {
+	fake := httpclienttest.Fake()
+	defer fake.Restore()
+
+	fake.Stub(httpclienttest.URL("http://example.com/hook")).Respond(204, "")
+
+	message := &outbox.Message{ID: 7, Kind: outbox.KindWebhook, URL: "http://example.com/hook", Topic: "order.created", Payload: `{"amount":10}`}
+	assert.NoError(t, outbox.NewDefaultDispatcher(nil).Dispatch(message))
+
+	fake.AssertSent(t,
+		httpclienttest.Method("POST"),
+		httpclienttest.Header("X-Outbox-Topic", "order.created"),
+		httpclienttest.JSONBody(map[string]int{"amount": 10}),
+	)
+}
@@ -0,0 +1,29 @@
+package outbox
+
+import (
+	"github.com/asaskevich/EventBus"
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago"
+	"github.com/lara-go/larago/logger"
+)
+
+// ServiceProvider struct.
+type ServiceProvider struct{}
+
+// Register service.
+func (p *ServiceProvider) Register(application *larago.Application) {
+	application.Commands(&CommandRelay{})
+
+	application.Bind(func() (*Relay, error) {
+		var events *EventBus.EventBus
+		if application.Bound("events") {
+			events = application.Get("events").(*EventBus.EventBus)
+		}
+
+		return NewRelay(
+			application.Get("db.connection").(*gorm.DB),
+			NewDefaultDispatcher(events),
+			application.Get("logger").(*logger.Logger),
+		), nil
+	}, "outbox")
+}
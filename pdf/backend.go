@@ -0,0 +1,158 @@
+package pdf
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// Options of the document.
+type Options struct {
+	PageSize     string
+	Landscape    bool
+	MarginTop    string
+	MarginRight  string
+	MarginBottom string
+	MarginLeft   string
+}
+
+// DefaultOptions for A4 portrait documents.
+func DefaultOptions() Options {
+	return Options{
+		PageSize:     "A4",
+		MarginTop:    "10mm",
+		MarginRight:  "10mm",
+		MarginBottom: "10mm",
+		MarginLeft:   "10mm",
+	}
+}
+
+// Backend converts html into PDF. Rendering stops when the context is done.
+type Backend interface {
+	Render(ctx context.Context, html string, options Options) ([]byte, error)
+}
+
+// BackendFunc adapts function to Backend, ex. to build documents with gofpdf.
+type BackendFunc func(ctx context.Context, html string, options Options) ([]byte, error)
+
+// Render html.
+func (f BackendFunc) Render(ctx context.Context, html string, options Options) ([]byte, error) {
+	return f(ctx, html, options)
+}
+
+// Wkhtmltopdf backend.
+type Wkhtmltopdf struct {
+	Binary string
+}
+
+// NewWkhtmltopdf constructor. Binary is looked up in PATH if empty.
+func NewWkhtmltopdf(binary string) *Wkhtmltopdf {
+	if binary == "" {
+		binary = "wkhtmltopdf"
+	}
+
+	return &Wkhtmltopdf{Binary: binary}
+}
+
+// Render html.
+func (b *Wkhtmltopdf) Render(ctx context.Context, html string, options Options) ([]byte, error) {
+	args := []string{
+		"--quiet",
+		"--page-size", options.PageSize,
+		"--margin-top", options.MarginTop,
+		"--margin-right", options.MarginRight,
+		"--margin-bottom", options.MarginBottom,
+		"--margin-left", options.MarginLeft,
+	}
+
+	if options.Landscape {
+		args = append(args, "--orientation", "Landscape")
+	}
+
+	// Read html from stdin and write PDF to stdout.
+	args = append(args, "-", "-")
+
+	return run(ctx, exec.CommandContext(ctx, b.Binary, args...), html)
+}
+
+// Chromium backend running headless chromium or chrome.
+type Chromium struct {
+	Binary string
+}
+
+// NewChromium constructor. Binary is looked up in PATH if empty.
+func NewChromium(binary string) *Chromium {
+	if binary == "" {
+		binary = "chromium"
+	}
+
+	return &Chromium{Binary: binary}
+}
+
+// Render html. Page size and margins are taken from @page CSS rule of the document.
+func (b *Chromium) Render(ctx context.Context, html string, options Options) ([]byte, error) {
+	dir, err := ioutil.TempDir("", "larago-pdf")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	source := filepath.Join(dir, "document.html")
+	target := filepath.Join(dir, "document.pdf")
+
+	if err := ioutil.WriteFile(source, []byte(withPageRule(html, options)), 0600); err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(
+		ctx,
+		b.Binary,
+		"--headless",
+		"--disable-gpu",
+		"--no-pdf-header-footer",
+		"--print-to-pdf="+target,
+		"file://"+source,
+	)
+
+	if _, err := run(ctx, cmd, ""); err != nil {
+		return nil, err
+	}
+
+	return ioutil.ReadFile(target)
+}
+
+// Prepend @page rule with options, so they can be overridden by the document.
+func withPageRule(html string, options Options) string {
+	size := options.PageSize
+	if options.Landscape {
+		size += " landscape"
+	}
+
+	return fmt.Sprintf(
+		"<style>@page { size: %s; margin: %s %s %s %s; }</style>\n%s",
+		size, options.MarginTop, options.MarginRight, options.MarginBottom, options.MarginLeft, html,
+	)
+}
+
+// Run command, passing input to stdin. Command is killed when the context is done.
+func run(ctx context.Context, cmd *exec.Cmd, input string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd.Stdin = bytes.NewBufferString(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("%s failed: %s", filepath.Base(cmd.Path), ctx.Err())
+		}
+
+		return nil, fmt.Errorf("%s failed: %s: %s", filepath.Base(cmd.Path), err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,11 @@
+package pdf
+
+import "github.com/lara-go/larago"
+
+// FacadeWrapper for facade.
+var FacadeWrapper = &larago.Facade{}
+
+// Facade for PDF generator.
+func Facade() *Generator {
+	return FacadeWrapper.Resolve("pdf").(*Generator)
+}
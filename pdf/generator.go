@@ -0,0 +1,120 @@
+package pdf
+
+import (
+	"context"
+	"io/ioutil"
+	net_http "net/http"
+	"time"
+
+	"github.com/lara-go/larago/http/responses"
+	"github.com/lara-go/larago/view"
+)
+
+// DefaultTimeout of rendering a document.
+const DefaultTimeout = time.Minute
+
+// Generator renders views into PDF documents.
+type Generator struct {
+	view    *view.Engine
+	backend Backend
+	options Options
+	ctx     context.Context
+	timeout time.Duration
+}
+
+// NewGenerator constructor.
+func NewGenerator(view *view.Engine, backend Backend) *Generator {
+	return &Generator{
+		view:    view,
+		backend: backend,
+		options: DefaultOptions(),
+		ctx:     context.Background(),
+		timeout: DefaultTimeout,
+	}
+}
+
+// SetTimeout of rendering a document, 0 renders without timeout.
+func (g *Generator) SetTimeout(timeout time.Duration) *Generator {
+	g.timeout = timeout
+
+	return g
+}
+
+// WithContext returns generator which stops rendering when the context is done,
+// ex. when client of the request is gone:
+//
+//	pdf.Facade().WithContext(request.BaseRequest().Context()).Download("invoice", invoice, "invoice.pdf")
+func (g *Generator) WithContext(ctx context.Context) *Generator {
+	clone := *g
+	clone.ctx = ctx
+
+	return &clone
+}
+
+// WithOptions returns generator using these document options.
+func (g *Generator) WithOptions(options Options) *Generator {
+	clone := *g
+	clone.options = options
+
+	return &clone
+}
+
+// Render view into PDF.
+func (g *Generator) Render(name string, data interface{}) ([]byte, error) {
+	html, err := g.view.Render(name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := g.ctx
+	if g.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.timeout)
+		defer cancel()
+	}
+
+	return g.backend.Render(ctx, html, g.options)
+}
+
+// Response displaying rendered view in browser.
+func (g *Generator) Response(name string, data interface{}, filename string) (*responses.PDF, error) {
+	content, err := g.Render(name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return responses.NewPDF(net_http.StatusOK, content, filename), nil
+}
+
+// Download response with rendered view.
+func (g *Generator) Download(name string, data interface{}, filename string) (*responses.PDF, error) {
+	response, err := g.Response(name, data, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Download(filename), nil
+}
+
+// Save rendered view to the file.
+func (g *Generator) Save(name string, data interface{}, path string) error {
+	content, err := g.Render(name, data)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, content, 0644)
+}
+
+// SaveAsync renders large document in background. Result is sent to the channel.
+// Context of the request would stop rendering when the request is handled, so do not pass it here.
+func (g *Generator) SaveAsync(name string, data interface{}, path string) <-chan error {
+	done := make(chan error, 1)
+
+	go func() {
+		done <- g.Save(name, data, path)
+		close(done)
+	}()
+
+	return done
+}
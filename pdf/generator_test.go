@@ -0,0 +1,68 @@
+package pdf_test
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/lara-go/larago/pdf"
+	"github.com/lara-go/larago/view"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeneratorRendersView(t *testing.T) {
+	engine := view.NewEngine().AddFS(fstest.MapFS{
+		"invoice.html": {Data: []byte(`<h1>Invoice {{ .Number }}</h1>`)},
+	})
+
+	var rendered string
+	var options pdf.Options
+	backend := pdf.BackendFunc(func(ctx context.Context, html string, o pdf.Options) ([]byte, error) {
+		rendered, options = html, o
+		return []byte("%PDF-1.4"), nil
+	})
+
+	generator := pdf.NewGenerator(engine, backend)
+
+	response, err := generator.Response("invoice", map[string]int{"Number": 42}, "invoice.pdf")
+	assert.Nil(t, err)
+	assert.Equal(t, "<h1>Invoice 42</h1>", rendered)
+	assert.Equal(t, "A4", options.PageSize)
+	assert.Equal(t, "application/pdf", response.ContentType())
+	assert.Equal(t, "%PDF-1.4", string(response.Body()))
+	assert.Equal(t, `inline; filename="invoice.pdf"`, response.Headers()["Content-Disposition"])
+
+	landscape := pdf.DefaultOptions()
+	landscape.Landscape = true
+
+	download, err := generator.WithOptions(landscape).Download("invoice", map[string]int{"Number": 1}, "invoice.pdf")
+	assert.Nil(t, err)
+	assert.True(t, options.Landscape)
+	assert.Equal(t, `attachment; filename="invoice.pdf"`, download.Headers()["Content-Disposition"])
+}
+
+func TestGeneratorStopsRendering(t *testing.T) {
+	engine := view.NewEngine().AddFS(fstest.MapFS{
+		"invoice.html": {Data: []byte(`<h1>Invoice</h1>`)},
+	})
+
+	// Backend hangs until killed.
+	binary := filepath.Join(t.TempDir(), "wkhtmltopdf")
+	assert.NoError(t, ioutil.WriteFile(binary, []byte("#!/bin/sh\nexec sleep 10\n"), 0755))
+
+	generator := pdf.NewGenerator(engine, pdf.NewWkhtmltopdf(binary)).SetTimeout(50 * time.Millisecond)
+
+	started := time.Now()
+	_, err := generator.Render("invoice", nil)
+	assert.EqualError(t, err, "wkhtmltopdf failed: context deadline exceeded")
+	assert.True(t, time.Since(started) < 5*time.Second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = generator.WithContext(ctx).Render("invoice", nil)
+	assert.EqualError(t, err, "wkhtmltopdf failed: context canceled")
+}
@@ -0,0 +1,55 @@
+package pdf
+
+import (
+	"fmt"
+
+	"github.com/lara-go/larago"
+	"github.com/lara-go/larago/view"
+)
+
+// ServiceProvider struct.
+type ServiceProvider struct{}
+
+// Register service.
+func (p *ServiceProvider) Register(application *larago.Application) {
+	application.Bind(func() (*Generator, error) {
+		backend, err := p.backend(application.Config())
+		if err != nil {
+			return nil, err
+		}
+
+		generator := NewGenerator(application.Get("view").(*view.Engine), backend)
+
+		if config := application.Config(); config.Has("PDF.Timeout") {
+			timeout, err := config.Duration("PDF.Timeout")
+			if err != nil {
+				return nil, err
+			}
+			generator.SetTimeout(timeout)
+		}
+
+		return generator, nil
+	}, "pdf")
+}
+
+// Make backend from PDF.Driver and PDF.Binary config values. Rendering time is limited by PDF.Timeout.
+func (p *ServiceProvider) backend(config *larago.ConfigRepository) (Backend, error) {
+	driver, binary := "wkhtmltopdf", ""
+
+	if config.Has("PDF.Driver") {
+		driver = config.Get("PDF.Driver").(string)
+	}
+
+	if config.Has("PDF.Binary") {
+		binary = config.Get("PDF.Binary").(string)
+	}
+
+	switch driver {
+	case "wkhtmltopdf":
+		return NewWkhtmltopdf(binary), nil
+	case "chromium":
+		return NewChromium(binary), nil
+	default:
+		return nil, fmt.Errorf("Unknown PDF driver %s", driver)
+	}
+}
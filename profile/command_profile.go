@@ -0,0 +1,250 @@
+package profile
+
+import (
+	"errors"
+	"fmt"
+	net_http "net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lara-go/larago"
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/logger"
+	"github.com/lara-go/larago/schedule"
+
+	"github.com/urfave/cli"
+)
+
+// CommandProfile runs workload against the booted application and records profiles.
+type CommandProfile struct {
+	Application *larago.Application
+	Logger      *logger.Logger
+
+	output      string
+	method      string
+	headers     cli.StringSlice
+	tasks       cli.StringSlice
+	requests    int
+	duration    time.Duration
+	concurrency int
+}
+
+// GetCommand for the cli to register.
+func (c *CommandProfile) GetCommand() cli.Command {
+	return cli.Command{
+		Name:  "profile",
+		Usage: "Profile application under the workload",
+		UsageText: "Runs requests to the path (or scheduled tasks given by --task) in process " +
+			"and writes CPU, heap and execution trace profiles with a report.\n",
+		ArgsUsage: "[path]",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:        "output, o",
+				Usage:       "directory to write profiles to (default: profiles/<timestamp>)",
+				Destination: &c.output,
+			},
+			cli.StringFlag{
+				Name:        "method, m",
+				Value:       net_http.MethodGet,
+				Usage:       "HTTP method to use",
+				Destination: &c.method,
+			},
+			cli.StringSliceFlag{
+				Name:  "header, H",
+				Usage: "additional header to send (ex. 'Accept: application/json')",
+				Value: &c.headers,
+			},
+			cli.StringSliceFlag{
+				Name:  "task, t",
+				Usage: "scheduled task to run instead of requests",
+				Value: &c.tasks,
+			},
+			cli.IntFlag{
+				Name:        "requests, n",
+				Value:       1000,
+				Usage:       "number of requests or task runs",
+				Destination: &c.requests,
+			},
+			cli.DurationFlag{
+				Name:        "duration, d",
+				Usage:       "run workload for the duration instead of fixed number of operations",
+				Destination: &c.duration,
+			},
+			cli.IntFlag{
+				Name:        "concurrency, c",
+				Value:       10,
+				Usage:       "number of concurrent workers",
+				Destination: &c.concurrency,
+			},
+		},
+	}
+}
+
+// Handle command.
+func (c *CommandProfile) Handle(args cli.Args) error {
+	if c.concurrency <= 0 {
+		return errors.New("Concurrency must be positive")
+	}
+
+	if c.duration <= 0 && c.requests <= 0 {
+		return errors.New("Number of requests or duration must be positive")
+	}
+
+	workload, operation, err := c.workload(args)
+	if err != nil {
+		return err
+	}
+
+	output := c.output
+	if output == "" {
+		output = filepath.Join("profiles", time.Now().Format("20060102-150405"))
+	}
+
+	c.Logger.Info("Profiling %s...", workload)
+
+	profiler := NewProfiler(output)
+	if err := profiler.Start(); err != nil {
+		return fmt.Errorf("Can't start profiler: %s", err)
+	}
+
+	samples := Run(operation, c.concurrency, c.requests, c.duration)
+
+	report, err := profiler.Stop(workload, samples)
+	if err != nil {
+		return fmt.Errorf("Can't write profiles: %s", err)
+	}
+
+	fmt.Print(report)
+
+	if report.Failures > 0 {
+		c.Logger.Warning("%d of %d operations failed.", report.Failures, report.Operations)
+	}
+
+	c.Logger.Success("Profiles are written to %s, inspect them with 'go tool pprof' and 'go tool trace'.", output)
+
+	return nil
+}
+
+// Make operation for the workload asked.
+func (c *CommandProfile) workload(args cli.Args) (string, func() bool, error) {
+	if len(c.tasks) > 0 {
+		return c.taskWorkload()
+	}
+
+	path := args.First()
+	if path == "" {
+		return "", nil, errors.New("Path or task is required")
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	handler := c.Application.Get("router").(*http.Router).Bootstrap().GetHTTPRouter()
+
+	return fmt.Sprintf("%s %s", c.method, path), func() bool {
+		return c.request(handler, path)
+	}, nil
+}
+
+// Run scheduled tasks in turn.
+func (c *CommandProfile) taskWorkload() (string, func() bool, error) {
+	if !c.Application.Bound("schedule") {
+		return "", nil, errors.New("Scheduler is not registered")
+	}
+
+	scheduler := c.Application.Get("schedule").(*schedule.Scheduler)
+
+	tasks := make([]*schedule.Task, 0, len(c.tasks))
+	for _, name := range c.tasks {
+		task, ok := scheduler.Find(name)
+		if !ok {
+			return "", nil, fmt.Errorf("Task %s is not scheduled", name)
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	var mutex sync.Mutex
+	var next int
+
+	return "tasks " + strings.Join(c.tasks, ", "), func() bool {
+		mutex.Lock()
+		task := tasks[next%len(tasks)]
+		next++
+		mutex.Unlock()
+
+		return !task.Exec().Failed()
+	}, nil
+}
+
+// Make request to the application in process.
+func (c *CommandProfile) request(handler net_http.Handler, path string) bool {
+	request := httptest.NewRequest(c.method, path, nil)
+	for _, header := range c.headers {
+		if parts := strings.SplitN(header, ":", 2); len(parts) == 2 {
+			request.Header.Set(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	return recorder.Code < 500
+}
+
+// Run operation with concurrent workers, until number of operations is made
+// or duration is over if it is given. Operation returns false when it failed.
+func Run(operation func() bool, concurrency, operations int, duration time.Duration) []Sample {
+	var deadline time.Time
+	if duration > 0 {
+		deadline = time.Now().Add(duration)
+	}
+
+	var mutex sync.Mutex
+	var samples []Sample
+
+	// Take next operation, false when workload is done.
+	take := func() bool {
+		if !deadline.IsZero() {
+			return time.Now().Before(deadline)
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if operations <= 0 {
+			return false
+		}
+		operations--
+
+		return true
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			var collected []Sample
+			for take() {
+				startTime := time.Now()
+				ok := operation()
+				collected = append(collected, Sample{Latency: time.Since(startTime), Failed: !ok})
+			}
+
+			mutex.Lock()
+			samples = append(samples, collected...)
+			mutex.Unlock()
+		}()
+	}
+
+	wg.Wait()
+
+	return samples
+}
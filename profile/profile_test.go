@@ -0,0 +1,80 @@
+package profile_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lara-go/larago/profile"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunOperations(t *testing.T) {
+	var calls int32
+	samples := profile.Run(func() bool {
+		return atomic.AddInt32(&calls, 1)%4 != 0
+	}, 3, 20, 0)
+
+	assert.Len(t, samples, 20)
+	assert.Equal(t, int32(20), calls)
+
+	var failed int
+	for _, sample := range samples {
+		if sample.Failed {
+			failed++
+		}
+	}
+	assert.Equal(t, 5, failed)
+}
+
+func TestRunDuration(t *testing.T) {
+	startTime := time.Now()
+	samples := profile.Run(func() bool {
+		time.Sleep(time.Millisecond)
+		return true
+	}, 2, 0, 20*time.Millisecond)
+
+	assert.NotEmpty(t, samples)
+	assert.True(t, time.Since(startTime) >= 20*time.Millisecond)
+}
+
+func TestProfiler(t *testing.T) {
+	directory, _ := ioutil.TempDir("", "profile")
+	defer os.RemoveAll(directory)
+
+	profiler := profile.NewProfiler(filepath.Join(directory, "run"))
+	assert.NoError(t, profiler.Start())
+	assert.Error(t, profiler.Start())
+
+	samples := profile.Run(func() bool {
+		_ = make([]byte, 1024)
+		return true
+	}, 2, 100, 0)
+
+	report, err := profiler.Stop("test", samples)
+	assert.NoError(t, err)
+	assert.Equal(t, 100, report.Operations)
+	assert.Equal(t, 0, report.Failures)
+	assert.True(t, report.Latency.Max >= report.Latency.P50)
+	assert.Contains(t, report.String(), "Operations: 100")
+
+	for _, file := range []string{profile.CPUFile, profile.HeapFile, profile.TraceFile, profile.ReportFile} {
+		info, err := os.Stat(filepath.Join(directory, "run", file))
+		assert.NoError(t, err, file)
+		if err == nil {
+			assert.NotZero(t, info.Size(), file)
+		}
+	}
+
+	encoded, _ := ioutil.ReadFile(filepath.Join(directory, "run", profile.ReportFile))
+	var saved profile.Report
+	assert.NoError(t, json.Unmarshal(encoded, &saved))
+	assert.Equal(t, "test", saved.Workload)
+
+	_, err = profiler.Stop("test", nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,253 @@
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Names of the files written to the output directory.
+const (
+	CPUFile    = "cpu.pprof"
+	HeapFile   = "heap.pprof"
+	TraceFile  = "trace.out"
+	ReportFile = "report.json"
+)
+
+// Sample is a single operation made by the workload.
+type Sample struct {
+	Latency time.Duration
+	Failed  bool
+}
+
+// Latency summary of the workload.
+type Latency struct {
+	Avg time.Duration `json:"avg"`
+	P50 time.Duration `json:"p50"`
+	P90 time.Duration `json:"p90"`
+	P99 time.Duration `json:"p99"`
+	Max time.Duration `json:"max"`
+}
+
+// Memory stats collected while workload was running.
+type Memory struct {
+	TotalAlloc uint64        `json:"total_alloc"`
+	Mallocs    uint64        `json:"mallocs"`
+	HeapInUse  uint64        `json:"heap_in_use"`
+	NumGC      uint32        `json:"num_gc"`
+	PauseTotal time.Duration `json:"gc_pause_total"`
+}
+
+// Report of the profiled workload.
+type Report struct {
+	Workload   string        `json:"workload"`
+	StartedAt  time.Time     `json:"started_at"`
+	Duration   time.Duration `json:"duration"`
+	Operations int           `json:"operations"`
+	Failures   int           `json:"failures"`
+	Throughput float64       `json:"throughput"`
+	Latency    Latency       `json:"latency"`
+	Memory     Memory        `json:"memory"`
+	Goroutines int           `json:"goroutines"`
+	Files      []string      `json:"files"`
+}
+
+// Profiler records CPU profile and execution trace while workload runs,
+// then writes heap profile and report to the output directory.
+type Profiler struct {
+	directory string
+
+	cpu       *os.File
+	trace     *os.File
+	startedAt time.Time
+	memory    runtime.MemStats
+}
+
+// NewProfiler constructor.
+func NewProfiler(directory string) *Profiler {
+	return &Profiler{
+		directory: directory,
+	}
+}
+
+// Start profiling.
+func (p *Profiler) Start() error {
+	if p.cpu != nil {
+		return errors.New("Profiler is already started")
+	}
+
+	if err := os.MkdirAll(p.directory, 0755); err != nil {
+		return err
+	}
+
+	cpu, err := os.Create(filepath.Join(p.directory, CPUFile))
+	if err != nil {
+		return err
+	}
+
+	if err := pprof.StartCPUProfile(cpu); err != nil {
+		cpu.Close()
+		return err
+	}
+
+	executionTrace, err := os.Create(filepath.Join(p.directory, TraceFile))
+	if err != nil {
+		pprof.StopCPUProfile()
+		cpu.Close()
+		return err
+	}
+
+	if err := trace.Start(executionTrace); err != nil {
+		pprof.StopCPUProfile()
+		cpu.Close()
+		executionTrace.Close()
+		return err
+	}
+
+	p.cpu = cpu
+	p.trace = executionTrace
+	p.startedAt = time.Now()
+	runtime.ReadMemStats(&p.memory)
+
+	return nil
+}
+
+// Stop profiling and write report of the samples.
+func (p *Profiler) Stop(workload string, samples []Sample) (*Report, error) {
+	if p.cpu == nil {
+		return nil, errors.New("Profiler is not started")
+	}
+
+	duration := time.Since(p.startedAt)
+
+	trace.Stop()
+	pprof.StopCPUProfile()
+	p.trace.Close()
+	p.cpu.Close()
+	p.cpu, p.trace = nil, nil
+
+	var memory runtime.MemStats
+	runtime.ReadMemStats(&memory)
+
+	report := &Report{
+		Workload:   workload,
+		StartedAt:  p.startedAt,
+		Duration:   duration,
+		Operations: len(samples),
+		Latency:    summarize(samples),
+		Goroutines: runtime.NumGoroutine(),
+		Memory: Memory{
+			TotalAlloc: memory.TotalAlloc - p.memory.TotalAlloc,
+			Mallocs:    memory.Mallocs - p.memory.Mallocs,
+			HeapInUse:  memory.HeapInuse,
+			NumGC:      memory.NumGC - p.memory.NumGC,
+			PauseTotal: time.Duration(memory.PauseTotalNs - p.memory.PauseTotalNs),
+		},
+		Files: []string{CPUFile, TraceFile, HeapFile, ReportFile},
+	}
+
+	for _, sample := range samples {
+		if sample.Failed {
+			report.Failures++
+		}
+	}
+
+	if duration > 0 {
+		report.Throughput = float64(len(samples)) / duration.Seconds()
+	}
+
+	if err := p.writeHeap(); err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(p.directory, ReportFile), encoded, 0644); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// Write heap profile after garbage collection, so it shows live objects.
+func (p *Profiler) writeHeap() error {
+	heap, err := os.Create(filepath.Join(p.directory, HeapFile))
+	if err != nil {
+		return err
+	}
+	defer heap.Close()
+
+	runtime.GC()
+
+	return pprof.WriteHeapProfile(heap)
+}
+
+// String formats report for the console.
+func (r *Report) String() string {
+	var report strings.Builder
+
+	fmt.Fprintf(&report, "Workload: %s\n", r.Workload)
+	fmt.Fprintf(&report, "Operations: %d in %s, %.2f op/sec, %d failed\n", r.Operations, r.Duration, r.Throughput, r.Failures)
+	fmt.Fprintf(
+		&report,
+		"Latency: avg %s, p50 %s, p90 %s, p99 %s, max %s\n",
+		r.Latency.Avg, r.Latency.P50, r.Latency.P90, r.Latency.P99, r.Latency.Max,
+	)
+	fmt.Fprintf(
+		&report,
+		"Memory: %d bytes allocated in %d objects, %d bytes of heap in use\n",
+		r.Memory.TotalAlloc, r.Memory.Mallocs, r.Memory.HeapInUse,
+	)
+	fmt.Fprintf(&report, "GC: %d runs, %s paused\n", r.Memory.NumGC, r.Memory.PauseTotal)
+	fmt.Fprintf(&report, "Goroutines: %d\n", r.Goroutines)
+
+	return report.String()
+}
+
+// Summarize latencies of the samples.
+func summarize(samples []Sample) Latency {
+	if len(samples) == 0 {
+		return Latency{}
+	}
+
+	var total time.Duration
+	latencies := make([]time.Duration, len(samples))
+	for i, sample := range samples {
+		latencies[i] = sample.Latency
+		total += sample.Latency
+	}
+
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+
+	return Latency{
+		Avg: total / time.Duration(len(latencies)),
+		P50: percentile(latencies, 50),
+		P90: percentile(latencies, 90),
+		P99: percentile(latencies, 99),
+		Max: latencies[len(latencies)-1],
+	}
+}
+
+// Get percentile value from sorted latencies.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	index := len(sorted) * p / 100
+	if index >= len(sorted) {
+		index = len(sorted) - 1
+	}
+
+	return sorted[index]
+}
@@ -0,0 +1,13 @@
+package profile
+
+import "github.com/lara-go/larago"
+
+// ServiceProvider struct.
+type ServiceProvider struct{}
+
+// Register service.
+func (p *ServiceProvider) Register(application *larago.Application) {
+	application.Commands(
+		&CommandProfile{},
+	)
+}
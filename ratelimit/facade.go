@@ -0,0 +1,11 @@
+package ratelimit
+
+import "github.com/lara-go/larago"
+
+// FacadeWrapper for facade.
+var FacadeWrapper = &larago.Facade{}
+
+// Facade for named rate limiters.
+func Facade() *RateLimiter {
+	return FacadeWrapper.Resolve("ratelimiter").(*RateLimiter)
+}
@@ -0,0 +1,201 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// Sweep idle buckets every that many takes.
+const sweepEvery = 1000
+
+// Retry-After of the limits denying every request.
+const denyRetryAfter = time.Hour
+
+// Limit of the token bucket.
+type Limit struct {
+	// Rate of tokens refill per second.
+	Rate float64
+
+	// Burst is the size of the bucket.
+	Burst int
+
+	// Key of the bucket, used by named limiters.
+	Key string
+
+	unlimited bool
+}
+
+// Unlimited does not limit requests, ex. for internal services.
+func Unlimited() Limit {
+	return Limit{unlimited: true}
+}
+
+// Deny rejects every request, same as a limit with zero rate.
+func Deny() Limit {
+	return Limit{}
+}
+
+// IsDenied checks if every request is rejected.
+func (l Limit) IsDenied() bool {
+	return !l.unlimited && l.Rate <= 0
+}
+
+// Check that request of the cost can ever be allowed by the limit.
+func (l Limit) check(cost int) error {
+	if !l.unlimited && !l.IsDenied() && cost > l.Burst {
+		return fmt.Errorf("Cost %d is greater than the rate limit burst %d, such requests are never allowed", cost, l.Burst)
+	}
+
+	return nil
+}
+
+// IsUnlimited checks if requests are not limited.
+func (l Limit) IsUnlimited() bool {
+	return l.unlimited
+}
+
+// By sets key of the bucket, ex. user id.
+func (l Limit) By(key string) Limit {
+	l.Key = key
+
+	return l
+}
+
+// PerSecond limit.
+func PerSecond(tokens int) Limit {
+	return Limit{Rate: float64(tokens), Burst: tokens}
+}
+
+// PerMinute limit.
+func PerMinute(tokens int) Limit {
+	return Limit{Rate: float64(tokens) / 60, Burst: tokens}
+}
+
+// PerHour limit.
+func PerHour(tokens int) Limit {
+	return Limit{Rate: float64(tokens) / 3600, Burst: tokens}
+}
+
+// WithBurst changes bucket size keeping the refill rate.
+func (l Limit) WithBurst(burst int) Limit {
+	l.Burst = burst
+
+	return l
+}
+
+// Quota state after the take.
+type Quota struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	Cost       int
+	RetryAfter time.Duration
+	ResetAfter time.Duration
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	limit   Limit
+}
+
+// Limiter keeps token buckets by keys in memory.
+type Limiter struct {
+	buckets map[string]*bucket
+	takes   int
+	mutex   sync.Mutex
+	now     func() time.Time
+}
+
+// NewLimiter constructor.
+func NewLimiter() *Limiter {
+	return &Limiter{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Take cost tokens from the bucket if there are enough of them.
+// Limits with zero rate deny every request.
+func (l *Limiter) Take(key string, limit Limit, cost int) Quota {
+	return l.take(key, limit, cost, true)
+}
+
+// Peek shows quota as if cost tokens were taken, not taking them.
+func (l *Limiter) Peek(key string, limit Limit, cost int) Quota {
+	return l.take(key, limit, cost, false)
+}
+
+// Reset bucket to the full state.
+func (l *Limiter) Reset(key string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	delete(l.buckets, key)
+}
+
+func (l *Limiter) take(key string, limit Limit, cost int, consume bool) Quota {
+	if limit.IsDenied() {
+		return Quota{Limit: limit.Burst, Cost: cost, RetryAfter: denyRetryAfter, ResetAfter: denyRetryAfter}
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.now()
+
+	l.takes++
+	if l.takes%sweepEvery == 0 {
+		l.sweep(now)
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		l.buckets[key] = b
+	}
+	b.limit = limit
+
+	// Refill tokens for the passed time.
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+now.Sub(b.updated).Seconds()*limit.Rate)
+	b.updated = now
+
+	quota := Quota{
+		Limit: limit.Burst,
+		Cost:  cost,
+	}
+
+	if b.tokens >= float64(cost) {
+		quota.Allowed = true
+		if consume {
+			b.tokens -= float64(cost)
+		}
+	} else {
+		quota.RetryAfter = l.duration(float64(cost)-b.tokens, limit)
+	}
+
+	quota.Remaining = int(math.Floor(b.tokens))
+	quota.ResetAfter = l.duration(float64(limit.Burst)-b.tokens, limit)
+
+	return quota
+}
+
+// Time needed to refill tokens.
+func (l *Limiter) duration(tokens float64, limit Limit) time.Duration {
+	if tokens <= 0 {
+		return 0
+	}
+
+	return time.Duration(math.Ceil(tokens / limit.Rate * float64(time.Second)))
+}
+
+// Remove buckets that are already refilled.
+func (l *Limiter) sweep(now time.Time) {
+	for key, b := range l.buckets {
+		if now.Sub(b.updated) >= l.duration(float64(b.limit.Burst), b.limit) {
+			delete(l.buckets, key)
+		}
+	}
+}
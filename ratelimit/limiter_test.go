@@ -0,0 +1,78 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func limiterFactory() (*Limiter, *time.Time) {
+	now := time.Now()
+	limiter := NewLimiter()
+	limiter.now = func() time.Time {
+		return now
+	}
+
+	return limiter, &now
+}
+
+func TestLimiter_Burst(t *testing.T) {
+	limiter, _ := limiterFactory()
+	limit := PerMinute(60).WithBurst(3)
+
+	for i := 0; i < 3; i++ {
+		assert.True(t, limiter.Take("key", limit, 1).Allowed)
+	}
+
+	quota := limiter.Take("key", limit, 1)
+	assert.False(t, quota.Allowed)
+	assert.Equal(t, 0, quota.Remaining)
+	assert.Equal(t, time.Second, quota.RetryAfter)
+}
+
+func TestLimiter_Cost(t *testing.T) {
+	limiter, now := limiterFactory()
+	limit := PerSecond(10)
+
+	quota := limiter.Take("key", limit, 8)
+	assert.True(t, quota.Allowed)
+	assert.Equal(t, 2, quota.Remaining)
+
+	assert.False(t, limiter.Take("key", limit, 5).Allowed)
+	assert.True(t, limiter.Take("other", limit, 5).Allowed)
+
+	*now = now.Add(300 * time.Millisecond)
+	assert.True(t, limiter.Take("key", limit, 5).Allowed)
+}
+
+func TestLimiter_Peek(t *testing.T) {
+	limiter, _ := limiterFactory()
+	limit := PerSecond(1)
+
+	assert.True(t, limiter.Peek("key", limit, 1).Allowed)
+	assert.True(t, limiter.Take("key", limit, 1).Allowed)
+	assert.False(t, limiter.Peek("key", limit, 1).Allowed)
+
+	limiter.Reset("key")
+	assert.True(t, limiter.Take("key", limit, 1).Allowed)
+}
+
+func TestLimiter_Deny(t *testing.T) {
+	limiter, _ := limiterFactory()
+
+	quota := limiter.Take("key", Deny(), 1)
+	assert.False(t, quota.Allowed)
+	assert.Equal(t, time.Hour, quota.RetryAfter)
+
+	quota = limiter.Take("key", PerMinute(0), 1)
+	assert.False(t, quota.Allowed)
+	assert.Equal(t, time.Hour, quota.RetryAfter)
+}
+
+func TestLimit_CostGreaterThanBurst(t *testing.T) {
+	assert.NoError(t, PerSecond(10).check(10))
+	assert.Error(t, PerSecond(10).check(11))
+	assert.NoError(t, Unlimited().check(11))
+	assert.NoError(t, Deny().check(11))
+}
@@ -0,0 +1,127 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/http/errors"
+	"github.com/lara-go/larago/http/responses"
+)
+
+// QuotaAttribute is the request attribute with the rate limiter quota.
+const QuotaAttribute = "ratelimit.quota"
+
+// KeyResolver returns the bucket key of the request.
+type KeyResolver func(request *http.Request) string
+
+// Throttle middleware limits requests with token buckets.
+// Routes may consume more tokens than one with Route.WithCost.
+type Throttle struct {
+	limiter *Limiter
+	limit   Limit
+	key     KeyResolver
+	soft    bool
+
+	// Take from the named limiter, if throttle was made by RateLimiter.
+	named func(request *http.Request, cost int) (Quota, error)
+}
+
+// NewThrottle constructor.
+func NewThrottle(limit Limit) *Throttle {
+	return &Throttle{
+		limiter: NewLimiter(),
+		limit:   limit,
+		key: func(request *http.Request) string {
+			return request.IP()
+		},
+	}
+}
+
+// By changes how requests are grouped into buckets. By IP by default.
+// Named limiters group requests by the key of the resolved limit instead.
+func (m *Throttle) By(key KeyResolver) *Throttle {
+	m.key = key
+
+	return m
+}
+
+// Soft does not reject requests over the limit.
+// Handlers should check QuotaFrom(request).Allowed and degrade gracefully.
+func (m *Throttle) Soft() *Throttle {
+	m.soft = true
+
+	return m
+}
+
+// Handle request.
+func (m *Throttle) Handle(request *http.Request, next http.Handler) responses.Response {
+	cost := 1
+	if request.Route != nil && request.Route.Cost > 0 {
+		cost = request.Route.Cost
+	}
+
+	quota, err := m.take(request, cost)
+	if err != nil {
+		panic(err)
+	}
+	request.SetAttribute(QuotaAttribute, &quota)
+
+	// Unlimited requests have no rate limit headers.
+	if quota.Limit < 0 {
+		return next(request)
+	}
+
+	if !quota.Allowed && !m.soft {
+		err := errors.TooManyRequestsHTTPError().
+			WithHeader("Retry-After", strconv.Itoa(seconds(quota.RetryAfter.Seconds())))
+
+		for name, value := range headers(&quota) {
+			err.WithHeader(name, value)
+		}
+
+		panic(err)
+	}
+
+	response := next(request)
+	for name, value := range headers(&quota) {
+		response.WithHeader(name, value)
+	}
+
+	return response
+}
+
+// Take tokens of the request.
+func (m *Throttle) take(request *http.Request, cost int) (Quota, error) {
+	if m.named != nil {
+		return m.named(request, cost)
+	}
+
+	if err := m.limit.check(cost); err != nil {
+		return Quota{}, err
+	}
+
+	return m.limiter.Take(m.key(request), m.limit, cost), nil
+}
+
+// QuotaFrom returns quota of the request taken by throttle middleware.
+func QuotaFrom(request *http.Request) *Quota {
+	quota, _ := request.Attribute(QuotaAttribute).(*Quota)
+
+	return quota
+}
+
+// Rate limit headers of the quota.
+func headers(quota *Quota) map[string]string {
+	return map[string]string{
+		"X-RateLimit-Limit":     strconv.Itoa(quota.Limit),
+		"X-RateLimit-Remaining": strconv.Itoa(quota.Remaining),
+		"X-RateLimit-Reset":     fmt.Sprint(seconds(quota.ResetAfter.Seconds())),
+	}
+}
+
+// Round seconds up.
+func seconds(value float64) int {
+	return int(math.Ceil(value))
+}
@@ -0,0 +1,111 @@
+package ratelimit
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/lara-go/larago/http"
+)
+
+// LimitResolver returns limit of the request, ex. larger one for premium users.
+// Key of the returned limit groups requests into buckets, by IP if empty.
+type LimitResolver func(request *http.Request) Limit
+
+// RateLimiter keeps named limiter definitions resolved per request.
+type RateLimiter struct {
+	limiter     *Limiter
+	definitions map[string]LimitResolver
+	mutex       sync.RWMutex
+}
+
+// NewRateLimiter constructor.
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		limiter:     NewLimiter(),
+		definitions: make(map[string]LimitResolver),
+	}
+}
+
+// For defines named limiter.
+//
+//	limiter.For("api", func(request *http.Request) ratelimit.Limit {
+//		if user := currentUser(request); user.Premium {
+//			return ratelimit.PerMinute(1000).By(user.ID)
+//		}
+//
+//		return ratelimit.PerMinute(60)
+//	})
+func (r *RateLimiter) For(name string, resolver LimitResolver) *RateLimiter {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.definitions[name] = resolver
+
+	return r
+}
+
+// Has checks if limiter is defined.
+func (r *RateLimiter) Has(name string) bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	_, ok := r.definitions[name]
+
+	return ok
+}
+
+// Resolve limit of the request by the named limiter.
+func (r *RateLimiter) Resolve(name string, request *http.Request) (Limit, error) {
+	r.mutex.RLock()
+	resolver, ok := r.definitions[name]
+	r.mutex.RUnlock()
+
+	if !ok {
+		return Limit{}, fmt.Errorf("Unknown rate limiter %s", name)
+	}
+
+	limit := resolver(request)
+	if limit.Key == "" {
+		limit.Key = request.IP()
+	}
+
+	return limit, nil
+}
+
+// Take tokens from the request bucket of the named limiter.
+func (r *RateLimiter) Take(name string, request *http.Request, cost int) (Quota, error) {
+	limit, err := r.Resolve(name, request)
+	if err != nil {
+		return Quota{}, err
+	}
+
+	if limit.IsUnlimited() {
+		return Quota{Allowed: true, Limit: -1, Remaining: -1, Cost: cost}, nil
+	}
+
+	if err := limit.check(cost); err != nil {
+		return Quota{}, fmt.Errorf("Rate limiter %s: %s", name, err)
+	}
+
+	return r.limiter.Take(r.key(name, limit), limit, cost), nil
+}
+
+// Reset bucket of the named limiter by key.
+func (r *RateLimiter) Reset(name, key string) {
+	r.limiter.Reset(r.key(name, Limit{Key: key}))
+}
+
+// Throttle middleware limiting requests with the named limiter.
+// Limiter may be defined later, it is resolved on every request.
+func (r *RateLimiter) Throttle(name string) *Throttle {
+	return &Throttle{
+		named: func(request *http.Request, cost int) (Quota, error) {
+			return r.Take(name, request, cost)
+		},
+	}
+}
+
+// Bucket key of the named limiter.
+func (r *RateLimiter) key(name string, limit Limit) string {
+	return name + ":" + limit.Key
+}
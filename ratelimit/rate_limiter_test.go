@@ -0,0 +1,86 @@
+package ratelimit_test
+
+import (
+	net_http "net/http"
+	"testing"
+
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/http/errors"
+	"github.com/lara-go/larago/http/responses"
+	"github.com/lara-go/larago/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+func requestFrom(plan string) *http.Request {
+	netRequest, _ := net_http.NewRequest("GET", "/", nil)
+	netRequest.RemoteAddr = "10.0.0.1:1234"
+	if plan != "" {
+		netRequest.Header.Set("X-Plan", plan)
+	}
+
+	return http.NewRequest(netRequest)
+}
+
+func TestNamedLimiters(t *testing.T) {
+	limiter := ratelimit.NewRateLimiter().For("api", func(request *http.Request) ratelimit.Limit {
+		switch request.Header("X-Plan") {
+		case "premium":
+			return ratelimit.PerMinute(3).By("premium")
+		case "internal":
+			return ratelimit.Unlimited()
+		default:
+			return ratelimit.PerMinute(1)
+		}
+	})
+
+	throttle := limiter.Throttle("api")
+	next := func(request *http.Request) responses.Response {
+		return responses.NewText(200, "OK")
+	}
+
+	handle := func(request *http.Request) (status int) {
+		defer func() {
+			if err, ok := recover().(*errors.HTTPError); ok {
+				status = err.HTTPStatus
+			}
+		}()
+
+		return throttle.Handle(request, next).Status()
+	}
+
+	assert.Equal(t, 200, handle(requestFrom("")))
+	assert.Equal(t, 429, handle(requestFrom("")))
+
+	// Premium users have their own larger bucket.
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, 200, handle(requestFrom("premium")))
+	}
+	assert.Equal(t, 429, handle(requestFrom("premium")))
+
+	// Internal services are not limited and get no headers.
+	request := requestFrom("internal")
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, 200, handle(request))
+	}
+	assert.True(t, ratelimit.QuotaFrom(request).Allowed)
+	assert.Empty(t, throttle.Handle(request, next).Headers()["X-RateLimit-Limit"])
+
+	limiter.Reset("api", "premium")
+	assert.Equal(t, 200, handle(requestFrom("premium")))
+
+	_, err := limiter.Take("missing", requestFrom(""), 1)
+	assert.Error(t, err)
+}
+
+func TestNamedLimiterCostGreaterThanBurst(t *testing.T) {
+	limiter := ratelimit.NewRateLimiter().For("reports", func(request *http.Request) ratelimit.Limit {
+		return ratelimit.PerMinute(5)
+	})
+
+	_, err := limiter.Take("reports", requestFrom(""), 5)
+	assert.NoError(t, err)
+
+	// Such request could never be allowed, it is a configuration error rather than 429.
+	_, err = limiter.Take("reports", requestFrom(""), 6)
+	assert.Error(t, err)
+}
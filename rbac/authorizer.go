@@ -0,0 +1,24 @@
+package rbac
+
+import "github.com/lara-go/larago/http"
+
+// Authorizer checks route requirements declared with Route.Can and Route.Role.
+//
+//	router.SetAuthorizer(&rbac.Authorizer{})
+type Authorizer struct {
+	Manager *Manager
+}
+
+// Can checks if request user has the permission.
+func (a *Authorizer) Can(request *http.Request, ability string) bool {
+	user := a.Manager.RequestUser(request)
+
+	return user != nil && user.HasPermission(ability)
+}
+
+// HasRole checks if request user has the role.
+func (a *Authorizer) HasRole(request *http.Request, role string) bool {
+	user := a.Manager.RequestUser(request)
+
+	return user != nil && user.HasRole(role)
+}
@@ -0,0 +1,11 @@
+package rbac
+
+import "github.com/lara-go/larago"
+
+// FacadeWrapper for facade.
+var FacadeWrapper = &larago.Facade{}
+
+// Facade for roles and permissions.
+func Facade() *Manager {
+	return FacadeWrapper.Resolve("rbac").(*Manager)
+}
@@ -0,0 +1,257 @@
+package rbac
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago/cache"
+	"github.com/lara-go/larago/http"
+)
+
+// Cache key of the grants version. Changing roles bumps it, so cached grants of all users are outdated at once.
+const versionCacheKey = "rbac.version"
+
+// UserResolver returns ID of the request user, nil for guests.
+type UserResolver func(request *http.Request) interface{}
+
+// Manager of roles, permissions and their assignments.
+type Manager struct {
+	db    *gorm.DB
+	cache cache.Cache
+
+	resolver UserResolver
+}
+
+// NewManager constructor. Cache is optional.
+func NewManager(db *gorm.DB, cache cache.Cache) *Manager {
+	return &Manager{
+		db:    db,
+		cache: cache,
+	}
+}
+
+// ResolveUserWith sets how request user is found for middleware and authorizer.
+func (m *Manager) ResolveUserWith(resolver UserResolver) *Manager {
+	m.resolver = resolver
+
+	return m
+}
+
+// RequestUser returns grants of the request user, nil for guests.
+func (m *Manager) RequestUser(request *http.Request) *User {
+	if m.resolver == nil {
+		return nil
+	}
+
+	id := m.resolver(request)
+	if id == nil {
+		return nil
+	}
+
+	return m.User(id)
+}
+
+// User returns grants of the user with the ID.
+func (m *Manager) User(id interface{}) *User {
+	return &User{manager: m, id: fmt.Sprint(id)}
+}
+
+// CreatePermissions creates missing permissions.
+func (m *Manager) CreatePermissions(names ...string) error {
+	_, err := m.permissions(m.db, names)
+
+	return err
+}
+
+// CreateRole creates role if it is missing and grants it permissions.
+func (m *Manager) CreateRole(name string, permissions ...string) (*Role, error) {
+	var role Role
+	if err := m.db.Where(Role{Name: name}).FirstOrCreate(&role).Error; err != nil {
+		return nil, err
+	}
+
+	if err := m.GivePermissionsToRole(name, permissions...); err != nil {
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+// DeleteRole deletes role with its assignments.
+func (m *Manager) DeleteRole(name string) error {
+	role, err := m.role(m.db, name)
+	if err != nil {
+		return err
+	}
+
+	err = m.transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("role_id = ?", role.ID).Delete(&RolePermission{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("role_id = ?", role.ID).Delete(&UserRole{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(role).Error
+	})
+
+	return m.changed(err)
+}
+
+// GivePermissionsToRole grants permissions to the role, creating missing ones.
+func (m *Manager) GivePermissionsToRole(name string, permissions ...string) error {
+	role, err := m.role(m.db, name)
+	if err != nil {
+		return err
+	}
+
+	return m.changed(m.grant(m.db, role, permissions))
+}
+
+// RevokePermissionsFromRole revokes permissions of the role.
+func (m *Manager) RevokePermissionsFromRole(name string, permissions ...string) error {
+	role, err := m.role(m.db, name)
+	if err != nil {
+		return err
+	}
+
+	err = m.db.
+		Where("role_id = ?", role.ID).
+		Where("permission_id IN (?)", m.db.Model(&Permission{}).Select("id").Where("name IN (?)", permissions).QueryExpr()).
+		Delete(&RolePermission{}).Error
+
+	return m.changed(err)
+}
+
+// SyncRolePermissions makes permissions the only ones granted to the role.
+func (m *Manager) SyncRolePermissions(name string, permissions ...string) error {
+	// Role keeps its old permissions until the new ones are granted.
+	err := m.transaction(func(tx *gorm.DB) error {
+		role, err := m.role(tx, name)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("role_id = ?", role.ID).Delete(&RolePermission{}).Error; err != nil {
+			return err
+		}
+
+		return m.grant(tx, role, permissions)
+	})
+
+	return m.changed(err)
+}
+
+// RolePermissions returns names of permissions granted to the role.
+func (m *Manager) RolePermissions(name string) ([]string, error) {
+	var names []string
+
+	err := m.db.Table("rbac_permissions").
+		Joins("JOIN rbac_role_permissions ON rbac_role_permissions.permission_id = rbac_permissions.id").
+		Joins("JOIN rbac_roles ON rbac_roles.id = rbac_role_permissions.role_id").
+		Where("rbac_roles.name = ?", name).
+		Order("rbac_permissions.name").
+		Pluck("rbac_permissions.name", &names).Error
+
+	return names, err
+}
+
+// Seed creates roles with permissions, replacing permissions of existing roles.
+// Safe to run on every deploy.
+//
+//	manager.Seed(map[string][]string{
+//		"admin":  {"*"},
+//		"editor": {"posts.*", "comments.moderate"},
+//	})
+func (m *Manager) Seed(roles map[string][]string) error {
+	for name, permissions := range roles {
+		if _, err := m.CreateRole(name); err != nil {
+			return err
+		}
+
+		if err := m.SyncRolePermissions(name, permissions...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Find role by name.
+func (m *Manager) role(db *gorm.DB, name string) (*Role, error) {
+	var role Role
+	if err := db.Where("name = ?", name).First(&role).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil, fmt.Errorf("Role %s does not exist", name)
+		}
+
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+// Grant permissions to the role, creating missing ones.
+func (m *Manager) grant(db *gorm.DB, role *Role, permissions []string) error {
+	granted, err := m.permissions(db, permissions)
+	if err != nil {
+		return err
+	}
+
+	for _, permission := range granted {
+		grant := RolePermission{RoleID: role.ID, PermissionID: permission.ID}
+		if err := db.Where(grant).FirstOrCreate(&grant).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Find permissions by names creating missing ones.
+func (m *Manager) permissions(db *gorm.DB, names []string) ([]Permission, error) {
+	permissions := make([]Permission, 0, len(names))
+
+	for _, name := range names {
+		permission := Permission{Name: name}
+		if err := db.Where(permission).FirstOrCreate(&permission).Error; err != nil {
+			return nil, err
+		}
+
+		permissions = append(permissions, permission)
+	}
+
+	return permissions, nil
+}
+
+// Run callback in transaction.
+func (m *Manager) transaction(callback func(tx *gorm.DB) error) error {
+	tx := m.db.Begin()
+	if err := callback(tx); err != nil {
+		tx.Rollback()
+
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// Outdate cached grants of all the users after successful change.
+func (m *Manager) changed(err error) error {
+	if err == nil && m.cache != nil {
+		m.cache.Forever(versionCacheKey, strconv.FormatInt(time.Now().UnixNano(), 10))
+	}
+
+	return err
+}
+
+// Cache key of the user grants.
+func (m *Manager) userCacheKey(id string) string {
+	var version string
+	m.cache.Get(versionCacheKey, &version)
+
+	return strings.Join([]string{"rbac.user", version, id}, ".")
+}
@@ -0,0 +1,61 @@
+package rbac
+
+import (
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/http/errors"
+	"github.com/lara-go/larago/http/responses"
+)
+
+// RequireRoleMiddleware lets in users having any of the roles.
+type RequireRoleMiddleware struct {
+	Manager *Manager
+
+	roles []string
+}
+
+// RequireRole makes middleware letting in users having any of the roles.
+func RequireRole(roles ...string) *RequireRoleMiddleware {
+	return &RequireRoleMiddleware{roles: roles}
+}
+
+// Handle request.
+func (m *RequireRoleMiddleware) Handle(request *http.Request, next http.Handler) responses.Response {
+	user := m.Manager.RequestUser(request)
+	if user == nil {
+		panic(errors.UnauthorizedHTTPError())
+	}
+
+	if !user.HasRole(m.roles...) {
+		panic(errors.ForbiddenHTTPError())
+	}
+
+	return next(request)
+}
+
+// RequirePermissionMiddleware lets in users having every of the permissions.
+type RequirePermissionMiddleware struct {
+	Manager *Manager
+
+	permissions []string
+}
+
+// RequirePermission makes middleware letting in users having every of the permissions.
+func RequirePermission(permissions ...string) *RequirePermissionMiddleware {
+	return &RequirePermissionMiddleware{permissions: permissions}
+}
+
+// Handle request.
+func (m *RequirePermissionMiddleware) Handle(request *http.Request, next http.Handler) responses.Response {
+	user := m.Manager.RequestUser(request)
+	if user == nil {
+		panic(errors.UnauthorizedHTTPError())
+	}
+
+	for _, permission := range m.permissions {
+		if !user.HasPermission(permission) {
+			panic(errors.ForbiddenHTTPError())
+		}
+	}
+
+	return next(request)
+}
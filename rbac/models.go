@@ -0,0 +1,83 @@
+package rbac
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Role groups permissions.
+type Role struct {
+	ID          uint   `gorm:"primary_key"`
+	Name        string `gorm:"unique_index"`
+	Description string
+	CreatedAt   time.Time
+}
+
+// TableName of the roles.
+func (Role) TableName() string {
+	return "rbac_roles"
+}
+
+// Permission to perform an ability, ex. "posts.update".
+type Permission struct {
+	ID        uint   `gorm:"primary_key"`
+	Name      string `gorm:"unique_index"`
+	CreatedAt time.Time
+}
+
+// TableName of the permissions.
+func (Permission) TableName() string {
+	return "rbac_permissions"
+}
+
+// RolePermission grants permission to the role.
+type RolePermission struct {
+	RoleID       uint `gorm:"primary_key;auto_increment:false"`
+	PermissionID uint `gorm:"primary_key;auto_increment:false"`
+}
+
+// TableName of the role permissions.
+func (RolePermission) TableName() string {
+	return "rbac_role_permissions"
+}
+
+// UserRole assigns role to the user.
+type UserRole struct {
+	UserID string `gorm:"primary_key"`
+	RoleID uint   `gorm:"primary_key;auto_increment:false"`
+}
+
+// TableName of the user roles.
+func (UserRole) TableName() string {
+	return "rbac_user_roles"
+}
+
+// UserPermission grants permission to the user directly.
+type UserPermission struct {
+	UserID       string `gorm:"primary_key"`
+	PermissionID uint   `gorm:"primary_key;auto_increment:false"`
+}
+
+// TableName of the user permissions.
+func (UserPermission) TableName() string {
+	return "rbac_user_permissions"
+}
+
+// Migration creates roles and permissions tables.
+type Migration struct{}
+
+// MigrationID returns unique migration ID.
+func (m *Migration) MigrationID() string {
+	return "rbac_create_roles_and_permissions_tables"
+}
+
+// Migrate runs migrations.
+func (m *Migration) Migrate(tx *gorm.DB) error {
+	return tx.AutoMigrate(&Role{}, &Permission{}, &RolePermission{}, &UserRole{}, &UserPermission{}).Error
+}
+
+// Rollback changes.
+func (m *Migration) Rollback(tx *gorm.DB) error {
+	return tx.DropTableIfExists(&UserPermission{}, &UserRole{}, &RolePermission{}, &Permission{}, &Role{}).Error
+}
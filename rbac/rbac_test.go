@@ -0,0 +1,137 @@
+package rbac_test
+
+import (
+	"errors"
+	net_http "net/http"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago/cache"
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/rbac"
+	"github.com/lara-go/larago/support/testsuite"
+	"github.com/stretchr/testify/assert"
+
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+)
+
+func managerFactory(t *testing.T) *rbac.Manager {
+	db := testsuite.MemoryDB(t)
+	assert.NoError(t, (&rbac.Migration{}).Migrate(db))
+
+	return rbac.NewManager(db, cache.NewRepository(cache.NewInMemoryStore()))
+}
+
+func TestRolesAndPermissions(t *testing.T) {
+	manager := managerFactory(t)
+
+	assert.NoError(t, manager.Seed(map[string][]string{
+		"admin":  {"*"},
+		"editor": {"posts.*", "comments.moderate"},
+		"author": {"posts.create"},
+	}))
+
+	// Seeding twice changes nothing.
+	assert.NoError(t, manager.Seed(map[string][]string{"author": {"posts.create"}}))
+
+	permissions, _ := manager.RolePermissions("editor")
+	assert.Equal(t, []string{"comments.moderate", "posts.*"}, permissions)
+
+	user := manager.User(42)
+	assert.False(t, user.HasRole("author"))
+	assert.False(t, user.HasPermission("posts.create"))
+
+	assert.NoError(t, user.AssignRoles("author"))
+	assert.True(t, user.HasRole("author", "admin"))
+	assert.False(t, user.HasAllRoles("author", "admin"))
+	assert.True(t, user.HasPermission("posts.create"))
+	assert.False(t, user.Can("posts.update"))
+
+	assert.NoError(t, user.GivePermissions("reports.view"))
+	assert.Equal(t, []string{"posts.create", "reports.view"}, user.Permissions())
+
+	// Role changes outdate cached grants of all users.
+	assert.NoError(t, manager.GivePermissionsToRole("author", "posts.update"))
+	assert.True(t, user.Can("posts.update"))
+
+	assert.NoError(t, manager.RevokePermissionsFromRole("author", "posts.update"))
+	assert.False(t, user.Can("posts.update"))
+
+	assert.NoError(t, user.SyncRoles("editor"))
+	assert.Equal(t, []string{"editor"}, user.Roles())
+	assert.True(t, user.Can("posts.delete"))
+	assert.False(t, user.Can("users.delete"))
+
+	// Failed sync keeps the old grants.
+	assert.Error(t, user.SyncRoles("author", "root"))
+	assert.Equal(t, []string{"editor"}, user.Roles())
+
+	assert.NoError(t, user.RevokePermissions("reports.view"))
+	assert.False(t, user.Can("reports.view"))
+
+	assert.NoError(t, user.RemoveRoles("editor"))
+	assert.Empty(t, user.Roles())
+
+	admin := manager.User("1")
+	assert.Error(t, admin.AssignRoles("root"))
+	assert.NoError(t, admin.AssignRoles("admin"))
+	assert.True(t, admin.Can("users.delete"))
+
+	assert.NoError(t, manager.DeleteRole("admin"))
+	assert.False(t, admin.Can("users.delete"))
+}
+
+func TestSyncRolePermissionsIsAtomic(t *testing.T) {
+	db := testsuite.MemoryDB(t)
+	assert.NoError(t, (&rbac.Migration{}).Migrate(db))
+	manager := rbac.NewManager(db, nil)
+
+	_, err := manager.CreateRole("editor", "posts.*")
+	assert.NoError(t, err)
+
+	// Creating the permission fails after old ones were deleted.
+	db.Callback().Create().Before("gorm:create").Register("test:fail", func(scope *gorm.Scope) {
+		if permission, ok := scope.Value.(*rbac.Permission); ok && permission.Name == "broken" {
+			scope.Err(errors.New("broken"))
+		}
+	})
+
+	assert.Error(t, manager.SyncRolePermissions("editor", "comments.moderate", "broken"))
+
+	permissions, _ := manager.RolePermissions("editor")
+	assert.Equal(t, []string{"posts.*"}, permissions)
+}
+
+func TestMatches(t *testing.T) {
+	assert.True(t, rbac.Matches("posts.update", "posts.update"))
+	assert.True(t, rbac.Matches("posts.*", "posts.update"))
+	assert.True(t, rbac.Matches("*", "posts.update"))
+	assert.False(t, rbac.Matches("posts.*", "postsx.update"))
+	assert.False(t, rbac.Matches("posts.update", "posts.delete"))
+}
+
+func TestAuthorizer(t *testing.T) {
+	manager := managerFactory(t)
+	manager.Seed(map[string][]string{"editor": {"posts.*"}})
+	manager.User("7").AssignRoles("editor")
+
+	manager.ResolveUserWith(func(request *http.Request) interface{} {
+		if id := request.Header("X-User"); id != "" {
+			return id
+		}
+
+		return nil
+	})
+
+	authorizer := &rbac.Authorizer{Manager: manager}
+
+	netRequest, _ := net_http.NewRequest("GET", "/", nil)
+	guest := http.NewRequest(netRequest)
+	assert.False(t, authorizer.Can(guest, "posts.update"))
+
+	netRequest.Header.Set("X-User", "7")
+	user := http.NewRequest(netRequest)
+	assert.True(t, authorizer.Can(user, "posts.update"))
+	assert.True(t, authorizer.HasRole(user, "editor"))
+	assert.False(t, authorizer.HasRole(user, "admin"))
+}
@@ -0,0 +1,33 @@
+package rbac
+
+import (
+	"sync"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago"
+	"github.com/lara-go/larago/cache"
+)
+
+// ServiceProvider struct.
+type ServiceProvider struct{}
+
+// Register service.
+func (p *ServiceProvider) Register(application *larago.Application) {
+	// User resolver is set on the manager resolved by alias and used by middleware resolving it by type,
+	// so they must be the same instance.
+	var manager *Manager
+	var once sync.Once
+
+	application.Bind(func() (*Manager, error) {
+		once.Do(func() {
+			var repository cache.Cache
+			if application.Bound("cache") {
+				repository = application.Get("cache").(cache.Cache)
+			}
+
+			manager = NewManager(application.Get("db.connection").(*gorm.DB), repository)
+		})
+
+		return manager, nil
+	}, "rbac")
+}
@@ -0,0 +1,248 @@
+package rbac
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// How long user grants are cached.
+const grantsCacheDuration = time.Hour
+
+// Grants of the user.
+type Grants struct {
+	Roles       []string
+	Permissions []string
+}
+
+// User with roles and permissions.
+type User struct {
+	manager *Manager
+	id      string
+}
+
+// ID of the user.
+func (u *User) ID() string {
+	return u.id
+}
+
+// AssignRoles assigns existing roles to the user.
+func (u *User) AssignRoles(roles ...string) error {
+	return u.changed(u.assign(u.manager.db, roles))
+}
+
+// RemoveRoles removes roles from the user.
+func (u *User) RemoveRoles(roles ...string) error {
+	err := u.manager.db.
+		Where("user_id = ?", u.id).
+		Where("role_id IN (?)", u.manager.db.Model(&Role{}).Select("id").Where("name IN (?)", roles).QueryExpr()).
+		Delete(&UserRole{}).Error
+
+	return u.changed(err)
+}
+
+// SyncRoles makes roles the only ones assigned to the user.
+func (u *User) SyncRoles(roles ...string) error {
+	// User keeps the old roles until the new ones are assigned.
+	err := u.manager.transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", u.id).Delete(&UserRole{}).Error; err != nil {
+			return err
+		}
+
+		return u.assign(tx, roles)
+	})
+
+	return u.changed(err)
+}
+
+// GivePermissions grants permissions to the user directly, creating missing ones.
+func (u *User) GivePermissions(permissions ...string) error {
+	granted, err := u.manager.permissions(u.manager.db, permissions)
+	if err != nil {
+		return err
+	}
+
+	for _, permission := range granted {
+		grant := UserPermission{UserID: u.id, PermissionID: permission.ID}
+		if err := u.manager.db.Where(grant).FirstOrCreate(&grant).Error; err != nil {
+			return err
+		}
+	}
+
+	return u.changed(nil)
+}
+
+// RevokePermissions revokes permissions granted to the user directly.
+func (u *User) RevokePermissions(permissions ...string) error {
+	err := u.manager.db.
+		Where("user_id = ?", u.id).
+		Where("permission_id IN (?)", u.manager.db.Model(&Permission{}).Select("id").Where("name IN (?)", permissions).QueryExpr()).
+		Delete(&UserPermission{}).Error
+
+	return u.changed(err)
+}
+
+// Grants returns roles of the user and permissions granted directly or via roles.
+func (u *User) Grants() (*Grants, error) {
+	if u.manager.cache == nil {
+		return u.load()
+	}
+
+	var grants Grants
+	err := u.manager.cache.Remember(u.manager.userCacheKey(u.id), grantsCacheDuration, func() (interface{}, error) {
+		loaded, err := u.load()
+		if err != nil {
+			return nil, err
+		}
+
+		return *loaded, nil
+	}, &grants)
+
+	return &grants, err
+}
+
+// Roles of the user.
+func (u *User) Roles() []string {
+	grants, err := u.Grants()
+	if err != nil {
+		return nil
+	}
+
+	return grants.Roles
+}
+
+// Permissions of the user.
+func (u *User) Permissions() []string {
+	grants, err := u.Grants()
+	if err != nil {
+		return nil
+	}
+
+	return grants.Permissions
+}
+
+// HasRole checks if user has any of the roles.
+func (u *User) HasRole(roles ...string) bool {
+	for _, assigned := range u.Roles() {
+		for _, role := range roles {
+			if assigned == role {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// HasAllRoles checks if user has every of the roles.
+func (u *User) HasAllRoles(roles ...string) bool {
+	for _, role := range roles {
+		if !u.HasRole(role) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// HasPermission checks if user has the permission.
+// Permission "posts.*" grants every posts permission, "*" grants everything.
+func (u *User) HasPermission(permission string) bool {
+	for _, granted := range u.Permissions() {
+		if Matches(granted, permission) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Can is an alias of HasPermission.
+func (u *User) Can(permission string) bool {
+	return u.HasPermission(permission)
+}
+
+// Matches checks if granted permission covers the requested one.
+func Matches(granted, permission string) bool {
+	if granted == permission || granted == "*" {
+		return true
+	}
+
+	return strings.HasSuffix(granted, ".*") && strings.HasPrefix(permission, strings.TrimSuffix(granted, "*"))
+}
+
+// Load grants from the database.
+func (u *User) load() (*Grants, error) {
+	grants := &Grants{}
+	db := u.manager.db
+
+	err := db.Table("rbac_roles").
+		Joins("JOIN rbac_user_roles ON rbac_user_roles.role_id = rbac_roles.id").
+		Where("rbac_user_roles.user_id = ?", u.id).
+		Order("rbac_roles.name").
+		Pluck("rbac_roles.name", &grants.Roles).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var viaRoles, direct []string
+
+	err = u.permissionsQuery(db).
+		Joins("JOIN rbac_role_permissions ON rbac_role_permissions.permission_id = rbac_permissions.id").
+		Joins("JOIN rbac_user_roles ON rbac_user_roles.role_id = rbac_role_permissions.role_id").
+		Where("rbac_user_roles.user_id = ?", u.id).
+		Pluck("rbac_permissions.name", &viaRoles).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.permissionsQuery(db).
+		Joins("JOIN rbac_user_permissions ON rbac_user_permissions.permission_id = rbac_permissions.id").
+		Where("rbac_user_permissions.user_id = ?", u.id).
+		Pluck("rbac_permissions.name", &direct).Error
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, permission := range append(viaRoles, direct...) {
+		if !seen[permission] {
+			seen[permission] = true
+			grants.Permissions = append(grants.Permissions, permission)
+		}
+	}
+
+	return grants, nil
+}
+
+// Assign existing roles to the user.
+func (u *User) assign(db *gorm.DB, roles []string) error {
+	for _, name := range roles {
+		role, err := u.manager.role(db, name)
+		if err != nil {
+			return err
+		}
+
+		assignment := UserRole{UserID: u.id, RoleID: role.ID}
+		if err := db.Where(assignment).FirstOrCreate(&assignment).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Base query of the permission names.
+func (u *User) permissionsQuery(db *gorm.DB) *gorm.DB {
+	return db.Table("rbac_permissions").Order("rbac_permissions.name")
+}
+
+// Forget cached grants of the user after successful change.
+func (u *User) changed(err error) error {
+	if err == nil && u.manager.cache != nil {
+		u.manager.cache.Forget(u.manager.userCacheKey(u.id))
+	}
+
+	return err
+}
@@ -0,0 +1,156 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/asaskevich/EventBus"
+	"github.com/lara-go/larago"
+	"github.com/lara-go/larago/logger"
+	"github.com/olekukonko/tablewriter"
+
+	"github.com/urfave/cli"
+)
+
+// CommandRun runs scheduled tasks.
+type CommandRun struct {
+	Scheduler *Scheduler
+	Events    *EventBus.EventBus
+	Logger    *logger.Logger
+
+	once bool
+}
+
+// GetCommand for the cli to register.
+func (c *CommandRun) GetCommand() cli.Command {
+	return cli.Command{
+		Name:      "schedule:run",
+		Usage:     "Run scheduled tasks",
+		UsageText: "Runs as a daemon by default. Use --once to call it from cron every minute.\n",
+		Category:  "Schedule",
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:        "once",
+				Usage:       "run tasks due at the current minute and exit",
+				Destination: &c.once,
+			},
+		},
+	}
+}
+
+// Handle command.
+func (c *CommandRun) Handle(args cli.Args) error {
+	if c.once {
+		results := c.Scheduler.RunDue(time.Now())
+		if len(results) == 0 {
+			c.Logger.Info("No scheduled tasks are due.")
+		}
+
+		for _, result := range results {
+			logResult(c.Logger, result)
+		}
+
+		return nil
+	}
+
+	// Stop scheduler before application exits on sigterm.
+	if c.Events != nil {
+		c.Events.Subscribe("sigterm", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+			defer cancel()
+
+			c.Scheduler.Stop(ctx)
+		})
+	}
+
+	c.Logger.Info("Running scheduled tasks...")
+
+	return c.Scheduler.Start()
+}
+
+// CommandList prints scheduled tasks.
+type CommandList struct {
+	Scheduler   *Scheduler
+	Application *larago.Application
+}
+
+// GetCommand for the cli to register.
+func (c *CommandList) GetCommand() cli.Command {
+	return cli.Command{
+		Name:     "schedule:list",
+		Usage:    "List scheduled tasks",
+		Category: "Schedule",
+	}
+}
+
+// Handle command.
+func (c *CommandList) Handle(args cli.Args) error {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Task", "Schedule", "Next run", "Description"})
+	table.SetColWidth(200)
+	table.SetAutoFormatHeaders(false)
+
+	now := time.Now()
+	for _, task := range c.Scheduler.Tasks() {
+		table.Append([]string{
+			task.Name(),
+			task.Schedule(),
+			task.NextRun(now).Format(c.Application.DateTimeFormat),
+			task.Description(),
+		})
+	}
+
+	table.Render()
+
+	return nil
+}
+
+// CommandTest runs scheduled task immediately.
+type CommandTest struct {
+	Scheduler *Scheduler
+	Logger    *logger.Logger
+}
+
+// GetCommand for the cli to register.
+func (c *CommandTest) GetCommand() cli.Command {
+	return cli.Command{
+		Name:      "schedule:test",
+		Usage:     "Run scheduled task immediately",
+		Category:  "Schedule",
+		ArgsUsage: "[task]",
+	}
+}
+
+// Handle command.
+func (c *CommandTest) Handle(args cli.Args) error {
+	name := args.First()
+	if name == "" {
+		return fmt.Errorf("Task name is required")
+	}
+
+	task, ok := c.Scheduler.Find(name)
+	if !ok {
+		return fmt.Errorf("Task %s is not scheduled", name)
+	}
+
+	result := c.Scheduler.Run(task)
+	if result.Output != "" {
+		fmt.Fprint(os.Stdout, result.Output)
+	}
+
+	logResult(c.Logger, result)
+
+	return result.Err
+}
+
+// Log result of the task.
+func logResult(l *logger.Logger, result *Result) {
+	if result.Failed() {
+		l.Warning("Task %s failed in %s: %s", result.Task, result.Duration, result.Err)
+		return
+	}
+
+	l.Success("Task %s finished in %s.", result.Task, result.Duration)
+}
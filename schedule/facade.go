@@ -0,0 +1,11 @@
+package schedule
+
+import "github.com/lara-go/larago"
+
+// FacadeWrapper for the scheduler.
+var FacadeWrapper = &larago.Facade{}
+
+// Facade returns scheduler to define tasks.
+func Facade() *Scheduler {
+	return FacadeWrapper.Resolve("schedule").(*Scheduler)
+}
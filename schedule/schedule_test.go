@@ -0,0 +1,100 @@
+package schedule_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	net_http "net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lara-go/larago/schedule"
+	"github.com/stretchr/testify/assert"
+)
+
+func at(clock string) time.Time {
+	t, _ := time.ParseInLocation("2006-01-02 15:04", "2018-03-10 "+clock, time.Local)
+
+	return t
+}
+
+func TestDue(t *testing.T) {
+	s := schedule.NewScheduler(nil)
+	noop := func(io.Writer) error { return nil }
+
+	every := s.Call("every", noop).Every(15 * time.Minute)
+	daily := s.Call("daily", noop).DailyAt("03:30")
+
+	assert.True(t, every.Due(at("10:45")))
+	assert.False(t, every.Due(at("10:50")))
+	assert.True(t, daily.Due(at("03:30")))
+	assert.False(t, daily.Due(at("04:30")))
+
+	assert.Equal(t, at("11:00"), every.NextRun(at("10:46")))
+	assert.Equal(t, at("03:30").Add(24*time.Hour), daily.NextRun(at("03:30")))
+
+	assert.Equal(t, "every 15m", every.Schedule())
+	assert.Equal(t, "daily at 03:30", daily.Schedule())
+	assert.Len(t, s.Due(at("03:30")), 2)
+}
+
+func TestRunCapturesOutputAndNotifies(t *testing.T) {
+	var mutex sync.Mutex
+	var pings []string
+	var webhook map[string]interface{}
+
+	server := httptest.NewServer(net_http.HandlerFunc(func(w net_http.ResponseWriter, r *net_http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		pings = append(pings, r.Method+" "+r.URL.Path)
+		if r.Method == "POST" {
+			json.NewDecoder(r.Body).Decode(&webhook)
+		}
+	}))
+	defer server.Close()
+
+	dir, _ := ioutil.TempDir("", "schedule")
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "task.log")
+
+	var failed *schedule.Result
+
+	s := schedule.NewScheduler(nil)
+	task := s.Call("report", func(output io.Writer) error {
+		fmt.Fprint(output, "processing")
+		return errors.New("boom")
+	}).
+		Monitor(server.URL + "/check").
+		WebhookOnFailure(server.URL + "/hook").
+		OnFailure(func(result *schedule.Result) { failed = result }).
+		AppendOutputTo(file)
+
+	result := s.Run(task)
+
+	assert.True(t, result.Failed())
+	assert.Equal(t, "processing", result.Output)
+	assert.Equal(t, result, failed)
+	assert.Equal(t, []string{"GET /check/start", "GET /check/fail", "POST /hook"}, pings)
+	assert.Equal(t, "report", webhook["task"])
+	assert.Equal(t, "boom", webhook["error"])
+
+	content, _ := ioutil.ReadFile(file)
+	assert.Equal(t, "processing", string(content))
+}
+
+func TestRunRecoversFromPanics(t *testing.T) {
+	s := schedule.NewScheduler(nil)
+	s.Call("panics", func(io.Writer) error { panic("oops") }).Daily()
+
+	results := s.RunDue(at("00:00"))
+
+	assert.Len(t, results, 1)
+	assert.EqualError(t, results[0].Err, "Task panicked: oops")
+}
@@ -0,0 +1,251 @@
+package schedule
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	net_http "net/http"
+	"sync"
+	"time"
+
+	"github.com/lara-go/larago/httpclient"
+	"github.com/lara-go/larago/logger"
+)
+
+// Scheduler runs tasks on schedule.
+type Scheduler struct {
+	logger *logger.Logger
+	client *net_http.Client
+	now    func() time.Time
+
+	mutex sync.Mutex
+	tasks []*Task
+
+	stop     chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+// NewScheduler constructor.
+func NewScheduler(logger *logger.Logger) *Scheduler {
+	return &Scheduler{
+		logger:  logger,
+		client:  httpclient.New(10 * time.Second),
+		now:     time.Now,
+		stop:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+}
+
+// SetHTTPClient used for pings and webhooks.
+func (s *Scheduler) SetHTTPClient(client *net_http.Client) *Scheduler {
+	s.client = client
+
+	return s
+}
+
+// Call schedules callback. Task runs every minute unless specified otherwise.
+func (s *Scheduler) Call(name string, callback TaskFunc) *Task {
+	return s.add(newTask(name, callback))
+}
+
+// Command schedules console command of the application (ex. Command("cache:clear")).
+func (s *Scheduler) Command(args ...string) *Task {
+	if len(args) == 0 {
+		panic("Command name is required to schedule")
+	}
+
+	return s.add(newTask(args[0], commandFunc(args)))
+}
+
+// Add task to the list.
+func (s *Scheduler) add(task *Task) *Task {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.tasks = append(s.tasks, task)
+
+	return task
+}
+
+// Tasks returns list of scheduled tasks.
+func (s *Scheduler) Tasks() []*Task {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]*Task{}, s.tasks...)
+}
+
+// Find task by name.
+func (s *Scheduler) Find(name string) (*Task, bool) {
+	for _, task := range s.Tasks() {
+		if task.name == name {
+			return task, true
+		}
+	}
+
+	return nil, false
+}
+
+// Due returns tasks due at the minute.
+func (s *Scheduler) Due(now time.Time) []*Task {
+	var due []*Task
+	for _, task := range s.Tasks() {
+		if task.Due(now) {
+			due = append(due, task)
+		}
+	}
+
+	return due
+}
+
+// RunDue runs tasks due at the minute concurrently and waits for them to finish.
+// Call it every minute from cron: `* * * * * app schedule:run --once`.
+func (s *Scheduler) RunDue(now time.Time) []*Result {
+	due := s.Due(now)
+	results := make([]*Result, len(due))
+
+	var wg sync.WaitGroup
+	for i, task := range due {
+		wg.Add(1)
+
+		go func(i int, task *Task) {
+			defer wg.Done()
+
+			results[i] = s.Run(task)
+		}(i, task)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// Run task immediately with all hooks.
+func (s *Scheduler) Run(task *Task) *Result {
+	s.ping(task.pingBefore)
+
+	result := task.run()
+
+	if err := task.writeOutput(result); err != nil {
+		s.report("Can't write output of task %s: %s", task.name, err)
+	}
+
+	if result.Failed() {
+		s.report("Task %s failed: %s", task.name, result.Err)
+		s.ping(task.pingFailure)
+		s.notify(task, result)
+	} else {
+		s.ping(task.pingSuccess)
+	}
+
+	return result
+}
+
+// Name of the component.
+func (s *Scheduler) Name() string {
+	return "scheduler"
+}
+
+// Start running tasks every minute. Blocks until stopped.
+func (s *Scheduler) Start() error {
+	defer close(s.stopped)
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		now := s.now()
+		next := now.Truncate(time.Minute).Add(time.Minute)
+		timer := time.NewTimer(next.Sub(now))
+
+		select {
+		case <-s.stop:
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+
+		// Don't block the loop with long tasks so next minute is not missed.
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			s.RunDue(next)
+		}()
+	}
+}
+
+// Stop scheduling and wait for running tasks.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+
+	select {
+	case <-s.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Ping monitor urls.
+func (s *Scheduler) ping(urls []string) {
+	for _, url := range urls {
+		response, err := s.client.Get(url)
+		if err != nil {
+			s.report("Can't ping %s: %s", url, err)
+			continue
+		}
+
+		drain(response.Body)
+	}
+}
+
+// Notify about failure via callbacks and webhooks.
+func (s *Scheduler) notify(task *Task, result *Result) {
+	for _, callback := range task.onFailure {
+		callback(result)
+	}
+
+	if len(task.failureHooks) == 0 {
+		return
+	}
+
+	payload, err := json.Marshal(result)
+	if err != nil {
+		s.report("Can't encode result of task %s: %s", task.name, err)
+		return
+	}
+
+	for _, url := range task.failureHooks {
+		response, err := s.client.Post(url, "application/json", bytes.NewReader(payload))
+		if err != nil {
+			s.report("Can't call webhook %s: %s", url, err)
+			continue
+		}
+
+		if response.StatusCode >= 300 {
+			s.report("Webhook %s responded with %d", url, response.StatusCode)
+		}
+
+		drain(response.Body)
+	}
+}
+
+// Report problem to logger.
+func (s *Scheduler) report(format string, args ...interface{}) {
+	if s.logger != nil {
+		s.logger.Error(fmt.Errorf(format, args...))
+	}
+}
+
+// Drain body to reuse keep-alive connection.
+func drain(body io.ReadCloser) {
+	io.Copy(ioutil.Discard, body)
+	body.Close()
+}
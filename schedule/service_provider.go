@@ -0,0 +1,33 @@
+package schedule
+
+import (
+	"sync"
+
+	"github.com/lara-go/larago"
+	"github.com/lara-go/larago/logger"
+)
+
+// ServiceProvider struct.
+type ServiceProvider struct{}
+
+// Register service.
+func (p *ServiceProvider) Register(application *larago.Application) {
+	application.Commands(
+		&CommandRun{},
+		&CommandList{},
+		&CommandTest{},
+	)
+
+	// Tasks are defined on the scheduler resolved by alias and run on the one resolved by type,
+	// so they must be the same instance.
+	var scheduler *Scheduler
+	var once sync.Once
+
+	application.Bind(func() (*Scheduler, error) {
+		once.Do(func() {
+			scheduler = NewScheduler(application.Get("logger").(*logger.Logger))
+		})
+
+		return scheduler, nil
+	}, "schedule")
+}
@@ -0,0 +1,298 @@
+package schedule
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// TaskFunc is a scheduled job. Everything written to output is captured.
+type TaskFunc func(output io.Writer) error
+
+// FailureCallback is called when task fails.
+type FailureCallback func(result *Result)
+
+// Result of the task run.
+type Result struct {
+	Task      string        `json:"task"`
+	StartedAt time.Time     `json:"started_at"`
+	Duration  time.Duration `json:"duration"`
+	Output    string        `json:"output"`
+	Error     string        `json:"error,omitempty"`
+	Err       error         `json:"-"`
+}
+
+// Failed checks if task failed.
+func (r *Result) Failed() bool {
+	return r.Err != nil
+}
+
+// Task scheduled to run periodically.
+type Task struct {
+	name        string
+	description string
+	callback    TaskFunc
+
+	interval time.Duration
+	hour     int
+	minute   int
+	daily    bool
+
+	outputFile   string
+	appendOutput bool
+
+	pingBefore   []string
+	pingSuccess  []string
+	pingFailure  []string
+	failureHooks []string
+	onFailure    []FailureCallback
+}
+
+func newTask(name string, callback TaskFunc) *Task {
+	return &Task{
+		name:     name,
+		callback: callback,
+		interval: time.Minute,
+	}
+}
+
+// Name of the task.
+func (t *Task) Name() string {
+	return t.name
+}
+
+// Description of the task.
+func (t *Task) Description() string {
+	return t.description
+}
+
+// Describe task for schedule:list.
+func (t *Task) Describe(description string) *Task {
+	t.description = description
+
+	return t
+}
+
+// Every runs task with the interval aligned to the clock. Interval is rounded to minutes.
+func (t *Task) Every(interval time.Duration) *Task {
+	if interval < time.Minute {
+		interval = time.Minute
+	}
+
+	t.interval = interval.Truncate(time.Minute)
+	t.daily = false
+
+	return t
+}
+
+// EveryMinute runs task every minute.
+func (t *Task) EveryMinute() *Task {
+	return t.Every(time.Minute)
+}
+
+// Hourly runs task at the beginning of every hour.
+func (t *Task) Hourly() *Task {
+	return t.Every(time.Hour)
+}
+
+// Daily runs task at midnight.
+func (t *Task) Daily() *Task {
+	return t.DailyAt("00:00")
+}
+
+// DailyAt runs task every day at the time (ex. "13:30").
+func (t *Task) DailyAt(clock string) *Task {
+	parsed, err := time.Parse("15:04", clock)
+	if err != nil {
+		panic(fmt.Errorf("Invalid time %s of task %s", clock, t.name))
+	}
+
+	t.daily = true
+	t.hour = parsed.Hour()
+	t.minute = parsed.Minute()
+
+	return t
+}
+
+// SendOutputTo writes output of every run into the file.
+func (t *Task) SendOutputTo(file string) *Task {
+	t.outputFile = file
+	t.appendOutput = false
+
+	return t
+}
+
+// AppendOutputTo appends output of every run to the file.
+func (t *Task) AppendOutputTo(file string) *Task {
+	t.outputFile = file
+	t.appendOutput = true
+
+	return t
+}
+
+// PingBefore pings url before every run.
+func (t *Task) PingBefore(url string) *Task {
+	t.pingBefore = append(t.pingBefore, url)
+
+	return t
+}
+
+// PingOnSuccess pings url after successful run.
+func (t *Task) PingOnSuccess(url string) *Task {
+	t.pingSuccess = append(t.pingSuccess, url)
+
+	return t
+}
+
+// PingOnFailure pings url after failed run.
+func (t *Task) PingOnFailure(url string) *Task {
+	t.pingFailure = append(t.pingFailure, url)
+
+	return t
+}
+
+// Monitor reports runs to healthchecks.io-style monitor:
+// url/start before run, url after success and url/fail after failure.
+func (t *Task) Monitor(url string) *Task {
+	url = strings.TrimRight(url, "/")
+
+	return t.PingBefore(url + "/start").PingOnSuccess(url).PingOnFailure(url + "/fail")
+}
+
+// WebhookOnFailure posts JSON result to url when task fails.
+func (t *Task) WebhookOnFailure(url string) *Task {
+	t.failureHooks = append(t.failureHooks, url)
+
+	return t
+}
+
+// OnFailure calls callback when task fails. Use it to send emails and other notifications.
+func (t *Task) OnFailure(callback FailureCallback) *Task {
+	t.onFailure = append(t.onFailure, callback)
+
+	return t
+}
+
+// Schedule returns human readable schedule.
+func (t *Task) Schedule() string {
+	if t.daily {
+		return fmt.Sprintf("daily at %02d:%02d", t.hour, t.minute)
+	}
+
+	return fmt.Sprintf("every %s", strings.TrimSuffix(strings.TrimSuffix(t.interval.String(), "0s"), "0m"))
+}
+
+// Due checks if task must run at the minute.
+func (t *Task) Due(now time.Time) bool {
+	now = now.Truncate(time.Minute)
+
+	if t.daily {
+		return now.Hour() == t.hour && now.Minute() == t.minute
+	}
+
+	minutes := int64(t.interval / time.Minute)
+	sinceMidnight := int64(now.Hour()*60 + now.Minute())
+
+	// Intervals of a day and longer are counted from the Unix epoch.
+	if t.interval >= 24*time.Hour {
+		return (now.Unix()/60)%minutes == 0
+	}
+
+	return sinceMidnight%minutes == 0
+}
+
+// NextRun returns the next minute the task is due after the time.
+func (t *Task) NextRun(after time.Time) time.Time {
+	next := after.Truncate(time.Minute).Add(time.Minute)
+
+	// Daily tasks are due at least once in 2 days, interval ones within their interval.
+	limit := next.Add(48 * time.Hour)
+	if !t.daily && t.interval > 48*time.Hour {
+		limit = next.Add(t.interval)
+	}
+
+	for ; next.Before(limit); next = next.Add(time.Minute) {
+		if t.Due(next) {
+			return next
+		}
+	}
+
+	return next
+}
+
+// Exec runs task once without pings, notifications and output files.
+func (t *Task) Exec() *Result {
+	return t.run()
+}
+
+// Run task capturing its output.
+func (t *Task) run() *Result {
+	var output bytes.Buffer
+
+	result := &Result{
+		Task:      t.name,
+		StartedAt: time.Now(),
+	}
+
+	result.Err = t.safeCall(&output)
+	result.Duration = time.Now().Sub(result.StartedAt)
+	result.Output = output.String()
+	if result.Err != nil {
+		result.Error = result.Err.Error()
+	}
+
+	return result
+}
+
+// Call task recovering from panics.
+func (t *Task) safeCall(output io.Writer) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("Task panicked: %v", r)
+		}
+	}()
+
+	return t.callback(output)
+}
+
+// Save output to the file.
+func (t *Task) writeOutput(result *Result) error {
+	if t.outputFile == "" {
+		return nil
+	}
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if t.appendOutput {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+
+	f, err := os.OpenFile(t.outputFile, flags, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(result.Output)
+
+	return err
+}
+
+// Run console command of the application binary.
+func commandFunc(args []string) TaskFunc {
+	return func(output io.Writer) error {
+		executable, err := os.Executable()
+		if err != nil {
+			return err
+		}
+
+		cmd := exec.Command(executable, args...)
+		cmd.Stdout = output
+		cmd.Stderr = output
+
+		return cmd.Run()
+	}
+}
@@ -0,0 +1,168 @@
+package search
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Builder of search queries.
+type Builder struct {
+	engine Engine
+	db     *gorm.DB
+	query  *Query
+}
+
+// Paginator holds pagination state of the search.
+type Paginator struct {
+	Total       int
+	PerPage     int
+	CurrentPage int
+	LastPage    int
+}
+
+// NewBuilder constructor.
+func NewBuilder(engine Engine, db *gorm.DB, model Searchable, query string) *Builder {
+	return &Builder{
+		engine: engine,
+		db:     db,
+		query: &Query{
+			Index:  model.SearchableAs(),
+			Model:  model,
+			Query:  query,
+			Wheres: make(map[string]interface{}),
+		},
+	}
+}
+
+// Where adds exact match filter.
+func (b *Builder) Where(field string, value interface{}) *Builder {
+	b.query.Wheres[field] = value
+
+	return b
+}
+
+// Take limits amount of results.
+func (b *Builder) Take(limit int) *Builder {
+	b.query.Limit = limit
+
+	return b
+}
+
+// Keys returns IDs of the found models.
+func (b *Builder) Keys() ([]string, error) {
+	results, err := b.engine.Search(b.query)
+	if err != nil {
+		return nil, err
+	}
+
+	return results.IDs, nil
+}
+
+// Get found models into target slice.
+func (b *Builder) Get(target interface{}) error {
+	results, err := b.engine.Search(b.query)
+	if err != nil {
+		return err
+	}
+
+	return b.load(results.IDs, target)
+}
+
+// Paginate results and load models of the page into target slice.
+func (b *Builder) Paginate(perPage, page int, target interface{}) (*Paginator, error) {
+	if perPage < 1 {
+		perPage = 15
+	}
+	if page < 1 {
+		page = 1
+	}
+
+	b.query.Offset = (page - 1) * perPage
+	b.query.Limit = perPage
+
+	results, err := b.engine.Search(b.query)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.load(results.IDs, target); err != nil {
+		return nil, err
+	}
+
+	lastPage := (results.Total + perPage - 1) / perPage
+	if lastPage < 1 {
+		lastPage = 1
+	}
+
+	return &Paginator{
+		Total:       results.Total,
+		PerPage:     perPage,
+		CurrentPage: page,
+		LastPage:    lastPage,
+	}, nil
+}
+
+// Load models from the database keeping the order of the search results.
+func (b *Builder) load(ids []string, target interface{}) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Slice {
+		return errors.New("Search target must be a pointer to slice")
+	}
+
+	if len(ids) == 0 {
+		v.Elem().Set(reflect.MakeSlice(v.Elem().Type(), 0, 0))
+
+		return nil
+	}
+
+	scope := b.db.NewScope(b.query.Model)
+	err := b.db.Where(fmt.Sprintf("%s IN (?)", scope.Quote(scope.PrimaryKey())), ids).Find(target).Error
+	if err != nil {
+		return err
+	}
+
+	positions := make(map[string]int, len(ids))
+	for i, id := range ids {
+		positions[id] = i
+	}
+
+	slice := v.Elem()
+	sorted := reflect.MakeSlice(slice.Type(), len(ids), len(ids))
+	found := make([]bool, len(ids))
+
+	for i := 0; i < slice.Len(); i++ {
+		item := slice.Index(i)
+		model := item.Interface()
+		if item.Kind() != reflect.Ptr {
+			model = item.Addr().Interface()
+		}
+
+		position, ok := positions[modelKey(b.db, model)]
+		if !ok {
+			continue
+		}
+
+		sorted.Index(position).Set(item)
+		found[position] = true
+	}
+
+	// Skip models that were already removed from the database.
+	result := reflect.MakeSlice(slice.Type(), 0, len(ids))
+	for i := range ids {
+		if found[i] {
+			result = reflect.Append(result, sorted.Index(i))
+		}
+	}
+
+	slice.Set(result)
+
+	return nil
+}
+
+// Get primary key of the model as string.
+func modelKey(db *gorm.DB, model interface{}) string {
+	return fmt.Sprint(db.NewScope(model).PrimaryKeyValue())
+}
@@ -0,0 +1,153 @@
+package search_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago/search"
+	"github.com/lara-go/larago/support/testsuite"
+	"github.com/stretchr/testify/assert"
+
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+)
+
+type article struct {
+	ID    uint `gorm:"primary_key"`
+	Title string
+	Kind  string
+}
+
+func (a *article) SearchableAs() string {
+	return "articles"
+}
+
+func (a *article) ToSearchableArray() map[string]interface{} {
+	return map[string]interface{}{
+		"title": a.Title,
+	}
+}
+
+func databaseFactory(t *testing.T) *gorm.DB {
+	db := testsuite.MemoryDB(t, &article{})
+	db.Create(&article{Title: "Go routines explained", Kind: "post"})
+	db.Create(&article{Title: "Cooking pasta", Kind: "post"})
+	db.Create(&article{Title: "Go modules", Kind: "news"})
+	db.Create(&article{Title: "Go testing", Kind: "post"})
+
+	return db
+}
+
+func TestBuilder_Get(t *testing.T) {
+	db := databaseFactory(t)
+
+	var articles []article
+	err := search.NewBuilder(search.NewDatabaseEngine(db), db, &article{}, "Go").Get(&articles)
+
+	assert.NoError(t, err)
+	assert.Len(t, articles, 3)
+	assert.Equal(t, "Go routines explained", articles[0].Title)
+}
+
+func TestBuilder_Where(t *testing.T) {
+	db := databaseFactory(t)
+
+	var articles []*article
+	err := search.NewBuilder(search.NewDatabaseEngine(db), db, &article{}, "Go").
+		Where("kind", "news").
+		Get(&articles)
+
+	assert.NoError(t, err)
+	assert.Len(t, articles, 1)
+	assert.Equal(t, "Go modules", articles[0].Title)
+}
+
+func TestBuilder_Paginate(t *testing.T) {
+	db := databaseFactory(t)
+
+	var articles []article
+	paginator, err := search.NewBuilder(search.NewDatabaseEngine(db), db, &article{}, "Go").
+		Paginate(2, 2, &articles)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, paginator.Total)
+	assert.Equal(t, 2, paginator.LastPage)
+	assert.Len(t, articles, 1)
+	assert.Equal(t, "Go testing", articles[0].Title)
+}
+
+type recordingEngine struct {
+	search.DatabaseEngine
+	updated []search.Document
+	deleted []string
+}
+
+func (e *recordingEngine) Update(index string, documents []search.Document) error {
+	e.updated = append(e.updated, documents...)
+
+	return nil
+}
+
+func (e *recordingEngine) Delete(index string, ids []string) error {
+	e.deleted = append(e.deleted, ids...)
+
+	return nil
+}
+
+func TestIndexer_ModelEvents(t *testing.T) {
+	db := databaseFactory(t)
+
+	engine := &recordingEngine{}
+	indexer := search.NewIndexer(engine, nil, 10)
+	indexer.Register(db)
+
+	model := &article{Title: "Indexed"}
+	db.Create(model)
+	db.Delete(model)
+
+	indexer.Close()
+
+	assert.Len(t, engine.updated, 1)
+	assert.Equal(t, "Indexed", engine.updated[0].Fields["title"])
+	assert.Equal(t, []string{"5"}, engine.deleted)
+}
+
+func TestIndexer_QueuesCommittedChanges(t *testing.T) {
+	db := databaseFactory(t)
+
+	engine := &recordingEngine{}
+	indexer := search.NewIndexer(engine, nil, 10)
+	indexer.Register(db)
+
+	err := indexer.Transaction(db, func(tx *gorm.DB) error {
+		tx.Create(&article{Title: "Rolled back"})
+
+		return errors.New("Failed")
+	})
+	assert.Error(t, err)
+
+	assert.NoError(t, indexer.Transaction(db, func(tx *gorm.DB) error {
+		return tx.Create(&article{Title: "Committed"}).Error
+	}))
+
+	indexer.Close()
+
+	assert.Len(t, engine.updated, 1)
+	assert.Equal(t, "Committed", engine.updated[0].Fields["title"])
+
+	// Changes made after the indexer is closed are not indexed.
+	assert.NotPanics(t, func() {
+		db.Create(&article{Title: "Late"})
+	})
+	assert.Len(t, engine.updated, 1)
+}
+
+func TestBuilder_EscapesWildcards(t *testing.T) {
+	db := databaseFactory(t)
+
+	var articles []article
+	err := search.NewBuilder(search.NewDatabaseEngine(db), db, &article{}, "%").Get(&articles)
+
+	assert.NoError(t, err)
+	assert.Empty(t, articles)
+}
@@ -0,0 +1,96 @@
+package search
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+)
+
+// DatabaseEngine searches models right in the database with LIKE queries.
+// It does not need any index, so updates are ignored.
+type DatabaseEngine struct {
+	db *gorm.DB
+}
+
+// NewDatabaseEngine constructor.
+func NewDatabaseEngine(db *gorm.DB) *DatabaseEngine {
+	return &DatabaseEngine{
+		db: db,
+	}
+}
+
+// Update is not needed for database.
+func (e *DatabaseEngine) Update(index string, documents []Document) error {
+	return nil
+}
+
+// Delete is not needed for database.
+func (e *DatabaseEngine) Delete(index string, ids []string) error {
+	return nil
+}
+
+// Flush is not needed for database.
+func (e *DatabaseEngine) Flush(index string) error {
+	return nil
+}
+
+// Escapes wildcards of LIKE patterns, the escape character works the same in all the databases.
+var likeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// Search models with LIKE over searchable fields.
+func (e *DatabaseEngine) Search(query *Query) (*Results, error) {
+	scope := e.db.NewScope(query.Model)
+	db := e.db.Model(query.Model)
+
+	if query.Query != "" {
+		var columns []string
+		for column := range query.Model.ToSearchableArray() {
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+
+		conditions := make([]string, 0, len(columns))
+		values := make([]interface{}, 0, len(columns))
+		for _, column := range columns {
+			conditions = append(conditions, fmt.Sprintf("%s LIKE ? ESCAPE '!'", scope.Quote(column)))
+			values = append(values, "%"+likeEscaper.Replace(query.Query)+"%")
+		}
+
+		if len(conditions) > 0 {
+			db = db.Where(strings.Join(conditions, " OR "), values...)
+		}
+	}
+
+	for field, value := range query.Wheres {
+		db = db.Where(fmt.Sprintf("%s = ?", scope.Quote(field)), value)
+	}
+
+	var total int
+	if err := db.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	if query.Offset > 0 {
+		db = db.Offset(query.Offset)
+	}
+	if query.Limit > 0 {
+		db = db.Limit(query.Limit)
+	}
+
+	var keys []interface{}
+	if err := db.Order(scope.Quote(scope.PrimaryKey())).Pluck(scope.PrimaryKey(), &keys).Error; err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if bytes, ok := key.([]byte); ok {
+			key = string(bytes)
+		}
+		ids = append(ids, fmt.Sprint(key))
+	}
+
+	return &Results{IDs: ids, Total: total}, nil
+}
@@ -0,0 +1,132 @@
+package search
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// ElasticsearchEngine indexes documents in Elasticsearch.
+type ElasticsearchEngine struct {
+	client *httpClient
+}
+
+// NewElasticsearchEngine constructor.
+func NewElasticsearchEngine(host, username, password string) *ElasticsearchEngine {
+	headers := make(map[string]string)
+	if username != "" {
+		headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))
+	}
+
+	return &ElasticsearchEngine{
+		client: newHTTPClient(host, headers),
+	}
+}
+
+// Update or insert documents into the index.
+func (e *ElasticsearchEngine) Update(index string, documents []Document) error {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+
+	for _, document := range documents {
+		encoder.Encode(map[string]interface{}{
+			"index": map[string]string{"_index": index, "_id": document.ID},
+		})
+		if err := encoder.Encode(document.Fields); err != nil {
+			return err
+		}
+	}
+
+	return e.bulk(&buffer)
+}
+
+// Delete documents from the index.
+func (e *ElasticsearchEngine) Delete(index string, ids []string) error {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+
+	for _, id := range ids {
+		encoder.Encode(map[string]interface{}{
+			"delete": map[string]string{"_index": index, "_id": id},
+		})
+	}
+
+	return e.bulk(&buffer)
+}
+
+// Flush all documents from the index.
+func (e *ElasticsearchEngine) Flush(index string) error {
+	payload := map[string]interface{}{
+		"query": map[string]interface{}{"match_all": map[string]interface{}{}},
+	}
+
+	return e.client.json("POST", fmt.Sprintf("/%s/_delete_by_query", url.PathEscape(index)), payload, nil)
+}
+
+// Search documents.
+func (e *ElasticsearchEngine) Search(query *Query) (*Results, error) {
+	boolQuery := map[string]interface{}{}
+
+	if query.Query != "" {
+		boolQuery["must"] = map[string]interface{}{
+			"multi_match": map[string]interface{}{
+				"query":  query.Query,
+				"fields": []string{"*"},
+			},
+		}
+	} else {
+		boolQuery["must"] = map[string]interface{}{"match_all": map[string]interface{}{}}
+	}
+
+	var filters []interface{}
+	for field, value := range query.Wheres {
+		filters = append(filters, map[string]interface{}{
+			"term": map[string]interface{}{field: value},
+		})
+	}
+	if len(filters) > 0 {
+		boolQuery["filter"] = filters
+	}
+
+	payload := map[string]interface{}{
+		"query":   map[string]interface{}{"bool": boolQuery},
+		"from":    query.Offset,
+		"_source": false,
+	}
+	if query.Limit > 0 {
+		payload["size"] = query.Limit
+	}
+
+	var response struct {
+		Hits struct {
+			Total struct {
+				Value int `json:"value"`
+			} `json:"total"`
+			Hits []struct {
+				ID string `json:"_id"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+
+	if err := e.client.json("POST", fmt.Sprintf("/%s/_search", url.PathEscape(query.Index)), payload, &response); err != nil {
+		return nil, err
+	}
+
+	results := &Results{Total: response.Hits.Total.Value}
+	for _, hit := range response.Hits.Hits {
+		results.IDs = append(results.IDs, hit.ID)
+	}
+
+	return results, nil
+}
+
+// Send bulk request.
+func (e *ElasticsearchEngine) bulk(body *bytes.Buffer) error {
+	if body.Len() == 0 {
+		return nil
+	}
+
+	return e.client.do("POST", "/_bulk?refresh=wait_for", "application/x-ndjson", body, nil)
+}
@@ -0,0 +1,19 @@
+package search
+
+import (
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago"
+)
+
+// FacadeWrapper for facade.
+var FacadeWrapper = &larago.Facade{}
+
+// Facade for search indexer.
+func Facade() *Indexer {
+	return FacadeWrapper.Resolve("search").(*Indexer)
+}
+
+// Search models with the configured engine.
+func Search(db *gorm.DB, model Searchable, query string) *Builder {
+	return NewBuilder(Facade().Engine(), db, model, query)
+}
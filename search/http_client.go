@@ -0,0 +1,77 @@
+package search
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	net_http "net/http"
+	"strings"
+	"time"
+
+	"github.com/lara-go/larago/httpclient"
+)
+
+// Small JSON client shared by HTTP engines.
+type httpClient struct {
+	host    string
+	headers map[string]string
+	client  *net_http.Client
+}
+
+func newHTTPClient(host string, headers map[string]string) *httpClient {
+	return &httpClient{
+		host:    strings.TrimRight(host, "/"),
+		headers: headers,
+		client:  httpclient.New(10 * time.Second),
+	}
+}
+
+// Send request with JSON payload and decode JSON response into target.
+func (c *httpClient) json(method, path string, payload, target interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	return c.do(method, path, "application/json", body, target)
+}
+
+// Send raw request and decode JSON response into target.
+func (c *httpClient) do(method, path, contentType string, body io.Reader, target interface{}) error {
+	request, err := net_http.NewRequest(method, c.host+path, body)
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", contentType)
+	for name, value := range c.headers {
+		request.Header.Set(name, value)
+	}
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	content, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode >= 300 {
+		return fmt.Errorf("Search engine responded with %d: %s", response.StatusCode, content)
+	}
+
+	if target == nil || len(content) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(content, target)
+}
@@ -0,0 +1,215 @@
+package search
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago/logger"
+)
+
+// Index update operations.
+const (
+	operationUpdate = iota
+	operationDelete
+)
+
+type indexJob struct {
+	operation int
+	index     string
+	document  Document
+}
+
+// Setting of the transaction with its queued jobs.
+const pendingJobsSetting = "larago:search_pending_jobs"
+
+// Jobs waiting for the transaction to commit.
+type pendingJobs struct {
+	mutex sync.Mutex
+	jobs  []indexJob
+}
+
+// Indexer keeps search indexes in sync with models.
+// Index updates are queued after changes are committed and sent to the engine in background.
+// Updates are dropped when the queue is full or the indexer is closed.
+type Indexer struct {
+	engine Engine
+	logger *logger.Logger
+
+	jobs   chan indexJob
+	wg     sync.WaitGroup
+	mutex  sync.RWMutex
+	closed bool
+}
+
+// NewIndexer constructor.
+func NewIndexer(engine Engine, logger *logger.Logger, queueSize int) *Indexer {
+	indexer := &Indexer{
+		engine: engine,
+		logger: logger,
+		jobs:   make(chan indexJob, queueSize),
+	}
+
+	indexer.wg.Add(1)
+	go indexer.work()
+
+	return indexer
+}
+
+// Engine returns search engine.
+func (i *Indexer) Engine() Engine {
+	return i.engine
+}
+
+// Register model events callbacks on the connection.
+func (i *Indexer) Register(db *gorm.DB) {
+	db.Callback().Create().After("gorm:commit_or_rollback_transaction").Register("larago:search_update", i.updateCallback)
+	db.Callback().Update().After("gorm:commit_or_rollback_transaction").Register("larago:search_update", i.updateCallback)
+	db.Callback().Delete().After("gorm:commit_or_rollback_transaction").Register("larago:search_delete", i.deleteCallback)
+}
+
+// Transaction runs callback in transaction and queues index updates of its models only when it is committed.
+// Changes made in other explicit transactions are queued as soon as the statement succeeds.
+func (i *Indexer) Transaction(db *gorm.DB, callback func(tx *gorm.DB) error) error {
+	tx := db.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			tx.Rollback()
+		}
+	}()
+
+	pending := &pendingJobs{}
+	if err := callback(tx.Set(pendingJobsSetting, pending)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return err
+	}
+	committed = true
+
+	for _, job := range pending.jobs {
+		i.queue(job)
+	}
+
+	return nil
+}
+
+// Import models into their index synchronously.
+func (i *Indexer) Import(db *gorm.DB, models ...Searchable) error {
+	documents := make(map[string][]Document)
+	for _, model := range models {
+		documents[model.SearchableAs()] = append(documents[model.SearchableAs()], Document{
+			ID:     modelKey(db, model),
+			Fields: model.ToSearchableArray(),
+		})
+	}
+
+	for index, batch := range documents {
+		if err := i.engine.Update(index, batch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close waits until queued updates are sent and stops the worker.
+func (i *Indexer) Close() {
+	i.mutex.Lock()
+	if !i.closed {
+		i.closed = true
+		close(i.jobs)
+	}
+	i.mutex.Unlock()
+
+	i.wg.Wait()
+}
+
+// Queue model update after it was saved.
+func (i *Indexer) updateCallback(scope *gorm.Scope) {
+	model, ok := scope.Value.(Searchable)
+	if !ok || scope.HasError() || scope.PrimaryKeyZero() {
+		return
+	}
+
+	i.hold(scope, indexJob{
+		operation: operationUpdate,
+		index:     model.SearchableAs(),
+		document: Document{
+			ID:     modelKey(scope.DB(), model),
+			Fields: model.ToSearchableArray(),
+		},
+	})
+}
+
+// Queue model removal after it was deleted.
+func (i *Indexer) deleteCallback(scope *gorm.Scope) {
+	model, ok := scope.Value.(Searchable)
+	if !ok || scope.HasError() || scope.PrimaryKeyZero() {
+		return
+	}
+
+	i.hold(scope, indexJob{
+		operation: operationDelete,
+		index:     model.SearchableAs(),
+		document:  Document{ID: modelKey(scope.DB(), model)},
+	})
+}
+
+// Queue job, or keep it until the transaction of the scope is committed.
+func (i *Indexer) hold(scope *gorm.Scope, job indexJob) {
+	if value, ok := scope.Get(pendingJobsSetting); ok {
+		pending := value.(*pendingJobs)
+		pending.mutex.Lock()
+		pending.jobs = append(pending.jobs, job)
+		pending.mutex.Unlock()
+
+		return
+	}
+
+	i.queue(job)
+}
+
+// Queue job without blocking the caller.
+func (i *Indexer) queue(job indexJob) {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	if i.closed {
+		return
+	}
+
+	select {
+	case i.jobs <- job:
+	default:
+		if i.logger != nil {
+			i.logger.Error(fmt.Errorf("Search queue is full, update of %s/%s is dropped", job.index, job.document.ID))
+		}
+	}
+}
+
+// Send queued updates to the engine.
+func (i *Indexer) work() {
+	defer i.wg.Done()
+
+	for job := range i.jobs {
+		var err error
+
+		switch job.operation {
+		case operationUpdate:
+			err = i.engine.Update(job.index, []Document{job.document})
+		case operationDelete:
+			err = i.engine.Delete(job.index, []string{job.document.ID})
+		}
+
+		if err != nil && i.logger != nil {
+			i.logger.Error(err)
+		}
+	}
+}
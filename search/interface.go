@@ -0,0 +1,47 @@
+package search
+
+// Searchable model can be indexed by the search engine.
+type Searchable interface {
+	// SearchableAs returns index name.
+	SearchableAs() string
+
+	// ToSearchableArray returns fields to index.
+	ToSearchableArray() map[string]interface{}
+}
+
+// Engine to index and search documents.
+type Engine interface {
+	// Update or insert documents into the index.
+	Update(index string, documents []Document) error
+
+	// Delete documents from the index.
+	Delete(index string, ids []string) error
+
+	// Search documents.
+	Search(query *Query) (*Results, error)
+
+	// Flush all documents from the index.
+	Flush(index string) error
+}
+
+// Document to index.
+type Document struct {
+	ID     string
+	Fields map[string]interface{}
+}
+
+// Query to the engine.
+type Query struct {
+	Index  string
+	Model  Searchable
+	Query  string
+	Wheres map[string]interface{}
+	Offset int
+	Limit  int
+}
+
+// Results of the search.
+type Results struct {
+	IDs   []string
+	Total int
+}
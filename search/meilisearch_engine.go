@@ -0,0 +1,92 @@
+package search
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// MeilisearchEngine indexes documents in Meilisearch.
+type MeilisearchEngine struct {
+	client *httpClient
+}
+
+// NewMeilisearchEngine constructor.
+func NewMeilisearchEngine(host, key string) *MeilisearchEngine {
+	headers := make(map[string]string)
+	if key != "" {
+		headers["Authorization"] = "Bearer " + key
+	}
+
+	return &MeilisearchEngine{
+		client: newHTTPClient(host, headers),
+	}
+}
+
+// Update or insert documents into the index.
+func (e *MeilisearchEngine) Update(index string, documents []Document) error {
+	payload := make([]map[string]interface{}, 0, len(documents))
+	for _, document := range documents {
+		fields := make(map[string]interface{}, len(document.Fields)+1)
+		for name, value := range document.Fields {
+			fields[name] = value
+		}
+		fields["id"] = document.ID
+
+		payload = append(payload, fields)
+	}
+
+	return e.client.json("POST", fmt.Sprintf("/indexes/%s/documents?primaryKey=id", url.PathEscape(index)), payload, nil)
+}
+
+// Delete documents from the index.
+func (e *MeilisearchEngine) Delete(index string, ids []string) error {
+	return e.client.json("POST", fmt.Sprintf("/indexes/%s/documents/delete-batch", url.PathEscape(index)), ids, nil)
+}
+
+// Flush all documents from the index.
+func (e *MeilisearchEngine) Flush(index string) error {
+	return e.client.json("DELETE", fmt.Sprintf("/indexes/%s/documents", url.PathEscape(index)), nil, nil)
+}
+
+// Search documents.
+func (e *MeilisearchEngine) Search(query *Query) (*Results, error) {
+	payload := map[string]interface{}{
+		"q":                    query.Query,
+		"offset":               query.Offset,
+		"attributesToRetrieve": []string{"id"},
+	}
+
+	if query.Limit > 0 {
+		payload["limit"] = query.Limit
+	}
+
+	if len(query.Wheres) > 0 {
+		var filters []string
+		for field, value := range query.Wheres {
+			filters = append(filters, fmt.Sprintf("%s = %q", field, fmt.Sprint(value)))
+		}
+		sort.Strings(filters)
+
+		payload["filter"] = strings.Join(filters, " AND ")
+	}
+
+	var response struct {
+		Hits []struct {
+			ID interface{} `json:"id"`
+		} `json:"hits"`
+		EstimatedTotalHits int `json:"estimatedTotalHits"`
+	}
+
+	if err := e.client.json("POST", fmt.Sprintf("/indexes/%s/search", url.PathEscape(query.Index)), payload, &response); err != nil {
+		return nil, err
+	}
+
+	results := &Results{Total: response.EstimatedTotalHits}
+	for _, hit := range response.Hits {
+		results.IDs = append(results.IDs, fmt.Sprint(hit.ID))
+	}
+
+	return results, nil
+}
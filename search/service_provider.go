@@ -0,0 +1,65 @@
+package search
+
+import (
+	"fmt"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago"
+	"github.com/lara-go/larago/logger"
+)
+
+// ServiceProvider struct.
+type ServiceProvider struct{}
+
+// Register service.
+func (p *ServiceProvider) Register(application *larago.Application) {
+	application.Bind(func() (Engine, error) {
+		return p.makeEngine(application)
+	}, "search.engine")
+
+	application.Bind(func() (*Indexer, error) {
+		indexer := NewIndexer(
+			application.Get("search.engine").(Engine),
+			application.Get("logger").(*logger.Logger),
+			1000,
+		)
+		indexer.Register(application.Get("db.connection").(*gorm.DB))
+
+		return indexer, nil
+	}, "search")
+}
+
+// Make engine configured by Search.Driver.
+func (p *ServiceProvider) makeEngine(application *larago.Application) (Engine, error) {
+	switch driver := p.config(application, "Search.Driver", "database"); driver {
+	case "database":
+		return NewDatabaseEngine(application.Get("db.connection").(*gorm.DB)), nil
+
+	case "meilisearch":
+		return NewMeilisearchEngine(
+			p.config(application, "Search.Host", "http://127.0.0.1:7700"),
+			p.config(application, "Search.Key", ""),
+		), nil
+
+	case "elasticsearch":
+		return NewElasticsearchEngine(
+			p.config(application, "Search.Host", "http://127.0.0.1:9200"),
+			p.config(application, "Search.Username", ""),
+			p.config(application, "Search.Password", ""),
+		), nil
+
+	default:
+		return nil, fmt.Errorf("Unknown search driver %s", driver)
+	}
+}
+
+// Get string config value or default one.
+func (p *ServiceProvider) config(application *larago.Application, key, value string) string {
+	if application.Config().Has(key) {
+		if configured, ok := application.Config().Get(key).(string); ok && configured != "" {
+			return configured
+		}
+	}
+
+	return value
+}
@@ -0,0 +1,37 @@
+package session
+
+import (
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/http/errors"
+	"github.com/lara-go/larago/http/responses"
+)
+
+// Request attributes that should be filled by authentication middleware.
+// Expiration time.Time of the session token is optional.
+const (
+	UserIDAttribute         = "session.user_id"
+	SessionIDAttribute      = "session.id"
+	SessionExpiresAttribute = "session.expires_at"
+)
+
+// TrackDevices middleware registers activity of authenticated sessions
+// and rejects sessions that were revoked.
+type TrackDevices struct {
+	Registry *Registry
+}
+
+// Handle request.
+func (m *TrackDevices) Handle(request *http.Request, next http.Handler) responses.Response {
+	userID, _ := request.Attribute(UserIDAttribute).(string)
+	sessionID, _ := request.Attribute(SessionIDAttribute).(string)
+
+	if userID == "" || sessionID == "" {
+		return next(request)
+	}
+
+	if err := m.Registry.Touch(userID, sessionID, request); err == ErrorRevoked {
+		panic(errors.UnauthorizedHTTPError())
+	}
+
+	return next(request)
+}
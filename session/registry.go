@@ -0,0 +1,229 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lara-go/larago/cache"
+	"github.com/lara-go/larago/http"
+)
+
+// Do not rewrite device in store more often than that, if nothing has changed.
+const touchInterval = time.Minute
+
+// ErrorRevoked is returned when revoked session tries to be active.
+var ErrorRevoked = errors.New("Session was revoked")
+
+// Device is an active session of the user.
+type Device struct {
+	SessionID  string
+	UserAgent  string
+	Browser    string
+	OS         string
+	Device     string
+	IP         string
+	CreatedAt  time.Time
+	LastActive time.Time
+	ExpiresAt  time.Time
+}
+
+// Registry tracks active sessions of every user.
+// Every device is stored under its own key, so concurrent requests of different sessions do not overwrite each other.
+type Registry struct {
+	cache    cache.Cache
+	lifetime time.Duration
+	mutex    sync.Mutex
+}
+
+// NewRegistry constructor.
+func NewRegistry(cache cache.Cache, lifetime time.Duration) *Registry {
+	return &Registry{
+		cache:    cache,
+		lifetime: lifetime,
+	}
+}
+
+// Touch registers session activity made by request.
+// Expiration of the session token is taken from SessionExpiresAttribute of the request, if it is set.
+func (r *Registry) Touch(userID, sessionID string, request *http.Request) error {
+	if r.Revoked(sessionID) {
+		return ErrorRevoked
+	}
+
+	now := time.Now()
+	ip := request.IP()
+
+	var device Device
+	err := r.cache.Get(r.deviceKey(userID, sessionID), &device)
+	if err == nil && device.IP == ip && now.Sub(device.LastActive) < touchInterval {
+		return nil
+	}
+
+	if err != nil {
+		agent := request.UserAgent()
+		device = Device{
+			SessionID: sessionID,
+			UserAgent: agent.Raw,
+			Browser:   agent.Browser,
+			OS:        agent.OS,
+			Device:    agent.Device,
+			CreatedAt: now,
+		}
+	}
+
+	if expiresAt, ok := request.Attribute(SessionExpiresAttribute).(time.Time); ok {
+		device.ExpiresAt = expiresAt
+	}
+
+	device.IP = ip
+	device.LastActive = now
+
+	r.cache.Put(r.deviceKey(userID, sessionID), device, r.deviceLifetime(device))
+	r.addSession(userID, sessionID)
+
+	return nil
+}
+
+// Revoked checks if session was revoked.
+func (r *Registry) Revoked(sessionID string) bool {
+	return r.cache.Has(r.revokedKey(sessionID))
+}
+
+// List active sessions of the user, most recent first.
+func (r *Registry) List(userID string) []Device {
+	sessions := r.sessions(userID)
+
+	list := make([]Device, 0, len(sessions))
+	for sessionID := range sessions {
+		var device Device
+		if err := r.cache.Get(r.deviceKey(userID, sessionID), &device); err == nil {
+			list = append(list, device)
+		}
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].LastActive.After(list[j].LastActive)
+	})
+
+	return list
+}
+
+// Revoke session of the user.
+func (r *Registry) Revoke(userID, sessionID string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	sessions := r.sessions(userID)
+	r.revoke(userID, sessionID)
+	delete(sessions, sessionID)
+
+	r.saveSessions(userID, sessions)
+}
+
+// RevokeOthers revokes every session of the user except the current one.
+// Useful after password change.
+func (r *Registry) RevokeOthers(userID, currentSessionID string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	sessions := r.sessions(userID)
+	for sessionID := range sessions {
+		if sessionID != currentSessionID {
+			r.revoke(userID, sessionID)
+			delete(sessions, sessionID)
+		}
+	}
+
+	r.saveSessions(userID, sessions)
+}
+
+// RevokeAll revokes every session of the user.
+func (r *Registry) RevokeAll(userID string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for sessionID := range r.sessions(userID) {
+		r.revoke(userID, sessionID)
+	}
+
+	r.saveSessions(userID, nil)
+}
+
+// Forget device and remember the session was revoked until its token expires.
+func (r *Registry) revoke(userID, sessionID string) {
+	var device Device
+	r.cache.Get(r.deviceKey(userID, sessionID), &device)
+
+	lifetime := r.lifetime
+	if !device.ExpiresAt.IsZero() {
+		lifetime = time.Until(device.ExpiresAt)
+	}
+
+	r.cache.Put(r.revokedKey(sessionID), true, lifetime)
+	r.cache.Forget(r.deviceKey(userID, sessionID))
+}
+
+// Device is forgotten after lifetime of inactivity or when its token expires.
+func (r *Registry) deviceLifetime(device Device) time.Duration {
+	if !device.ExpiresAt.IsZero() {
+		if untilExpired := time.Until(device.ExpiresAt); untilExpired < r.lifetime {
+			return untilExpired
+		}
+	}
+
+	return r.lifetime
+}
+
+// Add session to the list of the user.
+// Concurrent servers may lose the session from the list, it is added back on the next write of the device.
+func (r *Registry) addSession(userID, sessionID string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	sessions := r.sessions(userID)
+	if sessions[sessionID] {
+		return
+	}
+
+	sessions[sessionID] = true
+	r.saveSessions(userID, sessions)
+}
+
+// Load session IDs of the user.
+func (r *Registry) sessions(userID string) map[string]bool {
+	var sessions map[string]bool
+	if err := r.cache.Get(r.key(userID), &sessions); err != nil || sessions == nil {
+		return make(map[string]bool)
+	}
+
+	return sessions
+}
+
+// Save session IDs of the user.
+func (r *Registry) saveSessions(userID string, sessions map[string]bool) {
+	if len(sessions) == 0 {
+		r.cache.Forget(r.key(userID))
+
+		return
+	}
+
+	r.cache.Put(r.key(userID), sessions, r.lifetime)
+}
+
+// Make cache key for the user sessions list.
+func (r *Registry) key(userID string) string {
+	return fmt.Sprintf("sessions:%s", userID)
+}
+
+// Make cache key for the device of the session.
+func (r *Registry) deviceKey(userID, sessionID string) string {
+	return fmt.Sprintf("sessions:device:%s:%s", userID, sessionID)
+}
+
+// Make cache key for the revoked session.
+func (r *Registry) revokedKey(sessionID string) string {
+	return fmt.Sprintf("sessions:revoked:%s", sessionID)
+}
@@ -0,0 +1,101 @@
+package session_test
+
+import (
+	net_http "net/http"
+	"testing"
+	"time"
+
+	"github.com/lara-go/larago/cache"
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/session"
+	"github.com/stretchr/testify/assert"
+)
+
+func registryFactory() *session.Registry {
+	return session.NewRegistry(cache.NewRepository(cache.NewInMemoryStore()), time.Hour)
+}
+
+func requestFactory(ip string) *http.Request {
+	netRequest, _ := net_http.NewRequest("GET", "/", nil)
+	netRequest.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) Chrome/120.0.0.0 Safari/537.36")
+	netRequest.Header.Set("X-Real-IP", ip)
+
+	return http.NewRequest(netRequest)
+}
+
+func TestRegistry_List(t *testing.T) {
+	registry := registryFactory()
+
+	assert.NoError(t, registry.Touch("1", "a", requestFactory("10.0.0.1")))
+	assert.NoError(t, registry.Touch("1", "b", requestFactory("10.0.0.2")))
+	assert.NoError(t, registry.Touch("2", "c", requestFactory("10.0.0.3")))
+
+	devices := registry.List("1")
+	assert.Len(t, devices, 2)
+	assert.Equal(t, "b", devices[0].SessionID)
+	assert.Equal(t, "10.0.0.2", devices[0].IP)
+	assert.Equal(t, "Chrome", devices[0].Browser)
+	assert.Equal(t, "Windows", devices[0].OS)
+}
+
+func TestRegistry_RevokeOthers(t *testing.T) {
+	registry := registryFactory()
+
+	registry.Touch("1", "a", requestFactory("10.0.0.1"))
+	registry.Touch("1", "b", requestFactory("10.0.0.2"))
+	registry.Touch("1", "c", requestFactory("10.0.0.3"))
+
+	registry.RevokeOthers("1", "b")
+
+	devices := registry.List("1")
+	assert.Len(t, devices, 1)
+	assert.Equal(t, "b", devices[0].SessionID)
+
+	assert.True(t, registry.Revoked("a"))
+	assert.False(t, registry.Revoked("b"))
+	assert.Equal(t, session.ErrorRevoked, registry.Touch("1", "a", requestFactory("10.0.0.1")))
+}
+
+func TestRegistry_RevokeAll(t *testing.T) {
+	registry := registryFactory()
+
+	registry.Touch("1", "a", requestFactory("10.0.0.1"))
+	registry.RevokeAll("1")
+
+	assert.Empty(t, registry.List("1"))
+	assert.True(t, registry.Revoked("a"))
+}
+
+func TestRegistry_SharedStore(t *testing.T) {
+	// Servers behind a load balancer share the cache store, but not the registry.
+	store := cache.NewRepository(cache.NewInMemoryStore())
+	first := session.NewRegistry(store, time.Hour)
+	second := session.NewRegistry(store, time.Hour)
+
+	assert.NoError(t, first.Touch("1", "a", requestFactory("10.0.0.1")))
+	assert.NoError(t, second.Touch("1", "b", requestFactory("10.0.0.2")))
+	assert.NoError(t, first.Touch("1", "a", requestFactory("10.0.0.3")))
+
+	devices := second.List("1")
+	assert.Len(t, devices, 2)
+	assert.Equal(t, "a", devices[0].SessionID)
+	assert.Equal(t, "10.0.0.3", devices[0].IP)
+
+	second.Revoke("1", "a")
+	assert.Equal(t, session.ErrorRevoked, first.Touch("1", "a", requestFactory("10.0.0.1")))
+}
+
+func TestRegistry_TokenExpiration(t *testing.T) {
+	registry := registryFactory()
+
+	request := requestFactory("10.0.0.1")
+	request.SetAttribute(session.SessionExpiresAttribute, time.Now().Add(time.Second))
+
+	assert.NoError(t, registry.Touch("1", "a", request))
+	registry.Revoke("1", "a")
+	assert.True(t, registry.Revoked("a"))
+
+	// Revoked token can not be used after it expires, so it is not remembered longer.
+	time.Sleep(2 * time.Second)
+	assert.False(t, registry.Revoked("a"))
+}
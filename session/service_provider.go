@@ -0,0 +1,29 @@
+package session
+
+import (
+	"time"
+
+	"github.com/lara-go/larago"
+	"github.com/lara-go/larago/cache"
+)
+
+// Default time of inactivity after which device is forgotten.
+const defaultLifetime = 30 * 24 * time.Hour
+
+// ServiceProvider struct.
+type ServiceProvider struct{}
+
+// Register service.
+func (p *ServiceProvider) Register(application *larago.Application) {
+	application.Bind(func() (*Registry, error) {
+		lifetime := defaultLifetime
+		if application.Config().Has("Session.Lifetime") {
+			var err error
+			if lifetime, err = application.Config().Duration("Session.Lifetime"); err != nil {
+				return nil, err
+			}
+		}
+
+		return NewRegistry(application.Get("cache").(cache.Cache), lifetime), nil
+	}, "sessions")
+}
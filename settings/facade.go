@@ -0,0 +1,11 @@
+package settings
+
+import "github.com/lara-go/larago"
+
+// FacadeWrapper for facade.
+var FacadeWrapper = &larago.Facade{}
+
+// Facade for settings.
+func Facade() *Store {
+	return FacadeWrapper.Resolve("settings").(*Store)
+}
@@ -0,0 +1,28 @@
+package settings
+
+import (
+	"github.com/asaskevich/EventBus"
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago"
+	"github.com/lara-go/larago/cache"
+)
+
+// ServiceProvider struct.
+type ServiceProvider struct{}
+
+// Register service.
+func (p *ServiceProvider) Register(application *larago.Application) {
+	application.Bind(func() (*Store, error) {
+		var repository cache.Cache
+		if application.Bound("cache") {
+			repository = application.Get("cache").(cache.Cache)
+		}
+
+		var events *EventBus.EventBus
+		if application.Bound("events") {
+			events = application.Get("events").(*EventBus.EventBus)
+		}
+
+		return NewStore(application.Get("db.connection").(*gorm.DB), repository, events), nil
+	}, "settings")
+}
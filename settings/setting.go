@@ -0,0 +1,39 @@
+package settings
+
+import (
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Setting stored in the database.
+type Setting struct {
+	ID        uint   `gorm:"primary_key"`
+	Tenant    string `gorm:"unique_index:settings_tenant_key"`
+	Key       string `gorm:"unique_index:settings_tenant_key"`
+	Value     string `gorm:"type:text"`
+	UpdatedAt time.Time
+}
+
+// TableName of the settings.
+func (Setting) TableName() string {
+	return "settings"
+}
+
+// Migration creates settings table.
+type Migration struct{}
+
+// MigrationID returns unique migration ID.
+func (m *Migration) MigrationID() string {
+	return "settings_create_settings_table"
+}
+
+// Migrate runs migrations.
+func (m *Migration) Migrate(tx *gorm.DB) error {
+	return tx.AutoMigrate(&Setting{}).Error
+}
+
+// Rollback changes.
+func (m *Migration) Rollback(tx *gorm.DB) error {
+	return tx.DropTableIfExists(&Setting{}).Error
+}
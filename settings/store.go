@@ -0,0 +1,192 @@
+package settings
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/asaskevich/EventBus"
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago/cache"
+)
+
+// ChangedEvent is published when setting was changed or forgotten.
+const ChangedEvent = "settings.changed"
+
+// Store of runtime-editable settings.
+// All settings of the tenant are cached together and reloaded after every change.
+type Store struct {
+	db     *gorm.DB
+	cache  cache.Cache
+	events *EventBus.EventBus
+	tenant string
+}
+
+// NewStore constructor. Cache and events are optional.
+func NewStore(db *gorm.DB, cache cache.Cache, events *EventBus.EventBus) *Store {
+	return &Store{
+		db:     db,
+		cache:  cache,
+		events: events,
+	}
+}
+
+// ForTenant returns store scoped by the tenant.
+func (s *Store) ForTenant(tenant string) *Store {
+	scoped := *s
+	scoped.tenant = tenant
+
+	return &scoped
+}
+
+// Tenant of the store. Empty for global settings.
+func (s *Store) Tenant() string {
+	return s.tenant
+}
+
+// Has checks if setting was saved.
+func (s *Store) Has(key string) bool {
+	values, err := s.All()
+	if err != nil {
+		return false
+	}
+
+	_, ok := values[key]
+
+	return ok
+}
+
+// Get decodes setting into target. Target is left untouched if there is no such setting.
+func (s *Store) Get(key string, target interface{}) error {
+	values, err := s.All()
+	if err != nil {
+		return err
+	}
+
+	value, ok := values[key]
+	if !ok {
+		return nil
+	}
+
+	return json.Unmarshal([]byte(value), target)
+}
+
+// String setting or default value.
+func (s *Store) String(key, value string) string {
+	s.Get(key, &value)
+
+	return value
+}
+
+// Int setting or default value.
+func (s *Store) Int(key string, value int) int {
+	s.Get(key, &value)
+
+	return value
+}
+
+// Float setting or default value.
+func (s *Store) Float(key string, value float64) float64 {
+	s.Get(key, &value)
+
+	return value
+}
+
+// Bool setting or default value.
+func (s *Store) Bool(key string, value bool) bool {
+	s.Get(key, &value)
+
+	return value
+}
+
+// Duration setting or default value.
+func (s *Store) Duration(key string, value time.Duration) time.Duration {
+	s.Get(key, &value)
+
+	return value
+}
+
+// Set setting value.
+func (s *Store) Set(key string, value interface{}) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	var setting Setting
+	err = s.db.Where(s.conditions(key)).
+		Assign(Setting{Value: string(encoded)}).
+		FirstOrCreate(&setting).Error
+
+	if err != nil {
+		return err
+	}
+
+	s.changed(key, value)
+
+	return nil
+}
+
+// Forget setting.
+func (s *Store) Forget(key string) error {
+	if err := s.db.Where(s.conditions(key)).Delete(&Setting{}).Error; err != nil {
+		return err
+	}
+
+	s.changed(key, nil)
+
+	return nil
+}
+
+// All raw JSON values of the tenant.
+func (s *Store) All() (map[string]string, error) {
+	if s.cache == nil {
+		return s.load()
+	}
+
+	var values map[string]string
+	err := s.cache.RememberForever(s.cacheKey(), func() (interface{}, error) {
+		return s.load()
+	}, &values)
+
+	return values, err
+}
+
+// Load settings of the tenant from the database.
+func (s *Store) load() (map[string]string, error) {
+	var settings []Setting
+	if err := s.db.Where("tenant = ?", s.tenant).Find(&settings).Error; err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string, len(settings))
+	for _, setting := range settings {
+		values[setting.Key] = setting.Value
+	}
+
+	return values, nil
+}
+
+// Forget cached settings and notify listeners.
+func (s *Store) changed(key string, value interface{}) {
+	if s.cache != nil {
+		s.cache.Forget(s.cacheKey())
+	}
+
+	if s.events != nil {
+		s.events.Publish(ChangedEvent, s.tenant, key, value)
+	}
+}
+
+// Conditions to find the setting of the tenant.
+// Map is used since struct conditions skip empty global tenant.
+func (s *Store) conditions(key string) map[string]interface{} {
+	return map[string]interface{}{
+		"tenant": s.tenant,
+		"key":    key,
+	}
+}
+
+func (s *Store) cacheKey() string {
+	return fmt.Sprintf("settings:%s", s.tenant)
+}
@@ -0,0 +1,69 @@
+package settings_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asaskevich/EventBus"
+	"github.com/lara-go/larago/cache"
+	"github.com/lara-go/larago/settings"
+	"github.com/lara-go/larago/support/testsuite"
+	"github.com/stretchr/testify/assert"
+
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+)
+
+func storeFactory(t *testing.T) (*settings.Store, *EventBus.EventBus) {
+	db := testsuite.MemoryDB(t)
+	(&settings.Migration{}).Migrate(db)
+
+	events := EventBus.New().(*EventBus.EventBus)
+
+	return settings.NewStore(db, cache.NewRepository(cache.NewInMemoryStore()), events), events
+}
+
+func TestStore_TypedGetters(t *testing.T) {
+	store, _ := storeFactory(t)
+
+	assert.Equal(t, "Larago", store.String("site.name", "Larago"))
+
+	store.Set("site.name", "My site")
+	store.Set("uploads.limit", 10)
+	store.Set("features.beta", true)
+	store.Set("sessions.lifetime", time.Hour)
+
+	assert.Equal(t, "My site", store.String("site.name", "Larago"))
+	assert.Equal(t, 10, store.Int("uploads.limit", 5))
+	assert.True(t, store.Bool("features.beta", false))
+	assert.Equal(t, time.Hour, store.Duration("sessions.lifetime", time.Minute))
+
+	store.Set("uploads.limit", 20)
+	assert.Equal(t, 20, store.Int("uploads.limit", 5))
+
+	store.Forget("uploads.limit")
+	assert.False(t, store.Has("uploads.limit"))
+}
+
+func TestStore_Tenants(t *testing.T) {
+	store, _ := storeFactory(t)
+
+	store.Set("site.name", "Global")
+	store.ForTenant("acme").Set("site.name", "Acme")
+
+	assert.Equal(t, "Global", store.String("site.name", ""))
+	assert.Equal(t, "Acme", store.ForTenant("acme").String("site.name", ""))
+	assert.Equal(t, "", store.ForTenant("other").String("site.name", ""))
+}
+
+func TestStore_ChangedEvent(t *testing.T) {
+	store, events := storeFactory(t)
+
+	var changed []string
+	events.Subscribe(settings.ChangedEvent, func(tenant, key string, value interface{}) {
+		changed = append(changed, tenant+":"+key)
+	})
+
+	store.ForTenant("acme").Set("site.name", "Acme")
+
+	assert.Equal(t, []string{"acme:site.name"}, changed)
+}
@@ -0,0 +1,63 @@
+package spreadsheet
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/gorilla/schema"
+	"github.com/lara-go/larago/validation"
+)
+
+// RowError describes why row was not imported.
+type RowError struct {
+	Line int
+	Err  error
+}
+
+// Error message.
+func (e *RowError) Error() string {
+	return fmt.Sprintf("Row %d: %s", e.Line, e.Err)
+}
+
+// ImportResult summary.
+type ImportResult struct {
+	Imported int
+	Failed   []*RowError
+}
+
+// Import decodes every row into item made by factory, validates it if it is validation.SelfValidator and saves it.
+// Columns are matched to the item fields by `schema` tags, like form requests.
+// Invalid rows are collected in the result, reading errors stop import.
+func Import(reader *Reader, factory func() interface{}, save func(item interface{}) error) (*ImportResult, error) {
+	decoder := schema.NewDecoder()
+	decoder.IgnoreUnknownKeys(true)
+
+	result := &ImportResult{}
+
+	for reader.Next() {
+		values := make(url.Values)
+		for name, value := range reader.Row() {
+			values.Set(name, value)
+		}
+
+		item := factory()
+		err := decoder.Decode(item, values)
+
+		if validator, ok := item.(validation.SelfValidator); ok && err == nil {
+			err = validator.Validate()
+		}
+
+		if err == nil {
+			err = save(item)
+		}
+
+		if err != nil {
+			result.Failed = append(result.Failed, &RowError{Line: reader.Line(), Err: err})
+			continue
+		}
+
+		result.Imported++
+	}
+
+	return result, reader.Err()
+}
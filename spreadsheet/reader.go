@@ -0,0 +1,138 @@
+package spreadsheet
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Reader iterates over sheet rows. First row is used as header.
+type Reader struct {
+	file   *excelize.File
+	rows   *excelize.Rows
+	header []string
+	row    map[string]string
+	line   int
+	err    error
+}
+
+// NewReader opens sheet of the workbook. The first sheet is used if sheet is empty.
+func NewReader(input io.Reader, sheet string) (*Reader, error) {
+	file, err := excelize.OpenReader(input)
+	if err != nil {
+		return nil, err
+	}
+
+	if sheet == "" {
+		sheets := file.GetSheetList()
+		if len(sheets) == 0 {
+			file.Close()
+			return nil, errors.New("Workbook has no sheets")
+		}
+
+		sheet = sheets[0]
+	}
+
+	rows, err := file.Rows(sheet)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	reader := &Reader{
+		file: file,
+		rows: rows,
+	}
+
+	if !rows.Next() {
+		reader.Close()
+		return nil, errors.New("Sheet has no header row")
+	}
+
+	header, err := rows.Columns()
+	if err != nil {
+		reader.Close()
+		return nil, err
+	}
+
+	for i, name := range header {
+		header[i] = strings.TrimSpace(name)
+	}
+
+	reader.header = header
+	reader.line = 1
+
+	return reader, nil
+}
+
+// Header returns column names.
+func (r *Reader) Header() []string {
+	return r.header
+}
+
+// Next advances to the next non-empty row.
+func (r *Reader) Next() bool {
+	for r.err == nil && r.rows.Next() {
+		r.line++
+
+		columns, err := r.rows.Columns()
+		if err != nil {
+			r.err = err
+			return false
+		}
+
+		if isEmpty(columns) {
+			continue
+		}
+
+		r.row = make(map[string]string, len(r.header))
+		for i, name := range r.header {
+			if i < len(columns) && name != "" {
+				r.row[name] = strings.TrimSpace(columns[i])
+			}
+		}
+
+		return true
+	}
+
+	if r.err == nil {
+		r.err = r.rows.Error()
+	}
+
+	return false
+}
+
+// Row returns current row values by column names.
+func (r *Reader) Row() map[string]string {
+	return r.row
+}
+
+// Line returns current row number as shown in spreadsheet apps.
+func (r *Reader) Line() int {
+	return r.line
+}
+
+// Err returns error happened while reading.
+func (r *Reader) Err() error {
+	return r.err
+}
+
+// Close reader.
+func (r *Reader) Close() error {
+	r.rows.Close()
+
+	return r.file.Close()
+}
+
+// Check if all row cells are empty.
+func isEmpty(columns []string) bool {
+	for _, column := range columns {
+		if strings.TrimSpace(column) != "" {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,66 @@
+package spreadsheet_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/lara-go/larago/spreadsheet"
+	"github.com/lara-go/larago/support/testsuite"
+	"github.com/stretchr/testify/assert"
+
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+)
+
+type product struct {
+	ID    uint
+	Name  string  `schema:"name"`
+	Price float64 `schema:"price"`
+}
+
+func (p *product) Validate() error {
+	if p.Name == "" {
+		return errors.New("name is required")
+	}
+
+	return nil
+}
+
+func TestExportAndImport(t *testing.T) {
+	db := testsuite.MemoryDB(t, &product{})
+
+	db.Create(&product{Name: "Apple", Price: 1.5})
+	db.Create(&product{Name: "", Price: 2})
+	db.Create(&product{Name: "Pear", Price: 3})
+
+	writer, err := spreadsheet.NewWriter("Products")
+	assert.Nil(t, err)
+	defer writer.Close()
+
+	assert.Nil(t, spreadsheet.ExportQuery(writer, db.Table("products").Select("name, price").Order("id")))
+
+	var output bytes.Buffer
+	_, err = writer.WriteTo(&output)
+	assert.Nil(t, err)
+
+	reader, err := spreadsheet.NewReader(&output, "")
+	assert.Nil(t, err)
+	defer reader.Close()
+
+	assert.Equal(t, []string{"name", "price"}, reader.Header())
+
+	var imported []*product
+	result, err := spreadsheet.Import(reader, func() interface{} {
+		return &product{}
+	}, func(item interface{}) error {
+		imported = append(imported, item.(*product))
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, result.Imported)
+	assert.Len(t, result.Failed, 1)
+	assert.Equal(t, "Row 3: name is required", result.Failed[0].Error())
+	assert.Equal(t, "Pear", imported[1].Name)
+	assert.Equal(t, 3.0, imported[1].Price)
+}
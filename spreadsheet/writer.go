@@ -0,0 +1,113 @@
+package spreadsheet
+
+import (
+	"io"
+
+	"github.com/jinzhu/gorm"
+	"github.com/xuri/excelize/v2"
+)
+
+// Writer streams rows into XLSX workbook without keeping them in memory.
+type Writer struct {
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+// NewWriter creates workbook with the sheet.
+func NewWriter(sheet string) (*Writer, error) {
+	file := excelize.NewFile()
+
+	if sheet != "" && sheet != "Sheet1" {
+		file.SetSheetName("Sheet1", sheet)
+	} else {
+		sheet = "Sheet1"
+	}
+
+	stream, err := file.NewStreamWriter(sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Writer{
+		file:   file,
+		stream: stream,
+	}, nil
+}
+
+// WriteRow appends row to the sheet.
+func (w *Writer) WriteRow(values ...interface{}) error {
+	w.row++
+
+	cell, err := excelize.CoordinatesToCellName(1, w.row)
+	if err != nil {
+		return err
+	}
+
+	return w.stream.SetRow(cell, values)
+}
+
+// WriteTo flushes rows and writes workbook to the output.
+func (w *Writer) WriteTo(output io.Writer) (int64, error) {
+	if err := w.stream.Flush(); err != nil {
+		return 0, err
+	}
+
+	return w.file.WriteTo(output)
+}
+
+// Close releases temporary files.
+func (w *Writer) Close() error {
+	return w.file.Close()
+}
+
+// ExportQuery streams query results into the writer, with column names as header.
+func ExportQuery(w *Writer, query *gorm.DB) error {
+	rows, err := query.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	header := make([]interface{}, len(columns))
+	for i, column := range columns {
+		header[i] = column
+	}
+
+	if err := w.WriteRow(header...); err != nil {
+		return err
+	}
+
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return err
+		}
+
+		row := make([]interface{}, len(values))
+		for i, value := range values {
+			// Text columns are scanned as bytes by some drivers.
+			if bytes, ok := value.([]byte); ok {
+				value = string(bytes)
+			}
+
+			row[i] = value
+		}
+
+		if err := w.WriteRow(row...); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
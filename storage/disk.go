@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrorNotFound is returned when file does not exist on the disk.
+var ErrorNotFound = errors.New("File not found")
+
+// Disk stores files, ex. local directory served by CDN or object storage.
+type Disk interface {
+	// Put file content.
+	Put(path string, content []byte) error
+
+	// Get file content.
+	Get(path string) ([]byte, error)
+
+	// Exists checks if file exists.
+	Exists(path string) bool
+
+	// Modified returns time file was last changed.
+	Modified(path string) (time.Time, error)
+
+	// Delete file.
+	Delete(path string) error
+
+	// URL of the file for clients, empty if disk is not public.
+	URL(path string) string
+}
+
+// Lister is implemented by disks able to list their files.
+type Lister interface {
+	// Files returns paths of all the files in the directory and its subdirectories.
+	Files(directory string) ([]string, error)
+}
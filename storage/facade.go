@@ -0,0 +1,11 @@
+package storage
+
+import "github.com/lara-go/larago"
+
+// FacadeWrapper for facade.
+var FacadeWrapper = &larago.Facade{}
+
+// Facade for the storage disk.
+func Facade() Disk {
+	return FacadeWrapper.Resolve("storage").(Disk)
+}
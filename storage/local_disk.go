@@ -0,0 +1,141 @@
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LocalDisk stores files in the local directory.
+type LocalDisk struct {
+	root    string
+	baseURL string
+}
+
+// NewLocalDisk constructor. Base URL is where the directory is served from, if it is public.
+func NewLocalDisk(root, baseURL string) *LocalDisk {
+	return &LocalDisk{
+		root:    root,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// Put file content. File is replaced atomically, so readers never get it half written.
+func (d *LocalDisk) Put(name string, content []byte) error {
+	file := d.path(name)
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+
+	temp, err := ioutil.TempFile(filepath.Dir(file), ".put-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+
+	if _, err := temp.Write(content); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(temp.Name(), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(temp.Name(), file)
+}
+
+// Get file content.
+func (d *LocalDisk) Get(name string) ([]byte, error) {
+	content, err := ioutil.ReadFile(d.path(name))
+	if os.IsNotExist(err) {
+		return nil, ErrorNotFound
+	}
+
+	return content, err
+}
+
+// Exists checks if file exists.
+func (d *LocalDisk) Exists(name string) bool {
+	_, err := os.Stat(d.path(name))
+
+	return err == nil
+}
+
+// Modified returns time file was last changed.
+func (d *LocalDisk) Modified(name string) (time.Time, error) {
+	info, err := os.Stat(d.path(name))
+	if os.IsNotExist(err) {
+		return time.Time{}, ErrorNotFound
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return info.ModTime(), nil
+}
+
+// Delete file. Missing file is not an error.
+func (d *LocalDisk) Delete(name string) error {
+	err := os.Remove(d.path(name))
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	return err
+}
+
+// Files returns paths of all the files in the directory and its subdirectories.
+func (d *LocalDisk) Files(directory string) ([]string, error) {
+	var files []string
+
+	root := d.path(directory)
+	err := filepath.Walk(root, func(file string, info os.FileInfo, err error) error {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// Files being written by Put are skipped.
+		if info.IsDir() || strings.HasPrefix(info.Name(), ".put-") {
+			return nil
+		}
+
+		relative, err := filepath.Rel(root, file)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, path.Join(clean(directory), filepath.ToSlash(relative)))
+
+		return nil
+	})
+
+	return files, err
+}
+
+// URL of the file for clients, empty if disk is not public.
+func (d *LocalDisk) URL(name string) string {
+	if d.baseURL == "" {
+		return ""
+	}
+
+	return d.baseURL + clean(name)
+}
+
+// Full path of the file, never outside of the root.
+func (d *LocalDisk) path(name string) string {
+	return filepath.Join(d.root, filepath.FromSlash(clean(name)))
+}
+
+// Clean path of the file, rooted at slash.
+func clean(name string) string {
+	return path.Clean("/" + name)
+}
@@ -0,0 +1,357 @@
+package storage
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"mime"
+	net_http "net/http"
+	"strings"
+	"time"
+
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/http/responses"
+)
+
+// Header telling if response was served from the cache.
+const cacheStatusHeader = "X-Response-Cache"
+
+// ResponseCacheHook is called with the cache key and stored file path, ex. to purge CDN.
+type ResponseCacheHook func(key, path string)
+
+// Meta of the stored response.
+type storedResponse struct {
+	Path        string
+	ContentType string
+	Headers     map[string]string
+	StoredAt    time.Time
+	ExpiresAt   time.Time
+}
+
+// Fresh checks if stored response may be served.
+func (s *storedResponse) fresh() bool {
+	return s.ExpiresAt.IsZero() || time.Now().Before(s.ExpiresAt)
+}
+
+// ResponseCache middleware writes heavy generated responses, ex. reports or thumbnails,
+// to the disk and serves them from there on subsequent requests until TTL passes.
+// Only successful GET responses without cookies are stored.
+// Requests with credentials and private responses bypass the cache unless the key is set explicitly,
+// responses with "Cache-Control: no-store" are never stored.
+type ResponseCache struct {
+	disk     Disk
+	ttl      time.Duration
+	prefix   string
+	key      func(request *http.Request) string
+	keyed    bool
+	redirect bool
+
+	onStore      []ResponseCacheHook
+	onInvalidate []ResponseCacheHook
+}
+
+// NewResponseCache constructor. Zero TTL keeps responses until invalidated.
+func NewResponseCache(disk Disk, ttl time.Duration) *ResponseCache {
+	return &ResponseCache{
+		disk:   disk,
+		ttl:    ttl,
+		prefix: "responses",
+		key:    requestKey,
+	}
+}
+
+// Prefix sets directory of the stored responses.
+func (c *ResponseCache) Prefix(prefix string) *ResponseCache {
+	c.prefix = strings.Trim(prefix, "/")
+
+	return c
+}
+
+// By sets cache key of the request, ex. "reports/42", so it may be invalidated by it.
+// By URL path and query by default.
+func (c *ResponseCache) By(key func(request *http.Request) string) *ResponseCache {
+	c.key = key
+	c.keyed = true
+
+	return c
+}
+
+// Redirect clients to the stored file URL instead of serving it, if disk is public.
+// Files get new URLs when regenerated, so CDN caches never serve outdated ones.
+func (c *ResponseCache) Redirect() *ResponseCache {
+	c.redirect = true
+
+	return c
+}
+
+// OnStore subscribes hook to every stored response.
+func (c *ResponseCache) OnStore(hook ResponseCacheHook) *ResponseCache {
+	c.onStore = append(c.onStore, hook)
+
+	return c
+}
+
+// OnInvalidate subscribes hook to every removed response, ex. to purge it from CDN.
+func (c *ResponseCache) OnInvalidate(hook ResponseCacheHook) *ResponseCache {
+	c.onInvalidate = append(c.onInvalidate, hook)
+
+	return c
+}
+
+// Key of the request.
+func (c *ResponseCache) Key(request *http.Request) string {
+	return clean(c.key(request))
+}
+
+// Invalidate stored response by key.
+func (c *ResponseCache) Invalidate(key string) error {
+	key = clean(key)
+
+	stored, err := c.stored(key)
+	if err == ErrorNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := c.disk.Delete(stored.Path); err != nil {
+		return err
+	}
+	if err := c.disk.Delete(c.metaPath(key)); err != nil {
+		return err
+	}
+
+	c.fire(c.onInvalidate, key, stored.Path)
+
+	return nil
+}
+
+// Prune removes responses expired before the time, so the cache can be pruned by housekeeping.
+// Responses stored with zero TTL are kept until invalidated.
+func (c *ResponseCache) Prune(before time.Time) (int64, error) {
+	lister, ok := c.disk.(Lister)
+	if !ok {
+		return 0, fmt.Errorf("Disk %T can not list stored responses", c.disk)
+	}
+
+	files, err := lister.Files(c.prefix)
+	if err != nil {
+		return 0, err
+	}
+
+	var pruned int64
+	for _, file := range files {
+		if !strings.HasSuffix(file, ".json") {
+			continue
+		}
+
+		// Stored JSON responses are not meta, they have no path.
+		key := strings.TrimSuffix(strings.TrimPrefix(file, clean(c.prefix)), ".json")
+		stored, err := c.stored(key)
+		if err != nil || stored.Path == "" || stored.ExpiresAt.IsZero() || !stored.ExpiresAt.Before(before) {
+			continue
+		}
+
+		if err := c.Invalidate(key); err != nil {
+			return pruned, err
+		}
+		pruned++
+	}
+
+	return pruned, nil
+}
+
+// Handle request.
+func (c *ResponseCache) Handle(request *http.Request, next http.Handler) responses.Response {
+	if method := request.Method(); method != net_http.MethodGet && method != net_http.MethodHead {
+		return next(request)
+	}
+
+	// Responses to the users may differ, while the default key is the same for all of them.
+	if !c.keyed && (request.Header("Authorization") != "" || request.Header("Cookie") != "") {
+		return next(request)
+	}
+
+	key := c.Key(request)
+
+	if stored, err := c.stored(key); err == nil && stored.fresh() {
+		if response := c.serve(stored); response != nil {
+			return response
+		}
+	}
+
+	response := next(request)
+	if request.Method() != net_http.MethodGet || !c.cacheable(response) {
+		return response
+	}
+
+	if err := c.store(key, response); err != nil {
+		return response
+	}
+
+	return response.WithHeader(cacheStatusHeader, "MISS")
+}
+
+// Make response of the stored file.
+func (c *ResponseCache) serve(stored *storedResponse) responses.Response {
+	if c.redirect {
+		if url := c.disk.URL(stored.Path); url != "" {
+			return responses.NewRedirect(net_http.StatusFound).
+				To(url).
+				WithHeader(cacheStatusHeader, "HIT")
+		}
+	}
+
+	content, err := c.disk.Get(stored.Path)
+	if err != nil {
+		return nil
+	}
+
+	response := responses.NewFile(net_http.StatusOK, content, stored.ContentType)
+	for name, value := range stored.Headers {
+		response.WithHeader(name, value)
+	}
+
+	return response.WithHeader(cacheStatusHeader, "HIT")
+}
+
+// Check if response may be stored.
+func (c *ResponseCache) cacheable(response responses.Response) bool {
+	switch response.(type) {
+	case *responses.Stream, *responses.Redirect:
+		return false
+	}
+
+	if response.Status() != net_http.StatusOK || len(response.Cookies()) > 0 {
+		return false
+	}
+
+	for name, value := range response.Headers() {
+		if !strings.EqualFold(name, "Cache-Control") {
+			continue
+		}
+
+		for _, directive := range strings.Split(value, ",") {
+			switch strings.ToLower(strings.TrimSpace(directive)) {
+			case "no-store":
+				return false
+			case "private":
+				if !c.keyed {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// Write response content and meta to the disk, removing previous content.
+func (c *ResponseCache) store(key string, response responses.Response) error {
+	content := response.Body()
+	hash := sha1.Sum(content)
+
+	stored := &storedResponse{
+		Path:        c.prefix + key + "/" + hex.EncodeToString(hash[:6]) + extension(response.ContentType()),
+		ContentType: response.ContentType(),
+		Headers:     response.Headers(),
+		StoredAt:    time.Now(),
+	}
+	if c.ttl > 0 {
+		stored.ExpiresAt = stored.StoredAt.Add(c.ttl)
+	}
+
+	previous, _ := c.stored(key)
+
+	if err := c.disk.Put(stored.Path, content); err != nil {
+		return err
+	}
+
+	meta, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+	if err := c.disk.Put(c.metaPath(key), meta); err != nil {
+		return err
+	}
+
+	if previous != nil && previous.Path != stored.Path {
+		c.disk.Delete(previous.Path)
+	}
+
+	c.fire(c.onStore, key, stored.Path)
+
+	return nil
+}
+
+// Read meta of the stored response.
+func (c *ResponseCache) stored(key string) (*storedResponse, error) {
+	meta, err := c.disk.Get(c.metaPath(key))
+	if err != nil {
+		return nil, err
+	}
+
+	var stored storedResponse
+	if err := json.Unmarshal(meta, &stored); err != nil {
+		return nil, err
+	}
+
+	return &stored, nil
+}
+
+// Path of the stored response meta.
+func (c *ResponseCache) metaPath(key string) string {
+	return c.prefix + key + ".json"
+}
+
+// Call hooks.
+func (c *ResponseCache) fire(hooks []ResponseCacheHook, key, path string) {
+	for _, hook := range hooks {
+		hook(key, path)
+	}
+}
+
+// Default key of the request: hash of the path and sorted query.
+func requestKey(request *http.Request) string {
+	url := request.BaseRequest().URL
+
+	// Encode sorts query by keys.
+	hash := sha1.Sum([]byte(url.Path + "?" + url.Query().Encode()))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// Preferred extensions of the common types, as mime package lists several of them.
+var extensions = map[string]string{
+	"application/json": ".json",
+	"application/pdf":  ".pdf",
+	"application/zip":  ".zip",
+	"image/gif":        ".gif",
+	"image/jpeg":       ".jpg",
+	"image/png":        ".png",
+	"image/svg+xml":    ".svg",
+	"image/webp":       ".webp",
+	"text/csv":         ".csv",
+	"text/html":        ".html",
+	"text/plain":       ".txt",
+}
+
+// File extension of the content type, so CDNs serve files with proper type.
+func extension(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+
+	if ext, ok := extensions[mediaType]; ok {
+		return ext
+	}
+
+	if known, _ := mime.ExtensionsByType(mediaType); len(known) > 0 {
+		return known[0]
+	}
+
+	return ""
+}
@@ -0,0 +1,141 @@
+package storage_test
+
+import (
+	net_http "net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/http/responses"
+	"github.com/lara-go/larago/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func reportRequest(url string) *http.Request {
+	netRequest, _ := net_http.NewRequest("GET", url, nil)
+
+	return http.NewRequest(netRequest)
+}
+
+func TestResponseCache(t *testing.T) {
+	disk := storage.NewLocalDisk(t.TempDir(), "https://cdn.example.com/")
+
+	var generated int
+	report := func(request *http.Request) responses.Response {
+		generated++
+
+		return responses.NewFile(200, []byte("id,total\n1,100\n"), "text/csv").Download("report.csv")
+	}
+
+	var purged []string
+	cache := storage.NewResponseCache(disk, time.Hour).
+		By(func(request *http.Request) string {
+			return "reports/" + request.Query().Get("id")
+		}).
+		OnInvalidate(func(key, path string) {
+			purged = append(purged, disk.URL(path))
+		})
+
+	response := cache.Handle(reportRequest("/reports?id=42"), report)
+	assert.Equal(t, "MISS", response.Headers()["X-Response-Cache"])
+
+	response = cache.Handle(reportRequest("/reports?id=42"), report)
+	assert.Equal(t, 1, generated)
+	assert.Equal(t, "HIT", response.Headers()["X-Response-Cache"])
+	assert.Equal(t, "text/csv", response.ContentType())
+	assert.Equal(t, `attachment; filename="report.csv"`, response.Headers()["Content-Disposition"])
+	assert.Equal(t, "id,total\n1,100\n", string(response.Body()))
+
+	// Public disks may serve files themselves.
+	redirect := cache.Redirect().Handle(reportRequest("/reports?id=42"), report).(*responses.Redirect)
+	location := redirect.GetLocation()
+	assert.True(t, strings.HasPrefix(location, "https://cdn.example.com/responses/reports/42/"), location)
+	assert.True(t, strings.HasSuffix(location, ".csv"), location)
+
+	assert.NoError(t, cache.Invalidate("reports/42"))
+	assert.Equal(t, []string{location}, purged)
+
+	cache.Handle(reportRequest("/reports?id=42"), report)
+	assert.Equal(t, 2, generated)
+}
+
+func TestResponseCacheExpires(t *testing.T) {
+	cache := storage.NewResponseCache(storage.NewLocalDisk(t.TempDir(), ""), time.Nanosecond)
+
+	var generated int
+	thumbnail := func(request *http.Request) responses.Response {
+		generated++
+
+		return responses.NewFile(200, []byte{0x89, 'P', 'N', 'G'}, "image/png")
+	}
+
+	cache.Handle(reportRequest("/thumbnails/1"), thumbnail)
+	time.Sleep(time.Millisecond)
+	cache.Handle(reportRequest("/thumbnails/1"), thumbnail)
+	assert.Equal(t, 2, generated)
+
+	// Failed responses are not stored.
+	failed := func(request *http.Request) responses.Response {
+		return responses.NewText(500, "Error")
+	}
+	assert.Empty(t, storage.NewResponseCache(storage.NewLocalDisk(t.TempDir(), ""), 0).
+		Handle(reportRequest("/broken"), failed).Headers()["X-Response-Cache"])
+}
+
+func TestResponseCacheSkipsPersonalResponses(t *testing.T) {
+	cache := storage.NewResponseCache(storage.NewLocalDisk(t.TempDir(), ""), time.Hour)
+
+	profile := func(request *http.Request) responses.Response {
+		return responses.NewText(200, "%s", request.Header("Authorization"))
+	}
+
+	authorized := reportRequest("/profile")
+	authorized.BaseRequest().Header.Set("Authorization", "Bearer alice")
+	assert.Empty(t, cache.Handle(authorized, profile).Headers()["X-Response-Cache"])
+	assert.NotEqual(t, "HIT", cache.Handle(reportRequest("/profile"), profile).Headers()["X-Response-Cache"])
+
+	private := func(request *http.Request) responses.Response {
+		return responses.NewText(200, "private").WithHeader("Cache-Control", "private, max-age=60")
+	}
+	cache.Handle(reportRequest("/private"), private)
+	assert.Empty(t, cache.Handle(reportRequest("/private"), private).Headers()["X-Response-Cache"])
+
+	// Explicit key tells the response is shared.
+	keyed := storage.NewResponseCache(storage.NewLocalDisk(t.TempDir(), ""), time.Hour).
+		By(func(request *http.Request) string {
+			return "private"
+		})
+	keyed.Handle(reportRequest("/private"), private)
+	assert.Equal(t, "HIT", keyed.Handle(reportRequest("/private"), private).Headers()["X-Response-Cache"])
+}
+
+func TestResponseCachePrune(t *testing.T) {
+	disk := storage.NewLocalDisk(t.TempDir(), "")
+	byPath := func(request *http.Request) string {
+		return request.BaseRequest().URL.Path
+	}
+	report := func(request *http.Request) responses.Response {
+		return responses.NewJSON(200, map[string]int{"total": 100})
+	}
+
+	expiring := storage.NewResponseCache(disk, time.Hour).Prefix("reports").By(byPath)
+	kept := storage.NewResponseCache(disk, 0).Prefix("reports").By(byPath)
+
+	expiring.Handle(reportRequest("/daily"), report)
+	kept.Handle(reportRequest("/yearly"), report)
+
+	pruned, err := expiring.Prune(time.Now())
+	assert.NoError(t, err)
+	assert.Zero(t, pruned)
+
+	// Responses without TTL are kept until invalidated.
+	pruned, err = expiring.Prune(time.Now().Add(2 * time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), pruned)
+	assert.False(t, disk.Exists("reports/daily.json"))
+	assert.True(t, disk.Exists("reports/yearly.json"))
+
+	files, _ := disk.Files("reports")
+	assert.Len(t, files, 2)
+}
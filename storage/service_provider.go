@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"path"
+
+	"github.com/lara-go/larago"
+)
+
+// ServiceProvider struct.
+type ServiceProvider struct{}
+
+// Register service.
+func (p *ServiceProvider) Register(application *larago.Application) {
+	application.Bind(func() (Disk, error) {
+		root := path.Join(application.HomeDirectory, "storage")
+		if application.Config().Has("Storage.Root") {
+			root = application.Config().Get("Storage.Root").(string)
+		}
+
+		var baseURL string
+		if application.Config().Has("Storage.URL") {
+			baseURL = application.Config().Get("Storage.URL").(string)
+		}
+
+		return NewLocalDisk(root, baseURL), nil
+	}, "storage")
+}
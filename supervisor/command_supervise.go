@@ -0,0 +1,47 @@
+package supervisor
+
+import (
+	"errors"
+
+	"github.com/asaskevich/EventBus"
+	"github.com/lara-go/larago/logger"
+
+	"github.com/urfave/cli"
+)
+
+// CommandSupervise runs all registered components in one process.
+type CommandSupervise struct {
+	Supervisor *Supervisor
+	Events     *EventBus.EventBus
+	Logger     *logger.Logger
+}
+
+// GetCommand for the cli to register.
+func (c *CommandSupervise) GetCommand() cli.Command {
+	return cli.Command{
+		Name:     "supervise",
+		Usage:    "Run HTTP server, workers and other components in one process",
+		Category: "Supervisor",
+	}
+}
+
+// Handle command.
+func (c *CommandSupervise) Handle(args cli.Args) error {
+	if len(c.Supervisor.Components()) == 0 {
+		return errors.New("There are no components to supervise")
+	}
+
+	// Stop components before application exits on sigterm.
+	if c.Events != nil {
+		c.Events.Subscribe("sigterm", c.Supervisor.Shutdown)
+	}
+
+	err := c.Supervisor.Run()
+	if err == ErrorShutdown {
+		c.Logger.Success("All components were stopped.")
+
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,18 @@
+package supervisor
+
+import (
+	"github.com/lara-go/larago/http/responses"
+)
+
+// HealthAction is a route action reporting health of every component.
+// Responds with 503 if any of them is unhealthy.
+func HealthAction(supervisor *Supervisor) responses.Response {
+	status := 200
+	if !supervisor.Healthy() {
+		status = 503
+	}
+
+	return responses.NewJSON(status, map[string]interface{}{
+		"components": supervisor.Health(),
+	})
+}
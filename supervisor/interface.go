@@ -0,0 +1,28 @@
+package supervisor
+
+import "context"
+
+// Component is a long-running part of the application: HTTP server, queue worker, scheduler, etc.
+type Component interface {
+	// Name of the component used in logs and health reports.
+	Name() string
+
+	// Start component. Blocks until component is stopped or failed.
+	Start() error
+
+	// Stop component gracefully within context deadline.
+	Stop(ctx context.Context) error
+}
+
+// ReadyNotifier is implemented by components which need some time to start.
+// Supervisor waits for it before starting the next component.
+type ReadyNotifier interface {
+	// Ready returns channel closed when component is ready to work.
+	Ready() <-chan struct{}
+}
+
+// HealthChecker is implemented by components which can report their health.
+type HealthChecker interface {
+	// Health returns error if component is unhealthy.
+	Health() error
+}
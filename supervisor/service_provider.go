@@ -0,0 +1,18 @@
+package supervisor
+
+import "github.com/lara-go/larago"
+
+// ServiceProvider struct.
+type ServiceProvider struct{}
+
+// Register service.
+func (p *ServiceProvider) Register(application *larago.Application) {
+	application.Commands(&CommandSupervise{})
+
+	application.Bind(func() (*Supervisor, error) {
+		supervisor := New()
+		application.Make(supervisor)
+
+		return supervisor, nil
+	}, "supervisor")
+}
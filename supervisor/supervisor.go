@@ -0,0 +1,243 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lara-go/larago/logger"
+)
+
+const (
+	// StatusPending component is not started yet.
+	StatusPending = "pending"
+
+	// StatusStarting component is starting.
+	StatusStarting = "starting"
+
+	// StatusRunning component is running.
+	StatusRunning = "running"
+
+	// StatusStopped component was stopped.
+	StatusStopped = "stopped"
+
+	// StatusFailed component failed.
+	StatusFailed = "failed"
+)
+
+// ErrorShutdown is returned by Run when supervisor was shut down.
+var ErrorShutdown = errors.New("supervisor: shut down")
+
+// Health report of the component.
+type Health struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Supervisor runs components in one process with coordinated startup and shutdown.
+type Supervisor struct {
+	Logger *logger.Logger
+
+	lock       sync.RWMutex
+	components []Component
+	statuses   map[string]*Health
+
+	shutdownTimeout time.Duration
+	shutdownOnce    sync.Once
+
+	// Closed when shutdown starts and when every component is stopped.
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+// New supervisor constructor.
+func New() *Supervisor {
+	return &Supervisor{
+		statuses:        make(map[string]*Health),
+		shutdownTimeout: 30 * time.Second,
+		done:            make(chan struct{}),
+		stopped:         make(chan struct{}),
+	}
+}
+
+// Add components. They will be started in the order they were added and stopped in reverse.
+func (s *Supervisor) Add(components ...Component) *Supervisor {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, component := range components {
+		s.components = append(s.components, component)
+		s.statuses[component.Name()] = &Health{Name: component.Name(), Status: StatusPending}
+	}
+
+	return s
+}
+
+// SetShutdownTimeout sets time given to all components to stop.
+func (s *Supervisor) SetShutdownTimeout(timeout time.Duration) *Supervisor {
+	s.shutdownTimeout = timeout
+
+	return s
+}
+
+// Components returns registered components.
+func (s *Supervisor) Components() []Component {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.components
+}
+
+// Run starts all components one by one and blocks until any of them fails or Shutdown is called.
+func (s *Supervisor) Run() error {
+	failures := make(chan error, len(s.components))
+
+	for _, component := range s.Components() {
+		s.setStatus(component, StatusStarting, nil)
+		s.log("Starting %s...", component.Name())
+
+		go s.start(component, failures)
+
+		if err := s.waitReady(component, failures); err != nil {
+			s.Shutdown()
+
+			return err
+		}
+	}
+
+	select {
+	case err := <-failures:
+		s.Shutdown()
+
+		return err
+	case <-s.done:
+		// Process must not exit while components are still stopping.
+		<-s.stopped
+
+		return ErrorShutdown
+	}
+}
+
+// Start component and report its failure.
+func (s *Supervisor) start(component Component, failures chan<- error) {
+	err := component.Start()
+
+	select {
+	case <-s.done:
+		// Components return errors when stopped, it's expected.
+		s.setStatus(component, StatusStopped, nil)
+	default:
+		if err == nil {
+			err = errors.New("stopped unexpectedly")
+		}
+
+		s.setStatus(component, StatusFailed, err)
+		failures <- fmt.Errorf("%s: %s", component.Name(), err)
+	}
+}
+
+// Wait until component is ready if it can notify about it.
+func (s *Supervisor) waitReady(component Component, failures <-chan error) error {
+	if notifier, ok := component.(ReadyNotifier); ok {
+		select {
+		case <-notifier.Ready():
+		case err := <-failures:
+			return err
+		case <-s.done:
+			return ErrorShutdown
+		}
+	}
+
+	s.setStatus(component, StatusRunning, nil)
+
+	return nil
+}
+
+// Shutdown stops all components in reverse order and returns when they are stopped.
+func (s *Supervisor) Shutdown() {
+	s.shutdownOnce.Do(func() {
+		defer close(s.stopped)
+		close(s.done)
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+		defer cancel()
+
+		components := s.Components()
+		for i := len(components) - 1; i >= 0; i-- {
+			component := components[i]
+
+			if s.status(component) == StatusPending {
+				continue
+			}
+
+			s.log("Stopping %s...", component.Name())
+			if err := component.Stop(ctx); err != nil {
+				s.setStatus(component, StatusFailed, err)
+
+				continue
+			}
+
+			s.setStatus(component, StatusStopped, nil)
+		}
+	})
+}
+
+// Health returns health report of every component.
+func (s *Supervisor) Health() []Health {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	report := make([]Health, 0, len(s.components))
+	for _, component := range s.components {
+		health := *s.statuses[component.Name()]
+
+		if checker, ok := component.(HealthChecker); ok && health.Status == StatusRunning {
+			if err := checker.Health(); err != nil {
+				health.Error = err.Error()
+			}
+		}
+
+		report = append(report, health)
+	}
+
+	return report
+}
+
+// Healthy checks if every component is running without errors.
+func (s *Supervisor) Healthy() bool {
+	for _, health := range s.Health() {
+		if health.Status != StatusRunning || health.Error != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *Supervisor) status(component Component) string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.statuses[component.Name()].Status
+}
+
+func (s *Supervisor) setStatus(component Component, status string, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	health := s.statuses[component.Name()]
+	health.Status = status
+	health.Error = ""
+	if err != nil {
+		health.Error = err.Error()
+	}
+}
+
+func (s *Supervisor) log(format string, a ...interface{}) {
+	if s.Logger != nil {
+		s.Logger.Info(format, a...)
+	}
+}
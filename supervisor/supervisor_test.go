@@ -0,0 +1,109 @@
+package supervisor_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lara-go/larago/supervisor"
+)
+
+type journal struct {
+	lock    sync.Mutex
+	entries []string
+}
+
+func (j *journal) add(entry string) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	j.entries = append(j.entries, entry)
+}
+
+func (j *journal) all() []string {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	return append([]string(nil), j.entries...)
+}
+
+type component struct {
+	name  string
+	log   *journal
+	fail  error
+	ready chan struct{}
+	stop  chan struct{}
+}
+
+func newComponent(name string, log *journal, fail error) *component {
+	return &component{name: name, log: log, fail: fail, ready: make(chan struct{}), stop: make(chan struct{})}
+}
+
+func (c *component) Ready() <-chan struct{} {
+	return c.ready
+}
+
+func (c *component) Name() string {
+	return c.name
+}
+
+func (c *component) Start() error {
+	c.log.add("start " + c.name)
+
+	if c.fail != nil {
+		return c.fail
+	}
+
+	close(c.ready)
+
+	<-c.stop
+
+	return nil
+}
+
+func (c *component) Stop(ctx context.Context) error {
+	c.log.add("stop " + c.name)
+	close(c.stop)
+
+	return nil
+}
+
+func TestItStopsAllComponentsInReverseOrder(t *testing.T) {
+	log := &journal{}
+
+	s := supervisor.New().Add(
+		newComponent("first", log, nil),
+		newComponent("second", log, nil),
+	)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		assert.True(t, s.Healthy())
+		s.Shutdown()
+	}()
+
+	assert.Equal(t, supervisor.ErrorShutdown, s.Run())
+	assert.Equal(t, []string{"start first", "start second", "stop second", "stop first"}, log.all())
+
+	for _, health := range s.Health() {
+		assert.Equal(t, supervisor.StatusStopped, health.Status)
+	}
+}
+
+func TestItShutsDownWhenComponentFails(t *testing.T) {
+	log := &journal{}
+
+	s := supervisor.New().Add(
+		newComponent("first", log, nil),
+		newComponent("broken", log, errors.New("boom")),
+	)
+
+	err := s.Run()
+	assert.EqualError(t, err, "broken: boom")
+	assert.Contains(t, log.all(), "stop first")
+	assert.False(t, s.Healthy())
+}
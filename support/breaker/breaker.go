@@ -0,0 +1,316 @@
+package breaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// State of the breaker.
+type State int
+
+// Breaker states.
+const (
+	StateClosed State = iota
+	StateHalfOpen
+	StateOpen
+)
+
+// String returns state name.
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half-open"
+	case StateOpen:
+		return "open"
+	}
+
+	return "unknown"
+}
+
+var (
+	// ErrorOpen is returned when breaker is open and calls are rejected.
+	ErrorOpen = errors.New("Circuit breaker is open")
+
+	// ErrorTooManyProbes is returned when half-open breaker already probes the service.
+	ErrorTooManyProbes = errors.New("Circuit breaker is half-open and already probing")
+)
+
+// Settings of the breaker.
+type Settings struct {
+	// FailureThreshold is the number of consecutive failures that opens the breaker.
+	FailureThreshold int
+
+	// SuccessThreshold is the number of successful probes that closes half-open breaker.
+	SuccessThreshold int
+
+	// OpenTimeout is the time breaker stays open before probing.
+	OpenTimeout time.Duration
+
+	// MaxProbes is the number of concurrent calls allowed in half-open state.
+	MaxProbes int
+
+	// IsFailure decides if the error is a failure of the service. All errors are by default.
+	IsFailure func(err error) bool
+}
+
+// DefaultSettings of the breaker.
+func DefaultSettings() Settings {
+	return Settings{
+		FailureThreshold: 5,
+		SuccessThreshold: 1,
+		OpenTimeout:      30 * time.Second,
+		MaxProbes:        1,
+	}
+}
+
+// Metrics of the breaker.
+type Metrics struct {
+	Name                string
+	State               string
+	Requests            uint64
+	Successes           uint64
+	Failures            uint64
+	Rejections          uint64
+	ConsecutiveFailures int
+	StateChangedAt      time.Time
+}
+
+// StateChangeCallback is called when breaker changes its state.
+// It is called without the lock held, so it may use the breaker.
+type StateChangeCallback func(name string, from, to State)
+
+// Change of the state waiting to be reported to callbacks.
+type transition struct {
+	from, to State
+}
+
+// Breaker stops calling failing service for a while, letting it recover.
+type Breaker struct {
+	name     string
+	settings Settings
+
+	state                State
+	consecutiveFailures  int
+	consecutiveSuccesses int
+	probes               int
+	openedAt             time.Time
+	metrics              Metrics
+	callbacks            []StateChangeCallback
+
+	// Generation changes with every state, results of calls allowed in another one are ignored.
+	generation  uint64
+	transitions []transition
+
+	mutex sync.Mutex
+	now   func() time.Time
+}
+
+// New breaker.
+func New(name string, settings Settings) *Breaker {
+	defaults := DefaultSettings()
+	if settings.FailureThreshold <= 0 {
+		settings.FailureThreshold = defaults.FailureThreshold
+	}
+	if settings.SuccessThreshold <= 0 {
+		settings.SuccessThreshold = defaults.SuccessThreshold
+	}
+	if settings.OpenTimeout <= 0 {
+		settings.OpenTimeout = defaults.OpenTimeout
+	}
+	if settings.MaxProbes <= 0 {
+		settings.MaxProbes = defaults.MaxProbes
+	}
+
+	return &Breaker{
+		name:     name,
+		settings: settings,
+		metrics: Metrics{
+			Name:           name,
+			StateChangedAt: time.Now(),
+		},
+		now: time.Now,
+	}
+}
+
+// Name of the breaker.
+func (b *Breaker) Name() string {
+	return b.name
+}
+
+// OnStateChange registers state change callback.
+func (b *Breaker) OnStateChange(callback StateChangeCallback) *Breaker {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.callbacks = append(b.callbacks, callback)
+
+	return b
+}
+
+// Execute callback if breaker allows it.
+func (b *Breaker) Execute(callback func() error) error {
+	done, err := b.Allow()
+	if err != nil {
+		return err
+	}
+
+	err = callback()
+	done(err)
+
+	return err
+}
+
+// Allow checks if call may be made.
+// Returned function must be called with the result of the call.
+func (b *Breaker) Allow() (func(err error), error) {
+	b.mutex.Lock()
+	defer b.unlock()
+
+	b.refreshState()
+
+	switch b.state {
+	case StateOpen:
+		b.metrics.Rejections++
+
+		return nil, ErrorOpen
+
+	case StateHalfOpen:
+		if b.probes >= b.settings.MaxProbes {
+			b.metrics.Rejections++
+
+			return nil, ErrorTooManyProbes
+		}
+
+		b.probes++
+	}
+
+	b.metrics.Requests++
+	generation := b.generation
+
+	var once sync.Once
+	return func(err error) {
+		once.Do(func() {
+			b.done(generation, err)
+		})
+	}, nil
+}
+
+// State of the breaker.
+func (b *Breaker) State() State {
+	b.mutex.Lock()
+	defer b.unlock()
+
+	b.refreshState()
+
+	return b.state
+}
+
+// Metrics of the breaker.
+func (b *Breaker) Metrics() Metrics {
+	b.mutex.Lock()
+	defer b.unlock()
+
+	b.refreshState()
+
+	metrics := b.metrics
+	metrics.State = b.state.String()
+	metrics.ConsecutiveFailures = b.consecutiveFailures
+
+	return metrics
+}
+
+// Reset breaker to closed state.
+func (b *Breaker) Reset() {
+	b.mutex.Lock()
+	defer b.unlock()
+
+	b.setState(StateClosed)
+}
+
+// Register result of the call.
+func (b *Breaker) done(generation uint64, err error) {
+	b.mutex.Lock()
+	defer b.unlock()
+
+	// Breaker changed its state while the call was running.
+	if generation != b.generation {
+		return
+	}
+
+	if b.state == StateHalfOpen && b.probes > 0 {
+		b.probes--
+	}
+
+	failed := err != nil
+	if failed && b.settings.IsFailure != nil {
+		failed = b.settings.IsFailure(err)
+	}
+
+	if failed {
+		b.metrics.Failures++
+		b.consecutiveFailures++
+		b.consecutiveSuccesses = 0
+
+		if b.state == StateHalfOpen || b.consecutiveFailures >= b.settings.FailureThreshold {
+			b.setState(StateOpen)
+		}
+
+		return
+	}
+
+	b.metrics.Successes++
+	b.consecutiveFailures = 0
+	b.consecutiveSuccesses++
+
+	if b.state == StateHalfOpen && b.consecutiveSuccesses >= b.settings.SuccessThreshold {
+		b.setState(StateClosed)
+	}
+}
+
+// Move open breaker to half-open after timeout.
+func (b *Breaker) refreshState() {
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.settings.OpenTimeout {
+		b.setState(StateHalfOpen)
+	}
+}
+
+func (b *Breaker) setState(state State) {
+	from := b.state
+	if from == state {
+		return
+	}
+
+	b.state = state
+	b.generation++
+	b.consecutiveSuccesses = 0
+	b.probes = 0
+	b.metrics.StateChangedAt = b.now()
+
+	switch state {
+	case StateOpen:
+		b.openedAt = b.now()
+	case StateClosed:
+		b.consecutiveFailures = 0
+	}
+
+	if len(b.callbacks) > 0 {
+		b.transitions = append(b.transitions, transition{from: from, to: state})
+	}
+}
+
+// Unlock the breaker and report state changes made under the lock.
+func (b *Breaker) unlock() {
+	transitions := b.transitions
+	callbacks := b.callbacks
+	b.transitions = nil
+	b.mutex.Unlock()
+
+	for _, t := range transitions {
+		for _, callback := range callbacks {
+			callback(b.name, t.from, t.to)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package breaker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errorFailed = errors.New("failed")
+
+func breakerFactory() (*Breaker, *time.Time) {
+	now := time.Now()
+	b := New("service", Settings{FailureThreshold: 2, SuccessThreshold: 2, OpenTimeout: time.Minute})
+	b.now = func() time.Time {
+		return now
+	}
+
+	return b, &now
+}
+
+func fail() error {
+	return errorFailed
+}
+
+func succeed() error {
+	return nil
+}
+
+func TestBreaker_Opens(t *testing.T) {
+	b, _ := breakerFactory()
+
+	assert.Equal(t, errorFailed, b.Execute(fail))
+	assert.Equal(t, StateClosed, b.State())
+
+	assert.Equal(t, errorFailed, b.Execute(fail))
+	assert.Equal(t, StateOpen, b.State())
+
+	assert.Equal(t, ErrorOpen, b.Execute(succeed))
+
+	metrics := b.Metrics()
+	assert.Equal(t, uint64(2), metrics.Failures)
+	assert.Equal(t, uint64(1), metrics.Rejections)
+	assert.Equal(t, "open", metrics.State)
+}
+
+func TestBreaker_HalfOpen(t *testing.T) {
+	b, now := breakerFactory()
+
+	b.Execute(fail)
+	b.Execute(fail)
+
+	*now = now.Add(time.Minute)
+	assert.Equal(t, StateHalfOpen, b.State())
+
+	// Only one probe at a time.
+	done, err := b.Allow()
+	assert.NoError(t, err)
+	_, err = b.Allow()
+	assert.Equal(t, ErrorTooManyProbes, err)
+
+	done(nil)
+	assert.Equal(t, StateHalfOpen, b.State())
+
+	assert.NoError(t, b.Execute(succeed))
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestBreaker_HalfOpenFailure(t *testing.T) {
+	b, now := breakerFactory()
+
+	var transitions []string
+	b.OnStateChange(func(name string, from, to State) {
+		transitions = append(transitions, from.String()+">"+to.String())
+	})
+
+	b.Execute(fail)
+	b.Execute(fail)
+
+	*now = now.Add(time.Minute)
+	b.Execute(fail)
+
+	assert.Equal(t, StateOpen, b.State())
+	assert.Equal(t, []string{"closed>open", "open>half-open", "half-open>open"}, transitions)
+}
+
+func TestBreaker_CallbackUsesBreaker(t *testing.T) {
+	b, _ := breakerFactory()
+
+	var states []State
+	b.OnStateChange(func(name string, from, to State) {
+		states = append(states, b.State())
+	})
+
+	b.Execute(fail)
+	b.Execute(fail)
+
+	assert.Equal(t, []State{StateOpen}, states)
+}
+
+func TestBreaker_IgnoresResultsOfPreviousState(t *testing.T) {
+	b, now := breakerFactory()
+
+	// Slow call started while the breaker was closed.
+	done, err := b.Allow()
+	assert.NoError(t, err)
+
+	b.Execute(fail)
+	b.Execute(fail)
+
+	*now = now.Add(time.Minute)
+	probe, err := b.Allow()
+	assert.NoError(t, err)
+
+	// Late success neither counts as probe nor frees its slot.
+	done(nil)
+	assert.Equal(t, StateHalfOpen, b.State())
+	_, err = b.Allow()
+	assert.Equal(t, ErrorTooManyProbes, err)
+
+	probe(nil)
+	assert.NoError(t, b.Execute(succeed))
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestBreaker_IsFailure(t *testing.T) {
+	b := New("service", Settings{
+		FailureThreshold: 1,
+		IsFailure: func(err error) bool {
+			return err != errorFailed
+		},
+	})
+
+	b.Execute(fail)
+	assert.Equal(t, StateClosed, b.State())
+}
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry(DefaultSettings())
+	registry.Configure("payments", Settings{FailureThreshold: 1})
+
+	assert.Equal(t, registry.Get("payments"), registry.Get("payments"))
+
+	registry.Get("payments").Execute(fail)
+	registry.Get("search").Execute(fail)
+
+	metrics := registry.Metrics()
+	assert.Len(t, metrics, 2)
+	assert.Equal(t, "open", metrics[0].State)
+	assert.Equal(t, "closed", metrics[1].State)
+}
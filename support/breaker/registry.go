@@ -0,0 +1,93 @@
+package breaker
+
+import (
+	"sort"
+	"sync"
+)
+
+// Registry keeps breakers of every service.
+type Registry struct {
+	defaults  Settings
+	settings  map[string]Settings
+	breakers  map[string]*Breaker
+	callbacks []StateChangeCallback
+	mutex     sync.Mutex
+}
+
+// NewRegistry constructor.
+func NewRegistry(defaults Settings) *Registry {
+	return &Registry{
+		defaults: defaults,
+		settings: make(map[string]Settings),
+		breakers: make(map[string]*Breaker),
+	}
+}
+
+// Configure settings of the service breaker.
+func (r *Registry) Configure(name string, settings Settings) *Registry {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.settings[name] = settings
+	delete(r.breakers, name)
+
+	return r
+}
+
+// OnStateChange registers state change callback for every breaker.
+func (r *Registry) OnStateChange(callback StateChangeCallback) *Registry {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.callbacks = append(r.callbacks, callback)
+	for _, breaker := range r.breakers {
+		breaker.OnStateChange(callback)
+	}
+
+	return r
+}
+
+// Get breaker of the service.
+func (r *Registry) Get(name string) *Breaker {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if breaker, ok := r.breakers[name]; ok {
+		return breaker
+	}
+
+	settings, ok := r.settings[name]
+	if !ok {
+		settings = r.defaults
+	}
+
+	breaker := New(name, settings)
+	for _, callback := range r.callbacks {
+		breaker.OnStateChange(callback)
+	}
+
+	r.breakers[name] = breaker
+
+	return breaker
+}
+
+// Metrics of all breakers sorted by name.
+func (r *Registry) Metrics() []Metrics {
+	r.mutex.Lock()
+	breakers := make([]*Breaker, 0, len(r.breakers))
+	for _, breaker := range r.breakers {
+		breakers = append(breakers, breaker)
+	}
+	r.mutex.Unlock()
+
+	metrics := make([]Metrics, 0, len(breakers))
+	for _, breaker := range breakers {
+		metrics = append(metrics, breaker.Metrics())
+	}
+
+	sort.Slice(metrics, func(i, j int) bool {
+		return metrics[i].Name < metrics[j].Name
+	})
+
+	return metrics
+}
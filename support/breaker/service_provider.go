@@ -0,0 +1,25 @@
+package breaker
+
+import (
+	"github.com/asaskevich/EventBus"
+	"github.com/lara-go/larago"
+)
+
+// ServiceProvider struct.
+type ServiceProvider struct{}
+
+// Register service.
+func (p *ServiceProvider) Register(application *larago.Application) {
+	application.Bind(func() (*Registry, error) {
+		registry := NewRegistry(DefaultSettings())
+
+		if application.Bound("events") {
+			events := application.Get("events").(*EventBus.EventBus)
+			registry.OnStateChange(func(name string, from, to State) {
+				events.Publish("breaker.state-changed", name, from, to)
+			})
+		}
+
+		return registry, nil
+	}, "breakers")
+}
@@ -0,0 +1,58 @@
+package breaker
+
+import (
+	"fmt"
+	net_http "net/http"
+)
+
+// ServerError is a failure reported by the service with 5xx status.
+type ServerError struct {
+	Status int
+}
+
+// Error returns error message.
+func (e *ServerError) Error() string {
+	return fmt.Sprintf("Service responded with %d", e.Status)
+}
+
+// Transport guards outbound HTTP calls with the breaker.
+// Network errors and 5xx responses are counted as failures.
+type Transport struct {
+	breaker *Breaker
+	base    net_http.RoundTripper
+}
+
+// NewTransport constructor. Uses http.DefaultTransport if base is nil.
+func NewTransport(breaker *Breaker, base net_http.RoundTripper) *Transport {
+	if base == nil {
+		base = net_http.DefaultTransport
+	}
+
+	return &Transport{
+		breaker: breaker,
+		base:    base,
+	}
+}
+
+// RoundTrip executes a single HTTP transaction.
+func (t *Transport) RoundTrip(request *net_http.Request) (*net_http.Response, error) {
+	done, err := t.breaker.Allow()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := t.base.RoundTrip(request)
+	if err != nil {
+		done(err)
+
+		return nil, err
+	}
+
+	if response.StatusCode >= 500 {
+		done(&ServerError{Status: response.StatusCode})
+	} else {
+		done(nil)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,227 @@
+package configgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/lara-go/larago/support/utils"
+)
+
+// Generator makes typed config structs from JSON config files.
+// Every file is a section of the Config struct, ex. "database.json" becomes Config.Database,
+// so dot-notation keys like "Database.DSN" keep working with the generated config.
+type Generator struct {
+	pkg      string
+	sections []*section
+	types    map[string]*structType
+}
+
+// Section of the config made of the single file.
+type section struct {
+	name string
+	file string
+	typ  string
+}
+
+// Struct type to generate.
+type structType struct {
+	name   string
+	doc    string
+	fields map[string]*field
+}
+
+// Field of the struct.
+type field struct {
+	name string
+	key  string
+	typ  string
+}
+
+// New generator of the package.
+func New(pkg string) *Generator {
+	return &Generator{
+		pkg:   pkg,
+		types: make(map[string]*structType),
+	}
+}
+
+// AddFile adds config file as a section named by the file.
+func (g *Generator) AddFile(name string, content []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	var values map[string]interface{}
+	if err := decoder.Decode(&values); err != nil {
+		return fmt.Errorf("Can't parse config file %s: %s", name, err)
+	}
+
+	section := &section{
+		name: goName(name),
+		file: name,
+	}
+	if section.name == "Config" {
+		return fmt.Errorf("Config file %s conflicts with the Config struct, rename it", name)
+	}
+	section.typ = section.name
+	g.sections = append(g.sections, section)
+
+	g.addStruct(section.typ, fmt.Sprintf("%s config section, read from %s.json.", section.typ, name), values)
+
+	return nil
+}
+
+// Generate Go source.
+func (g *Generator) Generate() ([]byte, error) {
+	sort.Slice(g.sections, func(i, j int) bool {
+		return g.sections[i].name < g.sections[j].name
+	})
+
+	var out strings.Builder
+
+	out.WriteString("// Code generated by make:config. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", g.pkg)
+	out.WriteString("import (\n\t\"encoding/json\"\n\t\"fmt\"\n\t\"io/ioutil\"\n\t\"path/filepath\"\n\n\t\"github.com/lara-go/larago\"\n)\n\n")
+
+	out.WriteString("// Config of the application.\ntype Config struct {\n")
+	for _, section := range g.sections {
+		fmt.Fprintf(&out, "\t%s %s `json:\"%s\"`\n", section.name, section.typ, section.file)
+	}
+	out.WriteString("}\n\n")
+
+	g.writeEnvironment(&out)
+
+	out.WriteString("// Load reads config files from the directory.\nfunc Load(directory string) (*Config, error) {\n")
+	out.WriteString("\tconfig := &Config{}\n\n\tsections := map[string]interface{}{\n")
+	for _, section := range g.sections {
+		fmt.Fprintf(&out, "\t\t%q: &config.%s,\n", section.file, section.name)
+	}
+	out.WriteString("\t}\n\n\tfor name, section := range sections {\n")
+	out.WriteString("\t\tcontent, err := ioutil.ReadFile(filepath.Join(directory, name+\".json\"))\n")
+	out.WriteString("\t\tif err != nil {\n\t\t\treturn nil, err\n\t\t}\n\n")
+	out.WriteString("\t\tif err := json.Unmarshal(content, section); err != nil {\n")
+	out.WriteString("\t\t\treturn nil, fmt.Errorf(\"Can't parse config file %s: %s\", name, err)\n\t\t}\n\t}\n\n\treturn config, nil\n}\n\n")
+
+	out.WriteString("// Bind config and its sections in the container, so they are injected by type.\n")
+	out.WriteString("func Bind(application *larago.Application, config *Config) {\n\tapplication.Instance(config)\n")
+	for _, section := range g.sections {
+		fmt.Fprintf(&out, "\tapplication.Instance(&config.%s, %q)\n", section.name, "config."+section.file)
+	}
+	out.WriteString("}\n")
+
+	names := make([]string, 0, len(g.types))
+	for name := range g.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		g.writeStruct(&out, g.types[name])
+	}
+
+	return format.Source([]byte(out.String()))
+}
+
+// Config implements larago.Config if App section has env and debug values.
+func (g *Generator) writeEnvironment(out *strings.Builder) {
+	app, ok := g.types["App"]
+	if !ok || app.fields["Env"] == nil || app.fields["Debug"] == nil ||
+		app.fields["Env"].typ != "string" || app.fields["Debug"].typ != "bool" {
+		return
+	}
+
+	out.WriteString("// Env returns current environment name.\nfunc (c *Config) Env() string {\n\treturn c.App.Env\n}\n\n")
+	out.WriteString("// Debug returns debug mode state.\nfunc (c *Config) Debug() bool {\n\treturn c.App.Debug\n}\n\n")
+}
+
+// Write struct type.
+func (g *Generator) writeStruct(out *strings.Builder, typ *structType) {
+	fmt.Fprintf(out, "\n// %s\ntype %s struct {\n", typ.doc, typ.name)
+
+	names := make([]string, 0, len(typ.fields))
+	for name := range typ.fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		field := typ.fields[name]
+		fmt.Fprintf(out, "\t%s %s `json:\"%s\"`\n", field.name, field.typ, field.key)
+	}
+	out.WriteString("}\n")
+}
+
+// Add struct type for the object, merging fields if it is already known.
+func (g *Generator) addStruct(name, doc string, values map[string]interface{}) {
+	typ, ok := g.types[name]
+	if !ok {
+		typ = &structType{name: name, doc: doc, fields: make(map[string]*field)}
+		g.types[name] = typ
+	}
+
+	for key, value := range values {
+		fieldName := goName(key)
+		inferred := g.infer(name+fieldName, value)
+
+		if known, ok := typ.fields[fieldName]; ok && known.typ != inferred {
+			// Values of the different types in the list items.
+			if inferred == "interface{}" || known.typ == "interface{}" {
+				if known.typ == "interface{}" {
+					known.typ = inferred
+				}
+				continue
+			}
+			known.typ = "interface{}"
+			continue
+		}
+
+		typ.fields[fieldName] = &field{name: fieldName, key: key, typ: inferred}
+	}
+}
+
+// Infer Go type of the JSON value.
+func (g *Generator) infer(name string, value interface{}) string {
+	switch v := value.(type) {
+	case bool:
+		return "bool"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "int"
+		}
+		return "float64"
+	case map[string]interface{}:
+		g.addStruct(name, name+" config.", v)
+		return name
+	case []interface{}:
+		if len(v) == 0 {
+			return "[]interface{}"
+		}
+
+		elem := g.infer(name+"Item", v[0])
+		for _, item := range v[1:] {
+			if g.infer(name+"Item", item) != elem {
+				return "[]interface{}"
+			}
+		}
+
+		return "[]" + elem
+	default:
+		return "interface{}"
+	}
+}
+
+// Exported Go name of the key.
+func goName(key string) string {
+	name := utils.ToCamel(key)
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) {
+		name = "Field" + name
+	}
+
+	return name
+}
@@ -0,0 +1,40 @@
+package configgen_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lara-go/larago/support/configgen"
+)
+
+func TestGenerate(t *testing.T) {
+	generator := configgen.New("config")
+
+	assert.NoError(t, generator.AddFile("app", []byte(`{"env": "local", "debug": true, "key": null}`)))
+	assert.NoError(t, generator.AddFile("database", []byte(`{
+		"driver": "sqlite3",
+		"dsn": "db.sqlite",
+		"statement_cache": 100,
+		"replicas": [{"dsn": "replica1", "weight": 0.5}, {"dsn": "replica2"}],
+		"pool": {"max_open": 10}
+	}`)))
+	assert.Error(t, generator.AddFile("broken", []byte(`{`)))
+
+	source, err := generator.Generate()
+	assert.NoError(t, err)
+
+	code := string(source)
+	assert.Contains(t, code, "// Code generated by make:config. DO NOT EDIT.")
+	assert.Contains(t, code, "App      App      `json:\"app\"`")
+	assert.Contains(t, code, "Database Database `json:\"database\"`")
+	assert.Contains(t, code, "func (c *Config) Env() string {")
+	assert.Contains(t, code, "Key   interface{} `json:\"key\"`")
+	assert.Contains(t, code, "DSN            string                 `json:\"dsn\"`")
+	assert.Contains(t, code, "StatementCache int                    `json:\"statement_cache\"`")
+	assert.Contains(t, code, "Replicas       []DatabaseReplicasItem `json:\"replicas\"`")
+	assert.Contains(t, code, "Pool           DatabasePool           `json:\"pool\"`")
+	assert.Contains(t, code, "Weight float64 `json:\"weight\"`")
+	assert.Contains(t, code, "MaxOpen int `json:\"max_open\"`")
+	assert.Contains(t, code, `application.Instance(&config.Database, "config.database")`)
+}
@@ -0,0 +1,222 @@
+package filetype
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	net_http "net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// HeaderSize is amount of bytes needed to detect file type.
+const HeaderSize = 512
+
+// Type of the file.
+type Type struct {
+	MIME      string
+	Extension string
+}
+
+// Is checks if type matches one of the MIME types or extensions.
+// Wildcards like "image/*" are supported.
+func (t Type) Is(types ...string) bool {
+	for _, name := range types {
+		name = strings.ToLower(strings.TrimPrefix(name, "."))
+
+		switch {
+		case name == t.MIME, name == t.Extension:
+			return true
+		case strings.HasSuffix(name, "/*") && strings.HasPrefix(t.MIME, strings.TrimSuffix(name, "*")):
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsImage checks if file is an image.
+func (t Type) IsImage() bool {
+	return t.Is("image/*")
+}
+
+// IsSVG checks if file is an SVG image, which can contain scripts.
+func (t Type) IsSVG() bool {
+	return t.MIME == SVG.MIME
+}
+
+// Known types.
+var (
+	Unknown = Type{"application/octet-stream", ""}
+	Text    = Type{"text/plain", "txt"}
+	HTML    = Type{"text/html", "html"}
+	XML     = Type{"text/xml", "xml"}
+	JSON    = Type{"application/json", "json"}
+	JPEG    = Type{"image/jpeg", "jpg"}
+	PNG     = Type{"image/png", "png"}
+	GIF     = Type{"image/gif", "gif"}
+	WEBP    = Type{"image/webp", "webp"}
+	BMP     = Type{"image/bmp", "bmp"}
+	ICO     = Type{"image/x-icon", "ico"}
+	TIFF    = Type{"image/tiff", "tiff"}
+	SVG     = Type{"image/svg+xml", "svg"}
+	PDF     = Type{"application/pdf", "pdf"}
+	ZIP     = Type{"application/zip", "zip"}
+	GZIP    = Type{"application/gzip", "gz"}
+	RAR     = Type{"application/vnd.rar", "rar"}
+	SevenZ  = Type{"application/x-7z-compressed", "7z"}
+	MP3     = Type{"audio/mpeg", "mp3"}
+	WAV     = Type{"audio/wav", "wav"}
+	OGG     = Type{"audio/ogg", "ogg"}
+	MP4     = Type{"video/mp4", "mp4"}
+	WEBM    = Type{"video/webm", "webm"}
+)
+
+// Signature of the binary format.
+type signature struct {
+	offset int
+	magic  []byte
+	typ    Type
+}
+
+var signatures = []signature{
+	{0, []byte("\xFF\xD8\xFF"), JPEG},
+	{0, []byte("\x89PNG\r\n\x1A\n"), PNG},
+	{0, []byte("GIF87a"), GIF},
+	{0, []byte("GIF89a"), GIF},
+	{8, []byte("WEBP"), WEBP},
+	{0, []byte("BM"), BMP},
+	{0, []byte("\x00\x00\x01\x00"), ICO},
+	{0, []byte("II*\x00"), TIFF},
+	{0, []byte("MM\x00*"), TIFF},
+	{0, []byte("%PDF-"), PDF},
+	{0, []byte("PK\x03\x04"), ZIP},
+	{0, []byte("\x1F\x8B\x08"), GZIP},
+	{0, []byte("Rar!\x1A\x07"), RAR},
+	{0, []byte("7z\xBC\xAF\x27\x1C"), SevenZ},
+	{0, []byte("ID3"), MP3},
+	{0, []byte("\xFF\xFB"), MP3},
+	{8, []byte("WAVE"), WAV},
+	{0, []byte("OggS"), OGG},
+	{4, []byte("ftyp"), MP4},
+	{0, []byte("\x1A\x45\xDF\xA3"), WEBM},
+}
+
+// Detect type by the first bytes of the file. Extension is never trusted.
+func Detect(header []byte) Type {
+	if len(header) > HeaderSize {
+		header = header[:HeaderSize]
+	}
+
+	for _, s := range signatures {
+		end := s.offset + len(s.magic)
+		if len(header) >= end && bytes.Equal(header[s.offset:end], s.magic) {
+			return s.typ
+		}
+	}
+
+	return detectText(header)
+}
+
+// Detect text formats.
+func detectText(header []byte) Type {
+	sniffed := net_http.DetectContentType(header)
+	if !strings.HasPrefix(sniffed, "text/") {
+		return Unknown
+	}
+
+	trimmed := bytes.ToLower(bytes.TrimSpace(header))
+
+	switch {
+	case rootElement(header) == "svg":
+		return SVG
+	case strings.HasPrefix(sniffed, "text/html"):
+		return HTML
+	case bytes.Contains(trimmed, []byte("<svg")):
+		return SVG
+	case strings.HasPrefix(sniffed, "text/xml"):
+		return XML
+	case bytes.HasPrefix(trimmed, []byte("{")), bytes.HasPrefix(trimmed, []byte("[")):
+		return JSON
+	default:
+		return Text
+	}
+}
+
+// DetectReader detects type of the stream.
+// Returned reader must be used instead of the original one, since header was consumed.
+func DetectReader(r io.Reader) (Type, io.Reader, error) {
+	header := make([]byte, HeaderSize)
+
+	n, err := io.ReadFull(r, header)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return Unknown, nil, err
+	}
+
+	header = header[:n]
+
+	return Detect(header), io.MultiReader(bytes.NewReader(header), r), nil
+}
+
+// DetectFile detects type of the file on disk.
+func DetectFile(path string) (Type, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Unknown, err
+	}
+	defer f.Close()
+
+	typ, _, err := DetectReader(f)
+
+	return typ, err
+}
+
+// DetectUpload detects type of the uploaded file.
+func DetectUpload(file *multipart.FileHeader) (Type, error) {
+	f, err := file.Open()
+	if err != nil {
+		return Unknown, err
+	}
+	defer f.Close()
+
+	typ, _, err := DetectReader(f)
+
+	return typ, err
+}
+
+// ReadUpload reads uploaded file contents, sanitizing SVG images.
+// Text files are sanitized as SVG when they are one after the header, or are declared as SVG by the client.
+func ReadUpload(file *multipart.FileHeader) (Type, []byte, error) {
+	f, err := file.Open()
+	if err != nil {
+		return Unknown, nil, err
+	}
+	defer f.Close()
+
+	content, err := ioutil.ReadAll(f)
+	if err != nil {
+		return Unknown, nil, err
+	}
+
+	typ := Detect(content)
+	if typ.IsSVG() || (typ.Is("text/*") && (rootElement(content) == "svg" || declaresSVG(file))) {
+		typ = SVG
+		content, err = SanitizeSVG(content)
+	}
+
+	return typ, content, err
+}
+
+// Check if client sent file as SVG.
+func declaresSVG(file *multipart.FileHeader) bool {
+	if strings.EqualFold(filepath.Ext(file.Filename), "."+SVG.Extension) {
+		return true
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(file.Header.Get("Content-Type"))
+
+	return mediaType == SVG.MIME
+}
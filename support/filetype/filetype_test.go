@@ -0,0 +1,129 @@
+package filetype_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/lara-go/larago/support/filetype"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetect(t *testing.T) {
+	cases := map[string]filetype.Type{
+		"\x89PNG\r\n\x1A\n\x00\x00":                        filetype.PNG,
+		"\xFF\xD8\xFF\xE0\x00\x10JFIF":                     filetype.JPEG,
+		"GIF89a\x01\x00":                                   filetype.GIF,
+		"RIFF\x00\x00\x00\x00WEBPVP8 ":                     filetype.WEBP,
+		"%PDF-1.4\n":                                       filetype.PDF,
+		"<?xml version=\"1.0\"?>\n<svg xmlns=\"x\"></svg>": filetype.SVG,
+		"<!DOCTYPE html><html><svg></svg></html>":          filetype.HTML,
+		"<!-- drawing --><svg xmlns=\"x\"></svg>":          filetype.SVG,
+		"{\"key\": 1}":                                     filetype.JSON,
+		"plain text":                                       filetype.Text,
+		"\x00\x01\x02\x03":                                 filetype.Unknown,
+	}
+
+	for content, expected := range cases {
+		assert.Equal(t, expected, filetype.Detect([]byte(content)), content)
+	}
+}
+
+func TestDetectReaderKeepsContent(t *testing.T) {
+	typ, r, err := filetype.DetectReader(strings.NewReader("%PDF-1.4 content"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, filetype.PDF, typ)
+
+	content, _ := ioutil.ReadAll(r)
+	assert.Equal(t, "%PDF-1.4 content", string(content))
+}
+
+func TestTypeIs(t *testing.T) {
+	assert.True(t, filetype.PNG.Is("image/*"))
+	assert.True(t, filetype.PNG.Is(".png"))
+	assert.True(t, filetype.PNG.Is("image/jpeg", "image/png"))
+	assert.False(t, filetype.PDF.Is("image/*", "zip"))
+	assert.True(t, filetype.SVG.IsImage())
+}
+
+func TestSanitizeSVG(t *testing.T) {
+	svg := `<?xml version="1.0"?>
+<!DOCTYPE svg [<!ENTITY x "y">]>
+<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" onload="alert(1)">
+<script>alert(2)</script>
+<foreignObject><div>html</div></foreignObject>
+<a xlink:href="javascript:alert(3)"><circle r="5" onclick="alert(4)"/></a>
+<use xlink:href="#shape"/>
+</svg>`
+
+	sanitized, err := filetype.SanitizeSVG([]byte(svg))
+	assert.Nil(t, err)
+
+	result := string(sanitized)
+	for _, forbidden := range []string{"alert", "script", "foreignObject", "DOCTYPE", "onload", "onclick"} {
+		assert.NotContains(t, result, forbidden)
+	}
+
+	assert.Contains(t, result, `<use xlink:href="#shape"></use>`)
+	assert.Contains(t, result, `xmlns:xlink="http://www.w3.org/1999/xlink"`)
+	assert.Equal(t, filetype.SVG, filetype.Detect(sanitized))
+	assert.True(t, bytes.HasPrefix(sanitized, []byte("<?xml")))
+}
+
+func TestSanitizeSVGAllowlist(t *testing.T) {
+	svg := `<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink">
+<a xlink:href="#x"><animate attributeName="href" values="java&#9;script:alert(1)"/><set attributeName="href" to="javascript:alert(2)"/></a>
+<a href="java&#9;script:alert(3)"><text>link</text></a>
+<rect width="10" height="10" fill="url(#gradient)" style="fill: url(https://example.com/track)"/>
+<style>@import url(https://example.com/evil.css);</style>
+</svg>`
+
+	sanitized, err := filetype.SanitizeSVG([]byte(svg))
+	assert.Nil(t, err)
+
+	result := string(sanitized)
+	for _, forbidden := range []string{"alert", "animate", "<set", "style", "example.com"} {
+		assert.NotContains(t, result, forbidden)
+	}
+
+	assert.Contains(t, result, `<rect width="10" height="10" fill="url(#gradient)"></rect>`)
+	assert.Contains(t, result, `<a><text>link</text></a>`)
+}
+
+// Make uploaded file by parsing multipart form.
+func upload(t *testing.T, filename, contentType, content string) *multipart.FileHeader {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", `form-data; name="file"; filename="`+filename+`"`)
+	header.Set("Content-Type", contentType)
+	part, _ := writer.CreatePart(header)
+	part.Write([]byte(content))
+	writer.Close()
+
+	form, err := multipart.NewReader(&body, writer.Boundary()).ReadForm(1 << 20)
+	assert.Nil(t, err)
+
+	return form.File["file"][0]
+}
+
+func TestReadUploadSanitizesHiddenSVG(t *testing.T) {
+	// Root element is out of the sniffed header.
+	long := "<!--" + strings.Repeat(" ", filetype.HeaderSize) + `--><svg onload="alert(1)"></svg>`
+
+	typ, content, err := filetype.ReadUpload(upload(t, "image.txt", "text/plain", long))
+	assert.Nil(t, err)
+	assert.Equal(t, filetype.SVG, typ)
+	assert.NotContains(t, string(content), "alert")
+
+	// Client tells it is SVG, though it does not look like one.
+	typ, content, err = filetype.ReadUpload(upload(t, "image.svg", "image/svg+xml", `<html><script>alert(1)</script></html>`))
+	assert.Nil(t, err)
+	assert.Equal(t, filetype.SVG, typ)
+	assert.NotContains(t, string(content), "alert")
+}
@@ -0,0 +1,211 @@
+package filetype
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Elements kept in SVG, the others are removed together with their content.
+// Animations are not allowed, as they can set attributes to unsafe values.
+var allowedSVGElements = toSet(
+	"svg", "g", "defs", "title", "desc", "symbol", "use", "a", "image", "switch",
+	"path", "rect", "circle", "ellipse", "line", "polyline", "polygon",
+	"text", "tspan", "textpath",
+	"lineargradient", "radialgradient", "stop", "pattern", "clippath", "mask", "marker",
+	"filter", "feblend", "fecolormatrix", "fecomponenttransfer", "fecomposite", "feconvolvematrix",
+	"fediffuselighting", "fedisplacementmap", "fedistantlight", "fedropshadow", "feflood",
+	"fefunca", "fefuncb", "fefuncg", "fefuncr", "fegaussianblur", "feimage", "femerge",
+	"femergenode", "femorphology", "feoffset", "fepointlight", "fespecularlighting",
+	"fespotlight", "fetile", "feturbulence",
+)
+
+// Attributes kept in SVG elements.
+var allowedSVGAttributes = toSet(
+	"id", "class", "style", "lang", "xml:space", "xml:lang", "version", "baseprofile",
+	"viewbox", "preserveaspectratio", "width", "height", "x", "y", "x1", "y1", "x2", "y2",
+	"cx", "cy", "r", "rx", "ry", "fx", "fy", "fr", "d", "points", "pathlength", "transform",
+	"href", "xlink:href", "xlink:title",
+	"fill", "fill-opacity", "fill-rule", "stroke", "stroke-width", "stroke-linecap", "stroke-linejoin",
+	"stroke-miterlimit", "stroke-dasharray", "stroke-dashoffset", "stroke-opacity", "opacity",
+	"color", "display", "visibility", "overflow", "vector-effect", "shape-rendering", "paint-order",
+	"clip-path", "clip-rule", "clippathunits", "mask", "maskunits", "maskcontentunits",
+	"marker-start", "marker-mid", "marker-end", "markerwidth", "markerheight", "markerunits",
+	"refx", "refy", "orient", "offset", "stop-color", "stop-opacity",
+	"gradientunits", "gradienttransform", "spreadmethod",
+	"patternunits", "patterncontentunits", "patterntransform",
+	"font-family", "font-size", "font-weight", "font-style", "font-variant", "text-anchor",
+	"dominant-baseline", "alignment-baseline", "baseline-shift", "letter-spacing", "word-spacing",
+	"text-decoration", "writing-mode", "dx", "dy", "rotate", "textlength", "lengthadjust",
+	"startoffset", "method", "spacing",
+	"filter", "filterunits", "primitiveunits", "in", "in2", "result", "stddeviation", "mode",
+	"operator", "k1", "k2", "k3", "k4", "type", "values", "tablevalues", "slope", "intercept",
+	"amplitude", "exponent", "scale", "xchannelselector", "ychannelselector", "basefrequency",
+	"numoctaves", "seed", "stitchtiles", "radius", "order", "kernelmatrix", "divisor", "bias",
+	"targetx", "targety", "edgemode", "preservealpha", "surfacescale", "diffuseconstant",
+	"specularconstant", "specularexponent", "kernelunitlength", "azimuth", "elevation",
+	"z", "pointsatx", "pointsaty", "pointsatz", "limitingconeangle",
+	"flood-color", "flood-opacity", "lighting-color", "color-interpolation-filters",
+)
+
+// Links allowed in href attributes: local fragments and embedded raster images.
+var safeSVGLink = regexp.MustCompile(`^(#|data:image/(png|jpeg|gif|webp);)`)
+
+// References in presentation attributes and styles, only local ones are allowed.
+var svgReference = regexp.MustCompile(`url\(\s*['"]?([^'")]*)`)
+
+// Characters browsers ignore inside of URL schemes, ex. "java&#9;script:".
+var ignoredURLCharacters = regexp.MustCompile(`[\x00-\x20\x7f]+`)
+
+// SanitizeSVG keeps only known safe elements and attributes of SVG image, dropping scripts,
+// animations, event handlers, external links and DTDs, so it can't be used for stored XSS when served back to users.
+func SanitizeSVG(content []byte) ([]byte, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	decoder.Strict = false
+
+	var output bytes.Buffer
+	encoder := xml.NewEncoder(&output)
+
+	// Depth of forbidden element being skipped.
+	skipping := 0
+
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if skipping > 0 || !allowedSVGElements[strings.ToLower(t.Name.Local)] {
+				skipping++
+				continue
+			}
+
+			t.Name = flattenName(t.Name)
+			t.Attr = sanitizeSVGAttributes(t.Attr)
+			token = t
+		case xml.EndElement:
+			if skipping > 0 {
+				skipping--
+				continue
+			}
+
+			t.Name = flattenName(t.Name)
+			token = t
+		case xml.ProcInst:
+			// Keep only XML declaration.
+			if skipping > 0 || t.Target != "xml" {
+				continue
+			}
+		case xml.Directive:
+			// DOCTYPE may declare entities expanding into scripts.
+			continue
+		default:
+			if skipping > 0 {
+				continue
+			}
+		}
+
+		if err := encoder.EncodeToken(xml.CopyToken(token)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := encoder.Flush(); err != nil {
+		return nil, err
+	}
+
+	return output.Bytes(), nil
+}
+
+// Keep known attributes with safe values and namespace declarations.
+func sanitizeSVGAttributes(attrs []xml.Attr) []xml.Attr {
+	safe := attrs[:0]
+
+	for _, attr := range attrs {
+		attr.Name = flattenName(attr.Name)
+		name := strings.ToLower(attr.Name.Local)
+
+		if name == "xmlns" || strings.HasPrefix(name, "xmlns:") {
+			safe = append(safe, attr)
+			continue
+		}
+
+		if !allowedSVGAttributes[name] || !safeSVGValue(name, attr.Value) {
+			continue
+		}
+
+		safe = append(safe, attr)
+	}
+
+	return safe
+}
+
+// Check links and references of the attribute value.
+func safeSVGValue(name, value string) bool {
+	normalized := strings.ToLower(ignoredURLCharacters.ReplaceAllString(value, ""))
+
+	if name == "href" || name == "xlink:href" {
+		return safeSVGLink.MatchString(normalized)
+	}
+
+	if strings.Contains(normalized, "javascript:") || strings.Contains(normalized, "expression(") || strings.Contains(normalized, "@import") {
+		return false
+	}
+
+	for _, reference := range svgReference.FindAllStringSubmatch(normalized, -1) {
+		if !strings.HasPrefix(reference[1], "#") {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Name of the first element of XML document, lower cased.
+func rootElement(content []byte) string {
+	decoder := xml.NewDecoder(bytes.NewReader(content))
+	decoder.Strict = false
+
+	for {
+		token, err := decoder.RawToken()
+		if err != nil {
+			return ""
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			return strings.ToLower(t.Name.Local)
+		case xml.CharData:
+			if len(bytes.TrimSpace(t)) > 0 {
+				return ""
+			}
+		}
+	}
+}
+
+// Raw tokens keep namespace prefix in Space, which encoder would turn into new namespace.
+// Keep prefixed name as is instead.
+func flattenName(name xml.Name) xml.Name {
+	if name.Space == "" {
+		return name
+	}
+
+	return xml.Name{Local: name.Space + ":" + name.Local}
+}
+
+// Make set of the names.
+func toSet(names ...string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+
+	return set
+}
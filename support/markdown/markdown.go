@@ -0,0 +1,82 @@
+package markdown
+
+import (
+	"bytes"
+	"html/template"
+
+	"github.com/lara-go/larago/support/sanitize"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer/html"
+)
+
+// Renderer converts CommonMark with GFM tables and strikethrough into html.
+type Renderer struct {
+	markdown goldmark.Markdown
+	policy   *sanitize.Policy
+}
+
+// NewRenderer constructor. Output is sanitized with the default policy.
+func NewRenderer() *Renderer {
+	return &Renderer{
+		markdown: goldmark.New(
+			goldmark.WithExtensions(extension.Table, extension.Strikethrough),
+			// Raw html is passed to the sanitizer instead of being dropped.
+			goldmark.WithRendererOptions(html.WithUnsafe()),
+		),
+		policy: sanitize.Default,
+	}
+}
+
+// WithPolicy sets sanitize policy. Pass nil to render trusted content as is.
+func (r *Renderer) WithPolicy(policy *sanitize.Policy) *Renderer {
+	r.policy = policy
+
+	return r
+}
+
+// Render markdown into html.
+func (r *Renderer) Render(source string) (string, error) {
+	var output bytes.Buffer
+
+	if err := r.markdown.Convert([]byte(source), &output); err != nil {
+		return "", err
+	}
+
+	if r.policy == nil {
+		return output.String(), nil
+	}
+
+	return r.policy.Sanitize(output.String()), nil
+}
+
+// RenderHTML renders markdown into html safe to use in templates.
+// Errors are rendered as escaped source.
+func (r *Renderer) RenderHTML(source string) template.HTML {
+	rendered, err := r.Render(source)
+	if err != nil {
+		return template.HTML(template.HTMLEscapeString(source))
+	}
+
+	return template.HTML(rendered)
+}
+
+// FuncMap returns template functions: {{ markdown .Body }}.
+func (r *Renderer) FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"markdown": r.RenderHTML,
+	}
+}
+
+// Default renderer used by package functions.
+var Default = NewRenderer()
+
+// Render markdown with default renderer.
+func Render(source string) (string, error) {
+	return Default.Render(source)
+}
+
+// FuncMap returns template functions of the default renderer.
+func FuncMap() template.FuncMap {
+	return Default.FuncMap()
+}
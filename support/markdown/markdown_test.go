@@ -0,0 +1,31 @@
+package markdown_test
+
+import (
+	"testing"
+
+	"github.com/lara-go/larago/support/markdown"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	rendered, err := markdown.Render("# Title\n\n**bold** ~~old~~\n\n| a | b |\n|---|---|\n| 1 | 2 |\n")
+
+	assert.Nil(t, err)
+	assert.Contains(t, rendered, "<h1>Title</h1>")
+	assert.Contains(t, rendered, "<strong>bold</strong> <del>old</del>")
+	assert.Contains(t, rendered, "<td>1</td>")
+}
+
+func TestRenderSanitizes(t *testing.T) {
+	rendered, _ := markdown.Render("[link](javascript:alert(1)) <script>alert(2)</script><b onclick=\"x\">b</b>")
+
+	assert.NotContains(t, rendered, "alert")
+	assert.NotContains(t, rendered, "onclick")
+	assert.Contains(t, rendered, "<b>b</b>")
+}
+
+func TestRenderTrusted(t *testing.T) {
+	rendered, _ := markdown.NewRenderer().WithPolicy(nil).Render(`<span class="note">note</span>`)
+
+	assert.Contains(t, rendered, `<span class="note">note</span>`)
+}
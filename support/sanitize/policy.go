@@ -0,0 +1,299 @@
+package sanitize
+
+import (
+	"bytes"
+	"html"
+	"html/template"
+	"io"
+	"net/url"
+	"strings"
+
+	net_html "golang.org/x/net/html"
+)
+
+// Elements removed together with their content.
+var dropContent = map[string]bool{
+	"script":   true,
+	"style":    true,
+	"iframe":   true,
+	"object":   true,
+	"embed":    true,
+	"noscript": true,
+	"template": true,
+	"textarea": true,
+	"title":    true,
+	"svg":      true,
+	"math":     true,
+}
+
+// Elements without closing tag.
+var voidElements = map[string]bool{
+	"br":  true,
+	"hr":  true,
+	"img": true,
+	"wbr": true,
+	"col": true,
+}
+
+// Attributes containing urls.
+var urlAttributes = map[string]bool{
+	"href":       true,
+	"src":        true,
+	"cite":       true,
+	"action":     true,
+	"formaction": true,
+	"poster":     true,
+	"background": true,
+	"longdesc":   true,
+}
+
+// Policy is an allowlist of elements, attributes and url schemes.
+// Everything not allowed explicitly is removed.
+type Policy struct {
+	elements map[string]map[string]bool
+	global   map[string]bool
+	schemes  map[string]bool
+	nofollow bool
+}
+
+// NewPolicy creates empty policy which strips all tags.
+func NewPolicy() *Policy {
+	return &Policy{
+		elements: make(map[string]map[string]bool),
+		global:   make(map[string]bool),
+		schemes:  make(map[string]bool),
+	}
+}
+
+// AllowElements allows elements without attributes.
+func (p *Policy) AllowElements(elements ...string) *Policy {
+	for _, element := range elements {
+		element = strings.ToLower(element)
+		if p.elements[element] == nil {
+			p.elements[element] = make(map[string]bool)
+		}
+	}
+
+	return p
+}
+
+// AllowAttributes allows attributes on the element, allowing element itself.
+func (p *Policy) AllowAttributes(element string, attributes ...string) *Policy {
+	p.AllowElements(element)
+
+	for _, attribute := range attributes {
+		p.elements[strings.ToLower(element)][strings.ToLower(attribute)] = true
+	}
+
+	return p
+}
+
+// AllowGlobalAttributes allows attributes on all allowed elements.
+func (p *Policy) AllowGlobalAttributes(attributes ...string) *Policy {
+	for _, attribute := range attributes {
+		p.global[strings.ToLower(attribute)] = true
+	}
+
+	return p
+}
+
+// AllowURLSchemes allows absolute urls with these schemes. Relative urls are always allowed.
+func (p *Policy) AllowURLSchemes(schemes ...string) *Policy {
+	for _, scheme := range schemes {
+		p.schemes[strings.ToLower(scheme)] = true
+	}
+
+	return p
+}
+
+// RequireNoFollow adds rel="nofollow noopener" to all links.
+func (p *Policy) RequireNoFollow() *Policy {
+	p.nofollow = true
+
+	return p
+}
+
+// Sanitize html.
+func (p *Policy) Sanitize(input string) string {
+	output, _ := p.sanitize(input)
+
+	return output
+}
+
+// SanitizeHTML returns sanitized html safe to use in templates.
+func (p *Policy) SanitizeHTML(input string) template.HTML {
+	return template.HTML(p.Sanitize(input))
+}
+
+// IsSafe checks that nothing has to be removed from html.
+func (p *Policy) IsSafe(input string) bool {
+	_, removed := p.sanitize(input)
+
+	return !removed
+}
+
+// FuncMap returns template functions: {{ sanitize .Body }}.
+func (p *Policy) FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"sanitize": p.SanitizeHTML,
+	}
+}
+
+// Sanitize html and report whether something was removed.
+func (p *Policy) sanitize(input string) (string, bool) {
+	var output bytes.Buffer
+	var open []string
+
+	removed := false
+	skipping, skipDepth := "", 0
+
+	tokenizer := net_html.NewTokenizer(strings.NewReader(input))
+
+	for {
+		tokenType := tokenizer.Next()
+		if tokenType == net_html.ErrorToken {
+			if tokenizer.Err() != io.EOF {
+				removed = true
+			}
+			break
+		}
+
+		token := tokenizer.Token()
+
+		// Skip content of the dropped element.
+		if skipping != "" {
+			switch {
+			case token.Data != skipping:
+			case tokenType == net_html.StartTagToken:
+				skipDepth++
+			case tokenType == net_html.EndTagToken:
+				skipDepth--
+			}
+
+			if skipDepth == 0 {
+				skipping = ""
+			}
+
+			continue
+		}
+
+		switch tokenType {
+		case net_html.TextToken:
+			output.WriteString(html.EscapeString(token.Data))
+
+		case net_html.StartTagToken, net_html.SelfClosingTagToken:
+			if dropContent[token.Data] {
+				removed = true
+				if tokenType == net_html.StartTagToken {
+					skipping, skipDepth = token.Data, 1
+				}
+				continue
+			}
+
+			if !p.allowed(token.Data) {
+				removed = true
+				continue
+			}
+
+			output.WriteString("<" + token.Data)
+			if !p.writeAttributes(&output, token) {
+				removed = true
+			}
+			output.WriteString(">")
+
+			if tokenType == net_html.StartTagToken && !voidElements[token.Data] {
+				open = append(open, token.Data)
+			}
+
+		case net_html.EndTagToken:
+			if !p.allowed(token.Data) {
+				removed = true
+				continue
+			}
+
+			// Close only opened elements, closing nested ones on the way.
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == token.Data {
+					for j := len(open) - 1; j >= i; j-- {
+						output.WriteString("</" + open[j] + ">")
+					}
+					open = open[:i]
+					break
+				}
+			}
+
+		default:
+			// Comments and doctypes.
+			removed = true
+		}
+	}
+
+	// Close elements left open.
+	for i := len(open) - 1; i >= 0; i-- {
+		output.WriteString("</" + open[i] + ">")
+	}
+
+	return output.String(), removed
+}
+
+// Check if element is allowed.
+func (p *Policy) allowed(element string) bool {
+	_, ok := p.elements[element]
+
+	return ok
+}
+
+// Write allowed attributes and report whether all of them were allowed.
+func (p *Policy) writeAttributes(output *bytes.Buffer, token net_html.Token) bool {
+	clean := true
+	link := false
+
+	for _, attr := range token.Attr {
+		name := strings.ToLower(attr.Key)
+
+		if attr.Namespace != "" || !(p.global[name] || p.elements[token.Data][name]) {
+			clean = false
+			continue
+		}
+
+		if urlAttributes[name] {
+			if !p.safeURL(attr.Val) {
+				clean = false
+				continue
+			}
+
+			link = link || name == "href"
+		}
+
+		// Rel is replaced for links when nofollow is required.
+		if p.nofollow && token.Data == "a" && name == "rel" {
+			continue
+		}
+
+		output.WriteString(" " + name + `="` + html.EscapeString(attr.Val) + `"`)
+	}
+
+	if p.nofollow && token.Data == "a" && link {
+		output.WriteString(` rel="nofollow noopener"`)
+	}
+
+	return clean
+}
+
+// Check url scheme.
+func (p *Policy) safeURL(value string) bool {
+	// Browsers ignore whitespace and control characters inside schemes, ex. "java\tscript:".
+	cleaned := strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, value)
+
+	parsed, err := url.Parse(cleaned)
+	if err != nil {
+		return false
+	}
+
+	return parsed.Scheme == "" || p.schemes[strings.ToLower(parsed.Scheme)]
+}
@@ -0,0 +1,55 @@
+package sanitize
+
+import (
+	"html"
+	"html/template"
+)
+
+// StrictPolicy strips all html, leaving escaped text only.
+func StrictPolicy() *Policy {
+	return NewPolicy()
+}
+
+// UGCPolicy allows formatting, links, images and tables usual for user generated content.
+func UGCPolicy() *Policy {
+	return NewPolicy().
+		AllowElements(
+			"p", "br", "hr", "div", "span",
+			"b", "strong", "i", "em", "u", "s", "del", "ins", "mark", "small", "sub", "sup",
+			"h1", "h2", "h3", "h4", "h5", "h6",
+			"ul", "ol", "li", "dl", "dt", "dd",
+			"blockquote", "pre", "code", "kbd", "abbr",
+			"table", "thead", "tbody", "tfoot", "tr", "th", "td", "caption",
+			"figure", "figcaption",
+		).
+		AllowAttributes("a", "href", "title").
+		AllowAttributes("img", "src", "alt", "title", "width", "height").
+		AllowAttributes("blockquote", "cite").
+		AllowAttributes("abbr", "title").
+		AllowAttributes("th", "colspan", "rowspan").
+		AllowAttributes("td", "colspan", "rowspan").
+		AllowAttributes("ol", "start").
+		AllowURLSchemes("http", "https", "mailto").
+		RequireNoFollow()
+}
+
+// Default policy used by package functions.
+var Default = UGCPolicy()
+
+// HTML sanitizes user generated html with default policy.
+func HTML(input string) string {
+	return Default.Sanitize(input)
+}
+
+// Strip removes all html tags and returns plain text, escape it when writing as html.
+func Strip(input string) string {
+	return html.UnescapeString(StrictPolicy().Sanitize(input))
+}
+
+// FuncMap returns template functions: {{ sanitize .Body }} and {{ strip_tags .Body }}.
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"sanitize":   Default.SanitizeHTML,
+		"strip_tags": Strip,
+	}
+}
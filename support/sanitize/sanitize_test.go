@@ -0,0 +1,51 @@
+package sanitize_test
+
+import (
+	"bytes"
+	"html/template"
+	"testing"
+
+	"github.com/lara-go/larago/support/sanitize"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTML(t *testing.T) {
+	cases := map[string]string{
+		`<p>Hello <b>world</b></p>`:                          `<p>Hello <b>world</b></p>`,
+		`<p onclick="alert(1)">text</p>`:                     `<p>text</p>`,
+		`<script>alert(1)</script>safe`:                      `safe`,
+		`<a href="javascript:alert(1)">link</a>`:             `<a>link</a>`,
+		`<a href="java&#09;script:alert(1)">link</a>`:        `<a>link</a>`,
+		`<a href="https://example.com" rel="me">link</a>`:    `<a href="https://example.com" rel="nofollow noopener">link</a>`,
+		`<img src="/logo.png" onerror="alert(1)">`:           `<img src="/logo.png">`,
+		`<unknown>text</unknown>`:                            `text`,
+		`<p><b>unclosed`:                                     `<p><b>unclosed</b></p>`,
+		`</b>stray`:                                          `stray`,
+		`<!-- comment -->1 &lt; 2`:                           `1 &lt; 2`,
+		`<div><style>body{}</style><svg><g></g></svg></div>`: `<div></div>`,
+	}
+
+	for input, expected := range cases {
+		assert.Equal(t, expected, sanitize.HTML(input), input)
+	}
+}
+
+func TestStrip(t *testing.T) {
+	assert.Equal(t, "Hello world & all 1 < 2", sanitize.Strip(`<h1>Hello <i>world</i></h1> & all<script>x</script> 1 &lt; 2`))
+}
+
+func TestStripTagsAreEscapedOnce(t *testing.T) {
+	tmpl := template.Must(template.New("").Funcs(sanitize.FuncMap()).Parse(`{{ strip_tags . }}`))
+
+	var output bytes.Buffer
+	assert.NoError(t, tmpl.Execute(&output, `<b>Tom & Jerry</b> <script>x</script>`))
+	assert.Equal(t, "Tom &amp; Jerry ", output.String())
+}
+
+func TestIsSafe(t *testing.T) {
+	policy := sanitize.NewPolicy().AllowAttributes("a", "href").AllowURLSchemes("https")
+
+	assert.True(t, policy.IsSafe(`<a href="https://example.com">link</a>`))
+	assert.False(t, policy.IsSafe(`<a href="http://example.com">link</a>`))
+	assert.False(t, policy.IsSafe(`<b>bold</b>`))
+}
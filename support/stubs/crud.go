@@ -0,0 +1,299 @@
+package stubs
+
+// CrudControllerStub template.
+const CrudControllerStub = `
+package admin
+
+import (
+	"strconv"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago/database"
+	"github.com/lara-go/larago/http"
+	"github.com/lara-go/larago/http/responses"
+	"github.com/lara-go/larago/view"
+
+	requests "{{.Module}}/app/http/requests/admin"
+	"{{.Module}}/app/models"
+)
+
+// {{.Plural}}PerPage is the size of the {{.PluralSnake}} list page.
+const {{.Plural}}PerPage = 20
+
+// {{.Plural}}Controller handles admin CRUD of {{.PluralSnake}}.
+type {{.Plural}}Controller struct {
+	DB *gorm.DB
+}
+
+// Index lists {{.PluralSnake}} with pagination and search.
+func (c *{{.Plural}}Controller) Index(request *http.Request) (responses.Response, error) {
+	page, _ := strconv.Atoi(request.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+
+	query := c.DB.Model(&models.{{.Name}}{})
+
+	search := request.Query().Get("q")
+	if search != "" {
+		query = query.Where("{{.SearchCondition}}"{{range .SearchColumns}}, "%"+search+"%"{{end}})
+	}
+
+	var total int
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	var items []models.{{.Name}}
+	err := query.Order("id DESC").Offset((page - 1) * {{.Plural}}PerPage).Limit({{.Plural}}PerPage).Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	lastPage := (total + {{.Plural}}PerPage - 1) / {{.Plural}}PerPage
+	if lastPage < 1 {
+		lastPage = 1
+	}
+
+	return view.Facade().Response(200, "admin/{{.PluralSnake}}/index", map[string]interface{}{
+		"Items":    items,
+		"Search":   search,
+		"Total":    total,
+		"Page":     page,
+		"PrevPage": page - 1,
+		"NextPage": page + 1,
+		"LastPage": lastPage,
+	})
+}
+
+// Create shows the form of the new {{.Snake}}.
+func (c *{{.Plural}}Controller) Create() (responses.Response, error) {
+	return view.Facade().Response(200, "admin/{{.PluralSnake}}/form", map[string]interface{}{
+		"Item":   models.{{.Name}}{},
+		"Action": "/admin/{{.PluralSnake}}",
+	})
+}
+
+// Store new {{.Snake}}.
+func (c *{{.Plural}}Controller) Store(form *requests.{{.Name}}Form) (responses.Response, error) {
+	var item models.{{.Name}}
+	form.Fill(&item)
+
+	if err := c.DB.Create(&item).Error; err != nil {
+		return nil, err
+	}
+
+	return responses.NewRedirect(302).To("/admin/{{.PluralSnake}}"), nil
+}
+
+// Edit shows the form of existing {{.Snake}}.
+func (c *{{.Plural}}Controller) Edit(id string) (responses.Response, error) {
+	item, err := c.find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return view.Facade().Response(200, "admin/{{.PluralSnake}}/form", map[string]interface{}{
+		"Item":   item,
+		"Action": "/admin/{{.PluralSnake}}/update/" + id,
+	})
+}
+
+// Update existing {{.Snake}}.
+func (c *{{.Plural}}Controller) Update(id string, form *requests.{{.Name}}Form) (responses.Response, error) {
+	item, err := c.find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	form.Fill(item)
+
+	if err := c.DB.Save(item).Error; err != nil {
+		return nil, err
+	}
+
+	return responses.NewRedirect(302).To("/admin/{{.PluralSnake}}"), nil
+}
+
+// Destroy {{.Snake}}.
+func (c *{{.Plural}}Controller) Destroy(id string) (responses.Response, error) {
+	item, err := c.find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.DB.Delete(item).Error; err != nil {
+		return nil, err
+	}
+
+	return responses.NewRedirect(302).To("/admin/{{.PluralSnake}}"), nil
+}
+
+// Find {{.Snake}} or fail with 404.
+// ID comes from the URL, so it is parsed and bound, never put into SQL as is.
+func (c *{{.Plural}}Controller) find(id string) (*models.{{.Name}}, error) {
+	var item models.{{.Name}}
+
+	key, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return nil, database.ModelNotFound(&item, []interface{}{id})
+	}
+
+	err = c.DB.Where("id = ?", key).First(&item).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, database.ModelNotFound(&item, []interface{}{id})
+	}
+
+	return &item, err
+}
+`
+
+// CrudRequestStub template.
+const CrudRequestStub = `
+package admin
+
+import (
+	ozzo "github.com/go-ozzo/ozzo-validation"
+
+	"{{.Module}}/app/models"
+)
+
+// {{.Name}}Form is submitted to create or update {{.Snake}}.
+type {{.Name}}Form struct {
+{{- range .Fields}}
+	{{.Name}} {{.GoType}} ` + "`schema:\"{{.Column}}\"`" + `
+{{- end}}
+}
+
+// ValidateForm data.
+func (r *{{.Name}}Form) ValidateForm() error {
+	return r.Validate()
+}
+
+// Validate me.
+func (r *{{.Name}}Form) Validate() error {
+	return ozzo.ValidateStruct(r,
+{{- range .Fields}}{{if .Required}}
+		ozzo.Field(&r.{{.Name}}, ozzo.Required),
+{{- end}}{{end}}
+	)
+}
+
+// Fill model with form data.
+func (r *{{.Name}}Form) Fill(item *models.{{.Name}}) {
+{{- range .Fields}}
+	item.{{.Name}} = r.{{.Name}}
+{{- end}}
+}
+`
+
+// CrudRoutesStub template.
+const CrudRoutesStub = `
+package routes
+
+import (
+	"github.com/lara-go/larago/http"
+
+	"{{.Module}}/app/http/controllers/admin"
+	requests "{{.Module}}/app/http/requests/admin"
+)
+
+// Admin{{.Plural}}Routes registers admin CRUD of {{.PluralSnake}}.
+// Pass authentication middleware to protect them.
+func Admin{{.Plural}}Routes(router *http.Router, middleware ...http.Middleware) {
+	controller := &admin.{{.Plural}}Controller{}
+	router.Container.Make(controller)
+
+	router.Group("/admin/{{.PluralSnake}}", func() {
+		router.GET("/").As("admin.{{.PluralSnake}}.index").Action(controller.Index)
+		router.GET("/create").As("admin.{{.PluralSnake}}.create").Action(controller.Create)
+		router.POST("/").As("admin.{{.PluralSnake}}.store").Action(controller.Store).Validate(&requests.{{.Name}}Form{})
+		router.GET("/edit/:id").As("admin.{{.PluralSnake}}.edit").Action(controller.Edit)
+		router.POST("/update/:id").As("admin.{{.PluralSnake}}.update").Action(controller.Update).Validate(&requests.{{.Name}}Form{})
+		router.POST("/delete/:id").As("admin.{{.PluralSnake}}.destroy").Action(controller.Destroy)
+	}, middleware...)
+}
+`
+
+// CrudIndexViewStub template. Uses [[ ]] delimiters to keep {{ }} for the view.
+const CrudIndexViewStub = `<!DOCTYPE html>
+<html>
+<head>
+	<title>[[.Plural]]</title>
+</head>
+<body>
+	<h1>[[.Plural]] <small>({{.Total}})</small></h1>
+
+	<form method="GET" action="/admin/[[.PluralSnake]]">
+		<input type="search" name="q" value="{{.Search}}" placeholder="Search">
+		<button type="submit">Search</button>
+		<a href="/admin/[[.PluralSnake]]/create">New [[.Snake]]</a>
+	</form>
+
+	<table>
+		<thead>
+			<tr>
+				<th>ID</th>
+[[- range .Fields]]
+				<th>[[.Label]]</th>
+[[- end]]
+				<th></th>
+			</tr>
+		</thead>
+		<tbody>
+		{{range .Items}}
+			<tr>
+				<td>{{.ID}}</td>
+[[- range .Fields]]
+				<td>{{.[[.Name]]}}</td>
+[[- end]]
+				<td>
+					<a href="/admin/[[.PluralSnake]]/edit/{{.ID}}">Edit</a>
+					<form method="POST" action="/admin/[[.PluralSnake]]/delete/{{.ID}}" style="display:inline">
+						<button type="submit" onclick="return confirm('Delete?')">Delete</button>
+					</form>
+				</td>
+			</tr>
+		{{else}}
+			<tr><td colspan="[[.Columns]]">Nothing found.</td></tr>
+		{{end}}
+		</tbody>
+	</table>
+
+	<p>
+		Page {{.Page}} of {{.LastPage}}
+		{{if gt .Page 1}}<a href="?q={{.Search}}&page={{.PrevPage}}">Previous</a>{{end}}
+		{{if lt .Page .LastPage}}<a href="?q={{.Search}}&page={{.NextPage}}">Next</a>{{end}}
+	</p>
+</body>
+</html>
+`
+
+// CrudFormViewStub template. Uses [[ ]] delimiters to keep {{ }} for the view.
+const CrudFormViewStub = `<!DOCTYPE html>
+<html>
+<head>
+	<title>[[.Name]]</title>
+</head>
+<body>
+	<h1>{{if .Item.ID}}Edit [[.Snake]] #{{.Item.ID}}{{else}}New [[.Snake]]{{end}}</h1>
+
+	<form method="POST" action="{{.Action}}">
+[[- range .Fields]]
+		<p>
+			<label for="[[.Column]]">[[.Label]]</label>
+[[- if eq .Input "textarea"]]
+			<textarea id="[[.Column]]" name="[[.Column]]">{{.Item.[[.Name]]}}</textarea>
+[[- else if eq .Input "checkbox"]]
+			<input id="[[.Column]]" type="checkbox" name="[[.Column]]" value="true" {{if .Item.[[.Name]]}}checked{{end}}>
+[[- else]]
+			<input id="[[.Column]]" type="[[.Input]]" name="[[.Column]]" value="{{.Item.[[.Name]]}}">
+[[- end]]
+		</p>
+[[- end]]
+		<button type="submit">Save</button>
+		<a href="/admin/[[.PluralSnake]]">Cancel</a>
+	</form>
+</body>
+</html>
+`
@@ -0,0 +1,231 @@
+package testsuite
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/lara-go/larago"
+	larago_cli "github.com/lara-go/larago/cli"
+	"github.com/lara-go/larago/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli"
+)
+
+// Console runs registered commands in-process capturing their output.
+// Standard streams are replaced while command runs, so do not use it in parallel tests.
+type Console struct {
+	application *larago.Application
+	t           *testing.T
+	answers     []string
+}
+
+// NewConsole constructor.
+func NewConsole(application *larago.Application, t *testing.T) *Console {
+	return &Console{
+		application: application,
+		t:           t,
+	}
+}
+
+// WithAnswers feeds answers to the interactive questions, one per line.
+func (c *Console) WithAnswers(answers ...string) *Console {
+	c.answers = append(c.answers, answers...)
+
+	return c
+}
+
+// Run command with arguments, ex. Run("migrate", "--pretend").
+func (c *Console) Run(args ...string) *ConsoleResult {
+	result := &ConsoleResult{t: c.t}
+
+	stdin, err := c.feedStdin()
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	defer stdin()
+
+	stdout, err := capture(&os.Stdout)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+
+	stderr, err := capture(&os.Stderr)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+
+	// Logger writes to the stream it was made with.
+	var log *logger.Logger
+	if c.application.Bound("logger") {
+		log = c.application.Get("logger").(*logger.Logger)
+		log.SetOutput(os.Stdout)
+	}
+
+	result.Err = c.run(args)
+
+	if log != nil {
+		log.SetOutput(stdout.original)
+	}
+
+	result.Stdout = stdout.restore()
+	result.Stderr = stderr.restore()
+	result.ExitCode = exitCode(result.Err)
+
+	return result
+}
+
+// Run command catching errors, as kernel panics with them.
+func (c *Console) run(args []string) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			if e, ok := recovered.(error); ok {
+				err = e
+			} else {
+				err = fmt.Errorf("%v", recovered)
+			}
+		}
+	}()
+
+	kernel := &larago_cli.Kernel{Application: c.application}
+
+	return kernel.App().Run(append([]string{c.application.Name}, args...))
+}
+
+// Replace standard input with answers.
+func (c *Console) feedStdin() (func(), error) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		for _, answer := range c.answers {
+			fmt.Fprintln(writer, answer)
+		}
+
+		writer.Close()
+	}()
+
+	original := os.Stdin
+	os.Stdin = reader
+
+	return func() {
+		os.Stdin = original
+		reader.Close()
+	}, nil
+}
+
+// Captured standard stream.
+type capturedStream struct {
+	target   **os.File
+	original *os.File
+	writer   *os.File
+	output   chan string
+}
+
+// Replace stream with pipe collecting everything written.
+func capture(target **os.File) (*capturedStream, error) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+
+	stream := &capturedStream{
+		target:   target,
+		original: *target,
+		writer:   writer,
+		output:   make(chan string),
+	}
+
+	go func() {
+		var buffer bytes.Buffer
+		io.Copy(&buffer, reader)
+		reader.Close()
+
+		stream.output <- buffer.String()
+	}()
+
+	*target = writer
+
+	return stream, nil
+}
+
+// Restore original stream and return captured output.
+func (s *capturedStream) restore() string {
+	*s.target = s.original
+	s.writer.Close()
+
+	return <-s.output
+}
+
+// Exit code the command would exit with.
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	if coder, ok := err.(cli.ExitCoder); ok {
+		return coder.ExitCode()
+	}
+
+	return 1
+}
+
+// ConsoleResult of the command run.
+type ConsoleResult struct {
+	t *testing.T
+
+	ExitCode int
+	Stdout   string
+	Stderr   string
+	Err      error
+}
+
+// AssertExitCode checks exit code.
+func (r *ConsoleResult) AssertExitCode(code int) *ConsoleResult {
+	assert.Equal(r.t, code, r.ExitCode, "Unexpected exit code, error: %v", r.Err)
+
+	return r
+}
+
+// AssertSuccessful checks if command succeeded.
+func (r *ConsoleResult) AssertSuccessful() *ConsoleResult {
+	return r.AssertExitCode(0)
+}
+
+// AssertFailed checks if command failed.
+func (r *ConsoleResult) AssertFailed() *ConsoleResult {
+	assert.NotEqual(r.t, 0, r.ExitCode, "Command did not fail")
+
+	return r
+}
+
+// AssertOutputContains checks standard output.
+func (r *ConsoleResult) AssertOutputContains(substring string) *ConsoleResult {
+	assert.Contains(r.t, r.Stdout, substring)
+
+	return r
+}
+
+// AssertOutputNotContains checks standard output.
+func (r *ConsoleResult) AssertOutputNotContains(substring string) *ConsoleResult {
+	assert.NotContains(r.t, r.Stdout, substring)
+
+	return r
+}
+
+// AssertErrorContains checks error output and returned error.
+func (r *ConsoleResult) AssertErrorContains(substring string) *ConsoleResult {
+	message := r.Stderr
+	if r.Err != nil {
+		message += r.Err.Error()
+	}
+
+	assert.True(r.t, strings.Contains(message, substring), "Error output %q does not contain %q", message, substring)
+
+	return r
+}
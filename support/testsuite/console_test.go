@@ -0,0 +1,66 @@
+package testsuite_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lara-go/larago"
+	larago_cli "github.com/lara-go/larago/cli"
+	"github.com/lara-go/larago/support/testsuite"
+	"github.com/urfave/cli"
+)
+
+type greetCommand struct{}
+
+func (c *greetCommand) GetCommand() cli.Command {
+	return cli.Command{Name: "greet"}
+}
+
+func (c *greetCommand) Handle(args cli.Args) error {
+	name := larago_cli.Ask("What is your name?")
+	if !larago_cli.Confirm("Shout?") {
+		fmt.Printf("Hello, %s\n", name)
+		return nil
+	}
+
+	fmt.Printf("HELLO, %s!\n", name)
+
+	return nil
+}
+
+type failCommand struct{}
+
+func (c *failCommand) GetCommand() cli.Command {
+	return cli.Command{Name: "fail"}
+}
+
+func (c *failCommand) Handle(args cli.Args) error {
+	return errors.New("Something went wrong")
+}
+
+func TestConsole(t *testing.T) {
+	application := larago.New()
+	application.Name = "test"
+	application.Commands(&greetCommand{}, &failCommand{})
+
+	testsuite.NewConsole(application, t).
+		WithAnswers("Gopher", "yes").
+		Run("greet").
+		AssertSuccessful().
+		AssertOutputContains("What is your name?").
+		AssertOutputContains("HELLO, Gopher!")
+
+	testsuite.NewConsole(application, t).
+		WithAnswers("Gopher").
+		Run("greet").
+		AssertSuccessful().
+		AssertOutputContains("Hello, Gopher").
+		AssertOutputNotContains("HELLO")
+
+	testsuite.NewConsole(application, t).
+		Run("fail").
+		AssertFailed().
+		AssertExitCode(1).
+		AssertErrorContains("Something went wrong")
+}
@@ -0,0 +1,349 @@
+package testsuite
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-gormigrate/gormigrate"
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago/database"
+)
+
+// DSN key of Postgres database name.
+var postgresDBName = regexp.MustCompile(`(^|\s)dbname=\S*`)
+
+// TestDatabase is an isolated database made for tests.
+type TestDatabase struct {
+	Name string
+	DSN  string
+	DB   *gorm.DB
+
+	driver string
+	admin  string
+}
+
+// CreateTestDatabase creates new database on the server from the DSN and migrates it.
+// Sqlite databases are created as temporary files.
+func CreateTestDatabase(driver, dsn, name string, migrator *database.Migrator) (*TestDatabase, error) {
+	testDatabase := &TestDatabase{Name: name, driver: driver, admin: dsn}
+
+	if err := testDatabase.create(); err != nil {
+		return nil, err
+	}
+
+	db, err := gorm.Open(driver, testDatabase.DSN)
+	if err != nil {
+		testDatabase.Drop()
+		return nil, err
+	}
+	testDatabase.DB = db
+
+	if migrator != nil {
+		if err := migrator.Migrate(db); err != nil {
+			testDatabase.Drop()
+			return nil, err
+		}
+	}
+
+	return testDatabase, nil
+}
+
+// Create database and make DSN to connect to it.
+func (d *TestDatabase) create() error {
+	if d.driver == "sqlite3" {
+		file, err := ioutil.TempFile("", d.Name+"-*.sqlite")
+		if err != nil {
+			return err
+		}
+		file.Close()
+
+		d.DSN = file.Name()
+
+		return nil
+	}
+
+	dsn, err := replaceDatabaseName(d.driver, d.admin, d.Name)
+	if err != nil {
+		return err
+	}
+	d.DSN = dsn
+
+	return d.exec("CREATE DATABASE " + d.Name)
+}
+
+// Truncate all the tables except migrations, so the database can be reused by the next test.
+func (d *TestDatabase) Truncate() error {
+	tables, err := d.tables()
+	if err != nil {
+		return err
+	}
+
+	// Foreign key checks are switched per session, so all the statements go through one connection.
+	ctx := context.Background()
+	conn, err := database.Pool(d.DB).Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	// MySQL refuses to truncate tables referenced by foreign keys.
+	if d.driver == "mysql" {
+		if _, err := conn.ExecContext(ctx, "SET FOREIGN_KEY_CHECKS = 0"); err != nil {
+			return err
+		}
+		defer conn.ExecContext(ctx, "SET FOREIGN_KEY_CHECKS = 1")
+	}
+
+	for _, table := range tables {
+		if table == gormigrate.DefaultOptions.TableName {
+			continue
+		}
+
+		if _, err := conn.ExecContext(ctx, truncateStatement(d.driver, d.DB.Dialect().Quote(table))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// List tables of the database.
+func (d *TestDatabase) tables() ([]string, error) {
+	var query string
+
+	switch d.driver {
+	case "postgres":
+		query = "SELECT tablename FROM pg_tables WHERE schemaname = current_schema()"
+	case "mysql":
+		query = "SHOW TABLES"
+	default:
+		query = "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'"
+	}
+
+	rows, err := d.DB.Raw(query).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			return nil, err
+		}
+
+		tables = append(tables, table)
+	}
+
+	return tables, rows.Err()
+}
+
+// Drop database.
+func (d *TestDatabase) Drop() error {
+	if d.DB != nil {
+		d.DB.Close()
+	}
+
+	if d.driver == "sqlite3" {
+		return os.Remove(d.DSN)
+	}
+
+	return d.exec("DROP DATABASE IF EXISTS " + d.Name)
+}
+
+// Run statement via connection from the original DSN.
+func (d *TestDatabase) exec(statement string) error {
+	admin, err := gorm.Open(d.driver, d.admin)
+	if err != nil {
+		return err
+	}
+	defer admin.Close()
+
+	return admin.Exec(statement).Error
+}
+
+// TestDatabases gives every parallel test its own migrated database.
+// Databases are reused by the following tests after being truncated.
+//
+//	var databases *testsuite.TestDatabases
+//
+//	func TestMain(m *testing.M) {
+//		databases = testsuite.NewTestDatabases("postgres", os.Getenv("TEST_DATABASE_DSN"), migrator)
+//		code := m.Run()
+//		databases.Close()
+//		os.Exit(code)
+//	}
+type TestDatabases struct {
+	driver   string
+	dsn      string
+	migrator *database.Migrator
+
+	mutex sync.Mutex
+	all   []*TestDatabase
+	free  []*TestDatabase
+
+	// Amount of databases ever created, used in their names.
+	created uint64
+}
+
+// NewTestDatabases constructor.
+func NewTestDatabases(driver, dsn string, migrator *database.Migrator) *TestDatabases {
+	return &TestDatabases{
+		driver:   driver,
+		dsn:      dsn,
+		migrator: migrator,
+	}
+}
+
+// Acquire database for the test. It is released when the test and its subtests complete.
+func (p *TestDatabases) Acquire(t *testing.T) *gorm.DB {
+	testDatabase, err := p.take()
+	if err != nil {
+		t.Fatalf("Could not create test database: %s", err)
+	}
+
+	t.Cleanup(func() {
+		p.release(testDatabase)
+	})
+
+	return testDatabase.DB
+}
+
+// Take free database or create new one.
+func (p *TestDatabases) take() (*TestDatabase, error) {
+	p.mutex.Lock()
+	if count := len(p.free); count > 0 {
+		testDatabase := p.free[count-1]
+		p.free = p.free[:count-1]
+		p.mutex.Unlock()
+
+		return testDatabase, nil
+	}
+
+	p.mutex.Unlock()
+
+	// Process ID keeps names unique between packages tested at the same time.
+	name := fmt.Sprintf("larago_test_%d_%d", os.Getpid(), atomic.AddUint64(&p.created, 1))
+
+	testDatabase, err := CreateTestDatabase(p.driver, p.dsn, name, p.migrator)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mutex.Lock()
+	p.all = append(p.all, testDatabase)
+	p.mutex.Unlock()
+
+	return testDatabase, nil
+}
+
+// Release truncated database to the pool, dropping it if truncation failed.
+func (p *TestDatabases) release(testDatabase *TestDatabase) {
+	if err := testDatabase.Truncate(); err != nil {
+		testDatabase.Drop()
+
+		return
+	}
+
+	p.mutex.Lock()
+	p.free = append(p.free, testDatabase)
+	p.mutex.Unlock()
+}
+
+// Close drops all the created databases.
+func (p *TestDatabases) Close() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var err error
+	for _, testDatabase := range p.all {
+		if e := testDatabase.Drop(); e != nil && err == nil {
+			err = e
+		}
+	}
+
+	p.all = nil
+	p.free = nil
+
+	return err
+}
+
+// MemoryDB opens in-memory sqlite database closed with the test and migrates the models.
+// Test must import sqlite dialect of gorm.
+func MemoryDB(t *testing.T, models ...interface{}) *gorm.DB {
+	db, err := gorm.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("Could not open sqlite database: %s", err)
+	}
+
+	t.Cleanup(func() {
+		db.Close()
+	})
+
+	// Every connection to in-memory sqlite opens a new database, so the pool keeps the only one.
+	db.DB().SetMaxOpenConns(1)
+
+	if err := db.AutoMigrate(models...).Error; err != nil {
+		t.Fatalf("Could not migrate sqlite database: %s", err)
+	}
+
+	return db
+}
+
+// Statement deleting all rows of the table.
+func truncateStatement(driver, table string) string {
+	switch driver {
+	case "postgres":
+		return fmt.Sprintf("TRUNCATE TABLE %s RESTART IDENTITY CASCADE", table)
+	case "mysql":
+		return fmt.Sprintf("TRUNCATE TABLE %s", table)
+	default:
+		return fmt.Sprintf("DELETE FROM %s", table)
+	}
+}
+
+// Replace database name in the DSN.
+func replaceDatabaseName(driver, dsn, name string) (string, error) {
+	switch driver {
+	case "postgres":
+		if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+			u, err := url.Parse(dsn)
+			if err != nil {
+				return "", err
+			}
+			u.Path = "/" + name
+
+			return u.String(), nil
+		}
+
+		if postgresDBName.MatchString(dsn) {
+			return postgresDBName.ReplaceAllString(dsn, "${1}dbname="+name), nil
+		}
+
+		return strings.TrimSpace(dsn + " dbname=" + name), nil
+	case "mysql":
+		// user:password@tcp(host:port)/database?params
+		slash := strings.LastIndex(dsn, "/")
+		if slash < 0 {
+			return "", fmt.Errorf("Invalid MySQL DSN: %s", dsn)
+		}
+
+		params := ""
+		if question := strings.Index(dsn[slash:], "?"); question >= 0 {
+			params = dsn[slash+question:]
+		}
+
+		return dsn[:slash+1] + name + params, nil
+	default:
+		return "", fmt.Errorf("Test databases are not supported for %s", driver)
+	}
+}
@@ -0,0 +1,72 @@
+package testsuite_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago/database"
+	"github.com/lara-go/larago/support/testsuite"
+	"github.com/stretchr/testify/assert"
+
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+)
+
+type note struct {
+	ID   uint `gorm:"primary_key"`
+	Text string
+}
+
+type createNotes struct{}
+
+func (m *createNotes) Migrate(tx *gorm.DB) error {
+	return tx.CreateTable(&note{}).Error
+}
+
+func (m *createNotes) Rollback(tx *gorm.DB) error {
+	return tx.DropTable(&note{}).Error
+}
+
+func TestDatabases(t *testing.T) {
+	migrator := &database.Migrator{}
+	migrator.SetMigrations(&createNotes{})
+
+	databases := testsuite.NewTestDatabases("sqlite3", "", migrator)
+
+	t.Run("group", func(t *testing.T) {
+		for _, name := range []string{"first", "second", "third"} {
+			name := name
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				db := databases.Acquire(t)
+				// Every test starts with the empty migrated database.
+				var count int
+				assert.Nil(t, db.Model(&note{}).Count(&count).Error)
+				assert.Equal(t, 0, count)
+
+				assert.Nil(t, db.Create(&note{Text: name}).Error)
+			})
+		}
+	})
+
+	// Released database is reused.
+	t.Run("reused", func(t *testing.T) {
+		db := databases.Acquire(t)
+
+		var count int
+		db.Model(&note{}).Count(&count)
+		assert.Equal(t, 0, count)
+	})
+
+	assert.Nil(t, databases.Close())
+
+	// Databases of this process are removed.
+	prefix := fmt.Sprintf("larago_test_%d_", os.Getpid())
+	temp, _ := os.ReadDir(os.TempDir())
+	for _, entry := range temp {
+		assert.False(t, strings.HasPrefix(entry.Name(), prefix), entry.Name())
+	}
+}
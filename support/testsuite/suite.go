@@ -48,3 +48,8 @@ func (s *LaragoSuite) HTTP(t *testing.T) *httpexpect.Expect {
 
 	return NewHTTPExpect(router.GetHTTPRouter(), t)
 }
+
+// Console returns runner of console commands.
+func (s *LaragoSuite) Console(t *testing.T) *Console {
+	return NewConsole(s.Application, t)
+}
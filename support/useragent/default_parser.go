@@ -0,0 +1,93 @@
+package useragent
+
+import (
+	"regexp"
+	"strings"
+)
+
+type matcher struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// Order matters: more specific products go first, since most browsers mention others.
+var browsers = []matcher{
+	{"Edge", regexp.MustCompile(`(?:Edge|Edg|EdgA|EdgiOS)/([\d.]+)`)},
+	{"Opera", regexp.MustCompile(`(?:OPR|Opera)/([\d.]+)`)},
+	{"Yandex", regexp.MustCompile(`YaBrowser/([\d.]+)`)},
+	{"Samsung Internet", regexp.MustCompile(`SamsungBrowser/([\d.]+)`)},
+	{"Chrome", regexp.MustCompile(`(?:Chrome|CriOS)/([\d.]+)`)},
+	{"Firefox", regexp.MustCompile(`(?:Firefox|FxiOS)/([\d.]+)`)},
+	{"Safari", regexp.MustCompile(`Version/([\d.]+).*Safari/`)},
+	{"Internet Explorer", regexp.MustCompile(`(?:MSIE |Trident/.*rv:)([\d.]+)`)},
+	{"curl", regexp.MustCompile(`curl/([\d.]+)`)},
+}
+
+var systems = []matcher{
+	{"Windows Phone", regexp.MustCompile(`Windows Phone(?: OS)? ([\d.]+)`)},
+	{"Windows", regexp.MustCompile(`Windows NT ([\d.]+)`)},
+	{"iOS", regexp.MustCompile(`(?:iPhone|iPad|iPod).*? OS ([\d_]+)`)},
+	{"Android", regexp.MustCompile(`Android ([\d.]+)`)},
+	{"macOS", regexp.MustCompile(`Mac OS X ([\d_.]+)`)},
+	{"Chrome OS", regexp.MustCompile(`CrOS \S+ ([\d.]+)`)},
+	{"Linux", regexp.MustCompile(`Linux()`)},
+}
+
+var botPattern = regexp.MustCompile(`(?i)bot|crawl|spider|slurp|facebookexternalhit|mediapartners|headless|lighthouse|preview`)
+
+// DefaultParser classifies user agents with a set of regular expressions.
+type DefaultParser struct{}
+
+// NewDefaultParser constructor.
+func NewDefaultParser() *DefaultParser {
+	return &DefaultParser{}
+}
+
+// Parse User-Agent header.
+func (p *DefaultParser) Parse(header string) *UserAgent {
+	agent := &UserAgent{
+		Raw:    header,
+		Device: DeviceUnknown,
+	}
+
+	if header == "" {
+		return agent
+	}
+
+	agent.Browser, agent.BrowserVersion = match(browsers, header)
+	agent.OS, agent.OSVersion = match(systems, header)
+	agent.OSVersion = strings.Replace(agent.OSVersion, "_", ".", -1)
+	agent.Bot = botPattern.MatchString(header)
+	agent.Device = p.device(agent, header)
+
+	return agent
+}
+
+// Detect device type.
+func (p *DefaultParser) device(agent *UserAgent, header string) string {
+	switch {
+	case agent.Bot:
+		return DeviceBot
+	case strings.Contains(header, "iPad") || strings.Contains(header, "Tablet") ||
+		(agent.OS == "Android" && !strings.Contains(header, "Mobile")):
+		return DeviceTablet
+	case strings.Contains(header, "Mobi") || strings.Contains(header, "iPhone") ||
+		agent.OS == "Windows Phone":
+		return DeviceMobile
+	case agent.OS == "Windows" || agent.OS == "macOS" || agent.OS == "Linux" || agent.OS == "Chrome OS":
+		return DeviceDesktop
+	}
+
+	return DeviceUnknown
+}
+
+// Find first matching product and its version.
+func match(matchers []matcher, header string) (string, string) {
+	for _, m := range matchers {
+		if matches := m.pattern.FindStringSubmatch(header); matches != nil {
+			return m.name, matches[1]
+		}
+	}
+
+	return "", ""
+}
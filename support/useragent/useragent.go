@@ -0,0 +1,59 @@
+package useragent
+
+// Device types.
+const (
+	DeviceDesktop = "desktop"
+	DeviceMobile  = "mobile"
+	DeviceTablet  = "tablet"
+	DeviceBot     = "bot"
+	DeviceUnknown = "unknown"
+)
+
+// UserAgent holds parsed User-Agent header.
+type UserAgent struct {
+	Raw            string
+	Browser        string
+	BrowserVersion string
+	OS             string
+	OSVersion      string
+	Device         string
+	Bot            bool
+}
+
+// IsMobile checks if user agent is a phone.
+func (u *UserAgent) IsMobile() bool {
+	return u.Device == DeviceMobile
+}
+
+// IsTablet checks if user agent is a tablet.
+func (u *UserAgent) IsTablet() bool {
+	return u.Device == DeviceTablet
+}
+
+// IsDesktop checks if user agent is a desktop browser.
+func (u *UserAgent) IsDesktop() bool {
+	return u.Device == DeviceDesktop
+}
+
+// IsBot checks if user agent is a crawler or other robot.
+func (u *UserAgent) IsBot() bool {
+	return u.Bot
+}
+
+// Parser of User-Agent headers.
+type Parser interface {
+	// Parse User-Agent header.
+	Parse(header string) *UserAgent
+}
+
+var parser Parser = NewDefaultParser()
+
+// SetParser replaces parser used by Parse.
+func SetParser(p Parser) {
+	parser = p
+}
+
+// Parse User-Agent header with the current parser.
+func Parse(header string) *UserAgent {
+	return parser.Parse(header)
+}
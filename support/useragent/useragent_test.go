@@ -0,0 +1,71 @@
+package useragent_test
+
+import (
+	"testing"
+
+	"github.com/lara-go/larago/support/useragent"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		header  string
+		browser string
+		os      string
+		device  string
+	}{
+		{
+			"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			"Chrome", "Windows", useragent.DeviceDesktop,
+		},
+		{
+			"Mozilla/5.0 (iPhone; CPU iPhone OS 17_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Mobile/15E148 Safari/604.1",
+			"Safari", "iOS", useragent.DeviceMobile,
+		},
+		{
+			"Mozilla/5.0 (iPad; CPU OS 16_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.6 Mobile/15E148 Safari/604.1",
+			"Safari", "iOS", useragent.DeviceTablet,
+		},
+		{
+			"Mozilla/5.0 (Linux; Android 13; SM-S911B) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/119.0.0.0 Mobile Safari/537.36",
+			"Chrome", "Android", useragent.DeviceMobile,
+		},
+		{
+			"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36 Edg/120.0.0.0",
+			"Edge", "macOS", useragent.DeviceDesktop,
+		},
+		{
+			"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			"", "", useragent.DeviceBot,
+		},
+	}
+
+	for _, c := range cases {
+		agent := useragent.Parse(c.header)
+
+		assert.Equal(t, c.browser, agent.Browser, c.header)
+		assert.Equal(t, c.os, agent.OS, c.header)
+		assert.Equal(t, c.device, agent.Device, c.header)
+	}
+}
+
+func TestParse_Version(t *testing.T) {
+	agent := useragent.Parse("Mozilla/5.0 (iPhone; CPU iPhone OS 17_1 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.1 Mobile/15E148 Safari/604.1")
+
+	assert.Equal(t, "17.1", agent.BrowserVersion)
+	assert.Equal(t, "17.1", agent.OSVersion)
+	assert.True(t, agent.IsMobile())
+}
+
+type staticParser struct{}
+
+func (p staticParser) Parse(header string) *useragent.UserAgent {
+	return &useragent.UserAgent{Raw: header, Device: useragent.DeviceTablet}
+}
+
+func TestSetParser(t *testing.T) {
+	useragent.SetParser(staticParser{})
+	defer useragent.SetParser(useragent.NewDefaultParser())
+
+	assert.True(t, useragent.Parse("anything").IsTablet())
+}
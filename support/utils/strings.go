@@ -1,9 +1,18 @@
 package utils
 
 import (
+	"strings"
 	"unicode"
 )
 
+// Common initialisms kept upper-case in Go names.
+var initialisms = map[string]bool{
+	"API": true, "CPU": true, "CSS": true, "DB": true, "DNS": true, "DSN": true,
+	"HTML": true, "HTTP": true, "HTTPS": true, "ID": true, "IP": true, "JSON": true,
+	"SMTP": true, "SQL": true, "SSH": true, "TCP": true, "TLS": true, "TTL": true,
+	"UDP": true, "UI": true, "URI": true, "URL": true, "UUID": true, "XML": true,
+}
+
 // ToSnake convert the given string to snake case following the Golang format:
 // acronyms are converted to lower-case and preceded by an underscore.
 func ToSnake(in string) string {
@@ -29,3 +38,21 @@ func UcFirst(str string) string {
 
 	return ""
 }
+
+// ToCamel converts snake, kebab or dotted string to the exported Go name: "database_url" becomes "DatabaseURL".
+func ToCamel(in string) string {
+	parts := strings.FieldsFunc(in, func(r rune) bool {
+		return r == '_' || r == '-' || r == '.' || unicode.IsSpace(r)
+	})
+
+	var out strings.Builder
+	for _, part := range parts {
+		if upper := strings.ToUpper(part); initialisms[upper] {
+			out.WriteString(upper)
+		} else {
+			out.WriteString(UcFirst(part))
+		}
+	}
+
+	return out.String()
+}
@@ -0,0 +1,108 @@
+package translation
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/lara-go/larago/logger"
+
+	"github.com/urfave/cli"
+)
+
+// CommandLangExtract adds translation keys used in sources to locale files.
+type CommandLangExtract struct {
+	Logger *logger.Logger
+
+	source    string
+	directory string
+	locales   cli.StringSlice
+	prune     bool
+}
+
+// GetCommand for the cli to register.
+func (c *CommandLangExtract) GetCommand() cli.Command {
+	return cli.Command{
+		Name:      "lang:extract",
+		Usage:     "Add translation keys used in sources to locale files",
+		UsageText: "Scans Go sources and templates for literal translation keys and adds missing ones with empty lines to every locale file.\n",
+		Category:  "Translations",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:        "source, s",
+				Usage:       "directory with sources to scan",
+				Value:       ".",
+				Destination: &c.source,
+			},
+			cli.StringFlag{
+				Name:        "lang, l",
+				Usage:       "directory with locale files",
+				Value:       "lang",
+				Destination: &c.directory,
+			},
+			cli.StringSliceFlag{
+				Name:  "locale",
+				Usage: "locale to create file for, if there is none yet",
+				Value: &c.locales,
+			},
+			cli.BoolFlag{
+				Name:        "prune",
+				Usage:       "remove untranslated keys not used in sources",
+				Destination: &c.prune,
+			},
+		},
+	}
+}
+
+// Handle command.
+func (c *CommandLangExtract) Handle(args cli.Args) error {
+	keys, err := ExtractKeys(os.DirFS(c.source))
+	if err != nil {
+		return err
+	}
+
+	locales, err := ReadLocaleFiles(c.directory)
+	if err != nil {
+		return err
+	}
+
+	for _, locale := range c.locales {
+		if _, ok := locales[locale]; !ok {
+			locales[locale] = make(map[string]string)
+		}
+	}
+
+	if len(locales) == 0 {
+		c.Logger.Warning("No locale files in %s, create one with --locale flag.", c.directory)
+
+		return nil
+	}
+
+	for locale, lines := range locales {
+		var added, removed int
+
+		for key := range keys {
+			if _, ok := lines[key]; !ok {
+				lines[key] = ""
+				added++
+			}
+		}
+
+		// Translated keys may be built at runtime, so they are never removed.
+		if c.prune {
+			for key, line := range lines {
+				if _, ok := keys[key]; !ok && line == "" {
+					delete(lines, key)
+					removed++
+				}
+			}
+		}
+
+		if err := WriteLocaleFile(filepath.Join(c.directory, locale+Extension), lines); err != nil {
+			return err
+		}
+
+		c.Logger.Success("%s: %d keys added, %d removed.", locale, added, removed)
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package translation
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/lara-go/larago/logger"
+
+	"github.com/urfave/cli"
+)
+
+// CommandLangMissing reports untranslated keys of every locale.
+type CommandLangMissing struct {
+	Logger *logger.Logger
+
+	source    string
+	directory string
+	strict    bool
+}
+
+// GetCommand for the cli to register.
+func (c *CommandLangMissing) GetCommand() cli.Command {
+	return cli.Command{
+		Name:      "lang:missing",
+		Usage:     "Report untranslated keys of every locale",
+		UsageText: "Key is untranslated if it is used in sources or translated in another locale, but the locale has no line or an empty one.\n",
+		Category:  "Translations",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:        "source, s",
+				Usage:       "directory with sources to scan",
+				Value:       ".",
+				Destination: &c.source,
+			},
+			cli.StringFlag{
+				Name:        "lang, l",
+				Usage:       "directory with locale files",
+				Value:       "lang",
+				Destination: &c.directory,
+			},
+			cli.BoolFlag{
+				Name:        "strict",
+				Usage:       "fail if there are untranslated keys, ex. on CI",
+				Destination: &c.strict,
+			},
+		},
+	}
+}
+
+// Handle command.
+func (c *CommandLangMissing) Handle(args cli.Args) error {
+	keys, err := ExtractKeys(os.DirFS(c.source))
+	if err != nil {
+		return err
+	}
+
+	locales, err := ReadLocaleFiles(c.directory)
+	if err != nil {
+		return err
+	}
+
+	missing := MissingKeys(keys, locales)
+
+	names := make([]string, 0, len(locales))
+	for locale := range locales {
+		names = append(names, locale)
+	}
+	sort.Strings(names)
+
+	var total int
+	for _, locale := range names {
+		if len(missing[locale]) == 0 {
+			c.Logger.Success("%s: everything is translated.", locale)
+			continue
+		}
+
+		total += len(missing[locale])
+		c.Logger.Warning("%s: %d untranslated keys", locale, len(missing[locale]))
+		for _, key := range missing[locale] {
+			if files := keys[key]; len(files) > 0 {
+				c.Logger.Println(fmt.Sprintf("  %s (%s)", key, files[0]))
+			} else {
+				c.Logger.Println("  " + key)
+			}
+		}
+	}
+
+	if c.strict && total > 0 {
+		return fmt.Errorf("%d untranslated keys", total)
+	}
+
+	return nil
+}
+
+// MissingKeys returns sorted untranslated keys of every locale.
+// Key is untranslated if it is used in sources or translated in another locale,
+// but the locale has no line or an empty one.
+func MissingKeys(used map[string][]string, locales map[string]map[string]string) map[string][]string {
+	known := make(map[string]bool)
+	for key := range used {
+		known[key] = true
+	}
+	for _, lines := range locales {
+		for key, line := range lines {
+			if line != "" {
+				known[key] = true
+			}
+		}
+	}
+
+	missing := make(map[string][]string)
+	for locale, lines := range locales {
+		for key := range known {
+			if lines[key] == "" {
+				missing[locale] = append(missing[locale], key)
+			}
+		}
+		sort.Strings(missing[locale])
+	}
+
+	return missing
+}
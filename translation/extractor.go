@@ -0,0 +1,151 @@
+package translation
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Extensions of the template files scanned for keys.
+var templateExtensions = map[string]bool{
+	".html":   true,
+	".tmpl":   true,
+	".gohtml": true,
+	".tpl":    true,
+}
+
+// Directories never scanned for keys.
+var skippedDirectories = map[string]bool{
+	".git":         true,
+	"vendor":       true,
+	"node_modules": true,
+	"testdata":     true,
+}
+
+// Key argument position of the translator methods called in Go sources.
+var transMethods = map[string]int{
+	"Trans":   0,
+	"TransIn": 1,
+}
+
+// Calls of the trans template function: {{ trans "key" }} or {{ (trans "key") }}.
+var templateTrans = regexp.MustCompile("\\btrans\\s+(\"(?:[^\"\\\\]|\\\\.)*\"|`[^`]*`)")
+
+// ExtractKeys scans Go sources and templates for translation keys.
+// Only literal keys are found, keys built at runtime have to be added to locale files by hand.
+// Returns keys with files they are used in.
+func ExtractKeys(fsys fs.FS) (map[string][]string, error) {
+	keys := make(map[string][]string)
+	add := func(key, file string) {
+		for _, known := range keys[key] {
+			if known == file {
+				return
+			}
+		}
+		keys[key] = append(keys[key], file)
+	}
+
+	err := fs.WalkDir(fsys, ".", func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if file != "." && (skippedDirectories[entry.Name()] || strings.HasPrefix(entry.Name(), ".")) {
+				return fs.SkipDir
+			}
+
+			return nil
+		}
+
+		ext := path.Ext(file)
+		if ext != ".go" && !templateExtensions[ext] {
+			return nil
+		}
+
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return err
+		}
+
+		var found []string
+		if ext == ".go" {
+			found, err = goKeys(file, content)
+			if err != nil {
+				return err
+			}
+		} else {
+			found = templateKeys(content)
+		}
+
+		for _, key := range found {
+			add(key, file)
+		}
+
+		return nil
+	})
+
+	for _, files := range keys {
+		sort.Strings(files)
+	}
+
+	return keys, err
+}
+
+// Keys passed as literals to the translator methods.
+func goKeys(file string, content []byte) ([]string, error) {
+	// Syntax errors do not stop the scan, keys are taken from the part parsed.
+	parsed, err := parser.ParseFile(token.NewFileSet(), file, content, 0)
+	if parsed == nil {
+		return nil, err
+	}
+
+	var keys []string
+	ast.Inspect(parsed, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+
+		var name string
+		switch fn := call.Fun.(type) {
+		case *ast.SelectorExpr:
+			name = fn.Sel.Name
+		case *ast.Ident:
+			name = fn.Name
+		}
+
+		position, ok := transMethods[name]
+		if !ok || len(call.Args) <= position {
+			return true
+		}
+
+		if literal, ok := call.Args[position].(*ast.BasicLit); ok && literal.Kind == token.STRING {
+			if key, err := strconv.Unquote(literal.Value); err == nil && key != "" {
+				keys = append(keys, key)
+			}
+		}
+
+		return true
+	})
+
+	return keys, nil
+}
+
+// Keys passed to the trans template function.
+func templateKeys(content []byte) []string {
+	var keys []string
+	for _, match := range templateTrans.FindAllSubmatch(content, -1) {
+		if key, err := strconv.Unquote(string(match[1])); err == nil && key != "" {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
@@ -0,0 +1,64 @@
+package translation_test
+
+import (
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lara-go/larago/translation"
+)
+
+func TestExtractKeys(t *testing.T) {
+	fsys := fstest.MapFS{
+		"controllers/orders.go": {Data: []byte(`package controllers
+
+func index(t *translation.Translator) string {
+	title := t.Trans("orders.title")
+	t.TransIn("ru", "orders.empty")
+	translation.Facade().Trans(dynamicKey)
+
+	return title + t.Trans("orders.title")
+}
+`)},
+		"views/orders.html":       {Data: []byte(`<h1>{{ trans "orders.title" }}</h1><p>{{ (trans "orders.total" "count" .Count) }}</p>`)},
+		"vendor/lib/lib.go":       {Data: []byte(`package lib; func f() { t.Trans("vendor.key") }`)},
+		"node_modules/x/x.html":   {Data: []byte(`{{ trans "node.key" }}`)},
+		"views/partials/nav.tmpl": {Data: []byte("{{ trans `nav.home` }}")},
+		"models/testdata/bad.go":  {Data: []byte(`package bad; func f() { t.Trans("testdata.key") `)},
+		"models/broken.go":        {Data: []byte("package models\n\nfunc f() { t.Trans(\"broken.key\") }\n\nfunc g( {")},
+	}
+
+	keys, err := translation.ExtractKeys(fsys)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"orders.title": {"controllers/orders.go", "views/orders.html"},
+		"orders.empty": {"controllers/orders.go"},
+		"orders.total": {"views/orders.html"},
+		"nav.home":     {"views/partials/nav.tmpl"},
+		"broken.key":   {"models/broken.go"},
+	}, keys)
+}
+
+func TestLocaleFiles(t *testing.T) {
+	directory := t.TempDir()
+	lines := map[string]string{
+		"welcome":       "Hello",
+		"orders.title":  "Orders",
+		"orders.empty":  "",
+		"errors":        "Errors",
+		"errors.failed": "Failed",
+	}
+
+	assert.NoError(t, translation.WriteLocaleFile(filepath.Join(directory, "en.json"), lines))
+	assert.NoError(t, translation.WriteLocaleFile(filepath.Join(directory, "ru.json"), map[string]string{"welcome": "Привет"}))
+
+	locales, err := translation.ReadLocaleFiles(directory)
+	assert.NoError(t, err)
+	assert.Equal(t, lines, locales["en"])
+
+	missing := translation.MissingKeys(map[string][]string{"orders.empty": {"orders.go"}}, locales)
+	assert.Equal(t, []string{"orders.empty"}, missing["en"])
+	assert.Equal(t, []string{"errors", "errors.failed", "orders.empty", "orders.title"}, missing["ru"])
+}
@@ -0,0 +1,11 @@
+package translation
+
+import "github.com/lara-go/larago"
+
+// FacadeWrapper for facade.
+var FacadeWrapper = &larago.Facade{}
+
+// Facade for translator.
+func Facade() *Translator {
+	return FacadeWrapper.Resolve("translator").(*Translator)
+}
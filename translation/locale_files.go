@@ -0,0 +1,104 @@
+package translation
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ReadLocaleFiles reads lines of every locale file in the directory.
+func ReadLocaleFiles(directory string) (map[string]map[string]string, error) {
+	files, err := filepath.Glob(filepath.Join(directory, "*"+Extension))
+	if err != nil {
+		return nil, err
+	}
+
+	locales := make(map[string]map[string]string)
+	for _, file := range files {
+		lines, err := ReadLocaleFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		locales[strings.TrimSuffix(filepath.Base(file), Extension)] = lines
+	}
+
+	return locales, nil
+}
+
+// ReadLocaleFile reads lines of the locale file, missing file has no lines.
+func ReadLocaleFile(file string) (map[string]string, error) {
+	content, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var lines map[string]interface{}
+	if err := json.Unmarshal(content, &lines); err != nil {
+		return nil, fmt.Errorf("Can't parse locale file %s: %s", file, err)
+	}
+
+	return flatten("", lines), nil
+}
+
+// WriteLocaleFile writes lines to the locale file, nesting dot-notation keys.
+func WriteLocaleFile(file string, lines map[string]string) error {
+	content, err := json.MarshalIndent(nest(lines), "", "    ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(file, append(content, '\n'), 0644)
+}
+
+// Nest dot-notation keys. Key which can't be nested, because its prefix is a line itself,
+// is kept with dots, flatten reads it back the same.
+func nest(lines map[string]string) map[string]interface{} {
+	keys := make([]string, 0, len(lines))
+	for key := range lines {
+		keys = append(keys, key)
+	}
+	// Shorter keys first, so lines win over groups with the same name.
+	sort.Slice(keys, func(i, j int) bool {
+		return strings.Count(keys[i], ".") < strings.Count(keys[j], ".") ||
+			strings.Count(keys[i], ".") == strings.Count(keys[j], ".") && keys[i] < keys[j]
+	})
+
+	nested := make(map[string]interface{})
+	for _, key := range keys {
+		group := nested
+		segments := strings.Split(key, ".")
+
+		for i, segment := range segments[:len(segments)-1] {
+			next, exists := group[segment]
+			if !exists {
+				next = make(map[string]interface{})
+				group[segment] = next
+			}
+
+			if child, ok := next.(map[string]interface{}); ok {
+				group = child
+				continue
+			}
+
+			// Prefix is a line, keep the rest of the key with dots.
+			segments = append(segments[:i], strings.Join(segments[i:], "."))
+			break
+		}
+
+		group[segments[len(segments)-1]] = lines[key]
+	}
+
+	return nested
+}
@@ -0,0 +1,63 @@
+package translation
+
+import (
+	"fmt"
+	"io/fs"
+	"sync"
+
+	"github.com/lara-go/larago"
+)
+
+// ServiceProvider struct.
+type ServiceProvider struct{}
+
+// Register service.
+func (p *ServiceProvider) Register(application *larago.Application) {
+	application.Commands(
+		&CommandLangExtract{},
+		&CommandLangMissing{},
+	)
+
+	// Lines of the modules are added to the translator resolved by alias,
+	// so resolving by type must return the same instance.
+	var translator *Translator
+	var once sync.Once
+
+	application.Bind(func() (*Translator, error) {
+		once.Do(func() {
+			locale := "en"
+			if application.Config().Has("App.Locale") {
+				locale = application.Config().Get("App.Locale").(string)
+			}
+
+			translator = NewTranslator(locale, "en")
+		})
+
+		return translator, nil
+	}, "translator")
+}
+
+// TranslationsModule registers translations of the module.
+type TranslationsModule interface {
+	Translations() fs.FS
+}
+
+// Boot service. Adds translations of the modules.
+func (p *ServiceProvider) Boot(application *larago.Application) error {
+	modules := application.Modules()
+	if len(modules) == 0 {
+		return nil
+	}
+
+	translator := application.Get("translator").(*Translator)
+
+	for _, module := range modules {
+		if m, ok := module.(TranslationsModule); ok {
+			if err := translator.AddFS(m.Translations()); err != nil {
+				return fmt.Errorf("Module %s translations: %s", module.Name(), err)
+			}
+		}
+	}
+
+	return nil
+}
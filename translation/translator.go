@@ -0,0 +1,187 @@
+package translation
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Extension of the locale files.
+const Extension = ".json"
+
+// Translator keeps translations for every locale.
+// Locale files are JSON files named by locale, ex. "en.json", nested keys are joined with dots.
+type Translator struct {
+	lock     sync.RWMutex
+	locale   string
+	fallback string
+	lines    map[string]map[string]string
+}
+
+// NewTranslator constructor.
+func NewTranslator(locale, fallback string) *Translator {
+	return &Translator{
+		locale:   locale,
+		fallback: fallback,
+		lines:    make(map[string]map[string]string),
+	}
+}
+
+// AddFS loads every locale file from file system, ex. embed.FS.
+func (t *Translator) AddFS(fsys fs.FS) error {
+	return fs.WalkDir(fsys, ".", func(file string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || path.Ext(file) != Extension {
+			return err
+		}
+
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return err
+		}
+
+		var lines map[string]interface{}
+		if err := json.Unmarshal(content, &lines); err != nil {
+			return fmt.Errorf("Can't parse locale file %s: %s", file, err)
+		}
+
+		t.AddLines(strings.TrimSuffix(path.Base(file), Extension), flatten("", lines))
+
+		return nil
+	})
+}
+
+// AddDirectory loads every locale file from the directory.
+func (t *Translator) AddDirectory(directory string) error {
+	return t.AddFS(os.DirFS(directory))
+}
+
+// AddLines adds translations for the locale.
+func (t *Translator) AddLines(locale string, lines map[string]string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.lines[locale] == nil {
+		t.lines[locale] = make(map[string]string)
+	}
+
+	for key, line := range lines {
+		t.lines[locale][key] = line
+	}
+}
+
+// SetLocale changes current locale.
+func (t *Translator) SetLocale(locale string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.locale = locale
+}
+
+// Locale returns current locale.
+func (t *Translator) Locale() string {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	return t.locale
+}
+
+// Trans translates key in the current locale.
+// Replacements are passed in pairs: Trans("welcome", "name", "John") for "Hello, :name".
+func (t *Translator) Trans(key string, replace ...string) string {
+	return t.TransIn(t.Locale(), key, replace...)
+}
+
+// TransIn translates key in the given locale, falling back to fallback locale and then to the key itself.
+func (t *Translator) TransIn(locale, key string, replace ...string) string {
+	line, ok := t.line(locale, key)
+	if !ok {
+		if line, ok = t.line(t.fallback, key); !ok {
+			line = key
+		}
+	}
+
+	for i := 0; i+1 < len(replace); i += 2 {
+		line = strings.Replace(line, ":"+replace[i], replace[i+1], -1)
+	}
+
+	return line
+}
+
+// Has checks if key is translated in the locale.
+func (t *Translator) Has(locale, key string) bool {
+	_, ok := t.line(locale, key)
+
+	return ok
+}
+
+// Locales returns all loaded locales.
+func (t *Translator) Locales() []string {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	locales := make([]string, 0, len(t.lines))
+	for locale := range t.lines {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	return locales
+}
+
+// Lines returns copy of all translations of the locale.
+func (t *Translator) Lines(locale string) map[string]string {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	lines := make(map[string]string, len(t.lines[locale]))
+	for key, line := range t.lines[locale] {
+		lines[key] = line
+	}
+
+	return lines
+}
+
+// FuncMap returns template functions: {{ trans "key" "name" "John" }}.
+func (t *Translator) FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"trans": t.Trans,
+	}
+}
+
+func (t *Translator) line(locale, key string) (string, bool) {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	// Empty lines are placeholders added by lang:extract.
+	line, ok := t.lines[locale][key]
+
+	return line, ok && line != ""
+}
+
+// Flatten nested lines into dot-notation keys.
+func flatten(prefix string, lines map[string]interface{}) map[string]string {
+	result := make(map[string]string)
+
+	for key, value := range lines {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for nestedKey, line := range flatten(key, v) {
+				result[nestedKey] = line
+			}
+		default:
+			result[key] = fmt.Sprintf("%v", v)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,36 @@
+package translation_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lara-go/larago/translation"
+)
+
+func TestItLoadsLocalesFromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"en.json": {Data: []byte(`{"welcome": "Hello, :name", "auth": {"failed": "Failed"}}`)},
+		"ru.json": {Data: []byte(`{"welcome": "Привет, :name"}`)},
+	}
+
+	translator := translation.NewTranslator("ru", "en")
+	assert.Nil(t, translator.AddFS(fsys))
+
+	assert.Equal(t, []string{"en", "ru"}, translator.Locales())
+	assert.Equal(t, "Привет, John", translator.Trans("welcome", "name", "John"))
+	assert.Equal(t, "Failed", translator.Trans("auth.failed"))
+	assert.Equal(t, "missing.key", translator.Trans("missing.key"))
+	assert.False(t, translator.Has("ru", "auth.failed"))
+}
+
+func TestEmptyLinesFallBack(t *testing.T) {
+	translator := translation.NewTranslator("ru", "en")
+	translator.AddLines("en", map[string]string{"orders.title": "Orders", "orders.empty": ""})
+	translator.AddLines("ru", map[string]string{"orders.title": "", "orders.empty": ""})
+
+	assert.Equal(t, "Orders", translator.Trans("orders.title"))
+	assert.Equal(t, "orders.empty", translator.Trans("orders.empty"))
+	assert.False(t, translator.Has("ru", "orders.title"))
+}
@@ -0,0 +1,72 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"strings"
+
+	"github.com/lara-go/larago/support/filetype"
+)
+
+// FileTypeRule validates uploaded file type by its content.
+type FileTypeRule struct {
+	types   []string
+	message string
+}
+
+// FileType rule accepts files of MIME types or extensions (ex. "image/*", "pdf").
+// Works with *multipart.FileHeader and []byte values.
+func FileType(types ...string) *FileTypeRule {
+	return &FileTypeRule{
+		types:   types,
+		message: fmt.Sprintf("must be a file of type: %s", strings.Join(types, ", ")),
+	}
+}
+
+// Images rule accepts raster images only. SVG is excluded since it may contain scripts.
+func Images() *FileTypeRule {
+	return FileType("image/jpeg", "image/png", "image/gif", "image/webp").Error("must be an image")
+}
+
+// Error sets custom error message.
+func (r *FileTypeRule) Error(message string) *FileTypeRule {
+	r.message = message
+
+	return r
+}
+
+// Validate value.
+func (r *FileTypeRule) Validate(value interface{}) error {
+	var typ filetype.Type
+
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case *multipart.FileHeader:
+		if v == nil {
+			return nil
+		}
+
+		detected, err := filetype.DetectUpload(v)
+		if err != nil {
+			return err
+		}
+
+		typ = detected
+	case []byte:
+		if len(v) == 0 {
+			return nil
+		}
+
+		typ = filetype.Detect(v)
+	default:
+		return fmt.Errorf("cannot detect file type of %T", value)
+	}
+
+	if !typ.Is(r.types...) {
+		return errors.New(r.message)
+	}
+
+	return nil
+}
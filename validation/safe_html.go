@@ -0,0 +1,57 @@
+package validation
+
+import (
+	"errors"
+
+	"github.com/lara-go/larago/support/sanitize"
+)
+
+// SafeHTMLRule checks that html contains only allowed elements and attributes.
+type SafeHTMLRule struct {
+	policy  *sanitize.Policy
+	message string
+}
+
+// SafeHTML rule. Default sanitize policy is used if policy is nil.
+func SafeHTML(policy *sanitize.Policy) *SafeHTMLRule {
+	if policy == nil {
+		policy = sanitize.Default
+	}
+
+	return &SafeHTMLRule{
+		policy:  policy,
+		message: "contains forbidden html",
+	}
+}
+
+// Error sets custom error message.
+func (r *SafeHTMLRule) Error(message string) *SafeHTMLRule {
+	r.message = message
+
+	return r
+}
+
+// Validate value.
+func (r *SafeHTMLRule) Validate(value interface{}) error {
+	var input string
+
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		input = v
+	case *string:
+		if v == nil {
+			return nil
+		}
+		input = *v
+	default:
+		return errors.New("must be a string")
+	}
+
+	if !r.policy.IsSafe(input) {
+		return errors.New(r.message)
+	}
+
+	return nil
+}
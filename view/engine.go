@@ -0,0 +1,171 @@
+package view
+
+import (
+	"bytes"
+	"html/template"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/lara-go/larago/http/responses"
+)
+
+// Extension of the template files.
+const Extension = ".html"
+
+// Engine renders html templates from the set of file systems.
+// Template name is its path without extension, ex. "admin/users/index".
+type Engine struct {
+	lock      sync.RWMutex
+	sources   []fs.FS
+	funcs     template.FuncMap
+	templates *templateSet
+	resources map[string][]string
+}
+
+// NewEngine constructor.
+func NewEngine() *Engine {
+	engine := &Engine{
+		funcs: make(template.FuncMap),
+	}
+
+	return engine.Funcs(resourcesFuncMap())
+}
+
+// AddFS adds file system with templates, ex. embed.FS.
+// Templates from the later added file systems override earlier ones.
+func (e *Engine) AddFS(fsys fs.FS) *Engine {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.sources = append(e.sources, fsys)
+	e.templates = nil
+
+	return e
+}
+
+// AddDirectory adds directory with templates.
+func (e *Engine) AddDirectory(directory string) *Engine {
+	return e.AddFS(os.DirFS(directory))
+}
+
+// Funcs adds functions available in templates.
+func (e *Engine) Funcs(funcs template.FuncMap) *Engine {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	for name, fn := range funcs {
+		e.funcs[name] = fn
+	}
+	e.templates = nil
+
+	return e
+}
+
+// Reload drops parsed templates, so they will be parsed again on the next render.
+func (e *Engine) Reload() {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	e.templates = nil
+}
+
+// Exists checks if there is such template.
+func (e *Engine) Exists(name string) bool {
+	templates, err := e.load()
+	if err != nil {
+		return false
+	}
+
+	return templates.master.Lookup(name) != nil
+}
+
+// Render template with data.
+func (e *Engine) Render(name string, data interface{}) (string, error) {
+	html, _, err := e.render(name, data)
+
+	return html, err
+}
+
+// Response renders template into HTML response.
+func (e *Engine) Response(status int, name string, data interface{}) (responses.Response, error) {
+	html, pushed, err := e.render(name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	response := responses.NewHTML(status, "%s", html)
+
+	// Let the browser or the router fetch assets pushed while rendering.
+	if link := preloadHeader(pushed); link != "" {
+		response.WithHeader("Link", link)
+	}
+
+	return response, nil
+}
+
+// Render template returning resources pushed while executing it.
+func (e *Engine) render(name string, data interface{}) (string, []string, error) {
+	templates, err := e.load()
+	if err != nil {
+		return "", nil, err
+	}
+
+	var buffer bytes.Buffer
+	pushed, err := templates.execute(&buffer, name, data)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return buffer.String(), pushed, nil
+}
+
+// Load and parse templates once.
+func (e *Engine) load() (*templateSet, error) {
+	e.lock.RLock()
+	templates := e.templates
+	e.lock.RUnlock()
+
+	if templates != nil {
+		return templates, nil
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	// Templates could be parsed while waiting for the lock.
+	if e.templates != nil {
+		return e.templates, nil
+	}
+
+	master := template.New("").Funcs(e.funcs)
+	for _, source := range e.sources {
+		if err := e.parseFS(master, source); err != nil {
+			return nil, err
+		}
+	}
+	e.templates = &templateSet{master: master}
+	e.resources = templatesResources(master)
+
+	return e.templates, nil
+}
+
+// Parse every template file from file system.
+func (e *Engine) parseFS(templates *template.Template, source fs.FS) error {
+	return fs.WalkDir(source, ".", func(file string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || path.Ext(file) != Extension {
+			return err
+		}
+
+		content, err := fs.ReadFile(source, file)
+		if err != nil {
+			return err
+		}
+
+		_, err = templates.New(strings.TrimSuffix(file, Extension)).Parse(string(content))
+
+		return err
+	})
+}
@@ -0,0 +1,50 @@
+package view_test
+
+import (
+	"html/template"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lara-go/larago/view"
+)
+
+func TestItRendersTemplatesFromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/header.html": {Data: []byte(`<h1>{{ upper .Title }}</h1>`)},
+		"users/index.html":    {Data: []byte(`{{ template "layouts/header" . }}<p>{{ .Text }}</p>`)},
+	}
+
+	engine := view.NewEngine().
+		AddFS(fsys).
+		Funcs(template.FuncMap{"upper": strings.ToUpper})
+
+	assert.True(t, engine.Exists("users/index"))
+
+	html, err := engine.Render("users/index", map[string]string{"Title": "Users", "Text": "<b>"})
+	assert.Nil(t, err)
+	assert.Equal(t, "<h1>USERS</h1><p>&lt;b&gt;</p>", html)
+}
+
+func TestItCollectsPushedResources(t *testing.T) {
+	fsys := fstest.MapFS{
+		"layouts/head.html": {Data: []byte(`<link rel="stylesheet" href="{{ push "/css/app.css" }}">`)},
+		"users/index.html":  {Data: []byte(`{{ template "layouts/head" . }}{{ if .Admin }}<script src="{{ push "/js/admin.js" }}"></script>{{ end }}<img src="{{ push .Avatar }}">`)},
+	}
+
+	engine := view.NewEngine().AddFS(fsys)
+
+	assert.Equal(t, []string{"/css/app.css", "/js/admin.js"}, engine.Resources("users/index"))
+
+	// Only resources pushed while rendering are preloaded.
+	response, err := engine.Response(200, "users/index", map[string]interface{}{"Admin": false, "Avatar": "/a.png"})
+	assert.Nil(t, err)
+	assert.Equal(t, "</css/app.css>; rel=preload; as=style, </a.png>; rel=preload; as=image", response.Headers()["Link"])
+	assert.Equal(t, `<link rel="stylesheet" href="/css/app.css"><img src="/a.png">`, string(response.Body()))
+
+	response, err = engine.Response(200, "users/index", map[string]interface{}{"Admin": true, "Avatar": "/b.png"})
+	assert.Nil(t, err)
+	assert.Equal(t, "</css/app.css>; rel=preload; as=style, </js/admin.js>; rel=preload; as=script, </b.png>; rel=preload; as=image", response.Headers()["Link"])
+}
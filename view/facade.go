@@ -0,0 +1,11 @@
+package view
+
+import "github.com/lara-go/larago"
+
+// FacadeWrapper for facade.
+var FacadeWrapper = &larago.Facade{}
+
+// Facade for view engine.
+func Facade() *Engine {
+	return FacadeWrapper.Resolve("view").(*Engine)
+}
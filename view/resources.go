@@ -0,0 +1,190 @@
+package view
+
+import (
+	"html/template"
+	"io"
+	"strings"
+	"sync"
+	"text/template/parse"
+
+	"github.com/lara-go/larago/http"
+)
+
+// Name of the template function registering resource to preload.
+const pushFunc = "push"
+
+// FuncMap with the push function. It returns path as is:
+//
+//	<link rel="stylesheet" href="{{ push "/css/app.css" }}">
+//
+// Only paths pushed while rendering are preloaded, so assets under false conditions are not.
+func resourcesFuncMap() template.FuncMap {
+	return template.FuncMap{
+		pushFunc: func(path string) string {
+			return path
+		},
+	}
+}
+
+// Parsed templates. Master templates are never executed, they are cloned with own push function,
+// so every execution records its pushes. Clones are reused, as each of them is escaped on the first execution.
+type templateSet struct {
+	master *template.Template
+	clones sync.Pool
+}
+
+// Clone of the templates recording pushes.
+type templatesClone struct {
+	templates *template.Template
+	pushed    []string
+}
+
+// Execute template returning resources pushed by it.
+func (s *templateSet) execute(w io.Writer, name string, data interface{}) ([]string, error) {
+	clone, ok := s.clones.Get().(*templatesClone)
+	if !ok {
+		templates, err := s.master.Clone()
+		if err != nil {
+			return nil, err
+		}
+
+		clone = &templatesClone{}
+		clone.templates = templates.Funcs(template.FuncMap{pushFunc: clone.push})
+	}
+	defer s.clones.Put(clone)
+
+	clone.pushed = nil
+	err := clone.templates.ExecuteTemplate(w, name, data)
+
+	return clone.pushed, err
+}
+
+// Record pushed resource once.
+func (c *templatesClone) push(path string) string {
+	for _, pushed := range c.pushed {
+		if pushed == path {
+			return path
+		}
+	}
+
+	c.pushed = append(c.pushed, path)
+
+	return path
+}
+
+// Resources returns literal paths the template and templates it includes may push.
+func (e *Engine) Resources(name string) []string {
+	if _, err := e.load(); err != nil {
+		return nil
+	}
+
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+
+	return e.resources[name]
+}
+
+// Link header preloading the resources. Empty if there are none.
+func preloadHeader(resources []string) string {
+	links := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		links = append(links, http.PreloadLink(resource))
+	}
+
+	return strings.Join(links, ", ")
+}
+
+// Collect resources of every template before the first execution,
+// as html/template rewrites trees when escaping them.
+func templatesResources(templates *template.Template) map[string][]string {
+	resources := make(map[string][]string)
+
+	for _, tmpl := range templates.Templates() {
+		var collected []string
+		collectResources(templates, tmpl.Name(), make(map[string]bool), &collected)
+
+		if len(collected) > 0 {
+			resources[tmpl.Name()] = collected
+		}
+	}
+
+	return resources
+}
+
+// Walk template tree and included templates once.
+func collectResources(templates *template.Template, name string, visited map[string]bool, resources *[]string) {
+	if visited[name] {
+		return
+	}
+	visited[name] = true
+
+	tmpl := templates.Lookup(name)
+	if tmpl == nil || tmpl.Tree == nil {
+		return
+	}
+
+	var walk func(node parse.Node)
+	walk = func(node parse.Node) {
+		switch n := node.(type) {
+		case *parse.ListNode:
+			if n == nil {
+				return
+			}
+			for _, child := range n.Nodes {
+				walk(child)
+			}
+		case *parse.ActionNode:
+			walk(n.Pipe)
+		case *parse.PipeNode:
+			if n == nil {
+				return
+			}
+			for _, command := range n.Cmds {
+				walk(command)
+			}
+		case *parse.CommandNode:
+			collectCommand(n, resources)
+			for _, arg := range n.Args {
+				walk(arg)
+			}
+		case *parse.IfNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.RangeNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.WithNode:
+			walk(n.Pipe)
+			walk(n.List)
+			walk(n.ElseList)
+		case *parse.TemplateNode:
+			collectResources(templates, n.Name, visited, resources)
+		}
+	}
+
+	walk(tmpl.Tree.Root)
+}
+
+// Register literal argument of the push call.
+func collectCommand(command *parse.CommandNode, resources *[]string) {
+	if len(command.Args) != 2 {
+		return
+	}
+
+	identifier, ok := command.Args[0].(*parse.IdentifierNode)
+	if !ok || identifier.Ident != pushFunc {
+		return
+	}
+
+	if path, ok := command.Args[1].(*parse.StringNode); ok {
+		for _, resource := range *resources {
+			if resource == path.Text {
+				return
+			}
+		}
+
+		*resources = append(*resources, path.Text)
+	}
+}
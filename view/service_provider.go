@@ -0,0 +1,39 @@
+package view
+
+import (
+	"io/fs"
+
+	"github.com/lara-go/larago"
+	"github.com/lara-go/larago/support/markdown"
+	"github.com/lara-go/larago/support/sanitize"
+)
+
+// ServiceProvider struct.
+type ServiceProvider struct{}
+
+// Register service.
+func (p *ServiceProvider) Register(application *larago.Application) {
+	engine := NewEngine().
+		Funcs(sanitize.FuncMap()).
+		Funcs(markdown.FuncMap())
+
+	application.Bind(engine, "view")
+}
+
+// ViewsModule registers templates of the module.
+// Templates share one namespace, so module should keep them in its own directory.
+// Templates added by the application later override ones of the modules.
+type ViewsModule interface {
+	Views() fs.FS
+}
+
+// Boot service. Adds templates of the modules.
+func (p *ServiceProvider) Boot(application *larago.Application) {
+	engine := application.Get("view").(*Engine)
+
+	for _, module := range application.Modules() {
+		if m, ok := module.(ViewsModule); ok {
+			engine.AddFS(m.Views())
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package workflow
+
+import (
+	"github.com/lara-go/larago/logger"
+
+	"github.com/urfave/cli"
+)
+
+// CommandResume resumes workflows interrupted by crashes.
+type CommandResume struct {
+	Engine *Engine
+	Logger *logger.Logger
+}
+
+// GetCommand for the cli to register.
+func (c *CommandResume) GetCommand() cli.Command {
+	return cli.Command{
+		Name:     "workflow:resume",
+		Usage:    "Resume unfinished workflows",
+		Category: "Workflows",
+	}
+}
+
+// Handle command.
+func (c *CommandResume) Handle(args cli.Args) error {
+	resumed, err := c.Engine.ResumeAll()
+
+	c.Logger.Success("Resumed %d workflows.", resumed)
+
+	return err
+}
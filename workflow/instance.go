@@ -0,0 +1,116 @@
+package workflow
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// Instance statuses.
+const (
+	StatusRunning      = "running"
+	StatusCompleted    = "completed"
+	StatusCompensating = "compensating"
+	StatusCompensated  = "compensated"
+	StatusFailed       = "failed"
+)
+
+// Instance is a persisted state of the running workflow.
+type Instance struct {
+	ID          uint   `gorm:"primary_key"`
+	Workflow    string `gorm:"index"`
+	Status      string `gorm:"size:16;index"`
+	CurrentStep int
+	Data        string     `gorm:"type:text"`
+	Error       string     `gorm:"type:text"`
+	LockedBy    string     `gorm:"size:255"`
+	LockedUntil *time.Time `gorm:"index"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// TableName of the instances.
+func (Instance) TableName() string {
+	return "workflow_instances"
+}
+
+// Finished checks if workflow will not run anymore.
+func (i *Instance) Finished() bool {
+	return i.Status == StatusCompleted || i.Status == StatusCompensated || i.Status == StatusFailed
+}
+
+// Context is passed to every step and keeps workflow data between them.
+type Context struct {
+	Instance *Instance
+	data     map[string]json.RawMessage
+}
+
+func newContext(instance *Instance) (*Context, error) {
+	ctx := &Context{
+		Instance: instance,
+		data:     make(map[string]json.RawMessage),
+	}
+
+	if instance.Data != "" {
+		if err := json.Unmarshal([]byte(instance.Data), &ctx.data); err != nil {
+			return nil, err
+		}
+	}
+
+	return ctx, nil
+}
+
+// Set value to pass to the next steps.
+func (c *Context) Set(key string, value interface{}) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	c.data[key] = encoded
+
+	return nil
+}
+
+// Get value saved by previous steps.
+func (c *Context) Get(key string, target interface{}) error {
+	value, ok := c.data[key]
+	if !ok {
+		return nil
+	}
+
+	return json.Unmarshal(value, target)
+}
+
+// Has checks if value was saved.
+func (c *Context) Has(key string) bool {
+	_, ok := c.data[key]
+
+	return ok
+}
+
+// Encode data to persist.
+func (c *Context) encode() (string, error) {
+	encoded, err := json.Marshal(c.data)
+
+	return string(encoded), err
+}
+
+// Migration creates workflow instances table.
+type Migration struct{}
+
+// MigrationID returns unique migration ID.
+func (m *Migration) MigrationID() string {
+	return "workflow_create_instances_table"
+}
+
+// Migrate runs migrations.
+func (m *Migration) Migrate(tx *gorm.DB) error {
+	return tx.AutoMigrate(&Instance{}).Error
+}
+
+// Rollback changes.
+func (m *Migration) Rollback(tx *gorm.DB) error {
+	return tx.DropTableIfExists(&Instance{}).Error
+}
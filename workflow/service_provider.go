@@ -0,0 +1,18 @@
+package workflow
+
+import (
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago"
+)
+
+// ServiceProvider struct.
+type ServiceProvider struct{}
+
+// Register service.
+func (p *ServiceProvider) Register(application *larago.Application) {
+	application.Commands(&CommandResume{})
+
+	application.Bind(func() (*Engine, error) {
+		return NewEngine(application.Get("db.connection").(*gorm.DB)), nil
+	}, "workflow")
+}
@@ -0,0 +1,357 @@
+package workflow
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// ErrLocked is returned when instance is run by another process.
+var ErrLocked = errors.New("Workflow instance is run by another process")
+
+// Statuses of instances which still have to run.
+var unfinished = []string{StatusRunning, StatusCompensating}
+
+// StepFunc does the job of the step.
+type StepFunc func(ctx *Context) error
+
+// Step of the workflow.
+type Step struct {
+	Name       string
+	Action     StepFunc
+	Compensate StepFunc
+}
+
+// Definition of the workflow.
+type Definition struct {
+	name  string
+	steps []Step
+}
+
+// Define new workflow.
+func Define(name string) *Definition {
+	return &Definition{
+		name: name,
+	}
+}
+
+// Name of the workflow.
+func (d *Definition) Name() string {
+	return d.name
+}
+
+// Step adds step with compensation which undoes it when some later step fails.
+// Steps may run more than once after crashes, so they must be idempotent.
+func (d *Definition) Step(name string, action, compensate StepFunc) *Definition {
+	d.steps = append(d.steps, Step{
+		Name:       name,
+		Action:     action,
+		Compensate: compensate,
+	})
+
+	return d
+}
+
+// Steps of the workflow.
+func (d *Definition) Steps() []Step {
+	return d.steps
+}
+
+// Engine runs workflows persisting their state after every step.
+// Running instance is leased by the engine, so other processes do not resume it meanwhile.
+type Engine struct {
+	db          *gorm.DB
+	definitions map[string]*Definition
+	mutex       sync.RWMutex
+	owner       string
+	lease       time.Duration
+}
+
+// NewEngine constructor.
+func NewEngine(db *gorm.DB) *Engine {
+	return &Engine{
+		db:          db,
+		definitions: make(map[string]*Definition),
+		owner:       newOwner(),
+		lease:       5 * time.Minute,
+	}
+}
+
+// Lease sets how long instance stays claimed after every step, 5 minutes by default.
+// It must be longer than the slowest step.
+func (e *Engine) Lease(lease time.Duration) *Engine {
+	e.lease = lease
+
+	return e
+}
+
+// Register workflow definitions.
+func (e *Engine) Register(definitions ...*Definition) *Engine {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	for _, definition := range definitions {
+		e.definitions[definition.name] = definition
+	}
+
+	return e
+}
+
+// Start new workflow instance with initial data and run it.
+func (e *Engine) Start(name string, data map[string]interface{}) (*Instance, error) {
+	definition, err := e.definition(name)
+	if err != nil {
+		return nil, err
+	}
+
+	until := time.Now().Add(e.lease)
+	instance := &Instance{
+		Workflow:    name,
+		Status:      StatusRunning,
+		LockedBy:    e.owner,
+		LockedUntil: &until,
+	}
+
+	ctx, err := newContext(instance)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range data {
+		if err := ctx.Set(key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	if instance.Data, err = ctx.encode(); err != nil {
+		return nil, err
+	}
+
+	if err := e.db.Create(instance).Error; err != nil {
+		return nil, err
+	}
+
+	return instance, e.run(definition, ctx)
+}
+
+// Resume instance interrupted by the crash. Returns ErrLocked if the instance is leased by someone else.
+func (e *Engine) Resume(instance *Instance) error {
+	if instance.Finished() {
+		return nil
+	}
+
+	definition, err := e.definition(instance.Workflow)
+	if err != nil {
+		return err
+	}
+
+	if err := e.claim(instance); err != nil {
+		return err
+	}
+
+	if instance.Finished() {
+		return nil
+	}
+
+	ctx, err := newContext(instance)
+	if err != nil {
+		return err
+	}
+
+	return e.run(definition, ctx)
+}
+
+// ResumeAll resumes every unfinished instance not leased by other processes and returns their amount.
+// Failed instances do not stop the others, their errors are returned together.
+func (e *Engine) ResumeAll() (int, error) {
+	var instances []*Instance
+
+	err := e.db.
+		Where("status IN (?)", unfinished).
+		Where("locked_until IS NULL OR locked_until < ?", time.Now()).
+		Order("id").
+		Find(&instances).Error
+
+	if err != nil {
+		return 0, err
+	}
+
+	var resumed int
+	var failed []string
+
+	for _, instance := range instances {
+		switch err := e.Resume(instance); err {
+		case nil:
+			resumed++
+		case ErrLocked:
+		default:
+			failed = append(failed, fmt.Sprintf("#%d %s", instance.ID, err))
+		}
+	}
+
+	if len(failed) > 0 {
+		return resumed, fmt.Errorf("Could not resume %d workflows: %s", len(failed), strings.Join(failed, "; "))
+	}
+
+	return resumed, nil
+}
+
+// Find instance by ID.
+func (e *Engine) Find(id uint) (*Instance, error) {
+	var instance Instance
+	if err := e.db.First(&instance, id).Error; err != nil {
+		return nil, err
+	}
+
+	return &instance, nil
+}
+
+func (e *Engine) definition(name string) (*Definition, error) {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	definition, ok := e.definitions[name]
+	if !ok {
+		return nil, fmt.Errorf("Unknown workflow %s", name)
+	}
+
+	return definition, nil
+}
+
+// Run steps from the current one, compensating on failure.
+// Returns error only if state could not be persisted. Failures of steps are saved in the instance.
+func (e *Engine) run(definition *Definition, ctx *Context) error {
+	instance := ctx.Instance
+
+	for instance.Status == StatusRunning && instance.CurrentStep < len(definition.steps) {
+		step := definition.steps[instance.CurrentStep]
+
+		if err := step.Action(ctx); err != nil {
+			instance.Status = StatusCompensating
+			instance.Error = fmt.Sprintf("%s: %s", step.Name, err)
+
+			if err := e.save(ctx); err != nil {
+				return err
+			}
+
+			break
+		}
+
+		instance.CurrentStep++
+		if err := e.save(ctx); err != nil {
+			return err
+		}
+	}
+
+	if instance.Status == StatusRunning {
+		instance.Status = StatusCompleted
+
+		return e.save(ctx)
+	}
+
+	return e.compensate(definition, ctx)
+}
+
+// Undo completed steps in reverse order.
+func (e *Engine) compensate(definition *Definition, ctx *Context) error {
+	instance := ctx.Instance
+
+	for instance.CurrentStep > 0 {
+		step := definition.steps[instance.CurrentStep-1]
+
+		if step.Compensate != nil {
+			if err := step.Compensate(ctx); err != nil {
+				instance.Status = StatusFailed
+				instance.Error = fmt.Sprintf("%s; compensation of %s: %s", instance.Error, step.Name, err)
+
+				return e.save(ctx)
+			}
+		}
+
+		instance.CurrentStep--
+		if err := e.save(ctx); err != nil {
+			return err
+		}
+	}
+
+	instance.Status = StatusCompensated
+
+	return e.save(ctx)
+}
+
+// Claim unfinished instance with expired lease and reload its state.
+func (e *Engine) claim(instance *Instance) error {
+	now := time.Now()
+	until := now.Add(e.lease)
+
+	result := e.db.Model(&Instance{}).
+		Where("id = ? AND status IN (?)", instance.ID, unfinished).
+		Where("locked_until IS NULL OR locked_until < ? OR locked_by = ?", now, e.owner).
+		Updates(map[string]interface{}{"locked_by": e.owner, "locked_until": &until})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrLocked
+	}
+
+	return e.db.First(instance, instance.ID).Error
+}
+
+// Persist instance state extending the lease, finished instance is released.
+func (e *Engine) save(ctx *Context) error {
+	data, err := ctx.encode()
+	if err != nil {
+		return err
+	}
+
+	instance := ctx.Instance
+	instance.Data = data
+	instance.LockedBy = e.owner
+	until := time.Now().Add(e.lease)
+	instance.LockedUntil = &until
+	if instance.Finished() {
+		instance.LockedBy = ""
+		instance.LockedUntil = nil
+	}
+
+	// Lease could expire and be taken by another process, its state must not be overwritten.
+	result := e.db.Model(&Instance{}).
+		Where("id = ? AND locked_by = ?", instance.ID, e.owner).
+		Updates(map[string]interface{}{
+			"status":       instance.Status,
+			"current_step": instance.CurrentStep,
+			"data":         instance.Data,
+			"error":        instance.Error,
+			"locked_by":    instance.LockedBy,
+			"locked_until": instance.LockedUntil,
+		})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrLocked
+	}
+
+	return nil
+}
+
+// Unique name of the engine process.
+func newOwner() string {
+	random := make([]byte, 8)
+	rand.Read(random)
+	host, _ := os.Hostname()
+
+	return fmt.Sprintf("%s:%d:%s", host, os.Getpid(), hex.EncodeToString(random))
+}
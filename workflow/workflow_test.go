@@ -0,0 +1,144 @@
+package workflow_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/lara-go/larago/support/testsuite"
+	"github.com/lara-go/larago/workflow"
+	"github.com/stretchr/testify/assert"
+
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
+)
+
+func engineFactory(t *testing.T) (*workflow.Engine, *gorm.DB) {
+	db := testsuite.MemoryDB(t)
+	(&workflow.Migration{}).Migrate(db)
+
+	return workflow.NewEngine(db), db
+}
+
+func orderWorkflow(journal *[]string, failAt string) *workflow.Definition {
+	step := func(name string) (workflow.StepFunc, workflow.StepFunc) {
+		return func(ctx *workflow.Context) error {
+				if name == failAt {
+					return errors.New("declined")
+				}
+				*journal = append(*journal, name)
+
+				return ctx.Set(name, true)
+			}, func(ctx *workflow.Context) error {
+				*journal = append(*journal, "undo "+name)
+
+				return nil
+			}
+	}
+
+	reserve, unreserve := step("reserve")
+	charge, refund := step("charge")
+	ship, _ := step("ship")
+
+	return workflow.Define("order").
+		Step("reserve", reserve, unreserve).
+		Step("charge", charge, refund).
+		Step("ship", ship, nil)
+}
+
+func TestEngine_Completed(t *testing.T) {
+	var journal []string
+	engine, _ := engineFactory(t)
+	engine.Register(orderWorkflow(&journal, ""))
+
+	instance, err := engine.Start("order", map[string]interface{}{"order": 42})
+	assert.NoError(t, err)
+	assert.Equal(t, workflow.StatusCompleted, instance.Status)
+	assert.Equal(t, []string{"reserve", "charge", "ship"}, journal)
+
+	saved, _ := engine.Find(instance.ID)
+	assert.Equal(t, workflow.StatusCompleted, saved.Status)
+	assert.Equal(t, 3, saved.CurrentStep)
+}
+
+func TestEngine_Compensated(t *testing.T) {
+	var journal []string
+	engine, _ := engineFactory(t)
+	engine.Register(orderWorkflow(&journal, "ship"))
+
+	instance, err := engine.Start("order", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, workflow.StatusCompensated, instance.Status)
+	assert.Equal(t, "ship: declined", instance.Error)
+	assert.Equal(t, []string{"reserve", "charge", "undo charge", "undo reserve"}, journal)
+}
+
+func TestEngine_ResumeAll(t *testing.T) {
+	var journal []string
+	engine, db := engineFactory(t)
+	engine.Register(orderWorkflow(&journal, ""))
+
+	// Process crashed right after the first step.
+	db.Create(&workflow.Instance{Workflow: "order", Status: workflow.StatusRunning, CurrentStep: 1, Data: "{}"})
+	db.Create(&workflow.Instance{Workflow: "order", Status: workflow.StatusCompleted, CurrentStep: 3, Data: "{}"})
+
+	resumed, err := engine.ResumeAll()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, resumed)
+	assert.Equal(t, []string{"charge", "ship"}, journal)
+}
+
+func TestEngine_ResumeAllSkipsLeasedInstances(t *testing.T) {
+	var journal []string
+	engine, db := engineFactory(t)
+	engine.Register(orderWorkflow(&journal, ""))
+
+	future, past := time.Now().Add(time.Hour), time.Now().Add(-time.Hour)
+	leased := &workflow.Instance{Workflow: "order", Status: workflow.StatusRunning, CurrentStep: 2, Data: "{}", LockedBy: "other", LockedUntil: &future}
+	expired := &workflow.Instance{Workflow: "order", Status: workflow.StatusRunning, CurrentStep: 2, Data: "{}", LockedBy: "other", LockedUntil: &past}
+	db.Create(leased)
+	db.Create(expired)
+
+	resumed, err := engine.ResumeAll()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, resumed)
+	assert.Equal(t, []string{"ship"}, journal)
+	assert.Equal(t, workflow.ErrLocked, engine.Resume(leased))
+
+	saved, _ := engine.Find(expired.ID)
+	assert.Equal(t, workflow.StatusCompleted, saved.Status)
+	assert.Empty(t, saved.LockedBy)
+	assert.Nil(t, saved.LockedUntil)
+}
+
+func TestEngine_ResumeAllCollectsErrors(t *testing.T) {
+	var journal []string
+	engine, db := engineFactory(t)
+	engine.Register(orderWorkflow(&journal, ""))
+
+	db.Create(&workflow.Instance{Workflow: "unknown", Status: workflow.StatusRunning, Data: "{}"})
+	db.Create(&workflow.Instance{Workflow: "order", Status: workflow.StatusRunning, CurrentStep: 2, Data: "{}"})
+
+	resumed, err := engine.ResumeAll()
+	assert.EqualError(t, err, "Could not resume 1 workflows: #1 Unknown workflow unknown")
+	assert.Equal(t, 1, resumed)
+	assert.Equal(t, []string{"ship"}, journal)
+}
+
+func TestEngine_LostLeaseIsNotOverwritten(t *testing.T) {
+	engine, db := engineFactory(t)
+	other := workflow.NewEngine(db)
+
+	engine.Register(workflow.Define("slow").Step("wait", func(ctx *workflow.Context) error {
+		// Lease expired and another process took the instance meanwhile.
+		past := time.Now().Add(-time.Minute)
+		db.Model(ctx.Instance).Update("locked_until", &past)
+		claimed := *ctx.Instance
+		assert.NoError(t, other.Register(workflow.Define("slow")).Resume(&claimed))
+
+		return nil
+	}, nil))
+
+	_, err := engine.Start("slow", nil)
+	assert.Equal(t, workflow.ErrLocked, err)
+}